@@ -3,6 +3,8 @@ package main
 import (
 	"context"
 	"log"
+	// 実行環境にタイムゾーンデータが無くてもAPP_TIMEZONEを解釈できるよう埋め込む
+	_ "time/tzdata"
 
 	"Aicon-assignment/internal/infrastructure/server"
 )
@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+)
+
+// アイテムのカテゴリー（NewCategoryで許可リストに含まれる値かを検証する）
+type Category string
+
+const (
+	CategoryWatch   Category = "時計"
+	CategoryBag     Category = "バッグ"
+	CategoryJewelry Category = "ジュエリー"
+	CategoryShoes   Category = "靴"
+	CategoryOther   Category = "その他"
+)
+
+// カテゴリー定義
+var ValidCategories = []Category{CategoryWatch, CategoryBag, CategoryJewelry, CategoryShoes, CategoryOther}
+
+// 許可リストに無いカテゴリー
+var ErrInvalidCategory = errors.New(invalidCategoryMessage())
+
+// 前後の空白を除き、英字の大文字・小文字を区別せずに検証して、許可リストの表記のCategoryを返す
+func NewCategory(value string) (Category, error) {
+	category := Category(value).Normalize()
+	if !category.IsValid() {
+		return "", ErrInvalidCategory
+	}
+	return category, nil
+}
+
+func (c Category) String() string {
+	return string(c)
+}
+
+// 前後の空白を除き、英字の大文字・小文字だけが異なる場合は許可リストの表記にする
+// 許可リストに無い値は空白を除いた値のまま返す（IsValidで検証する）
+func (c Category) Normalize() Category {
+	trimmed := strings.TrimSpace(string(c))
+	for _, valid := range ValidCategories {
+		if equalFoldASCII(trimmed, string(valid)) {
+			return valid
+		}
+	}
+	return Category(trimmed)
+}
+
+// 許可リストに含まれるかどうか
+func (c Category) IsValid() bool {
+	for _, valid := range ValidCategories {
+		if c == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// カテゴリーの取得
+func GetValidCategories() []Category {
+	return ValidCategories
+}
+
+// ASCIIの英字のみ大文字・小文字を区別せずに比較する（全角英字などは区別する）
+func equalFoldASCII(a, b string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := 0; i < len(a); i++ {
+		if lowerASCII(a[i]) != lowerASCII(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func lowerASCII(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + ('a' - 'A')
+	}
+	return c
+}
+
+func invalidCategoryMessage() string {
+	names := make([]string, len(ValidCategories))
+	for i, category := range ValidCategories {
+		names[i] = category.String()
+	}
+	return "category must be one of: " + strings.Join(names, ", ")
+}
@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// 現在時刻の取得元（テストでは固定した時刻を返すClockに差し替える）
+type Clock interface {
+	Now() time.Time
+}
+
+// 実際の時刻を返すClock
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// 常に同じ時刻を返すClock
+type FixedClock time.Time
+
+func (c FixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+// 購入日の検証やタイムスタンプに使うClock（Now、Todayはこの時刻を返す）
+var clock Clock = SystemClock{}
+
+// Clockを差し替え、元に戻す関数を返す
+func SetClock(c Clock) (restore func()) {
+	previous := clock
+	clock = c
+	return func() { clock = previous }
+}
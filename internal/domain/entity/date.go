@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// 購入日として解釈できない
+var ErrInvalidDateFormat = errors.New("invalid date format")
+
+// 購入日として受け付ける形式（先頭から順に試す）
+// 月と日の順序が地域によって異なる "01/02/2020" のような形式は、誤って解釈しないよう受け付けない
+var PurchaseDateLayouts = []string{
+	dateLayout,         // 2020-01-02
+	"2006-1-2",         // 2020-1-2
+	"2006/1/2",         // 2020/01/02, 2020/1/2
+	"2006.1.2",         // 2020.01.02, 2020.1.2
+	"20060102",         // 20200102
+	"2006年1月2日",        // 2020年1月2日
+	"Jan 2 2006",       // Jan 2 2020
+	"Jan 2, 2006",      // Jan 2, 2020
+	"January 2 2006",   // January 2 2020
+	"January 2, 2006",  // January 2, 2020
+	"2 Jan 2006",       // 2 Jan 2020
+	"2 January 2006",   // 2 January 2020
+	"Mon, Jan 2, 2006", // Thu, Jan 2, 2020
+}
+
+// 購入日の文字列を解釈し、YYYY-MM-DD 形式にする（例: "2020/1/2" → "2020-01-02"）
+// 日時（RFC3339）の場合は、アプリケーションのタイムゾーンでの日付にする
+func ParsePurchaseDate(value string) (string, error) {
+	value = strings.Join(strings.Fields(value), " ")
+	if value == "" {
+		return "", ErrInvalidDateFormat
+	}
+
+	for _, layout := range PurchaseDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date.Format(dateLayout), nil
+		}
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.In(TimeZone()).Format(dateLayout), nil
+	}
+	return "", ErrInvalidDateFormat
+}
@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// 減価償却の各年度の簿価
+type DepreciationEntry struct {
+	Year                    int    `json:"year"` // 購入からの年数（1始まり）
+	Date                    string `json:"date"` // 年度末の日付（購入日からyear年後、YYYY-MM-DD形式）
+	Depreciation            Money  `json:"depreciation"`
+	AccumulatedDepreciation Money  `json:"accumulated_depreciation"`
+	BookValue               Money  `json:"book_value"`
+}
+
+// 定額法で耐用年数ごとの簿価を計算する
+// 1円未満の端数は最終年度で調整し、最終年度の簿価が残存価額と一致するようにする
+func (i *Item) StraightLineDepreciation(years int, salvage Money) ([]DepreciationEntry, error) {
+	if years <= 0 {
+		return nil, errors.New("years must be greater than 0")
+	}
+	if salvage.IsNegative() {
+		return nil, errors.New("salvage must be 0 or greater")
+	}
+	if salvage.Currency != i.PurchasePrice.Currency {
+		return nil, ErrCurrencyMismatch
+	}
+	if salvage.Amount > i.PurchasePrice.Amount {
+		return nil, errors.New("salvage must not exceed purchase_price")
+	}
+
+	purchaseDate, err := time.Parse(dateLayout, i.PurchaseDate)
+	if err != nil {
+		return nil, errors.New("purchase_date must be in YYYY-MM-DD format")
+	}
+
+	currency := i.PurchasePrice.Currency
+	depreciable := i.PurchasePrice.Amount - salvage.Amount
+	annual := depreciable / int64(years)
+
+	schedule := make([]DepreciationEntry, 0, years)
+	var accumulated int64
+	for year := 1; year <= years; year++ {
+		amount := annual
+		if year == years {
+			amount = depreciable - accumulated
+		}
+		accumulated += amount
+
+		schedule = append(schedule, DepreciationEntry{
+			Year:                    year,
+			Date:                    purchaseDate.AddDate(year, 0, 0).Format(dateLayout),
+			Depreciation:            NewMoney(amount, currency),
+			AccumulatedDepreciation: NewMoney(accumulated, currency),
+			BookValue:               NewMoney(i.PurchasePrice.Amount-accumulated, currency),
+		})
+	}
+
+	return schedule, nil
+}
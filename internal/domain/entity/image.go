@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// アイテムの画像（1つのアイテムに複数登録でき、positionの昇順で表示する）
+type ItemImage struct {
+	ID          int64     `json:"id"`
+	ItemID      int64     `json:"item_id"`
+	Position    int       `json:"position"` // 表示順（1始まり）
+	ContentType string    `json:"content_type"`
+	Size        int       `json:"size"` // バイト数
+	CreatedAt   time.Time `json:"created_at"`
+	Data        []byte    `json:"-"` // 画像の本体（一覧では読み込まない）
+	Thumbnail   []byte    `json:"-"` // 一覧表示用の縮小画像（JPEG、元の画像が十分小さい場合はnil）
+}
+
+// 取得する画像のサイズ
+type ImageSize string
+
+const (
+	ImageSizeOriginal ImageSize = "original"
+	ImageSizeThumb    ImageSize = "thumb"
+)
+
+// 縮小画像の形式
+const ThumbnailContentType = "image/jpeg"
+
+// 登録できる画像の形式（登録時に内容を検証し縮小画像を作るため、標準ライブラリで読み込める形式のみ）
+var ImageContentTypes = []string{"image/jpeg", "image/png", "image/gif"}
+
+// 対応していない形式の画像
+var ErrUnsupportedImageType = errors.New("image must be one of: image/jpeg, image/png, image/gif")
+
+// 画像の本体から形式を判定して新しい画像を作成する（クライアントが指定したContent-Typeは使わない）
+func NewItemImage(itemID int64, data []byte) (*ItemImage, error) {
+	if len(data) == 0 {
+		return nil, errors.New("image must not be empty")
+	}
+
+	contentType := http.DetectContentType(data)
+	if !isImageContentType(contentType) {
+		return nil, ErrUnsupportedImageType
+	}
+
+	return &ItemImage{
+		ItemID:      itemID,
+		ContentType: contentType,
+		Size:        len(data),
+		CreatedAt:   Now(),
+		Data:        data,
+	}, nil
+}
+
+// 画像の本体を取得するURL（itemPathIDはパスで指定するアイテムのID、ID_TYPE=uuid の場合はUUID）
+func (i *ItemImage) URL(itemPathID string) string {
+	return fmt.Sprintf("/items/%s/images/%d", itemPathID, i.ID)
+}
+
+// 縮小画像を取得するURL
+func (i *ItemImage) ThumbnailURL(itemPathID string) string {
+	return i.URL(itemPathID) + "?size=" + string(ImageSizeThumb)
+}
+
+// 指定したサイズの画像と形式（縮小画像が無い場合は元の画像）
+func (i *ItemImage) Variant(size ImageSize) ([]byte, string) {
+	if size == ImageSizeThumb && len(i.Thumbnail) > 0 {
+		return i.Thumbnail, ThumbnailContentType
+	}
+	return i.Data, i.ContentType
+}
+
+func isImageContentType(contentType string) bool {
+	for _, allowed := range ImageContentTypes {
+		if contentType == allowed {
+			return true
+		}
+	}
+	return false
+}
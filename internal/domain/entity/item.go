@@ -2,33 +2,48 @@ package entity
 
 import (
 	"errors"
+	"strconv"
 	"strings"
 	"time"
 )
 
+// 名前・ブランド・シリアル番号の最大文字数（バイト数）
+const MaxTextFieldLength = 100
+
+// 購入価格の上限（1兆円）
+// 集計は購入価格の合計をint64で扱うため、1件あたりの上限を設けて合計があふれないようにする
+const MaxPurchasePrice int64 = 1_000_000_000_000
+
+// 論理削除の理由の最大文字数
+const MaxDeleteReasonLength = 100
+
 type Item struct {
-	ID            int64     `json:"id"`
-	Name          string    `json:"name"`
-	Category      string    `json:"category"`
-	Brand         string    `json:"brand"`
-	PurchasePrice int       `json:"purchase_price"`
-	PurchaseDate  string    `json:"purchase_date"` // YYYY-MM-DD 形式
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID            int64      `json:"id"`
+	Name          string     `json:"name"`
+	Category      Category   `json:"category"`
+	Brand         string     `json:"brand"`
+	PurchasePrice Money      `json:"purchase_price"`
+	PurchaseDate  string     `json:"purchase_date"`           // YYYY-MM-DD 形式
+	SerialNumber  string     `json:"serial_number,omitempty"` // 任意（指定時は一意）
+	Status        Status     `json:"status"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"`    // 論理削除済みの場合のみ
+	DeleteReason  string     `json:"delete_reason,omitempty"` // 論理削除の理由（削除時に指定した場合のみ）
+	Version       int64      `json:"-"`                       // 書き込むたびに1増える（ETagに使う）
+	UUID          string     `json:"-"`                       // ID_TYPE=uuid の場合にAPIで使うID（それ以外は空）
 }
 
-// カテゴリー定義
-var ValidCategories = []string{"時計", "バッグ", "ジュエリー", "靴", "その他"}
-
-func NewItem(name, category, brand string, purchasePrice int, purchaseDate string) (*Item, error) {
+func NewItem(name string, category Category, brand string, purchasePrice int64, purchaseDate string) (*Item, error) {
 	item := &Item{
 		Name:          strings.TrimSpace(name),
-		Category:      strings.TrimSpace(category),
+		Category:      category.Normalize(),
 		Brand:         strings.TrimSpace(brand),
-		PurchasePrice: purchasePrice,
+		PurchasePrice: JPY(purchasePrice),
 		PurchaseDate:  strings.TrimSpace(purchaseDate),
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
+		Status:        StatusActive,
+		CreatedAt:     Now(),
+		UpdatedAt:     Now(),
 	}
 
 	if err := item.Validate(); err != nil {
@@ -38,74 +53,106 @@ func NewItem(name, category, brand string, purchasePrice int, purchaseDate strin
 	return item, nil
 }
 
+// フィールド単位のバリデーションエラー
+type FieldError struct {
+	Field   string
+	Message string
+}
+
 // アイテムフィールドのバリデーション
 func (i *Item) Validate() error {
-	var errs []string
+	fieldErrs := i.FieldErrors()
+	if len(fieldErrs) == 0 {
+		return nil
+	}
+
+	errs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		errs = append(errs, fieldErr.Message)
+	}
+	return errors.New(strings.Join(errs, ", "))
+}
+
+// バリデーションエラーをフィールドごとに返す（エラーが無い場合はnil）
+func (i *Item) FieldErrors() []FieldError {
+	var errs []FieldError
 
 	if i.Name == "" {
-		errs = append(errs, "name is required")
-	} else if len(i.Name) > 100 {
-		errs = append(errs, "name must be 100 characters or less")
+		errs = append(errs, FieldError{"name", "name is required"})
+	} else if len(i.Name) > MaxTextFieldLength {
+		errs = append(errs, FieldError{"name", "name must be 100 characters or less"})
 	}
 
 	if i.Category == "" {
-		errs = append(errs, "category is required")
-	} else if !isValidCategory(i.Category) {
-		errs = append(errs, "category must be one of: 時計, バッグ, ジュエリー, 靴, その他")
+		errs = append(errs, FieldError{"category", "category is required"})
+	} else if !i.Category.IsValid() {
+		errs = append(errs, FieldError{"category", ErrInvalidCategory.Error()})
 	}
 
 	if i.Brand == "" {
-		errs = append(errs, "brand is required")
-	} else if len(i.Brand) > 100 {
-		errs = append(errs, "brand must be 100 characters or less")
+		errs = append(errs, FieldError{"brand", "brand is required"})
+	} else if len(i.Brand) > MaxTextFieldLength {
+		errs = append(errs, FieldError{"brand", "brand must be 100 characters or less"})
 	}
 
-	if i.PurchasePrice < 0 {
-		errs = append(errs, "purchase_price must be 0 or greater")
+	if i.PurchasePrice.IsNegative() {
+		errs = append(errs, FieldError{"purchase_price", "purchase_price must be 0 or greater"})
+	} else if i.PurchasePrice.Amount > MaxPurchasePrice {
+		errs = append(errs, FieldError{"purchase_price", "purchase_price must be " + strconv.FormatInt(MaxPurchasePrice, 10) + " or less"})
 	}
 
 	if i.PurchaseDate == "" {
-		errs = append(errs, "purchase_date is required")
+		errs = append(errs, FieldError{"purchase_date", "purchase_date is required"})
 	} else if !isValidDateFormat(i.PurchaseDate) {
-		errs = append(errs, "purchase_date must be in YYYY-MM-DD format")
+		errs = append(errs, FieldError{"purchase_date", "purchase_date must be in YYYY-MM-DD format"})
+	} else if i.PurchaseDate > Today() {
+		// YYYY-MM-DD形式のため文字列の比較で日付の前後を判定できる
+		errs = append(errs, FieldError{"purchase_date", "purchase_date must not be in the future"})
 	}
 
-	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, ", "))
+	if len(i.SerialNumber) > MaxTextFieldLength {
+		errs = append(errs, FieldError{"serial_number", "serial_number must be 100 characters or less"})
 	}
 
-	return nil
+	// 空の場合は所有中として扱う
+	if i.Status != "" && !i.Status.IsValid() {
+		errs = append(errs, FieldError{"status", ErrInvalidStatus.Error()})
+	}
+
+	return errs
 }
 
 // アイテムフィールドのアップデート
-func (i *Item) Update(name, category, brand string, purchasePrice int, purchaseDate string) error {
+func (i *Item) Update(name string, category Category, brand string, purchasePrice int64, purchaseDate string) error {
 	i.Name = strings.TrimSpace(name)
-	i.Category = strings.TrimSpace(category)
+	i.Category = category.Normalize()
 	i.Brand = strings.TrimSpace(brand)
-	i.PurchasePrice = purchasePrice
+	i.PurchasePrice = JPY(purchasePrice)
 	i.PurchaseDate = strings.TrimSpace(purchaseDate)
-	i.UpdatedAt = time.Now()
+	i.UpdatedAt = Now()
 
 	return i.Validate()
 }
 
-// カテゴリーのバリデーション
-func isValidCategory(category string) bool {
-	for _, valid := range ValidCategories {
-		if category == valid {
-			return true
-		}
+// アイテムのバージョンを表すETag（IDとバージョンから生成）
+// 更新日時は秒単位のため、同じ秒に2回更新されても変わるようバージョンを使う
+func (i *Item) ETag() string {
+	return `"` + i.PublicID() + "-" + strconv.FormatInt(i.Version, 10) + `"`
+}
+
+// APIのパスやレスポンスで使うID（UUIDが振られている場合はUUID、それ以外は数値のID）
+func (i *Item) PublicID() string {
+	if i.UUID != "" {
+		return i.UUID
 	}
-	return false
+	return strconv.FormatInt(i.ID, 10)
 }
 
+// 購入日の形式
+const dateLayout = "2006-01-02"
+
 // デート形式のバリデーション
 func isValidDateFormat(dateStr string) bool {
-	_, err := time.Parse("2006-01-02", dateStr)
+	_, err := time.Parse(dateLayout, dateStr)
 	return err == nil
 }
-
-// カテゴリーの取得
-func GetValidCategories() []string {
-	return ValidCategories
-}
@@ -0,0 +1,83 @@
+package entity
+
+import "strings"
+
+// 見直しが必要な（情報が欠けている・不正な）フィールド
+type MissingField struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// アイテムの他に、欠けの判定に使う情報
+type CompletenessFacts struct {
+	// 画像の有無を判定するか（画像の登録状況を読み込んでいない場合はfalse）
+	CheckImages bool
+	// 画像が1枚以上登録されているか
+	HasImage bool
+}
+
+// 登録済みのデータの欠けを検出するルール
+// 問題が無い場合は空文字を返す。基準を追加する場合はcompletenessRulesに追記する
+type completenessRule struct {
+	field string
+	check func(i *Item, facts CompletenessFacts) string
+}
+
+var completenessRules = []completenessRule{
+	{field: "name", check: checkNamePresent},
+	{field: "brand", check: checkBrandPresent},
+	{field: "category", check: checkCategoryValid},
+	{field: "purchase_date", check: checkPurchaseDateValid},
+	{field: "images", check: checkImagePresent},
+}
+
+// 完全性の基準を満たしていないフィールドの一覧を返す（問題が無い場合はnil）
+func (i *Item) MissingFields(facts CompletenessFacts) []MissingField {
+	var missing []MissingField
+	for _, rule := range completenessRules {
+		if reason := rule.check(i, facts); reason != "" {
+			missing = append(missing, MissingField{Field: rule.field, Reason: reason})
+		}
+	}
+	return missing
+}
+
+func checkNamePresent(i *Item, _ CompletenessFacts) string {
+	if strings.TrimSpace(i.Name) == "" {
+		return "name is empty"
+	}
+	return ""
+}
+
+func checkBrandPresent(i *Item, _ CompletenessFacts) string {
+	if strings.TrimSpace(i.Brand) == "" {
+		return "brand is empty"
+	}
+	return ""
+}
+
+func checkCategoryValid(i *Item, _ CompletenessFacts) string {
+	if !i.Category.IsValid() {
+		return "category is not one of the valid categories"
+	}
+	return ""
+}
+
+func checkPurchaseDateValid(i *Item, _ CompletenessFacts) string {
+	switch {
+	case strings.TrimSpace(i.PurchaseDate) == "":
+		return "purchase_date is empty"
+	case !isValidDateFormat(i.PurchaseDate):
+		return "purchase_date is not a valid YYYY-MM-DD date"
+	case i.PurchaseDate > Today():
+		return "purchase_date is in the future"
+	}
+	return ""
+}
+
+func checkImagePresent(_ *Item, facts CompletenessFacts) string {
+	if facts.CheckImages && !facts.HasImage {
+		return "item has no images"
+	}
+	return ""
+}
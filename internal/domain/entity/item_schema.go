@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// フィールドの型（JSONでの表現）
+const (
+	FieldTypeString   = "string"
+	FieldTypeInteger  = "integer"
+	FieldTypeDate     = "date"      // YYYY-MM-DD
+	FieldTypeDateTime = "date-time" // RFC 3339
+	FieldTypeMoney    = "money"     // {"amount":1000000,"currency":"JPY"}（入力は数値のみでも可）
+	FieldTypeEnum     = "enum"      // values のいずれか
+)
+
+// アイテムの1フィールドの型と制約（フォームの自動生成などに使う）
+type FieldSchema struct {
+	Name      string   `json:"name"` // JSONのフィールド名
+	Type      string   `json:"type"`
+	Required  bool     `json:"required"`
+	MaxLength int      `json:"max_length,omitempty"`
+	Minimum   *int64   `json:"minimum,omitempty"`
+	Values    []string `json:"values,omitempty"` // enumの場合の選択肢
+}
+
+// FieldErrorsで検証している制約（ここに無いフィールドは任意で制約なし）
+var itemFieldRules = map[string]FieldSchema{
+	"name":           {Required: true, MaxLength: MaxTextFieldLength},
+	"category":       {Required: true},
+	"brand":          {Required: true, MaxLength: MaxTextFieldLength},
+	"purchase_price": {Minimum: new(int64)},
+	"purchase_date":  {Required: true, Type: FieldTypeDate},
+	"serial_number":  {MaxLength: MaxTextFieldLength},
+	"delete_reason":  {MaxLength: MaxDeleteReasonLength},
+}
+
+// Itemのフィールドの定義をJSONのフィールド順に返す
+// フィールド名と型はItemの定義から求めるため、フィールドを追加すると自動的に含まれる
+func ItemSchema() []FieldSchema {
+	itemType := reflect.TypeOf(Item{})
+	fields := make([]FieldSchema, 0, itemType.NumField())
+	for i := 0; i < itemType.NumField(); i++ {
+		name, _, _ := strings.Cut(itemType.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		field := itemFieldRules[name]
+		field.Name = name
+		if field.Type == "" {
+			field.Type, field.Values = fieldType(itemType.Field(i).Type)
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// Goの型からJSONでの型を求める（列挙型の場合は選択肢も返す）
+func fieldType(t reflect.Type) (string, []string) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t {
+	case reflect.TypeOf(Category("")):
+		values := make([]string, len(ValidCategories))
+		for i, category := range ValidCategories {
+			values[i] = category.String()
+		}
+		return FieldTypeEnum, values
+	case reflect.TypeOf(Status("")):
+		values := make([]string, len(ValidStatuses))
+		for i, status := range ValidStatuses {
+			values[i] = status.String()
+		}
+		return FieldTypeEnum, values
+	case reflect.TypeOf(Money{}):
+		return FieldTypeMoney, nil
+	case reflect.TypeOf(time.Time{}):
+		return FieldTypeDateTime, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return FieldTypeInteger, nil
+	default:
+		return FieldTypeString, nil
+	}
+}
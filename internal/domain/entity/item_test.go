@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"encoding/json"
+	"math"
 	"testing"
 	"time"
 
@@ -12,9 +14,9 @@ func TestNewItem(t *testing.T) {
 	tests := []struct {
 		name          string
 		itemName      string
-		category      string
+		category      Category
 		brand         string
-		purchasePrice int
+		purchasePrice int64
 		purchaseDate  string
 		wantErr       bool
 		expectedErr   string
@@ -98,6 +100,16 @@ func TestNewItem(t *testing.T) {
 			wantErr:       true,
 			expectedErr:   "purchase_price must be 0 or greater",
 		},
+		{
+			name:          "異常系: 購入価格が上限を超える",
+			itemName:      "ロレックス デイトナ",
+			category:      "時計",
+			brand:         "ROLEX",
+			purchasePrice: MaxPurchasePrice + 1,
+			purchaseDate:  "2023-01-15",
+			wantErr:       true,
+			expectedErr:   "purchase_price must be 1000000000000 or less",
+		},
 		{
 			name:          "異常系: 購入日が空",
 			itemName:      "ロレックス デイトナ",
@@ -118,6 +130,16 @@ func TestNewItem(t *testing.T) {
 			wantErr:       true,
 			expectedErr:   "purchase_date must be in YYYY-MM-DD format",
 		},
+		{
+			name:          "異常系: 未来の購入日",
+			itemName:      "ロレックス デイトナ",
+			category:      "時計",
+			brand:         "ROLEX",
+			purchasePrice: 1500000,
+			purchaseDate:  Now().AddDate(0, 0, 1).Format("2006-01-02"),
+			wantErr:       true,
+			expectedErr:   "purchase_date must not be in the future",
+		},
 		{
 			name:          "正常系: 購入価格が0",
 			itemName:      "ギフト品",
@@ -147,7 +169,7 @@ func TestNewItem(t *testing.T) {
 			assert.Equal(t, tt.itemName, item.Name)
 			assert.Equal(t, tt.category, item.Category)
 			assert.Equal(t, tt.brand, item.Brand)
-			assert.Equal(t, tt.purchasePrice, item.PurchasePrice)
+			assert.Equal(t, JPY(tt.purchasePrice), item.PurchasePrice)
 			assert.Equal(t, tt.purchaseDate, item.PurchaseDate)
 
 			// CreatedAt と UpdatedAt がセットされているかチェック
@@ -168,9 +190,9 @@ func TestItem_Update(t *testing.T) {
 	tests := []struct {
 		name        string
 		newName     string
-		newCategory string
+		newCategory Category
 		newBrand    string
-		newPrice    int
+		newPrice    int64
 		newDate     string
 		wantErr     bool
 		expectedErr string
@@ -222,7 +244,7 @@ func TestItem_Update(t *testing.T) {
 			assert.Equal(t, tt.newName, item.Name)
 			assert.Equal(t, tt.newCategory, item.Category)
 			assert.Equal(t, tt.newBrand, item.Brand)
-			assert.Equal(t, tt.newPrice, item.PurchasePrice)
+			assert.Equal(t, JPY(tt.newPrice), item.PurchasePrice)
 			assert.Equal(t, tt.newDate, item.PurchaseDate)
 
 			// UpdatedAt が更新されているかチェック
@@ -244,7 +266,7 @@ func TestItem_Validate(t *testing.T) {
 				Name:          "ロレックス デイトナ",
 				Category:      "時計",
 				Brand:         "ROLEX",
-				PurchasePrice: 1500000,
+				PurchasePrice: JPY(1500000),
 				PurchaseDate:  "2023-01-15",
 			},
 			wantErr: false,
@@ -255,7 +277,7 @@ func TestItem_Validate(t *testing.T) {
 				Name:          "",
 				Category:      "",
 				Brand:         "",
-				PurchasePrice: -1,
+				PurchasePrice: JPY(-1),
 				PurchaseDate:  "",
 			},
 			wantErr:     true,
@@ -278,10 +300,10 @@ func TestItem_Validate(t *testing.T) {
 	}
 }
 
-func TestIsValidCategory(t *testing.T) {
+func TestCategory_IsValid(t *testing.T) {
 	tests := []struct {
 		name     string
-		category string
+		category Category
 		want     bool
 	}{
 		{"有効なカテゴリー: 時計", "時計", true},
@@ -296,12 +318,114 @@ func TestIsValidCategory(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := isValidCategory(tt.category)
+			got := tt.category.IsValid()
 			assert.Equal(t, tt.want, got)
 		})
 	}
 }
 
+func TestNewCategory(t *testing.T) {
+	category, err := NewCategory(" 時計 ")
+	assert.NoError(t, err)
+	assert.Equal(t, CategoryWatch, category)
+
+	category, err = NewCategory("衣服")
+	assert.ErrorIs(t, err, ErrInvalidCategory)
+	assert.EqualError(t, err, "category must be one of: 時計, バッグ, ジュエリー, 靴, その他")
+	assert.Empty(t, category)
+}
+
+func TestCategory_Normalize(t *testing.T) {
+	// 英字のカテゴリーを許可リストに加えて、大文字・小文字の違いを確認する
+	defer func(categories []Category) { ValidCategories = categories }(ValidCategories)
+	ValidCategories = append([]Category{"Watch"}, ValidCategories...)
+
+	tests := []struct {
+		name     string
+		category Category
+		want     Category
+	}{
+		{"前後の空白", " 時計\t", CategoryWatch},
+		{"大文字・小文字の違い", "wATCH", "Watch"},
+		{"空白と小文字", " watch ", "Watch"},
+		{"全角英字は区別する", "ｗａｔｃｈ", "ｗａｔｃｈ"},
+		{"許可リストに無い値は空白のみ除く", " 衣服 ", "衣服"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.category.Normalize())
+		})
+	}
+
+	category, err := NewCategory("WATCH ")
+	assert.NoError(t, err)
+	assert.Equal(t, Category("Watch"), category)
+
+	item, err := NewItem("Speedmaster", " watch", "OMEGA", 650000, "2022-06-01")
+	require.NoError(t, err)
+	assert.Equal(t, Category("Watch"), item.Category)
+
+	require.NoError(t, item.Update("Speedmaster", "バッグ ", "OMEGA", 650000, "2022-06-01"))
+	assert.Equal(t, CategoryBag, item.Category)
+}
+
+func TestNewStatus(t *testing.T) {
+	status, err := NewStatus(" draft ")
+	assert.NoError(t, err)
+	assert.Equal(t, StatusDraft, status)
+
+	status, err = NewStatus("sold")
+	assert.ErrorIs(t, err, ErrInvalidStatus)
+	assert.Empty(t, status)
+}
+
+func TestItem_Status(t *testing.T) {
+	item, err := NewItem("ロレックス デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+	assert.NoError(t, err)
+	assert.Equal(t, StatusActive, item.Status)
+
+	item.Status = "sold"
+	assert.EqualError(t, item.Validate(), "status must be one of: draft, active, archived")
+}
+
+func TestItem_Validate_FutureDateAtMidnight(t *testing.T) {
+	defer SetTimeZone(TimeZone())
+	SetTimeZone(time.FixedZone("JST", 9*60*60))
+	item := &Item{Name: "時計", Category: CategoryWatch, Brand: "ROLEX", PurchaseDate: "2024-04-01"}
+
+	// 日付が変わる直前は翌日の購入日を未来として扱う
+	restore := SetClock(FixedClock(time.Date(2024, 3, 31, 23, 59, 59, 0, time.FixedZone("JST", 9*60*60))))
+	assert.EqualError(t, item.Validate(), "purchase_date must not be in the future")
+	restore()
+
+	defer SetClock(FixedClock(time.Date(2024, 4, 1, 0, 0, 0, 0, time.FixedZone("JST", 9*60*60))))()
+	assert.NoError(t, item.Validate())
+}
+
+func TestToday_UsesTimeZone(t *testing.T) {
+	defer SetTimeZone(TimeZone())
+	defer SetClock(FixedClock(time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)))()
+
+	// UTC+14とUTC-12では常に日付が異なる
+	ahead := time.FixedZone("UTC+14", 14*60*60)
+	behind := time.FixedZone("UTC-12", -12*60*60)
+
+	SetTimeZone(ahead)
+	aheadToday := Today()
+	assert.Equal(t, "2024-04-01", aheadToday)
+
+	SetTimeZone(behind)
+	assert.NotEqual(t, aheadToday, Today())
+
+	// UTC+14での今日はUTC-12ではまだ未来の日付
+	item := &Item{Name: "時計", Category: CategoryWatch, Brand: "ROLEX", PurchaseDate: aheadToday}
+	assert.EqualError(t, item.Validate(), "purchase_date must not be in the future")
+
+	SetTimeZone(ahead)
+	assert.NoError(t, item.Validate())
+}
+
 func TestIsValidDateFormat(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -330,8 +454,372 @@ func TestIsValidDateFormat(t *testing.T) {
 
 func TestGetValidCategories(t *testing.T) {
 	categories := GetValidCategories()
-	expected := []string{"時計", "バッグ", "ジュエリー", "靴", "その他"}
+	expected := []Category{"時計", "バッグ", "ジュエリー", "靴", "その他"}
 
 	assert.Equal(t, expected, categories)
 	assert.Len(t, categories, 5)
 }
+
+func TestItem_Warnings(t *testing.T) {
+	tests := []struct {
+		name          string
+		purchasePrice int64
+		purchaseDate  string
+		expectedCount int
+		expectedWarn  string
+	}{
+		{
+			name:          "正常系: 警告なし",
+			purchasePrice: 1500000,
+			purchaseDate:  "2023-01-15",
+			expectedCount: 0,
+		},
+		{
+			name:          "警告: 購入日が古すぎる",
+			purchasePrice: 1500000,
+			purchaseDate:  "1900-01-01",
+			expectedCount: 1,
+			expectedWarn:  "purchase_date is unusually old, please check the year",
+		},
+		{
+			name:          "正常系: 購入価格が0は警告なし",
+			purchasePrice: 0,
+			purchaseDate:  "2023-01-15",
+			expectedCount: 0,
+		},
+		{
+			name:          "警告: 購入価格が100万円単位ちょうど",
+			purchasePrice: 3000000,
+			purchaseDate:  "2023-01-15",
+			expectedCount: 1,
+			expectedWarn:  "purchase_price is an unusually round number, please check if this is an estimate",
+		},
+		{
+			name:          "警告: 複数の警告",
+			purchasePrice: 2000000,
+			purchaseDate:  "1900-01-01",
+			expectedCount: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := NewItem("アイテム", "時計", "ROLEX", tt.purchasePrice, tt.purchaseDate)
+			require.NoError(t, err)
+
+			warnings := item.Warnings()
+			assert.Len(t, warnings, tt.expectedCount)
+			if tt.expectedWarn != "" {
+				assert.Contains(t, warnings, tt.expectedWarn)
+			}
+		})
+	}
+}
+
+func TestFormatPrice(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int64
+		want   string
+	}{
+		{"0円", 0, "¥0"},
+		{"3桁", 999, "¥999"},
+		{"4桁", 1000, "¥1,000"},
+		{"7桁", 1000000, "¥1,000,000"},
+		{"端数あり", 1234567, "¥1,234,567"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FormatPrice(tt.amount))
+		})
+	}
+}
+
+func TestParsePrice(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{"数字のみ", "1000000", 1000000, false},
+		{"3桁区切り", "1,000,000", 1000000, false},
+		{"通貨記号あり", "¥1,500,000", 1500000, false},
+		{"全角の通貨記号", "￥980", 980, false},
+		{"末尾に円", "1,000円", 1000, false},
+		{"前後の空白", " ¥1,000 ", 1000, false},
+		{"負の値", "-1,000", -1000, false},
+		{"区切り位置が不正", "1,00,0", 0, true},
+		{"小数", "1,000.5", 0, true},
+		{"数字以外", "abc", 0, true},
+		{"記号のみ", "¥", 0, true},
+		{"プラス記号", "+1000", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePrice(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPriceFormat)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMoney_Add(t *testing.T) {
+	sum, err := JPY(1000000).Add(JPY(500000))
+	assert.NoError(t, err)
+	assert.Equal(t, JPY(1500000), sum)
+
+	_, err = JPY(1000).Add(NewMoney(1000, "USD"))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+}
+
+func TestMoney_String(t *testing.T) {
+	assert.Equal(t, "¥1,500,000", JPY(1500000).String())
+	assert.Equal(t, "1000 USD", NewMoney(1000, "USD").String())
+}
+
+func TestMoney_JSON(t *testing.T) {
+	data, err := json.Marshal(JPY(1000000))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"amount":1000000,"currency":"JPY"}`, string(data))
+
+	tests := []struct {
+		name    string
+		data    string
+		want    Money
+		wantErr bool
+	}{
+		{"オブジェクト形式", `{"amount":1000,"currency":"USD"}`, NewMoney(1000, "USD"), false},
+		{"数値のみは日本円", `1500000`, JPY(1500000), false},
+		{"小数", `1000.5`, Money{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Money
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestItem_StraightLineDepreciation(t *testing.T) {
+	item := &Item{PurchasePrice: JPY(1000000), PurchaseDate: "2024-02-29"}
+
+	schedule, err := item.StraightLineDepreciation(3, JPY(0))
+	require.NoError(t, err)
+	require.Len(t, schedule, 3)
+
+	// 1000000 / 3 の端数は最終年度で調整する
+	assert.Equal(t, JPY(333333), schedule[0].Depreciation)
+	assert.Equal(t, JPY(333334), schedule[2].Depreciation)
+	assert.Equal(t, JPY(1000000), schedule[2].AccumulatedDepreciation)
+	assert.Equal(t, JPY(0), schedule[2].BookValue)
+	assert.Equal(t, "2025-03-01", schedule[0].Date)
+
+	_, err = item.StraightLineDepreciation(-1, JPY(0))
+	assert.EqualError(t, err, "years must be greater than 0")
+	_, err = item.StraightLineDepreciation(5, JPY(-1))
+	assert.EqualError(t, err, "salvage must be 0 or greater")
+	_, err = item.StraightLineDepreciation(5, JPY(1000001))
+	assert.EqualError(t, err, "salvage must not exceed purchase_price")
+	_, err = item.StraightLineDepreciation(5, NewMoney(0, "USD"))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+}
+
+func TestMoney_JSON_MaxInt64(t *testing.T) {
+	item := Item{PurchasePrice: JPY(math.MaxInt64)}
+
+	data, err := json.Marshal(item)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"purchase_price":{"amount":9223372036854775807,"currency":"JPY"}`)
+
+	var decoded Item
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, int64(math.MaxInt64), decoded.PurchasePrice.Amount)
+
+	// 数値のみの形式も同様
+	var money Money
+	require.NoError(t, json.Unmarshal([]byte("9223372036854775807"), &money))
+	assert.Equal(t, JPY(math.MaxInt64), money)
+	assert.Error(t, json.Unmarshal([]byte("9223372036854775808"), &money))
+
+	amount, err := ParsePrice("¥9,223,372,036,854,775,807")
+	require.NoError(t, err)
+	assert.Equal(t, int64(math.MaxInt64), amount)
+	_, err = ParsePrice("9223372036854775808")
+	assert.ErrorIs(t, err, ErrInvalidPriceFormat)
+}
+
+func TestItem_MissingFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		item     Item
+		facts    CompletenessFacts
+		expected []string
+	}{
+		{
+			name:     "正常系: 欠けなし",
+			item:     Item{Name: "ロレックス デイトナ", Category: CategoryWatch, Brand: "ROLEX", PurchaseDate: "2023-01-15"},
+			expected: nil,
+		},
+		{
+			name:     "異常系: ブランドと購入日が欠けている",
+			item:     Item{Name: "ロレックス デイトナ", Category: CategoryWatch, Brand: " ", PurchaseDate: ""},
+			expected: []string{"brand", "purchase_date"},
+		},
+		{
+			name:     "異常系: 不正な日付とカテゴリー",
+			item:     Item{Name: "ロレックス デイトナ", Category: "家電", Brand: "ROLEX", PurchaseDate: "2023-02-30"},
+			expected: []string{"category", "purchase_date"},
+		},
+		{
+			name:     "正常系: 画像あり",
+			item:     Item{Name: "ロレックス デイトナ", Category: CategoryWatch, Brand: "ROLEX", PurchaseDate: "2023-01-15"},
+			facts:    CompletenessFacts{CheckImages: true, HasImage: true},
+			expected: nil,
+		},
+		{
+			name:     "異常系: 画像が無い",
+			item:     Item{Name: "ロレックス デイトナ", Category: CategoryWatch, Brand: "", PurchaseDate: "2023-01-15"},
+			facts:    CompletenessFacts{CheckImages: true},
+			expected: []string{"brand", "images"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fields []string
+			for _, missing := range tt.item.MissingFields(tt.facts) {
+				assert.NotEmpty(t, missing.Reason)
+				fields = append(fields, missing.Field)
+			}
+			assert.Equal(t, tt.expected, fields)
+		})
+	}
+}
+
+func TestParsePurchaseDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"YYYY-MM-DD", "2020-01-02", "2020-01-02", false},
+		{"ゼロ埋め無し", "2020-1-2", "2020-01-02", false},
+		{"スラッシュ区切り", "2020/01/02", "2020-01-02", false},
+		{"ドット区切り", "2020.1.2", "2020-01-02", false},
+		{"区切り無し", "20200102", "2020-01-02", false},
+		{"年月日", "2020年1月2日", "2020-01-02", false},
+		{"英語の月名", "Jan 2 2020", "2020-01-02", false},
+		{"英語の月名とカンマ", "January 2, 2020", "2020-01-02", false},
+		{"日が先", "2 Jan 2020", "2020-01-02", false},
+		{"大文字・小文字と空白", "  jan   2  2020 ", "2020-01-02", false},
+		{"RFC3339はアプリケーションのタイムゾーンの日付", "2020-01-01T16:00:00Z", "2020-01-02", false},
+		{"存在しない日付", "2020/02/30", "", true},
+		{"月と日の順序が曖昧", "01/02/2020", "", true},
+		{"空文字", " ", "", true},
+		{"日付以外", "yesterday", "", true},
+	}
+
+	tz := TimeZone()
+	SetTimeZone(time.FixedZone("JST", 9*60*60))
+	t.Cleanup(func() { SetTimeZone(tz) })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePurchaseDate(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidDateFormat)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestItemSchema(t *testing.T) {
+	fields := make(map[string]FieldSchema)
+	for _, field := range ItemSchema() {
+		fields[field.Name] = field
+	}
+
+	// JSONで返すフィールドがすべて含まれること
+	data, err := json.Marshal(&Item{SerialNumber: "SN-001", DeletedAt: &time.Time{}, DeleteReason: "sold"})
+	require.NoError(t, err)
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &body))
+	assert.Len(t, fields, len(body))
+	for name := range body {
+		assert.Contains(t, fields, name)
+	}
+
+	assert.Equal(t, FieldSchema{Name: "name", Type: FieldTypeString, Required: true, MaxLength: MaxTextFieldLength}, fields["name"])
+	assert.Equal(t, FieldTypeEnum, fields["category"].Type)
+	assert.Equal(t, []string{"時計", "バッグ", "ジュエリー", "靴", "その他"}, fields["category"].Values)
+	assert.Equal(t, []string{"draft", "active", "archived"}, fields["status"].Values)
+	assert.False(t, fields["status"].Required)
+	assert.Equal(t, FieldTypeMoney, fields["purchase_price"].Type)
+	require.NotNil(t, fields["purchase_price"].Minimum)
+	assert.Equal(t, int64(0), *fields["purchase_price"].Minimum)
+	assert.Equal(t, FieldTypeDate, fields["purchase_date"].Type)
+	assert.Equal(t, FieldTypeInteger, fields["id"].Type)
+	assert.Equal(t, FieldTypeDateTime, fields["deleted_at"].Type)
+}
+
+func TestNewUUID(t *testing.T) {
+	id := NewUUID()
+	parsed, ok := ParseUUID(id)
+	assert.True(t, ok)
+	assert.Equal(t, id, parsed)
+	assert.Equal(t, byte('4'), id[14], "version 4")
+	assert.Contains(t, "89ab", string(id[19]), "RFC 4122 variant")
+	assert.NotEqual(t, id, NewUUID())
+}
+
+func TestParseUUID(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+		ok       bool
+	}{
+		{name: "正常系: 小文字", value: "0b8f5c7e-3a1d-4e2b-9c6f-1a2b3c4d5e6f", expected: "0b8f5c7e-3a1d-4e2b-9c6f-1a2b3c4d5e6f", ok: true},
+		{name: "正常系: 大文字は小文字にする", value: "0B8F5C7E-3A1D-4E2B-9C6F-1A2B3C4D5E6F", expected: "0b8f5c7e-3a1d-4e2b-9c6f-1a2b3c4d5e6f", ok: true},
+		{name: "異常系: 数値のID", value: "123"},
+		{name: "異常系: ハイフンの位置が異なる", value: "0b8f5c7e3-a1d-4e2b-9c6f-1a2b3c4d5e6f"},
+		{name: "異常系: 16進数以外の文字", value: "0b8f5c7e-3a1d-4e2b-9c6f-1a2b3c4d5e6g"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, ok := ParseUUID(tt.value)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, parsed)
+		})
+	}
+}
+
+func TestItem_PublicID(t *testing.T) {
+	item := &Item{ID: 12, Version: 3}
+	assert.Equal(t, "12", item.PublicID())
+	assert.Equal(t, `"12-3"`, item.ETag())
+
+	// UUIDが振られている場合はETagにも数値のIDを含めない
+	item.UUID = "0b8f5c7e-3a1d-4e2b-9c6f-1a2b3c4d5e6f"
+	assert.Equal(t, item.UUID, item.PublicID())
+	assert.Equal(t, `"0b8f5c7e-3a1d-4e2b-9c6f-1a2b3c4d5e6f-3"`, item.ETag())
+}
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"time"
+)
+
+// ソフトバリデーションの閾値
+const (
+	// これより前の購入日は入力ミスの可能性が高い
+	oldPurchaseDateThreshold = "1950-01-01"
+	// この金額以上で100万円単位ちょうどの価格は概算値の可能性がある
+	roundPriceThreshold = 1000000
+)
+
+// 登録は妨げないが、入力ミスの可能性がある内容を検出するルール
+// 問題が無い場合は空文字を返す。ルールを追加する場合はwarningRulesに追記する
+type warningRule func(i *Item) string
+
+var warningRules = []warningRule{
+	warnOldPurchaseDate,
+	warnRoundPurchasePrice,
+}
+
+// ソフトバリデーションの警告一覧を返す（警告が無い場合はnil）
+func (i *Item) Warnings() []string {
+	var warnings []string
+	for _, rule := range warningRules {
+		if warning := rule(i); warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+	return warnings
+}
+
+func warnOldPurchaseDate(i *Item) string {
+	date, err := time.Parse("2006-01-02", i.PurchaseDate)
+	if err != nil {
+		return ""
+	}
+	threshold, _ := time.Parse("2006-01-02", oldPurchaseDateThreshold)
+	if date.Before(threshold) {
+		return "purchase_date is unusually old, please check the year"
+	}
+	return ""
+}
+
+func warnRoundPurchasePrice(i *Item) string {
+	amount := i.PurchasePrice.Amount
+	if amount >= roundPriceThreshold && amount%roundPriceThreshold == 0 {
+		return "purchase_price is an unusually round number, please check if this is an estimate"
+	}
+	return ""
+}
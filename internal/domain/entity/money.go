@@ -0,0 +1,131 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// 金額は通貨の最小単位の整数で扱う（日本円は補助単位が無いため1円単位）
+const (
+	PriceCurrency = "JPY"
+	priceSymbol   = "¥"
+)
+
+// 表示用の金額として解釈できない
+var ErrInvalidPriceFormat = errors.New("invalid price format")
+
+// 通貨が異なる金額同士を計算しようとした
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// 金額と通貨の組（JSONでは {"amount":1000000,"currency":"JPY"} になる）
+type Money struct {
+	Amount   int64  `json:"amount"`   // 通貨の最小単位での金額
+	Currency string `json:"currency"` // ISO 4217の通貨コード
+}
+
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// 日本円の金額
+func JPY(amount int64) Money {
+	return NewMoney(amount, PriceCurrency)
+}
+
+// 同じ通貨の金額を足し合わせる（通貨が異なる場合はErrCurrencyMismatch）
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return NewMoney(m.Amount+other.Amount, m.Currency), nil
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// 表示用の文字列（日本円は "¥1,000,000"、それ以外は "1000 USD"）
+func (m Money) String() string {
+	if m.Currency == PriceCurrency {
+		return FormatPrice(m.Amount)
+	}
+	return strconv.FormatInt(m.Amount, 10) + " " + m.Currency
+}
+
+// オブジェクト形式に加えて、数値のみの場合は日本円として受け付ける
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] != '{' {
+		var amount int64
+		if err := json.Unmarshal(trimmed, &amount); err != nil {
+			return err
+		}
+		*m = JPY(amount)
+		return nil
+	}
+
+	type money Money
+	var decoded money
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*m = Money(decoded)
+	return nil
+}
+
+// 金額を表示用の文字列にする（例: 1000000 → "¥1,000,000"）
+func FormatPrice(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+
+	return sign + priceSymbol + b.String()
+}
+
+// 表示用の金額の文字列を解釈する（例: "¥1,000,000" → 1000000）
+// 通貨記号（¥, ￥）、末尾の「円」、3桁区切りのカンマを許容する
+func ParsePrice(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	sign := ""
+	if strings.HasPrefix(value, "-") {
+		sign = "-"
+		value = value[1:]
+	}
+	for _, symbol := range []string{priceSymbol, "￥"} {
+		value = strings.TrimPrefix(value, symbol)
+	}
+	value = strings.TrimSpace(strings.TrimSuffix(value, "円"))
+
+	if strings.Contains(value, ",") {
+		groups := strings.Split(value, ",")
+		for i, group := range groups {
+			if (i == 0 && (len(group) == 0 || len(group) > 3)) || (i > 0 && len(group) != 3) {
+				return 0, ErrInvalidPriceFormat
+			}
+		}
+		value = strings.Join(groups, "")
+	}
+
+	// 先頭の "-" 以外の符号（"+1000" や "¥-1000" など）は受け付けない
+	if value == "" || strings.ContainsAny(value, "+-") {
+		return 0, ErrInvalidPriceFormat
+	}
+	amount, err := strconv.ParseInt(sign+value, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidPriceFormat
+	}
+	return amount, nil
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 名前の最大文字数
+const MaxSearchPresetNameLength = 50
+
+// 名前を付けて保存した検索条件（POST /items/search のリクエストボディ）
+type SearchPreset struct {
+	Name      string          `json:"name"`
+	Filter    json.RawMessage `json:"filter"` // 保存した時点の検索条件（実行時に改めて解釈する）
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// 名前を検証して新しい検索条件を作成する（前後の空白は除く）
+func NewSearchPreset(name string, filter json.RawMessage) (*SearchPreset, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if utf8.RuneCountInString(name) > MaxSearchPresetNameLength {
+		return nil, fmt.Errorf("name must be %d characters or less", MaxSearchPresetNameLength)
+	}
+	if strings.Contains(name, "/") {
+		return nil, fmt.Errorf("name must not contain /")
+	}
+
+	now := Now()
+	return &SearchPreset{
+		Name:      name,
+		Filter:    filter,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+)
+
+// アイテムの状態（下書き・所有中・アーカイブ）
+type Status string
+
+const (
+	StatusDraft    Status = "draft"    // 購入検討中などの下書き（一覧・集計には含めない）
+	StatusActive   Status = "active"   // 所有中
+	StatusArchived Status = "archived" // 手放したなどの記録
+)
+
+// 状態の定義
+var ValidStatuses = []Status{StatusDraft, StatusActive, StatusArchived}
+
+// 定義に無い状態
+var ErrInvalidStatus = errors.New("status must be one of: draft, active, archived")
+
+// 前後の空白を除いて検証し、Statusを返す
+func NewStatus(value string) (Status, error) {
+	status := Status(strings.TrimSpace(value))
+	if !status.IsValid() {
+		return "", ErrInvalidStatus
+	}
+	return status, nil
+}
+
+func (s Status) String() string {
+	return string(s)
+}
+
+// 定義に含まれるかどうか
+func (s Status) IsValid() bool {
+	for _, valid := range ValidStatuses {
+		if s == valid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// 日付の解釈と表示、「今日」の判定に使うアプリケーションのタイムゾーン
+// 起動時にSetTimeZoneで設定する（未設定の場合はサーバーのローカルタイムゾーン）
+var timeZone = time.Local
+
+func SetTimeZone(loc *time.Location) {
+	timeZone = loc
+}
+
+func TimeZone() *time.Location {
+	return timeZone
+}
+
+// アプリケーションのタイムゾーンでの現在時刻（SetClockで差し替えられる）
+func Now() time.Time {
+	return clock.Now().In(timeZone)
+}
+
+// アプリケーションのタイムゾーンでの今日の日付（YYYY-MM-DD形式）
+func Today() string {
+	return Now().Format(dateLayout)
+}
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+)
+
+// UUIDの文字列の長さ（items.uuid のCHAR(36)）
+const UUIDLength = 36
+
+// ランダムな（バージョン4の）UUIDを小文字の文字列で返す
+// 登録件数や登録順を推測できないよう、時刻やAUTO_INCREMENTのIDを含めない
+func NewUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// OSの乱数を読めない場合は、推測できるIDで登録しないよう停止する
+		panic(err)
+	}
+	b[6] = b[6]&0x0f | 0x40 // バージョン4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122のバリアント
+
+	var s [UUIDLength]byte
+	hex.Encode(s[0:8], b[0:4])
+	s[8] = '-'
+	hex.Encode(s[9:13], b[4:6])
+	s[13] = '-'
+	hex.Encode(s[14:18], b[6:8])
+	s[18] = '-'
+	hex.Encode(s[19:23], b[8:10])
+	s[23] = '-'
+	hex.Encode(s[24:], b[10:])
+	return string(s[:])
+}
+
+// 8-4-4-4-12 の形式のUUIDを、保存している小文字の表記にして返す（形式が異なる場合はfalse）
+func ParseUUID(value string) (string, bool) {
+	if len(value) != UUIDLength {
+		return "", false
+	}
+	value = strings.ToLower(value)
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case i == 8 || i == 13 || i == 18 || i == 23:
+			if c != '-' {
+				return "", false
+			}
+		case (c < '0' || c > '9') && (c < 'a' || c > 'f'):
+			return "", false
+		}
+	}
+	return value, true
+}
@@ -1,22 +1,130 @@
 package errors
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 var (
 	ErrItemNotFound   = errors.New("item not found")
 	ErrInvalidInput   = errors.New("invalid input")
 	ErrDatabaseError  = errors.New("database error")
 	ErrDuplicateEntry = errors.New("duplicate entry")
+
+	ErrPreconditionFailed      = errors.New("precondition failed")
+	ErrInvalidStatusTransition = errors.New("invalid status transition")
+
+	// DBに接続できない一時的な障害（ErrDatabaseErrorとしても判定される）
+	ErrDatabaseUnavailable = errors.New("database unavailable")
+	// コネクションプールが埋まっていて接続を取得できない過負荷（ErrDatabaseErrorとしても判定される）
+	ErrDatabaseBusy = errors.New("database busy")
+
+	ErrImageNotFound = errors.New("image not found")
+	// アイテムに登録できる画像の上限に達している
+	ErrTooManyImages = errors.New("too many images")
+	// クライアントが指定した日時がサーバーの時刻から許容範囲を外れている（未来すぎる・古すぎる）
+	ErrTimestampOutOfRange = errors.New("timestamp out of range")
+	// カテゴリーごとに必須としたフィールドが無い
+	ErrMissingRequiredFields = errors.New("missing required fields")
+	// 名前を付けて保存した検索条件が存在しない
+	ErrPresetNotFound = errors.New("search preset not found")
+	// 条件付き更新（PATCHのif）で、アイテムの現在の値が条件に一致しない
+	ErrUpdateConditionFailed = errors.New("update condition not met")
+	// 指定したシーケンス番号より後の変更履歴が保持期間を過ぎて削除されている
+	ErrChangeLogExpired = errors.New("change log expired")
+	// アイテムは存在したが削除されている（論理削除済み、または物理削除の記録が変更履歴に残っている）
+	// ErrItemNotFoundとしても判定される
+	ErrItemDeleted = errors.New("item deleted")
 )
 
+// 一意制約に違反したフィールドと、クライアントに返すメッセージ（ErrDuplicateEntryとして判定できる）
+type DuplicateEntryError struct {
+	Field   string
+	Message string
+}
+
+func (e *DuplicateEntryError) Error() string {
+	return ErrDuplicateEntry.Error() + ": " + e.Message
+}
+
+func (e *DuplicateEntryError) Unwrap() error {
+	return ErrDuplicateEntry
+}
+
+// カテゴリーごとに必須としたフィールドのうち、無いもの（ErrMissingRequiredFieldsとErrInvalidInputとして判定できる）
+type MissingRequiredFieldsError struct {
+	Category string
+	Fields   []string
+}
+
+func (e *MissingRequiredFieldsError) Error() string {
+	return ErrMissingRequiredFields.Error() + ": " + strings.Join(e.Fields, ", ") + " (required for " + e.Category + ")"
+}
+
+func (e *MissingRequiredFieldsError) Unwrap() []error {
+	return []error{ErrMissingRequiredFields, ErrInvalidInput}
+}
+
 func IsNotFoundError(err error) bool {
 	return errors.Is(err, ErrItemNotFound)
 }
 
+func IsImageNotFoundError(err error) bool {
+	return errors.Is(err, ErrImageNotFound)
+}
+
+func IsPresetNotFoundError(err error) bool {
+	return errors.Is(err, ErrPresetNotFound)
+}
+
+func IsTooManyImagesError(err error) bool {
+	return errors.Is(err, ErrTooManyImages)
+}
+
+func IsMissingRequiredFieldsError(err error) bool {
+	return errors.Is(err, ErrMissingRequiredFields)
+}
+
+func IsTimestampOutOfRangeError(err error) bool {
+	return errors.Is(err, ErrTimestampOutOfRange)
+}
+
 func IsDatabaseError(err error) bool {
 	return errors.Is(err, ErrDatabaseError)
 }
 
+func IsDatabaseUnavailableError(err error) bool {
+	return errors.Is(err, ErrDatabaseUnavailable)
+}
+
+func IsDatabaseBusyError(err error) bool {
+	return errors.Is(err, ErrDatabaseBusy)
+}
+
 func IsValidationError(err error) bool {
 	return errors.Is(err, ErrInvalidInput)
 }
+
+func IsDuplicateEntryError(err error) bool {
+	return errors.Is(err, ErrDuplicateEntry)
+}
+
+func IsPreconditionFailedError(err error) bool {
+	return errors.Is(err, ErrPreconditionFailed)
+}
+
+func IsUpdateConditionFailedError(err error) bool {
+	return errors.Is(err, ErrUpdateConditionFailed)
+}
+
+func IsChangeLogExpiredError(err error) bool {
+	return errors.Is(err, ErrChangeLogExpired)
+}
+
+func IsItemDeletedError(err error) bool {
+	return errors.Is(err, ErrItemDeleted)
+}
+
+func IsInvalidStatusTransitionError(err error) bool {
+	return errors.Is(err, ErrInvalidStatusTransition)
+}
@@ -0,0 +1,103 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// バックアップファイルの名前（items-20240101T090000Z.json）
+const (
+	filePrefix = "items-"
+	fileSuffix = ".json"
+	timeLayout = "20060102T150405Z"
+)
+
+// アイテムを定期的にJSONファイルへ書き出し、古いファイルを削除する
+type Writer struct {
+	exporter usecase.ItemExporter
+	dir      string
+	keep     int // 残すファイル数（0以下の場合は削除しない）
+	now      func() time.Time
+}
+
+func NewWriter(exporter usecase.ItemExporter, dir string, keep int) *Writer {
+	return &Writer{
+		exporter: exporter,
+		dir:      dir,
+		keep:     keep,
+		now:      time.Now,
+	}
+}
+
+// バックアップを1回作成し、作成したファイルのパスと件数を返す
+// 書き込み途中のファイルを残さないよう、一時ファイルに書き出してから名前を変更する
+func (w *Writer) Backup(ctx context.Context) (string, int, error) {
+	if err := os.MkdirAll(w.dir, 0o755); err != nil {
+		return "", 0, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(w.dir, filePrefix+"*.tmp")
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create backup file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	count, err := w.exporter.ExportItems(ctx, tmp)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	path := filepath.Join(w.dir, filePrefix+w.now().UTC().Format(timeLayout)+fileSuffix)
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", 0, fmt.Errorf("failed to save backup: %w", err)
+	}
+
+	if err := w.prune(); err != nil {
+		return path, count, err
+	}
+	return path, count, nil
+}
+
+// 新しいものからkeep個を残して、古いバックアップファイルを削除する
+func (w *Writer) prune() error {
+	if w.keep <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return fmt.Errorf("failed to list backups: %w", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.Type().IsRegular() && strings.HasPrefix(name, filePrefix) && strings.HasSuffix(name, fileSuffix) {
+			names = append(names, name)
+		}
+	}
+	if len(names) <= w.keep {
+		return nil
+	}
+
+	// 名前に含まれる日時はUTCの固定長のため、名前順が作成順になる
+	sort.Strings(names)
+	for _, name := range names[:len(names)-w.keep] {
+		if err := os.Remove(filepath.Join(w.dir, name)); err != nil {
+			return fmt.Errorf("failed to remove old backup: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 固定の内容を書き出すExporter
+type fakeExporter struct {
+	err error
+}
+
+func (e *fakeExporter) ExportItems(ctx context.Context, w io.Writer) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	_, err := io.WriteString(w, "[]\n")
+	return 0, err
+}
+
+func TestWriter_Backup(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "backups")
+	writer := NewWriter(&fakeExporter{}, dir, 2)
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	writer.now = func() time.Time { return now }
+
+	// 関係のないファイルは削除しない
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("keep"), 0o644))
+
+	var paths []string
+	for i := 0; i < 3; i++ {
+		path, _, err := writer.Backup(context.Background())
+		require.NoError(t, err)
+		paths = append(paths, path)
+		now = now.Add(time.Hour)
+	}
+
+	assert.Equal(t, filepath.Join(dir, "items-20240101T000000Z.json"), paths[0])
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.Equal(t, []string{"items-20240101T010000Z.json", "items-20240101T020000Z.json", "notes.txt"}, names)
+
+	data, err := os.ReadFile(paths[2])
+	require.NoError(t, err)
+	assert.Equal(t, "[]\n", string(data))
+}
+
+func TestWriter_Backup_ExportError(t *testing.T) {
+	dir := t.TempDir()
+	writer := NewWriter(&fakeExporter{err: errors.New("database error")}, dir, 2)
+
+	_, _, err := writer.Backup(context.Background())
+
+	assert.Error(t, err)
+	// 書き込みに失敗した一時ファイルは残さない
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
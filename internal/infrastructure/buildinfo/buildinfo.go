@@ -0,0 +1,59 @@
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// ビルド時に -ldflags で埋め込む値
+//
+//	go build -ldflags "-X Aicon-assignment/internal/infrastructure/buildinfo.Version=v1.2.3 \
+//	  -X Aicon-assignment/internal/infrastructure/buildinfo.Commit=$(git rev-parse HEAD) \
+//	  -X Aicon-assignment/internal/infrastructure/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = ""
+)
+
+// ビルド情報
+type Info struct {
+	Version   string
+	Commit    string
+	BuildTime string
+	GoVersion string
+}
+
+// ldflagsで埋め込まれた値を返す（未指定の場合はGoが埋め込んだVCS情報を使う）
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = setting.Value
+				}
+			}
+		}
+	}
+
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	if info.BuildTime == "" {
+		info.BuildTime = "unknown"
+	}
+
+	return info
+}
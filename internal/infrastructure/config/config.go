@@ -1,19 +1,212 @@
 package config
 
 import (
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"log"
+	"net"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
+// カスタムTLS設定をmysqlドライバに登録する際の名前（レプリカは証明書検証のサーバー名が異なるため別に登録する）
+const (
+	DBTLSConfigName        = "custom"
+	DBReplicaTLSConfigName = "custom-replica"
+)
+
+// 待ち受けポートのデフォルト値
+const defaultServerPort = "8080"
+
 var (
+	// HTTPサーバーの待ち受けアドレス
+	ServerHost string
+	ServerPort string
+
 	DBUser     string
 	DBPassword string
 	DBHost     string
 	DBName     string
 	DBPort     string
+
+	// 読み取りに使うレプリカ（ホストが空の場合はプライマリーで読み取る、ユーザー・DB名・TLSはプライマリーと同じ）
+	DBReplicaHost string
+	DBReplicaPort string
+
+	// DB接続のTLS設定
+	DBTLSMode       string // false / true / skip-verify / preferred
+	DBTLSCACert     string // 自己署名証明書などのCA証明書パス
+	DBTLSCert       string // クライアント証明書パス
+	DBTLSKey        string // クライアント秘密鍵パス
+	DBTLSServerName string // 証明書検証に使うサーバー名
+
+	// HTTPサーバーのTLS設定
+	TLSCertFile   string
+	TLSKeyFile    string
+	TLSMinVersion string
+
+	// CORSの設定
+	CORSAllowedOrigins []string // 空の場合はCORSヘッダーを付与しない（"*" で全オリジンを許可）
+	CORSMaxAge         int      // プリフライトの結果をブラウザがキャッシュする秒数
+
+	// スロークエリログの設定
+	SlowQueryThreshold time.Duration // 0の場合は無効
+	SlowQueryLogSQL    bool          // ログにSQL文を含めるか
+
+	// OpenTelemetryのトレース（送信先などはOTEL_EXPORTER_OTLP_ENDPOINTなどの標準の環境変数で指定する）
+	TracingEnabled   bool
+	TracingRedactSQL bool // SQLのスパンにSQL文を含めない（操作の種類のみ）
+
+	// コネクションプールの同時接続数の上限（0の場合は無制限）と、上限に達している場合に空きを待つ最大時間
+	DBMaxOpenConns int
+	DBPoolTimeout  time.Duration
+
+	// 起動時にsql/init.sqlの実行と不足カラムの追加を行わない（スキーマを別途管理する環境や読み取り専用のDBユーザー向け）
+	SkipMigrations bool
+
+	// MySQLのサーバー側でSELECT文を打ち切る時間（max_execution_time、0の場合は設定しない）
+	DBStatementTimeout time.Duration
+
+	// リクエスト処理のタイムアウト（0の場合は無効）
+	RequestTimeout time.Duration
+
+	// 同時に処理するリクエスト数の上限（0の場合は無制限）
+	MaxInFlightRequests int
+	// 上限到達時に空きを待つ最大時間（0の場合は待たずに503を返す）
+	RequestQueueTimeout time.Duration
+	// 1件のレスポンスボディがこのサイズ（バイト）を超えた場合に警告をログに出す（0の場合は出さない）
+	ResponseSizeWarnBytes int64
+	// 書き込みのリクエストのContent-Typeを検証し、受け付けない形式を415とするか（falseの場合は検証しない）
+	ContentTypeStrict bool
+
+	// 日付の解釈・表示に使うタイムゾーン（例: Asia/Tokyo、空の場合はサーバーのローカルタイムゾーン）
+	AppTimezone string
+
+	// 実行環境 (development / staging / production)
+	AppEnv string
+
+	// /debug 配下の開発用エンドポイントを有効にするか
+	DebugEndpoints bool
+
+	// JSONレスポンスを既定でインデント付きにするか（開発用、?pretty=false で無効にできる）
+	JSONPretty bool
+
+	// JSONのフィールド名の既定の表記（snake / camel、Accept / Content-Type の casing パラメータでリクエストごとに指定できる）
+	JSONFieldCase string
+
+	// 一定の割合のリクエストを遅延させたりエラーにしたりする（クライアントのリトライの確認用、本番環境では常に無効）
+	FaultInjection     bool
+	FaultErrorPercent  int      // エラーにする割合（0〜100）
+	FaultErrorStatuses []string // エラーにする場合のステータスコード（いずれかを返す）
+	FaultDelayPercent  int      // 遅延させる割合（0〜100）
+	FaultDelay         time.Duration
+	FaultSeed          int64 // 判定に使う乱数のシード（0の場合は起動ごとに異なる）
+
+	// アイテムの変更を通知するWebhookの設定
+	WebhookURLs       []string      // カンマ区切りで複数指定（空の場合は無効）
+	WebhookSecret     string        // 署名（X-Webhook-Signature）に使う共有シークレット
+	WebhookTimeout    time.Duration // 1回の送信のタイムアウト
+	WebhookMaxRetries int           // 2xx以外の応答時の最大リトライ回数
+
+	// outboxテーブルから未配信イベントを配信するワーカーの設定
+	OutboxPollInterval time.Duration
+	OutboxBatchSize    int
+	OutboxMaxAttempts  int // 配信を諦めるまでの試行回数（0以下の場合は無制限）
+
+	// アイテムの変更イベントのServer-Sent Events（GET /items/stream）
+	ItemStreamEnabled    bool
+	ItemStreamHeartbeat  time.Duration // 接続を保つためのコメントの間隔（0の場合は送らない）
+	ItemStreamMaxClients int           // 同時に接続できるクライアント数（0の場合は無制限）
+
+	// 論理削除済みアイテムを物理削除するワーカーの設定
+	PurgeEnabled   bool
+	PurgeRetention time.Duration // 論理削除からこの期間が経過したアイテムを削除する
+	PurgeInterval  time.Duration
+
+	// CSV一括登録（POST /items/import）の上限（0の場合は無制限）
+	ImportMaxBytes int64 // リクエストボディの最大サイズ（バイト）
+	ImportMaxRows  int   // ヘッダー行を除く最大行数
+	// カテゴリーが空の行に使うカテゴリー（空の場合はカテゴリーを必須とする）
+	ImportDefaultCategory string
+	// 仮の値と思われる購入価格（0や下限未満）の行の扱い（reject または draft、空の場合は確認しない）
+	ImportSuspiciousPriceAction string
+	// これより安い購入価格を仮の値とみなす（0ちょうどは常に仮の値とみなす）
+	ImportSuspiciousPriceFloor int
+
+	// カテゴリーが空の登録に、ブランドから補うカテゴリー（"ブランド=カテゴリー" のリスト、空の場合は補わない）
+	BrandCategories []string
+	// 登録・更新時のブランドの表記の統一方法（preserve, upper, lower, title、空の場合はpreserve）
+	BrandCase string
+	// 登録・更新時に名前・ブランド・シリアル番号から制御文字・ゼロ幅スペースを除き、NFCに正規化する
+	TextSanitization bool
+	// カテゴリーごとに追加で必須とするフィールド（"カテゴリー=フィールド" のリスト、空の場合は常に必須のフィールドのみ）
+	CategoryRequiredFields []string
+
+	// 一括操作（POST /items/bulk）1回あたりの最大件数（0の場合は無制限）
+	BulkMaxItems int
+
+	// アイテムがこの件数以上の場合、GET /items/summary をバックグラウンドで集計する（0の場合は常に同期）
+	SummaryAsyncThreshold int
+	SummaryJobTTL         time.Duration // 完了した集計の結果を保持する期間
+
+	// GET /items/{id} の結果をメモリに保持する件数と期間（件数が0の場合は保持しない）
+	ItemCacheSize int
+	ItemCacheTTL  time.Duration
+
+	// アイテムの画像（POST /items/:id/images）の上限
+	ImageMaxPerItem int   // 1つのアイテムに登録できる枚数（0の場合は無制限）
+	ImageMaxBytes   int64 // 1枚あたりの最大サイズ（バイト）
+	// 登録時に作成する縮小画像の長辺の最大ピクセル数（0の場合は作成しない）と、作成にかける最大時間
+	ImageThumbnailMaxDimension int
+	ImageThumbnailTimeout      time.Duration
+
+	// 共有用カード（GET /items/:id/card）に含めるフィールド（空の場合は name, brand, category）
+	ItemCardFields []string
+
+	// 存在しない（削除済みの）アイテムのDELETEを204とするか（falseの場合は404）
+	DeleteIdempotent bool
+	// DELETEのボディの reason に指定できる値（空の場合は100文字以内の自由記述）
+	DeleteReasons []string
+	// 更新するフィールドが無いPATCHを200（変更なし）とするか（falseの場合は400）
+	EmptyPatchNoOp bool
+	// 同じアイテムへのPATCHをこの時間まとめて1回の書き込みにする（0の場合はまとめない）
+	PatchCoalesceWindow time.Duration
+
+	// GETレスポンスから除外するフィールドと、X-Reveal-Fieldsヘッダーで除外せずに返すためのトークン
+	HiddenFields            []string
+	HiddenFieldsRevealToken string
+	// Accept-Languageが無い場合の表示用の金額（purchase_price_display）のロケール
+	PriceLocale string
+
+	// アイテムをJSONファイルへ定期的に書き出す（DBのバックアップが無い環境向け）
+	BackupEnabled  bool
+	BackupDir      string
+	BackupInterval time.Duration
+	BackupKeep     int // 残すファイル数（0の場合は削除しない）
+
+	// 管理者用のエンドポイント（/admin）の認証に使うトークン（空の場合はエンドポイントを登録しない）
+	AdminToken string
+	// 復元（POST /admin/restore）で受け付ける日時の、サーバーの時刻より未来の幅と過去の幅（0の場合は確認しない）
+	RestoreMaxFutureSkew time.Duration
+	RestoreMaxAge        time.Duration
+	// 派生カラムの再計算（POST /admin/backfill）で1トランザクションに書き込む行数
+	BackfillBatchSize int
+
+	// ?sort= を指定しない一覧の並び順（id, -id, created_at, -created_at, purchase_date, -purchase_date）
+	ListDefaultSort string
+	// ページ単位の一覧（?limit=&offset=）で指定できるoffsetの上限（0の場合は無制限）
+	PageMaxOffset int
+	// 検索語（q）で絞り込んだ一覧で返す最大件数（0の場合は無制限）
+	SearchMaxResults int
+	// 集計（/brands/summary, /items/group）の平均購入価格を丸める小数点以下の桁数（0〜4）
+	AverageDecimals int
+	// APIでアイテムを指すIDの形式（int64: 連番、uuid: ランダムなUUID）
+	IDType string
 )
 
 func init() {
@@ -21,18 +214,361 @@ func init() {
 	if err != nil {
 		log.Println("⚠️  .envファイルが見つかりませんでした。")
 	}
+	// 優先順位: 環境変数 > .env > 設定ファイル > デフォルト値
+	loadConfigFile()
+
+	ServerHost = parseServerHost(os.Getenv("HOST"))
+	ServerPort = parseServerPort(os.Getenv("PORT"))
 
 	DBUser = os.Getenv("DB_USER")
 	DBPassword = os.Getenv("DB_PASSWORD")
 	DBHost = os.Getenv("DB_HOST")
 	DBPort = os.Getenv("DB_PORT")
 	DBName = os.Getenv("DB_NAME")
+	DBReplicaHost = strings.TrimSpace(os.Getenv("DB_REPLICA_HOST"))
+	DBReplicaPort = strings.TrimSpace(os.Getenv("DB_REPLICA_PORT"))
+	if DBReplicaPort == "" {
+		DBReplicaPort = DBPort
+	}
+
+	DBTLSMode = strings.ToLower(strings.TrimSpace(os.Getenv("DB_TLS")))
+	DBTLSCACert = os.Getenv("DB_TLS_CA_CERT")
+	DBTLSCert = os.Getenv("DB_TLS_CERT")
+	DBTLSKey = os.Getenv("DB_TLS_KEY")
+	DBTLSServerName = os.Getenv("DB_TLS_SERVER_NAME")
+
+	TLSCertFile = os.Getenv("TLS_CERT_FILE")
+	TLSKeyFile = os.Getenv("TLS_KEY_FILE")
+	TLSMinVersion = os.Getenv("TLS_MIN_VERSION")
+
+	CORSAllowedOrigins = getEnvList("CORS_ALLOWED_ORIGINS")
+	CORSMaxAge = getEnvInt("CORS_MAX_AGE", 600)
+
+	SlowQueryThreshold = getEnvDuration("SLOW_QUERY_THRESHOLD", 500*time.Millisecond)
+	SlowQueryLogSQL = getEnvBool("SLOW_QUERY_LOG_SQL", false)
+
+	TracingEnabled = getEnvBool("TRACING_ENABLED", false)
+	TracingRedactSQL = getEnvBool("TRACING_REDACT_SQL", false)
+	DBMaxOpenConns = getEnvInt("DB_MAX_OPEN_CONNS", 0)
+	DBPoolTimeout = getEnvDuration("DB_POOL_TIMEOUT", 5*time.Second)
+	SkipMigrations = getEnvBool("SKIP_MIGRATIONS", false)
+	DBStatementTimeout = getEnvDuration("DB_STATEMENT_TIMEOUT", 0)
+
+	RequestTimeout = getEnvDuration("REQUEST_TIMEOUT", 30*time.Second)
+	MaxInFlightRequests = getEnvInt("MAX_IN_FLIGHT_REQUESTS", 0)
+	RequestQueueTimeout = getEnvDuration("REQUEST_QUEUE_TIMEOUT", 0)
+	ResponseSizeWarnBytes = int64(getEnvInt("RESPONSE_SIZE_WARN_BYTES", 1<<20))
+	ContentTypeStrict = getEnvBool("CONTENT_TYPE_STRICT", true)
+
+	AppTimezone = strings.TrimSpace(os.Getenv("APP_TIMEZONE"))
+	AppEnv = strings.ToLower(strings.TrimSpace(os.Getenv("APP_ENV")))
+	DebugEndpoints = getEnvBool("DEBUG_ENDPOINTS", false)
+	JSONPretty = getEnvBool("JSON_PRETTY", false)
+	JSONFieldCase = strings.TrimSpace(os.Getenv("JSON_FIELD_CASE"))
+
+	FaultInjection = getEnvBool("FAULT_INJECTION_ENABLED", false)
+	FaultErrorPercent = getEnvInt("FAULT_ERROR_PERCENT", 0)
+	FaultErrorStatuses = getEnvList("FAULT_ERROR_STATUSES")
+	if len(FaultErrorStatuses) == 0 {
+		FaultErrorStatuses = []string{"500", "503"}
+	}
+	FaultDelayPercent = getEnvInt("FAULT_DELAY_PERCENT", 0)
+	FaultDelay = getEnvDuration("FAULT_DELAY", 2*time.Second)
+	FaultSeed = int64(getEnvInt("FAULT_SEED", 0))
+
+	WebhookURLs = getEnvList("WEBHOOK_URLS")
+	WebhookSecret = os.Getenv("WEBHOOK_SECRET")
+	WebhookTimeout = getEnvDuration("WEBHOOK_TIMEOUT", 5*time.Second)
+	WebhookMaxRetries = getEnvInt("WEBHOOK_MAX_RETRIES", 3)
+
+	OutboxPollInterval = getEnvDuration("OUTBOX_POLL_INTERVAL", 5*time.Second)
+	OutboxBatchSize = getEnvInt("OUTBOX_BATCH_SIZE", 100)
+	OutboxMaxAttempts = getEnvInt("OUTBOX_MAX_ATTEMPTS", 10)
+
+	ItemStreamEnabled = getEnvBool("ITEM_STREAM_ENABLED", false)
+	ItemStreamHeartbeat = getEnvDuration("ITEM_STREAM_HEARTBEAT", 15*time.Second)
+	ItemStreamMaxClients = getEnvInt("ITEM_STREAM_MAX_CLIENTS", 100)
+
+	PurgeEnabled = getEnvBool("PURGE_ENABLED", true)
+	PurgeRetention = getEnvDuration("PURGE_RETENTION", 30*24*time.Hour)
+	PurgeInterval = getEnvDuration("PURGE_INTERVAL", time.Hour)
+
+	ImportMaxBytes = int64(getEnvInt("IMPORT_MAX_BYTES", 10<<20))
+	ImportMaxRows = getEnvInt("IMPORT_MAX_ROWS", 10000)
+	ImportDefaultCategory = strings.TrimSpace(os.Getenv("IMPORT_DEFAULT_CATEGORY"))
+	ImportSuspiciousPriceAction = strings.TrimSpace(os.Getenv("IMPORT_SUSPICIOUS_PRICE_ACTION"))
+	ImportSuspiciousPriceFloor = getEnvInt("IMPORT_SUSPICIOUS_PRICE_FLOOR", 0)
+	BrandCategories = getEnvList("BRAND_CATEGORIES")
+	BrandCase = strings.TrimSpace(os.Getenv("BRAND_CASE"))
+	TextSanitization = getEnvBool("TEXT_SANITIZATION", true)
+	CategoryRequiredFields = getEnvList("CATEGORY_REQUIRED_FIELDS")
+
+	BulkMaxItems = getEnvInt("BULK_MAX_ITEMS", 500)
+	SummaryAsyncThreshold = getEnvInt("SUMMARY_ASYNC_THRESHOLD", 0)
+	SummaryJobTTL = getEnvDuration("SUMMARY_JOB_TTL", 10*time.Minute)
+
+	ItemCacheSize = getEnvInt("ITEM_CACHE_SIZE", 0)
+	ItemCacheTTL = getEnvDuration("ITEM_CACHE_TTL", 30*time.Second)
+
+	ImageMaxPerItem = getEnvInt("IMAGE_MAX_PER_ITEM", 10)
+	ImageMaxBytes = int64(getEnvInt("IMAGE_MAX_BYTES", 5<<20))
+	ImageThumbnailMaxDimension = getEnvInt("IMAGE_THUMBNAIL_MAX_DIMENSION", 320)
+	ImageThumbnailTimeout = getEnvDuration("IMAGE_THUMBNAIL_TIMEOUT", 5*time.Second)
+
+	ItemCardFields = getEnvList("ITEM_CARD_FIELDS")
+
+	DeleteIdempotent = getEnvBool("DELETE_IDEMPOTENT", true)
+	DeleteReasons = getEnvList("DELETE_REASONS")
+	EmptyPatchNoOp = getEnvBool("EMPTY_PATCH_NOOP", false)
+	PatchCoalesceWindow = getEnvDuration("PATCH_COALESCE_WINDOW", 0)
+	HiddenFields = getEnvList("HIDDEN_FIELDS")
+	HiddenFieldsRevealToken = os.Getenv("HIDDEN_FIELDS_REVEAL_TOKEN")
+	PriceLocale = strings.TrimSpace(os.Getenv("PRICE_LOCALE"))
+	if PriceLocale == "" {
+		PriceLocale = "ja"
+	}
+	BackupEnabled = getEnvBool("BACKUP_ENABLED", false)
+	BackupDir = strings.TrimSpace(os.Getenv("BACKUP_DIR"))
+	if BackupDir == "" {
+		BackupDir = "backups"
+	}
+	BackupInterval = getEnvDuration("BACKUP_INTERVAL", 24*time.Hour)
+	BackupKeep = getEnvInt("BACKUP_KEEP", 7)
+	AdminToken = strings.TrimSpace(os.Getenv("ADMIN_TOKEN"))
+	RestoreMaxFutureSkew = getEnvDuration("RESTORE_MAX_FUTURE_SKEW", 5*time.Minute)
+	RestoreMaxAge = getEnvDuration("RESTORE_MAX_AGE", 0)
+	BackfillBatchSize = getEnvInt("BACKFILL_BATCH_SIZE", 100)
+	ListDefaultSort = strings.TrimSpace(os.Getenv("LIST_DEFAULT_SORT"))
+	if ListDefaultSort == "" {
+		ListDefaultSort = "-created_at"
+	}
+	PageMaxOffset = getEnvInt("PAGE_MAX_OFFSET", 10000)
+	SearchMaxResults = getEnvInt("SEARCH_MAX_RESULTS", 1000)
+	AverageDecimals = getEnvInt("AVERAGE_PRICE_DECIMALS", 0)
+	IDType = strings.TrimSpace(os.Getenv("ID_TYPE"))
+}
+
+// Webhookの通知先が設定されているか
+func WebhooksEnabled() bool {
+	return len(WebhookURLs) > 0
+}
+
+// 変更イベントをoutboxに記録するか（Webhookとストリームのどちらも無効の場合は記録しない）
+func EventsEnabled() bool {
+	return WebhooksEnabled() || ItemStreamEnabled
+}
+
+// 本番環境かどうか
+func IsProduction() bool {
+	return AppEnv == "production"
+}
+
+// 管理者用のエンドポイントを登録するか
+func AdminEndpointsEnabled() bool {
+	return AdminToken != ""
+}
+
+// デバッグ用エンドポイントを公開するか（本番環境では常に無効）
+func DebugEndpointsEnabled() bool {
+	return DebugEndpoints && !IsProduction()
+}
+
+// ?pretty を指定しないJSONレスポンスも整形するか（本番環境では常に無効）
+func JSONPrettyEnabled() bool {
+	return JSONPretty && !IsProduction()
+}
+
+// 障害の注入を有効にするか（本番環境では設定に関わらず常に無効）
+func FaultInjectionEnabled() bool {
+	return FaultInjection && !IsProduction()
+}
+
+// 真偽値の環境変数を読み込む（未設定・不正値の場合はデフォルト値）
+func getEnvBool(key string, defaultValue bool) bool {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("⚠️  %sの値が不正です（%s）。デフォルト値を使用します。", key, value)
+		return defaultValue
+	}
+	return parsed
+}
+
+// 整数の環境変数を読み込む（未設定・不正値の場合はデフォルト値）
+func getEnvInt(key string, defaultValue int) int {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("⚠️  %sの値が不正です（%s）。デフォルト値を使用します。", key, value)
+		return defaultValue
+	}
+	return parsed
+}
+
+// 時間の環境変数を読み込む（"500ms", "1s" 形式）
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("⚠️  %sの値が不正です（%s）。デフォルト値を使用します。", key, value)
+		return defaultValue
+	}
+	return parsed
+}
+
+// カンマ区切りの環境変数を読み込む（空要素は除外）
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// HTTPサーバーの待ち受けアドレスを返す（例: "0.0.0.0:8080", ":8080"）
+func GetServerAddress() string {
+	return net.JoinHostPort(ServerHost, ServerPort)
+}
+
+// HOSTの値を返す（IPv6アドレスの "[::1]" 形式も受け付け、角括弧はアドレスの組み立て時に付ける）
+func parseServerHost(value string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(value), "["), "]")
+}
+
+// PORTの値を返す（従来の ":8080" 形式も受け付け、空の場合はデフォルト値）
+func parseServerPort(value string) string {
+	port := strings.TrimPrefix(strings.TrimSpace(value), ":")
+	if port == "" {
+		return defaultServerPort
+	}
+	return port
+}
+
+// PORTが1〜65535の数値でない場合はエラーを返す（待ち受けの失敗を起動前に検出する）
+func CheckServerPort() error {
+	port, err := strconv.Atoi(ServerPort)
+	if err != nil || port < 1 || port > 65535 {
+		return fmt.Errorf("%s (must be a number between 1 and 65535)", ServerPort)
+	}
+	return nil
+}
+
+// アプリケーションのタイムゾーンを返す（APP_TIMEZONEが空の場合はサーバーのローカルタイムゾーン）
+func GetAppLocation() (*time.Location, error) {
+	if AppTimezone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(AppTimezone)
 }
 
 // DB接続文字列を返す
+// タイムスタンプはサーバーのタイムゾーンに関わらずUTCで保存・読み込みする
+// DSNのシステム変数はドライバーが接続ごとにSETするため、max_execution_timeもすべての接続に適用される
 func GetDSN() string {
-	return fmt.Sprintf(
-		"%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&collation=utf8mb4_unicode_ci&parseTime=true&loc=Local&sql_mode=TRADITIONAL",
-		DBUser, DBPassword, DBHost, DBPort, DBName,
+	return buildDSN(DBHost, DBPort, GetDBTLSParam())
+}
+
+// レプリカの接続文字列を返す（DB_REPLICA_HOSTが空の場合は空文字）
+func GetReplicaDSN() string {
+	if DBReplicaHost == "" {
+		return ""
+	}
+	tlsParam := GetDBTLSParam()
+	if tlsParam == DBTLSConfigName {
+		tlsParam = DBReplicaTLSConfigName
+	}
+	return buildDSN(DBReplicaHost, DBReplicaPort, tlsParam)
+}
+
+func buildDSN(host, port, tlsParam string) string {
+	dsn := fmt.Sprintf(
+		"%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&collation=utf8mb4_unicode_ci&parseTime=true&loc=UTC&time_zone=%%27%%2B00%%3A00%%27&sql_mode=TRADITIONAL",
+		DBUser, DBPassword, host, port, DBName,
 	)
+
+	if tlsParam != "" {
+		dsn += "&tls=" + tlsParam
+	}
+	// contextのキャンセルが届かない場合でも、サーバー側で実行時間の長いクエリを打ち切る
+	if ms := DBStatementTimeout.Milliseconds(); ms > 0 {
+		dsn += "&max_execution_time=" + strconv.FormatInt(ms, 10)
+	}
+
+	return dsn
+}
+
+// DSNに付与するtlsパラメータを返す（空文字の場合はTLSを使わない）
+func GetDBTLSParam() string {
+	switch DBTLSMode {
+	case "", "false", "disabled":
+		return ""
+	case "skip-verify", "preferred":
+		return DBTLSMode
+	default:
+		if UseCustomDBTLSConfig() {
+			return DBTLSConfigName
+		}
+		return "true"
+	}
+}
+
+// CA証明書やクライアント証明書が指定されている場合はカスタムTLS設定を使う
+func UseCustomDBTLSConfig() bool {
+	switch DBTLSMode {
+	case "", "false", "disabled", "skip-verify", "preferred":
+		return false
+	}
+	return DBTLSCACert != "" || DBTLSCert != "" || DBTLSKey != "" || DBTLSServerName != ""
+}
+
+// skip-verify / preferred ではドライバ組み込みの設定を使い、CA証明書やクライアント証明書を読み込まないためエラーにする
+// 指定した証明書で検証しているつもりで、検証なしの接続にならないようにする
+func CheckDBTLSConfig() error {
+	switch DBTLSMode {
+	case "skip-verify", "preferred":
+		if DBTLSCACert != "" || DBTLSCert != "" || DBTLSKey != "" || DBTLSServerName != "" {
+			return fmt.Errorf("DB_TLS_CA_CERT, DB_TLS_CERT, DB_TLS_KEY and DB_TLS_SERVER_NAME are not used with DB_TLS=%s (set DB_TLS=true to use them)", DBTLSMode)
+		}
+	}
+	return nil
+}
+
+// 証明書と秘密鍵の両方が指定されている場合はHTTPSで待ち受ける
+func ServerTLSEnabled() bool {
+	return TLSCertFile != "" && TLSKeyFile != ""
+}
+
+// 証明書と秘密鍵の片方だけが指定されている場合はエラーを返す（HTTPSのつもりでHTTPで起動しないため）
+func CheckServerTLSFiles() error {
+	switch {
+	case TLSCertFile != "" && TLSKeyFile == "":
+		return errors.New("TLS_CERT_FILE is set but TLS_KEY_FILE is empty (set both to enable HTTPS)")
+	case TLSCertFile == "" && TLSKeyFile != "":
+		return errors.New("TLS_KEY_FILE is set but TLS_CERT_FILE is empty (set both to enable HTTPS)")
+	}
+	return nil
+}
+
+// TLS_MIN_VERSIONをtlsパッケージの定数に変換する（デフォルト: TLS 1.2）
+// TLS 1.0 / 1.1 は非推奨（RFC 8996）のため指定できない
+func GetTLSMinVersion() (uint16, error) {
+	switch strings.TrimSpace(TLSMinVersion) {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS_MIN_VERSION: %s (must be one of: 1.2, 1.3)", TLSMinVersion)
+	}
 }
@@ -0,0 +1,194 @@
+package config
+
+import (
+	"crypto/tls"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDSN_StatementTimeout(t *testing.T) {
+	defaultTimeout := DBStatementTimeout
+	t.Cleanup(func() { DBStatementTimeout = defaultTimeout })
+
+	DBStatementTimeout = 0
+	assert.NotContains(t, GetDSN(), "max_execution_time")
+
+	DBStatementTimeout = 45 * time.Second
+	assert.Contains(t, GetDSN(), "&max_execution_time=45000")
+
+	// ミリ秒未満は切り捨てられ、0になる場合は設定しない
+	DBStatementTimeout = time.Microsecond
+	assert.NotContains(t, GetDSN(), "max_execution_time")
+}
+
+func TestGetReplicaDSN(t *testing.T) {
+	defaults := []string{DBReplicaHost, DBReplicaPort, DBTLSMode, DBTLSCACert}
+	t.Cleanup(func() {
+		DBReplicaHost, DBReplicaPort, DBTLSMode, DBTLSCACert = defaults[0], defaults[1], defaults[2], defaults[3]
+	})
+
+	DBReplicaHost = ""
+	assert.Empty(t, GetReplicaDSN())
+
+	DBReplicaHost, DBReplicaPort = "replica", "3307"
+	assert.Contains(t, GetReplicaDSN(), "@tcp(replica:3307)/")
+
+	// カスタムTLS設定はレプリカ用に登録した名前を使う
+	DBTLSMode, DBTLSCACert = "true", "/etc/ssl/ca.pem"
+	assert.Contains(t, GetReplicaDSN(), "&tls="+DBReplicaTLSConfigName)
+	assert.Contains(t, GetDSN(), "&tls="+DBTLSConfigName)
+}
+
+func TestCheckServerTLSFiles(t *testing.T) {
+	defaultCert, defaultKey := TLSCertFile, TLSKeyFile
+	t.Cleanup(func() { TLSCertFile, TLSKeyFile = defaultCert, defaultKey })
+
+	TLSCertFile, TLSKeyFile = "", ""
+	assert.NoError(t, CheckServerTLSFiles())
+
+	TLSCertFile, TLSKeyFile = "/etc/ssl/server.crt", "/etc/ssl/server.key"
+	assert.NoError(t, CheckServerTLSFiles())
+
+	// 片方だけの場合はHTTPで起動せずにエラーにする
+	TLSCertFile, TLSKeyFile = "/etc/ssl/server.crt", ""
+	assert.ErrorContains(t, CheckServerTLSFiles(), "TLS_KEY_FILE")
+
+	TLSCertFile, TLSKeyFile = "", "/etc/ssl/server.key"
+	assert.ErrorContains(t, CheckServerTLSFiles(), "TLS_CERT_FILE")
+}
+
+func TestGetTLSMinVersion(t *testing.T) {
+	defaultVersion := TLSMinVersion
+	t.Cleanup(func() { TLSMinVersion = defaultVersion })
+
+	tests := []struct {
+		name     string
+		value    string
+		expected uint16
+		wantErr  bool
+	}{
+		{name: "正常系: 未設定はTLS 1.2", value: "", expected: tls.VersionTLS12},
+		{name: "正常系: 1.2", value: "1.2", expected: tls.VersionTLS12},
+		{name: "正常系: 1.3（前後の空白を許容）", value: " 1.3 ", expected: tls.VersionTLS13},
+		{name: "異常系: 非推奨の1.1", value: "1.1", wantErr: true},
+		{name: "異常系: 非推奨の1.0", value: "1.0", wantErr: true},
+		{name: "異常系: 不明な値", value: "tls12", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			TLSMinVersion = tt.value
+			version, err := GetTLSMinVersion()
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "must be one of: 1.2, 1.3")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, version)
+		})
+	}
+}
+
+func TestGetDBTLSParam(t *testing.T) {
+	defaults := []string{DBTLSMode, DBTLSCACert, DBTLSCert, DBTLSKey, DBTLSServerName}
+	t.Cleanup(func() {
+		DBTLSMode, DBTLSCACert, DBTLSCert, DBTLSKey, DBTLSServerName = defaults[0], defaults[1], defaults[2], defaults[3], defaults[4]
+	})
+
+	tests := []struct {
+		name          string
+		mode          string
+		caCert        string
+		expectedParam string
+		expectCustom  bool
+		wantErr       bool
+	}{
+		{name: "正常系: 未設定はTLSを使わない", mode: "", expectedParam: ""},
+		{name: "正常系: disabled", mode: "disabled", expectedParam: ""},
+		{name: "正常系: false", mode: "false", expectedParam: ""},
+		{name: "正常系: trueはドライバ組み込みの検証", mode: "true", expectedParam: "true"},
+		{name: "正常系: preferred", mode: "preferred", expectedParam: "preferred"},
+		{name: "正常系: skip-verify", mode: "skip-verify", expectedParam: "skip-verify"},
+		{name: "正常系: CA証明書を指定した場合はカスタムTLS設定", mode: "true", caCert: "/etc/ssl/ca.pem", expectedParam: DBTLSConfigName, expectCustom: true},
+		{name: "異常系: preferredでCA証明書を指定", mode: "preferred", caCert: "/etc/ssl/ca.pem", expectedParam: "preferred", wantErr: true},
+		{name: "異常系: skip-verifyでCA証明書を指定", mode: "skip-verify", caCert: "/etc/ssl/ca.pem", expectedParam: "skip-verify", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			DBTLSMode, DBTLSCACert, DBTLSCert, DBTLSKey, DBTLSServerName = tt.mode, tt.caCert, "", "", ""
+
+			assert.Equal(t, tt.expectedParam, GetDBTLSParam())
+			assert.Equal(t, tt.expectCustom, UseCustomDBTLSConfig())
+			if tt.wantErr {
+				assert.ErrorContains(t, CheckDBTLSConfig(), "DB_TLS="+tt.mode)
+			} else {
+				assert.NoError(t, CheckDBTLSConfig())
+			}
+		})
+	}
+
+	// クライアント証明書やサーバー名もskip-verifyでは使わないためエラーにする
+	DBTLSMode, DBTLSCACert, DBTLSCert, DBTLSKey, DBTLSServerName = "skip-verify", "", "/etc/ssl/client.crt", "/etc/ssl/client.key", ""
+	assert.Error(t, CheckDBTLSConfig())
+	DBTLSMode, DBTLSCert, DBTLSKey, DBTLSServerName = "preferred", "", "", "db.internal"
+	assert.Error(t, CheckDBTLSConfig())
+}
+
+func TestGetServerAddress(t *testing.T) {
+	defaultHost, defaultPort := ServerHost, ServerPort
+	t.Cleanup(func() { ServerHost, ServerPort = defaultHost, defaultPort })
+
+	tests := []struct {
+		name     string
+		host     string
+		port     string
+		expected string
+	}{
+		{name: "正常系: 未設定は全インターフェースのデフォルトポート", host: "", port: "", expected: ":8080"},
+		{name: "正常系: 従来の \":9090\" 形式", host: "", port: ":9090", expected: ":9090"},
+		{name: "正常系: 前後の空白を除く", host: " 127.0.0.1 ", port: " 9090 ", expected: "127.0.0.1:9090"},
+		{name: "正常系: IPv6アドレス", host: "::1", port: "9090", expected: "[::1]:9090"},
+		{name: "正常系: 角括弧付きのIPv6アドレス", host: "[::1]", port: "9090", expected: "[::1]:9090"},
+		{name: "正常系: ホスト名", host: "localhost", port: "8081", expected: "localhost:8081"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ServerHost, ServerPort = parseServerHost(tt.host), parseServerPort(tt.port)
+			assert.Equal(t, tt.expected, GetServerAddress())
+			assert.NoError(t, CheckServerPort())
+		})
+	}
+}
+
+func TestCheckServerPort(t *testing.T) {
+	defaultPort := ServerPort
+	t.Cleanup(func() { ServerPort = defaultPort })
+
+	tests := []struct {
+		name    string
+		port    string
+		wantErr bool
+	}{
+		{name: "正常系: 数値", port: "8080"},
+		{name: "正常系: 上限", port: "65535"},
+		{name: "異常系: 数値でない", port: "http", wantErr: true},
+		{name: "異常系: 0", port: "0", wantErr: true},
+		{name: "異常系: 範囲外", port: "65536", wantErr: true},
+		{name: "異常系: ホスト付き", port: "localhost:8080", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ServerPort = parseServerPort(tt.port)
+			if tt.wantErr {
+				assert.ErrorContains(t, CheckServerPort(), "must be a number between 1 and 65535")
+				return
+			}
+			assert.NoError(t, CheckServerPort())
+		})
+	}
+}
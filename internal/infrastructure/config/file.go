@@ -0,0 +1,124 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 設定ファイルを指定しない場合に読み込むファイル（存在しない場合は読み込まない）
+const defaultConfigFile = "config.yaml"
+
+// 設定ファイルに書ける設定（環境変数と同じ名前）
+var knownConfigKeys = []string{
+	"HOST", "PORT",
+	"DB_USER", "DB_PASSWORD", "DB_HOST", "DB_PORT", "DB_NAME", "DB_REPLICA_HOST", "DB_REPLICA_PORT",
+	"DB_TLS", "DB_TLS_CA_CERT", "DB_TLS_CERT", "DB_TLS_KEY", "DB_TLS_SERVER_NAME",
+	"TLS_CERT_FILE", "TLS_KEY_FILE", "TLS_MIN_VERSION",
+	"CORS_ALLOWED_ORIGINS", "CORS_MAX_AGE",
+	"SLOW_QUERY_THRESHOLD", "SLOW_QUERY_LOG_SQL", "DB_STATEMENT_TIMEOUT", "SKIP_MIGRATIONS",
+	"DB_MAX_OPEN_CONNS", "DB_POOL_TIMEOUT",
+	"TRACING_ENABLED", "TRACING_REDACT_SQL",
+	"REQUEST_TIMEOUT", "MAX_IN_FLIGHT_REQUESTS", "REQUEST_QUEUE_TIMEOUT", "RESPONSE_SIZE_WARN_BYTES",
+	"CONTENT_TYPE_STRICT",
+	"APP_TIMEZONE", "APP_ENV", "DEBUG_ENDPOINTS", "JSON_PRETTY", "JSON_FIELD_CASE",
+	"FAULT_INJECTION_ENABLED", "FAULT_ERROR_PERCENT", "FAULT_ERROR_STATUSES", "FAULT_DELAY_PERCENT", "FAULT_DELAY", "FAULT_SEED",
+	"WEBHOOK_URLS", "WEBHOOK_SECRET", "WEBHOOK_TIMEOUT", "WEBHOOK_MAX_RETRIES",
+	"OUTBOX_POLL_INTERVAL", "OUTBOX_BATCH_SIZE", "OUTBOX_MAX_ATTEMPTS",
+	"ITEM_STREAM_ENABLED", "ITEM_STREAM_HEARTBEAT", "ITEM_STREAM_MAX_CLIENTS",
+	"PURGE_ENABLED", "PURGE_RETENTION", "PURGE_INTERVAL",
+	"IMPORT_MAX_BYTES", "IMPORT_MAX_ROWS", "IMPORT_DEFAULT_CATEGORY",
+	"IMPORT_SUSPICIOUS_PRICE_ACTION", "IMPORT_SUSPICIOUS_PRICE_FLOOR",
+	"BRAND_CATEGORIES", "CATEGORY_REQUIRED_FIELDS", "BRAND_CASE", "TEXT_SANITIZATION",
+	"BULK_MAX_ITEMS",
+	"SUMMARY_ASYNC_THRESHOLD", "SUMMARY_JOB_TTL",
+	"ITEM_CACHE_SIZE", "ITEM_CACHE_TTL",
+	"IMAGE_MAX_PER_ITEM", "IMAGE_MAX_BYTES", "IMAGE_THUMBNAIL_MAX_DIMENSION", "IMAGE_THUMBNAIL_TIMEOUT",
+	"ITEM_CARD_FIELDS",
+	"DELETE_IDEMPOTENT", "DELETE_REASONS", "EMPTY_PATCH_NOOP", "PATCH_COALESCE_WINDOW",
+	"HIDDEN_FIELDS", "HIDDEN_FIELDS_REVEAL_TOKEN", "PRICE_LOCALE",
+	"BACKUP_ENABLED", "BACKUP_DIR", "BACKUP_INTERVAL", "BACKUP_KEEP",
+	"ADMIN_TOKEN", "RESTORE_MAX_FUTURE_SKEW", "RESTORE_MAX_AGE", "BACKFILL_BATCH_SIZE",
+	"LIST_DEFAULT_SORT", "PAGE_MAX_OFFSET", "SEARCH_MAX_RESULTS", "AVERAGE_PRICE_DECIMALS",
+	"ID_TYPE",
+}
+
+// CONFIG_FILE（未設定の場合はconfig.yaml）から設定を読み込む
+// 既に環境変数（.envを含む）で設定されている値は上書きしない
+func loadConfigFile() {
+	path := strings.TrimSpace(os.Getenv("CONFIG_FILE"))
+	explicit := path != ""
+	if !explicit {
+		path = defaultConfigFile
+	}
+
+	unknown, err := applyConfigFile(path)
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		return
+	}
+	if err != nil {
+		log.Printf("⚠️  設定ファイルを読み込めませんでした（%s）: %v", path, err)
+		return
+	}
+	for _, key := range unknown {
+		log.Printf("⚠️  設定ファイルに不明な設定があります（%s: %s）。無視します。", path, key)
+	}
+}
+
+// YAMLの設定ファイルの値を、未設定の環境変数に反映する（不明な設定の名前を返す）
+// キーは環境変数と同じ名前で、大文字・小文字は区別しない。リストはカンマ区切りとして扱う
+func applyConfigFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]interface{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+
+	known := make(map[string]bool, len(knownConfigKeys))
+	for _, key := range knownConfigKeys {
+		known[key] = true
+	}
+
+	var unknown []string
+	for rawKey, value := range values {
+		key := strings.ToUpper(strings.TrimSpace(rawKey))
+		if !known[key] {
+			unknown = append(unknown, rawKey)
+			continue
+		}
+		if _, exists := os.LookupEnv(key); exists {
+			continue
+		}
+		if err := os.Setenv(key, configValueString(value)); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Strings(unknown)
+	return unknown, nil
+}
+
+// 設定ファイルの値を環境変数と同じ形式の文字列にする
+func configValueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, part := range v {
+			parts[i] = configValueString(part)
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
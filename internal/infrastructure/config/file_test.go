@@ -0,0 +1,51 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+DB_HOST: localhost
+db_port: 3306
+CORS_ALLOWED_ORIGINS:
+  - http://localhost:3000
+  - http://localhost:5173
+PURGE_ENABLED: false
+DB_NAME: from_file
+UNKNOWN_KEY: value
+`), 0o600))
+
+	for _, key := range []string{"DB_HOST", "DB_PORT", "CORS_ALLOWED_ORIGINS", "PURGE_ENABLED"} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+	// 環境変数で設定済みの値は設定ファイルより優先する
+	t.Setenv("DB_NAME", "from_env")
+
+	unknown, err := applyConfigFile(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"UNKNOWN_KEY"}, unknown)
+	assert.Equal(t, "localhost", os.Getenv("DB_HOST"))
+	assert.Equal(t, "3306", os.Getenv("DB_PORT"))
+	assert.Equal(t, "http://localhost:3000,http://localhost:5173", os.Getenv("CORS_ALLOWED_ORIGINS"))
+	assert.Equal(t, "false", os.Getenv("PURGE_ENABLED"))
+	assert.Equal(t, "from_env", os.Getenv("DB_NAME"))
+}
+
+func TestApplyConfigFile_Errors(t *testing.T) {
+	_, err := applyConfigFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("DB_HOST: [unclosed"), 0o600))
+	_, err = applyConfigFile(path)
+	assert.Error(t, err)
+}
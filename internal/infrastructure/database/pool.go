@@ -0,0 +1,28 @@
+package databaseInfra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"Aicon-assignment/internal/infrastructure/metrics"
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// dbのプールから接続を取得する（DB_MAX_OPEN_CONNSの上限に達している場合は、poolTimeoutまで空きを待つ）
+// 待っても取得できない場合はdatabase.ErrPoolTimeoutを返し、リクエストのcontextの期限切れと区別する
+func (h *MySqlHandler) acquire(ctx context.Context, db *sql.DB) (*sql.Conn, error) {
+	acquireCtx, cancel := context.WithTimeout(ctx, h.poolTimeout)
+	defer cancel()
+
+	metrics.DBPoolWaiting.Add(1)
+	conn, err := db.Conn(acquireCtx)
+	metrics.DBPoolWaiting.Add(-1)
+
+	if err != nil && ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+		metrics.DBPoolTimeouts.Add(1)
+		return nil, fmt.Errorf("%w (waited %s, %d connections in use)", database.ErrPoolTimeout, h.poolTimeout, db.Stats().InUse)
+	}
+	return conn, err
+}
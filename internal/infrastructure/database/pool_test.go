@@ -0,0 +1,94 @@
+package databaseInfra
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// 接続の取得のみを確認するためのドライバー（クエリは実行できない）
+type stubDriver struct{}
+
+func (stubDriver) Open(string) (driver.Conn, error) { return stubConn{}, nil }
+
+type stubConn struct{}
+
+func (stubConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (stubConn) Close() error                        { return nil }
+func (stubConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func init() {
+	sql.Register("pool-stub", stubDriver{})
+}
+
+func TestMySqlHandler_Acquire(t *testing.T) {
+	conn, err := sql.Open("pool-stub", "")
+	require.NoError(t, err)
+	defer conn.Close()
+	conn.SetMaxOpenConns(1)
+	handler := &MySqlHandler{Conn: conn, poolTimeout: 20 * time.Millisecond}
+
+	// 1件目で上限を埋める
+	held, err := handler.acquire(context.Background(), conn)
+	require.NoError(t, err)
+
+	t.Run("異常系: 待っても取得できない場合はErrPoolTimeout", func(t *testing.T) {
+		_, err := handler.acquire(context.Background(), conn)
+		assert.ErrorIs(t, err, database.ErrPoolTimeout)
+	})
+
+	t.Run("異常系: リクエストのcontextが先に期限切れになった場合はそのエラー", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		_, err := handler.acquire(ctx, conn)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.NotErrorIs(t, err, database.ErrPoolTimeout)
+	})
+
+	t.Run("正常系: 空きができれば取得できる", func(t *testing.T) {
+		require.NoError(t, held.Close())
+		second, err := handler.acquire(context.Background(), conn)
+		require.NoError(t, err)
+		assert.NoError(t, second.Close())
+	})
+}
+
+func TestMySqlHandler_ExecutorRouting(t *testing.T) {
+	primary, err := sql.Open("pool-stub", "primary")
+	require.NoError(t, err)
+	defer primary.Close()
+	replica, err := sql.Open("pool-stub", "replica")
+	require.NoError(t, err)
+	defer replica.Close()
+
+	tests := []struct {
+		name     string
+		replica  *sql.DB
+		ctx      context.Context
+		read     bool
+		expected *sql.DB
+	}{
+		{name: "正常系: 読み取りはレプリカ", replica: replica, ctx: context.Background(), read: true, expected: replica},
+		{name: "正常系: 書き込みはプライマリー", replica: replica, ctx: context.Background(), read: false, expected: primary},
+		{name: "正常系: WithPrimaryReadsの読み取りはプライマリー", replica: replica, ctx: database.WithPrimaryReads(context.Background()), read: true, expected: primary},
+		{name: "正常系: レプリカが無い場合の読み取りはプライマリー", replica: nil, ctx: context.Background(), read: true, expected: primary},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &MySqlHandler{Conn: primary, Replica: tt.replica}
+			ex, release, err := handler.executor(tt.ctx, tt.read)
+			require.NoError(t, err)
+			defer release()
+			assert.Same(t, tt.expected, ex)
+		})
+	}
+}
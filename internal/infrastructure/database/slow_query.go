@@ -0,0 +1,97 @@
+package databaseInfra
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// 閾値を超えたクエリをwarnレベルでログ出力するSqlHandlerのラッパー
+type slowQueryHandler struct {
+	database.SqlHandler
+	threshold time.Duration
+	logSQL    bool
+}
+
+// thresholdが0以下の場合はラップせずにそのまま返す
+func NewSlowQueryHandler(handler database.SqlHandler, threshold time.Duration, logSQL bool) database.SqlHandler {
+	if threshold <= 0 {
+		return handler
+	}
+	return &slowQueryHandler{
+		SqlHandler: handler,
+		threshold:  threshold,
+		logSQL:     logSQL,
+	}
+}
+
+func (h *slowQueryHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	start := time.Now()
+	result, err := h.SqlHandler.Execute(ctx, statement, args...)
+	h.observe(statement, time.Since(start))
+	return result, err
+}
+
+func (h *slowQueryHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	start := time.Now()
+	rows, err := h.SqlHandler.Query(ctx, statement, args...)
+	h.observe(statement, time.Since(start))
+	return rows, err
+}
+
+func (h *slowQueryHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	// QueryRowはScanまでクエリの完了を待たないため、Scan完了時点で計測する
+	return &slowQueryRow{
+		Row:       h.SqlHandler.QueryRow(ctx, statement, args...),
+		handler:   h,
+		statement: statement,
+		start:     time.Now(),
+	}
+}
+
+func (h *slowQueryHandler) observe(statement string, elapsed time.Duration) {
+	if elapsed < h.threshold {
+		return
+	}
+
+	attrs := []any{
+		"duration", elapsed.String(),
+		"threshold", h.threshold.String(),
+		"operation", queryOperation(statement),
+	}
+	// 引数は値が漏れないよう常に出力しない
+	if h.logSQL {
+		attrs = append(attrs, "statement", compactSQL(statement))
+	}
+	slog.Warn("slow query detected", attrs...)
+}
+
+type slowQueryRow struct {
+	database.Row
+	handler   *slowQueryHandler
+	statement string
+	start     time.Time
+}
+
+func (r *slowQueryRow) Scan(dest ...interface{}) error {
+	err := r.Row.Scan(dest...)
+	r.handler.observe(r.statement, time.Since(r.start))
+	return err
+}
+
+// SQL文の先頭キーワード（SELECT, UPDATEなど）を返す
+func queryOperation(statement string) string {
+	fields := strings.Fields(statement)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[0])
+}
+
+// 改行やインデントを詰めて1行にする
+func compactSQL(statement string) string {
+	return strings.Join(strings.Fields(statement), " ")
+}
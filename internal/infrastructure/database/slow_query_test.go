@@ -0,0 +1,151 @@
+package databaseInfra
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// 各クエリに delay だけ時間がかかるSqlHandler（QueryRowはScanで待つ）
+type fakeSqlHandler struct {
+	database.SqlHandler
+	delay time.Duration
+}
+
+func (h *fakeSqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	time.Sleep(h.delay)
+	return nil, nil
+}
+
+func (h *fakeSqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	time.Sleep(h.delay)
+	return nil, nil
+}
+
+func (h *fakeSqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	return fakeRow{delay: h.delay}
+}
+
+type fakeRow struct {
+	delay time.Duration
+}
+
+func (r fakeRow) Scan(dest ...interface{}) error {
+	time.Sleep(r.delay)
+	return nil
+}
+
+// slogの既定の出力をJSONで取得できるようにする（テストの終了時に元に戻す）
+func captureSlog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	original := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(original) })
+	return &buf
+}
+
+// 出力されたログを1行ずつ読み込む
+func slogRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestNewSlowQueryHandler_Disabled(t *testing.T) {
+	handler := &fakeSqlHandler{}
+	assert.Same(t, database.SqlHandler(handler), NewSlowQueryHandler(handler, 0, true))
+}
+
+func TestSlowQueryHandler(t *testing.T) {
+	const threshold = 20 * time.Millisecond
+	const statement = `
+        UPDATE items
+        SET name = ?
+        WHERE serial_number = ?
+    `
+	run := map[string]func(database.SqlHandler){
+		"Execute": func(h database.SqlHandler) {
+			_, _ = h.Execute(context.Background(), statement, "デイトナ", "SECRET-SERIAL")
+		},
+		"Query": func(h database.SqlHandler) {
+			_, _ = h.Query(context.Background(), statement, "デイトナ", "SECRET-SERIAL")
+		},
+		"QueryRow": func(h database.SqlHandler) {
+			// Scanまで結果を待たないため、QueryRowの呼び出しだけでは記録しない
+			row := h.QueryRow(context.Background(), statement, "デイトナ", "SECRET-SERIAL")
+			var name string
+			_ = row.Scan(&name)
+		},
+	}
+
+	for name, fn := range run {
+		t.Run("正常系: "+name+" で閾値未満のクエリは出力しない", func(t *testing.T) {
+			buf := captureSlog(t)
+			fn(NewSlowQueryHandler(&fakeSqlHandler{}, threshold, true))
+			assert.Empty(t, buf.String())
+		})
+
+		t.Run("正常系: "+name+" で閾値を超えたクエリをwarnで出力する", func(t *testing.T) {
+			buf := captureSlog(t)
+			fn(NewSlowQueryHandler(&fakeSqlHandler{delay: 2 * threshold}, threshold, true))
+
+			records := slogRecords(t, buf)
+			require.Len(t, records, 1)
+			assert.Equal(t, "WARN", records[0]["level"])
+			assert.Equal(t, "slow query detected", records[0]["msg"])
+			assert.Equal(t, "UPDATE", records[0]["operation"])
+			assert.Equal(t, threshold.String(), records[0]["threshold"])
+			duration, err := time.ParseDuration(records[0]["duration"].(string))
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, duration, 2*threshold)
+			assert.Equal(t, "UPDATE items SET name = ? WHERE serial_number = ?", records[0]["statement"])
+			// 引数の値は出力しない
+			assert.NotContains(t, buf.String(), "SECRET-SERIAL")
+			assert.NotContains(t, buf.String(), "デイトナ")
+		})
+
+		t.Run("正常系: "+name+" でSQLを出力しない設定の場合は操作の種類のみ", func(t *testing.T) {
+			buf := captureSlog(t)
+			fn(NewSlowQueryHandler(&fakeSqlHandler{delay: 2 * threshold}, threshold, false))
+
+			records := slogRecords(t, buf)
+			require.Len(t, records, 1)
+			assert.Equal(t, "UPDATE", records[0]["operation"])
+			assert.NotContains(t, records[0], "statement")
+			assert.NotContains(t, buf.String(), "serial_number")
+			assert.NotContains(t, buf.String(), "SECRET-SERIAL")
+		})
+	}
+}
+
+func TestSlowQueryHandler_Observe(t *testing.T) {
+	buf := captureSlog(t)
+	handler := &slowQueryHandler{threshold: 100 * time.Millisecond}
+
+	// 閾値ちょうどは出力し、それより短い場合は出力しない
+	handler.observe("SELECT 1", 99*time.Millisecond)
+	assert.Empty(t, buf.String())
+	handler.observe("select 1", 100*time.Millisecond)
+	records := slogRecords(t, buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "SELECT", records[0]["operation"])
+	assert.Equal(t, "100ms", records[0]["duration"])
+}
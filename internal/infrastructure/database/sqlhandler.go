@@ -2,12 +2,16 @@ package databaseInfra
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"database/sql"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 
 	"Aicon-assignment/internal/infrastructure/config"
 	"Aicon-assignment/internal/interfaces/database"
@@ -15,15 +19,41 @@ import (
 
 type MySqlHandler struct {
 	Conn *sql.DB
+	// 読み取りに使うレプリカ（DB_REPLICA_HOSTを設定しない場合はnilで、読み取りもConnで行う）
+	Replica *sql.DB
+	// プールから接続を取得するまで待つ最大時間（0の場合は取得をdatabase/sqlに任せ、contextの期限まで待つ）
+	poolTimeout time.Duration
 }
 
 func NewSqlHandler() database.SqlHandler {
+	if err := config.CheckDBTLSConfig(); err != nil {
+		panic(fmt.Sprintf("❌ Invalid database TLS configuration: %v", err))
+	}
+	if config.UseCustomDBTLSConfig() {
+		if err := registerTLSConfig(config.DBTLSConfigName, config.DBTLSServerName, config.DBHost); err != nil {
+			panic(fmt.Sprintf("❌ Failed to configure database TLS: %v", err))
+		}
+		// DB_TLS_SERVER_NAMEはプライマリーの証明書の名前のため、レプリカはホスト名で検証する
+		if config.DBReplicaHost != "" {
+			if err := registerTLSConfig(config.DBReplicaTLSConfigName, "", config.DBReplicaHost); err != nil {
+				panic(fmt.Sprintf("❌ Failed to configure database replica TLS: %v", err))
+			}
+		}
+	}
+
 	dsn := config.GetDSN()
 	conn, err := sql.Open("mysql", dsn)
 	if err != nil {
 		panic(fmt.Sprintf("❌ Failed to connect to database: %v", err))
 	}
 
+	// 上限を設定しない場合は接続の取得を待たないため、取得の待ち時間も計らない
+	conn.SetMaxOpenConns(config.DBMaxOpenConns)
+	var poolTimeout time.Duration
+	if config.DBMaxOpenConns > 0 {
+		poolTimeout = config.DBPoolTimeout
+	}
+
 	// DB接続が確立できているかを確認
 	if err := conn.Ping(); err != nil {
 		panic(fmt.Sprintf("❌ Failed to ping database: %v", err))
@@ -31,10 +61,45 @@ func NewSqlHandler() database.SqlHandler {
 
 	fmt.Println("✅ Successfully connected to the database!")
 
+	// 読み取り専用のDBユーザーやスキーマを別途管理する環境では適用しない
+	if config.SkipMigrations {
+		fmt.Println("⏭️  Skipping schema application (SKIP_MIGRATIONS=true)")
+	} else {
+		applySchema(conn)
+	}
+	warnMissingIndexes(conn)
+
+	return &MySqlHandler{Conn: conn, Replica: openReplica(), poolTimeout: poolTimeout}
+}
+
+// 読み取り用のレプリカに接続する（設定しない場合はnil）
+// スキーマはプライマリーからレプリケーションされるため適用しない
+func openReplica() *sql.DB {
+	dsn := config.GetReplicaDSN()
+	if dsn == "" {
+		return nil
+	}
+
+	replica, err := sql.Open("mysql", dsn)
+	if err != nil {
+		panic(fmt.Sprintf("❌ Failed to connect to database replica: %v", err))
+	}
+	replica.SetMaxOpenConns(config.DBMaxOpenConns)
+
+	if err := replica.Ping(); err != nil {
+		panic(fmt.Sprintf("❌ Failed to ping database replica: %v", err))
+	}
+
+	fmt.Printf("✅ Successfully connected to the database replica (%s:%s)!\n", config.DBReplicaHost, config.DBReplicaPort)
+	return replica
+}
+
+// init.sqlを実行し、既存テーブルに不足しているカラムを追加する
+func applySchema(conn *sql.DB) {
 	// init.sqlを読み込んで実行
 	sqlBytes, err := os.ReadFile("sql/init.sql")
 	if err != nil {
-		fmt.Printf("❌ Failed to read init.sql: %v\n", err)
+		fmt.Printf("❌ Failed to read init.sql: %v (set SKIP_MIGRATIONS=true to skip schema application)\n", err)
 	} else {
 		// SQLファイルを個別のステートメントに分割
 		sqlContent := string(sqlBytes)
@@ -52,9 +117,293 @@ func NewSqlHandler() database.SqlHandler {
 			}
 		}
 		fmt.Println("✅ Successfully initialized database from init.sql")
+		replaceChangedTriggers(conn, statements)
 	}
 
-	return &MySqlHandler{Conn: conn}
+	addMissingColumns(conn)
+	addMissingIndexes(conn)
+	changeColumnTypes(conn)
+}
+
+// 後から定義を変更したトリガー（トリガー名 → 現在の定義に含まれる文字列）
+// CREATE TRIGGER IF NOT EXISTS は既存のトリガーを置き換えないため、定義が古い場合は作り直す
+var changedTriggers = map[string]string{
+	// 値の変わらない書き込み（同じ値のupsertなど）を変更履歴に記録しない
+	"trg_items_changes_update": "NEW.version <> OLD.version",
+}
+
+// 既存のトリガーの定義が古い場合、init.sqlの定義で作り直す
+// MySQLにはトリガーを置き換える文が無いため、削除してから作成する（起動時のため、その間の書き込みは想定しない）
+func replaceChangedTriggers(conn *sql.DB, statements []string) {
+	for name, marker := range changedTriggers {
+		var action string
+		err := conn.QueryRow(
+			`SELECT ACTION_STATEMENT FROM information_schema.TRIGGERS
+			WHERE TRIGGER_SCHEMA = DATABASE() AND TRIGGER_NAME = ?`,
+			name,
+		).Scan(&action)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			fmt.Printf("⚠️  Failed to inspect trigger %s: %v\n", name, err)
+			continue
+		}
+		if strings.Contains(action, marker) {
+			continue
+		}
+
+		create := findCreateTrigger(statements, name)
+		if create == "" {
+			fmt.Printf("⚠️  Trigger %s is not defined in init.sql\n", name)
+			continue
+		}
+		if _, err := conn.Exec("DROP TRIGGER IF EXISTS " + name); err != nil {
+			fmt.Printf("⚠️  Failed to drop trigger %s: %v\n", name, err)
+			continue
+		}
+		if _, err := conn.Exec(create); err != nil {
+			fmt.Printf("⚠️  Failed to recreate trigger %s: %v\n", name, err)
+			continue
+		}
+		fmt.Printf("✅ Recreated trigger %s\n", name)
+	}
+}
+
+// init.sqlのステートメントから、nameのトリガーを作成する文を返す（無い場合は空文字）
+func findCreateTrigger(statements []string, name string) string {
+	for _, stmt := range statements {
+		stmt = strings.TrimSpace(stmt)
+		if strings.HasPrefix(stmt, "CREATE TRIGGER IF NOT EXISTS "+name+" ") {
+			return stmt
+		}
+	}
+	return ""
+}
+
+// 後から追加したカラム（テーブル名 → カラム名と定義）
+// CREATE TABLE IF NOT EXISTS は既存テーブルにカラムを追加しないため、起動時に不足分を追加する
+var addedColumns = map[string][]struct{ name, definition string }{
+	"items": {
+		{"deleted_at", "TIMESTAMP NULL DEFAULT NULL COMMENT 'Soft delete timestamp (NULL while active)'"},
+		// upsertはユニークインデックスが前提のため、カラムと同時に作成する
+		{"serial_number", "VARCHAR(100) NULL DEFAULT NULL COMMENT 'Serial number (unique when set)', ADD UNIQUE INDEX uniq_serial_number (serial_number)"},
+		// 既存のアイテムは所有中として扱う
+		{"status", "VARCHAR(20) NOT NULL DEFAULT 'active' COMMENT 'Item status: draft, active, archived' AFTER serial_number, ADD INDEX idx_status (status)"},
+		{"delete_reason", "VARCHAR(100) NULL DEFAULT NULL COMMENT 'Reason given when soft-deleted (sold, lost, returned, ...)' AFTER deleted_at"},
+		{"version", "BIGINT NOT NULL DEFAULT 1 COMMENT 'Incremented on every write (used for the ETag)' AFTER delete_reason"},
+		// UUIDでの取得はインデックスが前提のため、カラムと同時に作成する
+		{"uuid", "CHAR(36) NULL DEFAULT NULL COMMENT 'Public ID used by the API when ID_TYPE=uuid (NULL otherwise)' AFTER version, ADD UNIQUE INDEX uniq_uuid (uuid)"},
+	},
+	"outbox": {
+		{"dead_lettered_at", "TIMESTAMP NULL DEFAULT NULL COMMENT 'Time delivery was given up after OUTBOX_MAX_ATTEMPTS failures' AFTER delivered_at"},
+	},
+	"item_images": {
+		{"thumbnail", "MEDIUMBLOB NULL COMMENT 'Downscaled JPEG for list views (NULL when the original is small enough)' AFTER data"},
+	},
+}
+
+// 既存テーブルに不足しているカラムを追加する
+func addMissingColumns(conn *sql.DB) {
+	for table, columns := range addedColumns {
+		for _, column := range columns {
+			var count int
+			err := conn.QueryRow(
+				`SELECT COUNT(*) FROM information_schema.COLUMNS
+				WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?`,
+				table, column.name,
+			).Scan(&count)
+			if err != nil {
+				fmt.Printf("⚠️  Failed to inspect columns of %s: %v\n", table, err)
+				continue
+			}
+			if count > 0 {
+				continue
+			}
+
+			if _, err := conn.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column.name, column.definition)); err != nil {
+				fmt.Printf("⚠️  Failed to add column %s to %s: %v\n", column.name, table, err)
+				continue
+			}
+			fmt.Printf("✅ Added column %s to table %s\n", column.name, table)
+		}
+	}
+}
+
+// 後から追加したインデックス（テーブル名 → インデックス名と定義）
+// addedColumns と同じく、既存テーブルに不足しているものを起動時に追加する（カラムの追加より後に行う）
+var addedIndexes = map[string][]struct{ name, definition string }{
+	"items": {
+		// deleted_at のカラムを後から追加したテーブルには、一覧やゴミ箱の絞り込みに使うインデックスが無い
+		{"idx_deleted_at", "INDEX idx_deleted_at (deleted_at)"},
+	},
+	"item_changes": {
+		// 存在しないIDの取得で、削除済み（410）かを変更履歴から確認する際に使う
+		{"idx_item_changes_item_id", "INDEX idx_item_changes_item_id (item_id)"},
+	},
+}
+
+// 既存テーブルに不足しているインデックスを追加する
+func addMissingIndexes(conn *sql.DB) {
+	for table, indexes := range addedIndexes {
+		for _, index := range indexes {
+			var count int
+			err := conn.QueryRow(
+				`SELECT COUNT(*) FROM information_schema.STATISTICS
+				WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND INDEX_NAME = ?`,
+				table, index.name,
+			).Scan(&count)
+			if err != nil {
+				fmt.Printf("⚠️  Failed to inspect indexes of %s: %v\n", table, err)
+				continue
+			}
+			if count > 0 {
+				continue
+			}
+
+			if _, err := conn.Exec(fmt.Sprintf("ALTER TABLE %s ADD %s", table, index.definition)); err != nil {
+				fmt.Printf("⚠️  Failed to add index %s to %s: %v\n", index.name, table, err)
+				continue
+			}
+			fmt.Printf("✅ Added index %s to table %s\n", index.name, table)
+		}
+	}
+}
+
+// 後から型を変更したカラム（テーブル名 → カラム名、期待する型、定義）
+// CREATE TABLE IF NOT EXISTS は既存テーブルのカラムの型を変更しないため、起動時に変更する
+var changedColumns = map[string][]struct{ name, dataType, definition string }{
+	"items": {
+		// INTでは2,147,483,647円を超える購入価格を保存できない
+		{"purchase_price", "bigint", "BIGINT NOT NULL DEFAULT 0 COMMENT 'Purchase price in yen'"},
+	},
+}
+
+// 既存テーブルのカラムの型が期待と異なる場合に変更する
+func changeColumnTypes(conn *sql.DB) {
+	for table, columns := range changedColumns {
+		for _, column := range columns {
+			var dataType string
+			err := conn.QueryRow(
+				`SELECT DATA_TYPE FROM information_schema.COLUMNS
+				WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?`,
+				table, column.name,
+			).Scan(&dataType)
+			if err != nil {
+				fmt.Printf("⚠️  Failed to inspect column %s of %s: %v\n", column.name, table, err)
+				continue
+			}
+			if strings.EqualFold(dataType, column.dataType) {
+				continue
+			}
+
+			if _, err := conn.Exec(fmt.Sprintf("ALTER TABLE %s MODIFY %s %s", table, column.name, column.definition)); err != nil {
+				fmt.Printf("⚠️  Failed to change column %s of %s to %s: %v\n", column.name, table, column.dataType, err)
+				continue
+			}
+			fmt.Printf("✅ Changed column %s of table %s from %s to %s\n", column.name, table, dataType, column.dataType)
+		}
+	}
+}
+
+// フィルター系クエリが前提とするインデックス（テーブル名 → インデックス名）
+var expectedIndexes = map[string][]string{
+	"items": {
+		"idx_category",
+		"idx_brand",
+		"idx_purchase_date",
+		"idx_created_at",
+		"idx_category_purchase_date",
+		"idx_deleted_at",
+		"idx_status",
+		"uniq_serial_number",
+		"uniq_uuid",
+	},
+	"outbox": {
+		"idx_outbox_pending",
+	},
+	"item_changes": {
+		"idx_item_changes_changed_at",
+		"idx_item_changes_item_id",
+	},
+	"item_images": {
+		"idx_item_images_item_position",
+	},
+	"search_presets": {
+		"uniq_search_presets_name",
+	},
+}
+
+// 既存テーブルに期待するインデックスが無い場合に警告を出す
+// CREATE TABLE IF NOT EXISTS は既存テーブルにインデックスを追加しないため、起動時に確認する
+func warnMissingIndexes(conn *sql.DB) {
+	for table, indexes := range expectedIndexes {
+		rows, err := conn.Query(
+			`SELECT DISTINCT INDEX_NAME FROM information_schema.STATISTICS
+			WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?`,
+			table,
+		)
+		if err != nil {
+			fmt.Printf("⚠️  Failed to inspect indexes of %s: %v\n", table, err)
+			continue
+		}
+
+		existing := make(map[string]bool)
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&name); err == nil {
+				existing[name] = true
+			}
+		}
+		rows.Close()
+
+		for _, index := range indexes {
+			if !existing[index] {
+				fmt.Printf("⚠️  Missing index %s on table %s (see sql/init.sql)\n", index, table)
+			}
+		}
+	}
+}
+
+// CA証明書・クライアント証明書を読み込み、mysqlドライバにTLS設定をnameで登録する
+// 証明書検証に使うサーバー名はserverName（空の場合はhost）
+func registerTLSConfig(name, serverName, host string) error {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+	}
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = host
+	}
+
+	if config.DBTLSCACert != "" {
+		caPEM, err := os.ReadFile(config.DBTLSCACert)
+		if err != nil {
+			return fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		rootCAs := x509.NewCertPool()
+		if ok := rootCAs.AppendCertsFromPEM(caPEM); !ok {
+			return fmt.Errorf("failed to parse CA certificate: %s", config.DBTLSCACert)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	// 片方だけの場合は、クライアント証明書を送らずに接続してしまわないようエラーにする
+	switch {
+	case config.DBTLSCert != "" && config.DBTLSKey == "":
+		return errors.New("DB_TLS_CERT is set but DB_TLS_KEY is empty (set both to use a client certificate)")
+	case config.DBTLSCert == "" && config.DBTLSKey != "":
+		return errors.New("DB_TLS_KEY is set but DB_TLS_CERT is empty (set both to use a client certificate)")
+	}
+	if config.DBTLSCert != "" {
+		cert, err := tls.LoadX509KeyPair(config.DBTLSCert, config.DBTLSKey)
+		if err != nil {
+			return fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return mysql.RegisterTLSConfig(name, tlsConfig)
 }
 
 // SQLステートメントを分割するヘルパー関数
@@ -89,8 +438,78 @@ func splitSQLStatements(sql string) []string {
 	return statements
 }
 
+// トランザクションをctxに格納する際のキー
+type txKey struct{}
+
+// database/sqlの*sql.DBと*sql.Txに共通するメソッド
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// ctxにトランザクションがあればそれを、無ければコネクションプール（またはプールから取得した接続）を返す
+// readの場合はレプリカのプールを使う（レプリカが無い場合やWithPrimaryReadsの場合はプライマリー）
+// releaseは結果を読み終えた後に呼び、取得した接続をプールに戻す
+func (h *MySqlHandler) executor(ctx context.Context, read bool) (ex executor, release func(), err error) {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx, func() {}, nil
+	}
+	db := h.Conn
+	if read && h.Replica != nil && !database.UsePrimaryReads(ctx) {
+		db = h.Replica
+	}
+	if h.poolTimeout <= 0 {
+		return db, func() {}, nil
+	}
+	conn, err := h.acquire(ctx, db)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, func() { conn.Close() }, nil
+}
+
+func (h *MySqlHandler) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// 既にトランザクション内の場合はそのまま参加する
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	var beginner interface {
+		BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+	} = h.Conn
+	if h.poolTimeout > 0 {
+		conn, err := h.acquire(ctx, h.Conn)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		beginner = conn
+	}
+
+	tx, err := beginner.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rollbackErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func (h *MySqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
-	result, err := h.Conn.ExecContext(ctx, statement, args...)
+	ex, release, err := h.executor(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	result, err := ex.ExecContext(ctx, statement, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -98,23 +517,38 @@ func (h *MySqlHandler) Execute(ctx context.Context, statement string, args ...in
 }
 
 func (h *MySqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
-	rows, err := h.Conn.QueryContext(ctx, statement, args...)
+	ex, release, err := h.executor(ctx, true)
 	if err != nil {
 		return nil, err
 	}
-	return &mysqlRows{rows: rows}, nil
+
+	rows, err := ex.QueryContext(ctx, statement, args...)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	return &mysqlRows{rows: rows, release: release}, nil
 }
 
 func (h *MySqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
-	row := h.Conn.QueryRowContext(ctx, statement, args...)
-	return &mysqlRow{row: row}
+	ex, release, err := h.executor(ctx, true)
+	if err != nil {
+		return &mysqlRow{err: err}
+	}
+	return &mysqlRow{row: ex.QueryRowContext(ctx, statement, args...), release: release}
 }
 
 func (h *MySqlHandler) Close() error {
+	var err error
+	if h.Replica != nil {
+		err = h.Replica.Close()
+	}
 	if h.Conn != nil {
-		return h.Conn.Close()
+		if connErr := h.Conn.Close(); connErr != nil {
+			err = connErr
+		}
 	}
-	return nil
+	return err
 }
 
 type mysqlResult struct {
@@ -130,7 +564,8 @@ func (r *mysqlResult) RowsAffected() (int64, error) {
 }
 
 type mysqlRows struct {
-	rows *sql.Rows
+	rows    *sql.Rows
+	release func()
 }
 
 func (r *mysqlRows) Next() bool {
@@ -142,7 +577,9 @@ func (r *mysqlRows) Scan(dest ...interface{}) error {
 }
 
 func (r *mysqlRows) Close() error {
-	return r.rows.Close()
+	err := r.rows.Close()
+	r.release()
+	return err
 }
 
 func (r *mysqlRows) Err() error {
@@ -150,9 +587,15 @@ func (r *mysqlRows) Err() error {
 }
 
 type mysqlRow struct {
-	row *sql.Row
+	row     *sql.Row
+	err     error // 接続を取得できなかった場合のエラー（Scanで返す）
+	release func()
 }
 
 func (r *mysqlRow) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	defer r.release()
 	return r.row.Scan(dest...)
 }
\ No newline at end of file
@@ -0,0 +1,94 @@
+package databaseInfra
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/infrastructure/config"
+)
+
+func TestRegisterTLSConfig_ClientCertificatePair(t *testing.T) {
+	defaultCA, defaultCert, defaultKey := config.DBTLSCACert, config.DBTLSCert, config.DBTLSKey
+	t.Cleanup(func() { config.DBTLSCACert, config.DBTLSCert, config.DBTLSKey = defaultCA, defaultCert, defaultKey })
+	config.DBTLSCACert = ""
+
+	// 片方だけの場合は証明書を読み込む前にエラーにする
+	config.DBTLSCert, config.DBTLSKey = "/etc/ssl/client.crt", ""
+	assert.ErrorContains(t, registerTLSConfig("test-client-cert-only", "", "db"), "DB_TLS_KEY is empty")
+
+	config.DBTLSCert, config.DBTLSKey = "", "/etc/ssl/client.key"
+	assert.ErrorContains(t, registerTLSConfig("test-client-key-only", "", "db"), "DB_TLS_CERT is empty")
+
+	// 証明書も秘密鍵も無い場合はサーバーの検証のみの設定を登録する
+	config.DBTLSCert, config.DBTLSKey = "", ""
+	assert.NoError(t, registerTLSConfig("test-no-client-cert", "", "db"))
+}
+
+// テストからinit.sqlをステートメントに分割して読み込む
+func readInitSQLStatements(t *testing.T) []string {
+	t.Helper()
+	sqlBytes, err := os.ReadFile("../../../sql/init.sql")
+	require.NoError(t, err)
+	return splitSQLStatements(string(sqlBytes))
+}
+
+func TestInitSQL_ChangedTriggers(t *testing.T) {
+	statements := readInitSQLStatements(t)
+
+	// 作り直す際の判定に使う文字列が、init.sqlの現在の定義に含まれている
+	for name, marker := range changedTriggers {
+		create := findCreateTrigger(statements, name)
+		require.NotEmpty(t, create, name)
+		assert.Contains(t, create, marker)
+	}
+
+	// 値の変わらない更新（同じ値のupsert、UUIDの割り当て）は変更履歴に記録しない
+	update := findCreateTrigger(statements, "trg_items_changes_update")
+	assert.Contains(t, update, "WHERE NEW.version <> OLD.version OR NOT (OLD.deleted_at <=> NEW.deleted_at);")
+	assert.Empty(t, findCreateTrigger(statements, "trg_missing"))
+}
+
+// init.sqlのCREATE TABLEで定義しているインデックス名（テーブル名 → インデックス名）
+func initSQLIndexes(t *testing.T) map[string][]string {
+	t.Helper()
+	tablePattern := regexp.MustCompile(`^CREATE TABLE IF NOT EXISTS (\w+) \(`)
+	indexPattern := regexp.MustCompile(`(?m)^\s*(?:UNIQUE )?(?:INDEX|KEY) (\w+) \(`)
+
+	indexes := make(map[string][]string)
+	for _, stmt := range readInitSQLStatements(t) {
+		match := tablePattern.FindStringSubmatch(strings.TrimSpace(stmt))
+		if match == nil {
+			continue
+		}
+		for _, index := range indexPattern.FindAllStringSubmatch(stmt, -1) {
+			indexes[match[1]] = append(indexes[match[1]], index[1])
+		}
+	}
+	return indexes
+}
+
+func TestExpectedIndexes_MatchInitSQL(t *testing.T) {
+	declared := initSQLIndexes(t)
+	require.NotEmpty(t, declared)
+
+	// 起動時に不足を警告するインデックスは、すべてinit.sqlで作成している（名前の誤りで警告し続けない）
+	for table, indexes := range expectedIndexes {
+		assert.Subset(t, declared[table], indexes, "table %s", table)
+	}
+	// init.sqlに追加したインデックスは、既存のDBで不足した場合に警告する
+	for table, indexes := range declared {
+		assert.ElementsMatch(t, indexes, expectedIndexes[table], "table %s", table)
+	}
+	// 既存のDBに起動時に追加するインデックスも、init.sqlと同じ名前で作成する
+	for table, indexes := range addedIndexes {
+		for _, index := range indexes {
+			assert.Contains(t, declared[table], index.name)
+			assert.Contains(t, index.definition, "INDEX "+index.name+" (")
+		}
+	}
+}
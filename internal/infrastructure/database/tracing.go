@@ -0,0 +1,106 @@
+package databaseInfra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"Aicon-assignment/internal/infrastructure/tracing"
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// クエリごとにスパンを記録するSqlHandlerのラッパー
+// 引数は値が漏れないよう常に記録せず、redactSQLの場合はSQL文も記録しない（操作の種類のみ）
+type tracingHandler struct {
+	database.SqlHandler
+	tracer    trace.Tracer
+	redactSQL bool
+}
+
+// enabledでない場合はラップせずにそのまま返す
+func NewTracingHandler(handler database.SqlHandler, enabled, redactSQL bool) database.SqlHandler {
+	if !enabled {
+		return handler
+	}
+	return &tracingHandler{
+		SqlHandler: handler,
+		tracer:     tracing.Tracer(),
+		redactSQL:  redactSQL,
+	}
+}
+
+func (h *tracingHandler) start(ctx context.Context, statement string) (context.Context, trace.Span) {
+	operation := queryOperation(statement)
+	attrs := []attribute.KeyValue{
+		attribute.String("db.system", "mysql"),
+		attribute.String("db.operation", operation),
+	}
+	if !h.redactSQL {
+		attrs = append(attrs, attribute.String("db.statement", compactSQL(statement)))
+	}
+	return h.tracer.Start(ctx, "db."+operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+func (h *tracingHandler) Execute(ctx context.Context, statement string, args ...interface{}) (result database.Result, err error) {
+	ctx, span := h.start(ctx, statement)
+	defer func() { tracing.End(span, err) }()
+	return h.SqlHandler.Execute(ctx, statement, args...)
+}
+
+func (h *tracingHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	ctx, span := h.start(ctx, statement)
+	rows, err := h.SqlHandler.Query(ctx, statement, args...)
+	if err != nil {
+		tracing.End(span, err)
+		return nil, err
+	}
+	// 結果を読み終えるまでをクエリの時間とする
+	return &tracingRows{Rows: rows, span: span}, nil
+}
+
+func (h *tracingHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	ctx, span := h.start(ctx, statement)
+	// QueryRowはScanまでクエリの完了を待たないため、Scan完了時点で終了する
+	return &tracingRow{Row: h.SqlHandler.QueryRow(ctx, statement, args...), span: span}
+}
+
+// トランザクション内のクエリのスパンをまとめる
+func (h *tracingHandler) Transaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	ctx, span := h.tracer.Start(ctx, "db.transaction", trace.WithAttributes(attribute.String("db.system", "mysql")))
+	defer func() { tracing.End(span, err) }()
+	return h.SqlHandler.Transaction(ctx, fn)
+}
+
+type tracingRows struct {
+	database.Rows
+	span trace.Span
+}
+
+func (r *tracingRows) Close() error {
+	err := r.Rows.Close()
+	if rowsErr := r.Rows.Err(); rowsErr != nil {
+		tracing.End(r.span, rowsErr)
+	} else {
+		tracing.End(r.span, err)
+	}
+	return err
+}
+
+type tracingRow struct {
+	database.Row
+	span trace.Span
+}
+
+func (r *tracingRow) Scan(dest ...interface{}) error {
+	err := r.Row.Scan(dest...)
+	if errors.Is(err, sql.ErrNoRows) {
+		// 該当する行が無いのはクエリの失敗ではない
+		r.span.End()
+		return err
+	}
+	tracing.End(r.span, err)
+	return err
+}
@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"expvar"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// リクエスト・レスポンスのサイズのバケット（バイト、上限の値以下を数える）
+var sizeBuckets = []int64{1 << 10, 10 << 10, 100 << 10, 1 << 20, 10 << 20}
+
+// expvarで公開するメトリクス（GET /metrics でJSONとして取得できる）
+var (
+	// 処理中のリクエスト数
+	InFlightRequests = expvar.NewInt("http_in_flight_requests")
+	// 同時実行数の上限により503を返したリクエスト数
+	RejectedRequests = expvar.NewInt("http_rejected_requests_total")
+	// DBのコネクションプールから接続の取得を待っている数
+	DBPoolWaiting = expvar.NewInt("db_pool_waiting")
+	// DB_POOL_TIMEOUTまで待っても接続を取得できなかった数
+	DBPoolTimeouts = expvar.NewInt("db_pool_timeouts_total")
+	// リクエストボディのサイズ（Content-Lengthが分かるもののみ）
+	RequestSizes = NewHistogram("http_request_size_bytes", sizeBuckets)
+	// レスポンスボディのサイズ
+	ResponseSizes = NewHistogram("http_response_size_bytes", sizeBuckets)
+)
+
+// 値の分布を数えるヒストグラム（バケットは累積で、各上限以下の件数を持つ）
+type Histogram struct {
+	mu     sync.Mutex
+	bounds []int64
+	counts []int64 // 最後の要素は上限を超えた件数
+	count  int64
+	sum    int64
+}
+
+// ヒストグラムを作成し、expvarに登録する
+func NewHistogram(name string, bounds []int64) *Histogram {
+	h := newHistogram(bounds)
+	expvar.Publish(name, h)
+	return h
+}
+
+func newHistogram(bounds []int64) *Histogram {
+	return &Histogram{
+		bounds: bounds,
+		counts: make([]int64, len(bounds)+1),
+	}
+}
+
+// 値を1件記録する
+func (h *Histogram) Observe(value int64) {
+	index := len(h.bounds)
+	for i, bound := range h.bounds {
+		if value <= bound {
+			index = i
+			break
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.counts[index]++
+	h.count++
+	h.sum += value
+}
+
+// expvar.Var の実装（{"count":..,"sum":..,"buckets":{"1024":..,"+Inf":..}}）
+// バケットは上限の小さい順に並べる
+func (h *Histogram) String() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var b strings.Builder
+	b.WriteString(`{"count":` + strconv.FormatInt(h.count, 10))
+	b.WriteString(`,"sum":` + strconv.FormatInt(h.sum, 10))
+	b.WriteString(`,"buckets":{`)
+	var cumulative int64
+	for i, bound := range h.bounds {
+		cumulative += h.counts[i]
+		b.WriteString(`"` + strconv.FormatInt(bound, 10) + `":` + strconv.FormatInt(cumulative, 10) + `,`)
+	}
+	b.WriteString(`"+Inf":` + strconv.FormatInt(h.count, 10) + `}}`)
+	return b.String()
+}
+
+// メトリクスをJSONで返すハンドラー
+func Handler() http.Handler {
+	return expvar.Handler()
+}
@@ -0,0 +1,23 @@
+package metrics
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogram(t *testing.T) {
+	h := newHistogram([]int64{10, 100})
+	h.Observe(5)
+	h.Observe(10)
+	h.Observe(50)
+	h.Observe(1000)
+
+	assert.Equal(t, `{"count":4,"sum":1065,"buckets":{"10":2,"100":3,"+Inf":4}}`, h.String())
+
+	// expvarの値として有効なJSONであること
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(h.String()), &decoded))
+}
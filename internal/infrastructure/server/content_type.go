@@ -0,0 +1,68 @@
+package server
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+)
+
+// ボディを送る書き込みのエンドポイントで受け付けるContent-Type（ルートのパスごと、"image/*" は前方一致）
+// 一覧に無いルートはJSONのみを受け付ける
+var uploadContentTypes = map[string][]string{
+	"/items/import": {
+		echo.MIMEMultipartForm, "text/csv", echo.MIMETextPlain,
+		echo.MIMEApplicationJSON, "application/x-ndjson", "application/jsonl",
+	},
+	"/items/:id/images": {echo.MIMEMultipartForm, "image/*", echo.MIMEOctetStream},
+	"/admin/restore":    {echo.MIMEApplicationJSON, echo.MIMEMultipartForm},
+}
+
+var jsonContentTypes = []string{echo.MIMEApplicationJSON}
+
+// 書き込みのリクエストのContent-Typeが受け付ける形式でない場合は415を返す
+// フォーム形式などで送ったJSONが、空の入力としてバリデーションエラーになるのを防ぐ
+// ボディの無いリクエスト（POST /items/:id/archive やボディを省略したDELETE）は対象外とする
+func newContentTypeMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.Method == http.MethodGet || req.Method == http.MethodHead || req.Method == http.MethodOptions || req.ContentLength == 0 {
+				return next(c)
+			}
+
+			allowed, ok := uploadContentTypes[c.Path()]
+			if !ok {
+				allowed = jsonContentTypes
+			}
+			if !contentTypeAllowed(req.Header.Get(echo.HeaderContentType), allowed) {
+				return c.JSON(http.StatusUnsupportedMediaType, itemController.ErrorResponse{
+					Code:    itemController.CodeUnsupportedMediaType,
+					Error:   "unsupported media type",
+					Details: []string{"Content-Type must be one of: " + strings.Join(allowed, ", ")},
+				})
+			}
+			return next(c)
+		}
+	}
+}
+
+func contentTypeAllowed(header string, allowed []string) bool {
+	mediaType, _, err := mime.ParseMediaType(header)
+	if err != nil {
+		return false
+	}
+	for _, candidate := range allowed {
+		if prefix, wildcard := strings.CutSuffix(candidate, "*"); wildcard {
+			if strings.HasPrefix(mediaType, prefix) {
+				return true
+			}
+		} else if mediaType == candidate {
+			return true
+		}
+	}
+	return false
+}
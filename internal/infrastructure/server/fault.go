@@ -0,0 +1,122 @@
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/infrastructure/config"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+)
+
+// 障害を注入したレスポンスに付けるヘッダー（error / delay）
+const headerFaultInjected = "X-Fault-Injected"
+
+// クライアントのリトライを試すため、一定の割合のリクエストを遅延させたりエラーにしたりする（開発用）
+// 同じシードでは同じ順序で判定するため、リクエストを1件ずつ送れば結果を再現できる
+type faultInjector struct {
+	errorPercent int   // エラーにする割合（0〜100）
+	statuses     []int // エラーにする場合のステータスコード（いずれかを選ぶ）
+	delayPercent int   // 遅延させる割合（0〜100）
+	delay        time.Duration
+
+	mu     sync.Mutex
+	random *rand.Rand
+}
+
+// 設定から作成する（seedが0の場合は起動ごとに異なる順序になる）
+func newFaultInjectorFromConfig() (*faultInjector, error) {
+	statuses := make([]int, 0, len(config.FaultErrorStatuses))
+	for _, value := range config.FaultErrorStatuses {
+		status, err := strconv.Atoi(value)
+		if err != nil || status < 400 || status > 599 {
+			return nil, fmt.Errorf("invalid FAULT_ERROR_STATUSES: %s (must be 4xx or 5xx status codes)", value)
+		}
+		statuses = append(statuses, status)
+	}
+	seed := config.FaultSeed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return newFaultInjector(config.FaultErrorPercent, statuses, config.FaultDelayPercent, config.FaultDelay, seed)
+}
+
+func newFaultInjector(errorPercent int, statuses []int, delayPercent int, delay time.Duration, seed int64) (*faultInjector, error) {
+	if errorPercent < 0 || errorPercent > 100 {
+		return nil, fmt.Errorf("invalid FAULT_ERROR_PERCENT: %d (must be between 0 and 100)", errorPercent)
+	}
+	if delayPercent < 0 || delayPercent > 100 {
+		return nil, fmt.Errorf("invalid FAULT_DELAY_PERCENT: %d (must be between 0 and 100)", delayPercent)
+	}
+	if errorPercent > 0 && len(statuses) == 0 {
+		return nil, fmt.Errorf("FAULT_ERROR_STATUSES must not be empty")
+	}
+	return &faultInjector{
+		errorPercent: errorPercent,
+		statuses:     statuses,
+		delayPercent: delayPercent,
+		delay:        delay,
+		random:       rand.New(rand.NewSource(seed)),
+	}, nil
+}
+
+// 1件のリクエストに注入する障害を決める（statusが0の場合はエラーにしない）
+// 結果を再現できるよう、判定に関わらず毎回同じ回数だけ乱数を引く
+func (f *faultInjector) draw() (delay bool, status int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delayRoll, errorRoll, statusRoll := f.random.Intn(100), f.random.Intn(100), f.random.Int()
+	if errorRoll < f.errorPercent {
+		status = f.statuses[statusRoll%len(f.statuses)]
+	}
+	return delayRoll < f.delayPercent, status
+}
+
+func (f *faultInjector) String() string {
+	statuses := make([]string, len(f.statuses))
+	for i, status := range f.statuses {
+		statuses[i] = strconv.Itoa(status)
+	}
+	return fmt.Sprintf("error: %d%% (%s), delay: %d%% (%s)", f.errorPercent, strings.Join(statuses, ","), f.delayPercent, f.delay)
+}
+
+func (f *faultInjector) middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			delay, status := f.draw()
+
+			if delay && f.delay > 0 {
+				c.Response().Header().Add(headerFaultInjected, "delay")
+				timer := time.NewTimer(f.delay)
+				select {
+				case <-timer.C:
+				case <-c.Request().Context().Done():
+					timer.Stop()
+					return nil
+				}
+			}
+
+			if status != 0 {
+				slog.Debug("injected fault", "method", c.Request().Method, "path", c.Request().URL.Path, "status", status)
+				c.Response().Header().Add(headerFaultInjected, "error")
+				if status == http.StatusServiceUnavailable {
+					c.Response().Header().Set("Retry-After", "1")
+				}
+				return c.JSON(status, itemController.ErrorResponse{
+					Code:  httpErrorCode(status),
+					Error: "injected fault",
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+)
+
+// 整形する場合のインデント
+const prettyJSONIndent = "  "
+
+// JSONレスポンスのシリアライザー（リクエストボディの読み込みは、フィールド名の表記の変換を除いてEchoの既定のまま）
+// ?pretty=true でインデント付き、?pretty=false で整形しない（値を省略した ?pretty は true として扱う）
+// 指定しない場合はprettyByDefaultに従う。ETagは本文ではなくアイテムの更新日時などから計算するため、整形の有無で変わらない
+// フィールド名はfieldCase（空の場合はsnake）の表記にし、Accept / Content-Type の casing パラメータでリクエストごとに指定できる
+type jsonSerializer struct {
+	echo.DefaultJSONSerializer
+	prettyByDefault bool
+	fieldCase       jsonFieldCase
+}
+
+var _ itemController.RequestKeyNormalizer = jsonSerializer{}
+
+func (s jsonSerializer) Serialize(c echo.Context, i interface{}, _ string) error {
+	if s.responseFieldCase(c) == fieldCaseCamel {
+		return s.serializeCamel(c, i)
+	}
+	enc := json.NewEncoder(c.Response())
+	if s.pretty(c) {
+		enc.SetIndent("", prettyJSONIndent)
+	}
+	return enc.Encode(i)
+}
+
+// 構造体のjsonタグのまま書き出したものを、フィールド名だけ camelCase に書き換える
+func (s jsonSerializer) serializeCamel(c echo.Context, i interface{}) error {
+	encoded, err := json.Marshal(i)
+	if err != nil {
+		return err
+	}
+	body, err := renameJSONKeys(encoded, snakeToCamel)
+	if err != nil {
+		return err
+	}
+	if s.pretty(c) {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, body, "", prettyJSONIndent); err != nil {
+			return err
+		}
+		body = indented.Bytes()
+	}
+	_, err = c.Response().Write(append(body, '\n'))
+	return err
+}
+
+// camelCase のリクエストボディは、フィールド名を snake_case に書き換えてから読み込む
+// JSONとして読めない場合は、エラーの扱いを既定と同じにするため書き換えずに読み込む
+func (s jsonSerializer) Deserialize(c echo.Context, i interface{}) error {
+	req := c.Request()
+	if s.requestFieldCase(c) != fieldCaseCamel || req.Body == nil {
+		return s.DefaultJSONSerializer.Deserialize(c, i)
+	}
+
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		return s.DefaultJSONSerializer.Deserialize(c, i)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(normalizeRequestKeys(data)))
+	return s.DefaultJSONSerializer.Deserialize(c, i)
+}
+
+// c.Bind を使わずに読み込むボディ（検索条件・一括登録など）も、Deserialize と同じく camelCase を受け付ける
+func (s jsonSerializer) NormalizeRequestKeys(c echo.Context) func([]byte) []byte {
+	if s.requestFieldCase(c) != fieldCaseCamel {
+		return nil
+	}
+	return normalizeRequestKeys
+}
+
+// フィールド名を snake_case に書き換える（JSONとして読めない場合は、エラーの扱いを既定と同じにするため書き換えない）
+func normalizeRequestKeys(data []byte) []byte {
+	if renamed, err := renameJSONKeys(data, camelToSnake); err == nil {
+		return renamed
+	}
+	return data
+}
+
+func (s jsonSerializer) pretty(c echo.Context) bool {
+	values, ok := c.QueryParams()["pretty"]
+	if !ok {
+		return s.prettyByDefault
+	}
+	if values[0] == "" {
+		return true
+	}
+	pretty, err := strconv.ParseBool(values[0])
+	if err != nil {
+		return s.prettyByDefault
+	}
+	return pretty
+}
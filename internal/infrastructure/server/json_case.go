@@ -0,0 +1,170 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"strings"
+	"unicode"
+
+	"github.com/labstack/echo/v4"
+)
+
+// JSONのフィールド名の表記
+type jsonFieldCase string
+
+const (
+	fieldCaseSnake jsonFieldCase = "snake" // purchase_price（既定）
+	fieldCaseCamel jsonFieldCase = "camel" // purchasePrice
+)
+
+// Accept / Content-Type で表記を指定するパラメータ（application/json; casing=camel）
+const fieldCaseParam = "casing"
+
+// JSON_FIELD_CASE の値を検証する（空の場合はsnake）
+func parseJSONFieldCase(value string) (jsonFieldCase, error) {
+	fieldCase := jsonFieldCase(strings.ToLower(strings.TrimSpace(value)))
+	switch fieldCase {
+	case "":
+		return fieldCaseSnake, nil
+	case fieldCaseSnake, fieldCaseCamel:
+		return fieldCase, nil
+	}
+	return "", fmt.Errorf("%s (must be one of: snake, camel)", value)
+}
+
+// ヘッダーのcasingパラメータで指定した表記（指定しない場合や不正な値の場合はfallback）
+func fieldCaseFromHeader(header string, fallback jsonFieldCase) jsonFieldCase {
+	// Acceptは複数のメディアタイプを並べられるため、パラメータを指定したものを探す
+	for _, mediaRange := range strings.Split(header, ",") {
+		_, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+		if value, ok := params[fieldCaseParam]; ok {
+			if fieldCase, err := parseJSONFieldCase(value); err == nil && value != "" {
+				return fieldCase
+			}
+		}
+	}
+	return fallback
+}
+
+// レスポンスの表記（Acceptのcasing、無ければ既定の表記）
+func (s jsonSerializer) responseFieldCase(c echo.Context) jsonFieldCase {
+	return fieldCaseFromHeader(c.Request().Header.Get(echo.HeaderAccept), s.defaultFieldCase())
+}
+
+// リクエストボディの表記（Content-Typeのcasing、無ければ既定の表記）
+func (s jsonSerializer) requestFieldCase(c echo.Context) jsonFieldCase {
+	return fieldCaseFromHeader(c.Request().Header.Get(echo.HeaderContentType), s.defaultFieldCase())
+}
+
+func (s jsonSerializer) defaultFieldCase() jsonFieldCase {
+	if s.fieldCase == "" {
+		return fieldCaseSnake
+	}
+	return s.fieldCase
+}
+
+// snake_case のフィールド名を camelCase にする（purchase_price → purchasePrice）
+// 小文字・数字・_ のみの名前だけを変換し、カテゴリー別の集計のキーのような値はそのまま残す
+func snakeToCamel(name string) string {
+	if !strings.Contains(name, "_") || strings.IndexFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_')
+	}) >= 0 {
+		return name
+	}
+	words := strings.Split(name, "_")
+	var builder strings.Builder
+	builder.Grow(len(name))
+	for i, word := range words {
+		if i > 0 && word != "" {
+			builder.WriteString(strings.ToUpper(word[:1]))
+			word = word[1:]
+		}
+		builder.WriteString(word)
+	}
+	return builder.String()
+}
+
+// camelCase のフィールド名を snake_case にする（purchasePrice → purchase_price）
+// 英小文字で始まり、英数字のみの名前だけを変換する
+func camelToSnake(name string) string {
+	if name == "" || !(name[0] >= 'a' && name[0] <= 'z') || strings.IndexFunc(name, func(r rune) bool {
+		return r > unicode.MaxASCII || !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) >= 0 {
+		return name
+	}
+	var builder strings.Builder
+	builder.Grow(len(name) + 4)
+	for _, r := range name {
+		if unicode.IsUpper(r) {
+			builder.WriteByte('_')
+			r = unicode.ToLower(r)
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// JSONのオブジェクトのキーをrenameで書き換える（値とキーの順序はそのまま）
+func renameJSONKeys(data []byte, rename func(string) string) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var buf bytes.Buffer
+	buf.Grow(len(data))
+	if err := renameJSONValue(dec, &buf, rename); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func renameJSONValue(dec *json.Decoder, buf *bytes.Buffer, rename func(string) string) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		// 文字列・数値・真偽値・null はそのまま書き出す（json.Numberは元の表記のまま）
+		encoded, err := json.Marshal(token)
+		if err != nil {
+			return err
+		}
+		buf.Write(encoded)
+		return nil
+	}
+
+	isObject := delim == '{'
+	buf.WriteRune(rune(delim))
+	for i := 0; dec.More(); i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if isObject {
+			keyToken, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key, err := json.Marshal(rename(keyToken.(string)))
+			if err != nil {
+				return err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+		}
+		if err := renameJSONValue(dec, buf, rename); err != nil {
+			return err
+		}
+	}
+	// 閉じ括弧
+	end, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	buf.WriteRune(rune(end.(json.Delim)))
+	return nil
+}
@@ -0,0 +1,350 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"Aicon-assignment/internal/infrastructure/metrics"
+	"Aicon-assignment/internal/infrastructure/tracing"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// ハンドラー内のpanicを捕捉し、スタックトレースはログにのみ出力して500を返す
+func newRecoverMiddleware() echo.MiddlewareFunc {
+	return middleware.RecoverWithConfig(middleware.RecoverConfig{
+		LogErrorFunc: func(c echo.Context, err error, stack []byte) error {
+			slog.Error("panic recovered",
+				"method", c.Request().Method,
+				"path", c.Request().URL.Path,
+				"error", err,
+				"stack", string(stack),
+			)
+
+			if c.Response().Committed {
+				return nil
+			}
+			// スタックトレースはクライアントに返さない
+			return c.JSON(http.StatusInternalServerError, itemController.ErrorResponse{
+				Code:  itemController.CodeInternalError,
+				Error: "internal server error",
+			})
+		},
+	})
+}
+
+// Echoが返すエラー（存在しないパス、対応していないメソッドなど）もErrorResponseの形式で返す
+func httpErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	status := http.StatusInternalServerError
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		status = httpErr.Code
+	}
+	if status >= http.StatusInternalServerError {
+		slog.Error("request failed",
+			"method", c.Request().Method,
+			"path", c.Request().URL.Path,
+			"error", err,
+		)
+	}
+
+	if c.Request().Method == http.MethodHead {
+		_ = c.NoContent(status)
+		return
+	}
+	_ = c.JSON(status, itemController.ErrorResponse{
+		Code:  httpErrorCode(status),
+		Error: strings.ToLower(http.StatusText(status)),
+	})
+}
+
+func httpErrorCode(status int) string {
+	switch {
+	case status == http.StatusNotFound:
+		return itemController.CodeRouteNotFound
+	case status == http.StatusMethodNotAllowed:
+		return itemController.CodeMethodNotAllowed
+	case status == http.StatusRequestEntityTooLarge:
+		return itemController.CodePayloadTooLarge
+	case status == http.StatusUnsupportedMediaType:
+		return itemController.CodeUnsupportedMediaType
+	case status == http.StatusServiceUnavailable:
+		return itemController.CodeServerBusy
+	case status < http.StatusInternalServerError:
+		return itemController.CodeInvalidRequest
+	default:
+		return itemController.CodeInternalError
+	}
+}
+
+// 許可したオリジンからのクロスオリジンリクエストにCORSヘッダーを付与する
+// プリフライトの結果はmaxAge秒だけブラウザにキャッシュさせ、OPTIONSリクエストを減らす
+func newCORSMiddleware(allowedOrigins []string, maxAge int) echo.MiddlewareFunc {
+	return middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOrigins: allowedOrigins,
+		AllowMethods: []string{
+			http.MethodGet,
+			http.MethodPost,
+			http.MethodPut,
+			http.MethodPatch,
+			http.MethodDelete,
+		},
+		AllowHeaders: []string{
+			echo.HeaderContentType,
+			"If-Match",
+			"If-None-Match",
+			"If-Unmodified-Since",
+			"Prefer",
+			"X-Reveal-Fields",
+		},
+		ExposeHeaders: []string{
+			"ETag",
+			echo.HeaderLastModified,
+			echo.HeaderRetryAfter,
+			echo.HeaderLocation,
+			"Preference-Applied",
+			"X-Total-Count",
+			"X-Total-Matches",
+			"X-Refine-Suggested",
+		},
+		MaxAge: maxAge,
+	})
+}
+
+// リクエストのcontextにdeadlineを設定し、指定時間内に処理が終わらないリクエストに503を返す（0の場合は無効）
+// ハンドラーとは別のgoroutineで応答しないため、レスポンスの書き込みが競合しない
+// 実行中のクエリはcontextで打ち切られ、ハンドラーが戻った時点でまだ応答していなければ503を返す
+func newTimeoutMiddleware(timeout time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if timeout <= 0 {
+				return next(c)
+			}
+			ctx, cancel := context.WithTimeout(c.Request().Context(), timeout)
+			defer cancel()
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			err := next(c)
+			if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return err
+			}
+			slog.Warn("request timed out",
+				"method", c.Request().Method,
+				"path", c.Request().URL.Path,
+				"error", err,
+			)
+			if c.Response().Committed {
+				return nil
+			}
+			return c.JSON(http.StatusServiceUnavailable, itemController.ErrorResponse{
+				Code:  itemController.CodeRequestTimeout,
+				Error: "request timed out",
+			})
+		}
+	}
+}
+
+// 書き込みのリクエスト（GET・HEAD・OPTIONS以外）では、読み取りもプライマリーで行う
+// 書き込み前の存在確認や重複の検出に、レプリカの遅延した値を使わないようにする（レプリカが無い場合は影響しない）
+// 読み取りのリクエストも ?consistent=true を指定した場合はプライマリーで行う（書き込みの直後に読み直すクライアント向け）
+func newPrimaryReadsMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			switch c.Request().Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+			default:
+				return primaryReads(next)(c)
+			}
+
+			value := c.QueryParam("consistent")
+			if value == "" {
+				return next(c)
+			}
+			consistent, err := strconv.ParseBool(value)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, itemController.ErrorResponse{
+					Code:    itemController.CodeInvalidParameter,
+					Error:   "invalid query parameter",
+					Details: []string{"consistent must be a boolean"},
+				})
+			}
+			if consistent {
+				return primaryReads(next)(c)
+			}
+			return next(c)
+		}
+	}
+}
+
+// ルートに指定し、読み取りをプライマリーで行う
+// 書き込みの直後に読み直されることが多く、レプリカの遅延で古い値を返すと困るエンドポイントに使う
+func primaryReads(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		c.SetRequest(req.WithContext(database.WithPrimaryReads(req.Context())))
+		return next(c)
+	}
+}
+
+// 同時に処理するリクエスト数を制限し、上限を超えた場合は503とRetry-Afterを返す
+// queueTimeoutが正の場合は、その時間だけ空きを待ってから判定する
+func newConcurrencyLimitMiddleware(maxInFlight int, queueTimeout time.Duration) echo.MiddlewareFunc {
+	if maxInFlight <= 0 {
+		return func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				metrics.InFlightRequests.Add(1)
+				defer metrics.InFlightRequests.Add(-1)
+				return next(c)
+			}
+		}
+	}
+
+	slots := make(chan struct{}, maxInFlight)
+	retryAfter := strconv.Itoa(int(max(queueTimeout, time.Second).Seconds()))
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !acquireSlot(c, slots, queueTimeout) {
+				metrics.RejectedRequests.Add(1)
+				c.Response().Header().Set("Retry-After", retryAfter)
+				return c.JSON(http.StatusServiceUnavailable, itemController.ErrorResponse{
+					Code:  itemController.CodeServerBusy,
+					Error: "server is busy, please retry later",
+				})
+			}
+			defer func() { <-slots }()
+
+			metrics.InFlightRequests.Add(1)
+			defer metrics.InFlightRequests.Add(-1)
+			return next(c)
+		}
+	}
+}
+
+// 空きがあれば確保する。queueTimeoutの間だけ待機し、確保できなければfalseを返す
+func acquireSlot(c echo.Context, slots chan struct{}, queueTimeout time.Duration) bool {
+	select {
+	case slots <- struct{}{}:
+		return true
+	default:
+	}
+
+	if queueTimeout <= 0 {
+		return false
+	}
+
+	timer := time.NewTimer(queueTimeout)
+	defer timer.Stop()
+
+	select {
+	case slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-c.Request().Context().Done():
+		return false
+	}
+}
+
+// Authorization: Bearer <token> が管理者用のトークンと一致しない場合は401を返す
+func newAdminAuthMiddleware(token string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			scheme, credentials, _ := strings.Cut(c.Request().Header.Get(echo.HeaderAuthorization), " ")
+			if !strings.EqualFold(scheme, "Bearer") || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(credentials)), []byte(token)) != 1 {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+				return c.JSON(http.StatusUnauthorized, itemController.ErrorResponse{
+					Code:  itemController.CodeUnauthorized,
+					Error: "unauthorized",
+				})
+			}
+			return next(c)
+		}
+	}
+}
+
+// リクエスト・レスポンスのボディのサイズをメトリクスに記録する
+// レスポンスがwarnBytes（0の場合は無効）を超えた場合は、ページングしていない一覧などを見つけるため警告をログに出す
+func newResponseSizeMiddleware(warnBytes int64) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if size := c.Request().ContentLength; size >= 0 {
+				metrics.RequestSizes.Observe(size)
+			}
+
+			// エラーのレスポンスもサイズに含めるため、ここでエラーハンドラーに書き込ませる
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			size := c.Response().Size
+			metrics.ResponseSizes.Observe(size)
+			if warnBytes > 0 && size > warnBytes {
+				slog.Warn("large response",
+					"method", c.Request().Method,
+					"path", c.Request().URL.Path,
+					"query", c.Request().URL.RawQuery,
+					"status", c.Response().Status,
+					"bytes", size,
+				)
+			}
+			return nil
+		}
+	}
+}
+
+// リクエストごとにスパンを記録する（traceparentヘッダーがあれば呼び出し元のトレースを引き継ぐ）
+// ユースケースとSQLのスパンはリクエストのcontextを通じてこのスパンの子になる
+func newTracingMiddleware() echo.MiddlewareFunc {
+	tracer := tracing.Tracer()
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+
+			// スパン名にはIDなどを含まないルートのパターンを使う
+			route := c.Path()
+			ctx, span := tracer.Start(ctx, req.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.request.method", req.Method),
+					attribute.String("http.route", route),
+					attribute.String("url.path", req.URL.Path),
+				),
+			)
+			defer span.End()
+			c.SetRequest(req.WithContext(ctx))
+
+			// エラーのレスポンスのステータスも記録するため、ここでエラーハンドラーに書き込ませる
+			if err := next(c); err != nil {
+				span.RecordError(err)
+				c.Error(err)
+			}
+
+			status := c.Response().Status
+			span.SetAttributes(attribute.Int("http.response.status_code", status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+			return nil
+		}
+	}
+}
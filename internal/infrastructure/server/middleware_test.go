@@ -0,0 +1,482 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"Aicon-assignment/internal/infrastructure/metrics"
+	"Aicon-assignment/internal/infrastructure/tracing"
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+func TestRecoverMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(newRecoverMiddleware())
+	e.GET("/panic", func(c echo.Context) error {
+		panic("something went wrong")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	rec := httptest.NewRecorder()
+
+	// panicがサーバーまで伝播しないこと
+	assert.NotPanics(t, func() {
+		e.ServeHTTP(rec, req)
+	})
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "internal server error", body["error"])
+	assert.Equal(t, "INTERNAL_ERROR", body["code"])
+	// スタックトレースやpanicの内容がレスポンスに含まれないこと
+	assert.NotContains(t, rec.Body.String(), "something went wrong")
+	assert.NotContains(t, rec.Body.String(), "goroutine")
+}
+
+func TestConcurrencyLimitMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(newConcurrencyLimitMiddleware(1, 0))
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	e.GET("/slow", func(c echo.Context) error {
+		close(started)
+		<-release
+		return c.NoContent(http.StatusOK)
+	})
+
+	// 1件目のリクエストで枠を埋める
+	firstRec := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		e.ServeHTTP(firstRec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+		close(done)
+	}()
+	<-started
+
+	// 上限を超えたリクエストは待たずに503
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	close(release)
+	<-done
+	assert.Equal(t, http.StatusOK, firstRec.Code)
+}
+
+func TestTimeoutMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(newTimeoutMiddleware(10 * time.Millisecond))
+	// contextの期限切れを返すハンドラー（DBのクエリが打ち切られた場合と同じ）
+	e.GET("/slow", func(c echo.Context) error {
+		<-c.Request().Context().Done()
+		return c.Request().Context().Err()
+	})
+	// 期限が過ぎてから応答したハンドラー
+	e.GET("/late", func(c echo.Context) error {
+		<-c.Request().Context().Done()
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/fast", func(c echo.Context) error {
+		_, hasDeadline := c.Request().Context().Deadline()
+		assert.True(t, hasDeadline)
+		return c.NoContent(http.StatusOK)
+	})
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+	}{
+		{name: "正常系: 期限内に終わった場合はそのまま返す", path: "/fast", expectedStatus: http.StatusOK},
+		{name: "異常系: 期限が過ぎた場合は503", path: "/slow", expectedStatus: http.StatusServiceUnavailable},
+		{name: "異常系: ハンドラーが応答済みの場合は書き換えない", path: "/late", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusServiceUnavailable {
+				assert.JSONEq(t, `{"code":"REQUEST_TIMEOUT","error":"request timed out"}`, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestCORSMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(newCORSMiddleware([]string{"https://app.example.com"}, 600))
+	e.DELETE("/items/:id", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name           string
+		origin         string
+		expectedOrigin string
+		expectedMaxAge string
+	}{
+		{
+			name:           "正常系: 許可したオリジンのプリフライトはキャッシュ期間を返す",
+			origin:         "https://app.example.com",
+			expectedOrigin: "https://app.example.com",
+			expectedMaxAge: "600",
+		},
+		{
+			name:           "異常系: 許可していないオリジンにはCORSヘッダーを返さない",
+			origin:         "https://evil.example.com",
+			expectedOrigin: "",
+			expectedMaxAge: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, "/items/1", nil)
+			req.Header.Set(echo.HeaderOrigin, tt.origin)
+			req.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodDelete)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+			assert.Equal(t, tt.expectedOrigin, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+			assert.Equal(t, tt.expectedMaxAge, rec.Header().Get(echo.HeaderAccessControlMaxAge))
+		})
+	}
+}
+
+func TestCORSMiddleware_ExposeHeaders(t *testing.T) {
+	e := echo.New()
+	e.Use(newCORSMiddleware([]string{"https://app.example.com"}, 600))
+	e.GET("/brands/:brand/items", func(c echo.Context) error {
+		c.Response().Header().Set("X-Total-Count", "3")
+		return c.NoContent(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/brands/ROLEX/items", nil)
+	req.Header.Set(echo.HeaderOrigin, "https://app.example.com")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	// ブラウザのスクリプトから全件数のヘッダーを読めること
+	assert.Contains(t, rec.Header().Get(echo.HeaderAccessControlExposeHeaders), "X-Total-Count")
+	assert.Contains(t, rec.Header().Get(echo.HeaderAccessControlExposeHeaders), "ETag")
+}
+
+func TestHTTPErrorHandler(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = httpErrorHandler
+	e.GET("/items", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+		expectedCode   string
+		expectedError  string
+	}{
+		{"存在しないパス", http.MethodGet, "/unknown", http.StatusNotFound, "ROUTE_NOT_FOUND", "not found"},
+		{"対応していないメソッド", http.MethodDelete, "/items", http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.expectedCode, body["code"])
+			assert.Equal(t, tt.expectedError, body["error"])
+		})
+	}
+}
+
+func TestResponseSizeMiddleware(t *testing.T) {
+	var logs bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	e := echo.New()
+	e.HTTPErrorHandler = httpErrorHandler
+	e.Use(newResponseSizeMiddleware(100))
+	e.GET("/small", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+	e.GET("/large", func(c echo.Context) error {
+		return c.String(http.StatusOK, strings.Repeat("a", 1000))
+	})
+	e.GET("/error", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	})
+
+	before := responseSizeCount(t)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/small", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, logs.String())
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/large?limit=1000", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, logs.String(), "large response")
+	assert.Contains(t, logs.String(), "path=/large")
+	assert.Contains(t, logs.String(), "bytes=1000")
+
+	// ハンドラーが返したエラーはエラーハンドラーが書き込んだレスポンスとして記録する
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/error", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "INVALID_REQUEST")
+
+	assert.Equal(t, before+3, responseSizeCount(t))
+}
+
+func responseSizeCount(t *testing.T) int64 {
+	var histogram struct {
+		Count int64 `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(metrics.ResponseSizes.String()), &histogram))
+	return histogram.Count
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	e := echo.New()
+	e.HTTPErrorHandler = httpErrorHandler
+	e.Use(newTracingMiddleware())
+	e.GET("/items/:id", func(c echo.Context) error {
+		// ユースケースやSQLと同じく、リクエストのcontextからスパンを作る
+		_, span := tracing.Tracer().Start(c.Request().Context(), "child")
+		span.End()
+		return errors.New("database is down")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	child, server := spans[0], spans[1]
+
+	// 呼び出し元のトレースを引き継ぐ
+	assert.Equal(t, "GET /items/:id", server.Name)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent.SpanID().String())
+	assert.Equal(t, server.SpanContext.SpanID(), child.Parent.SpanID())
+
+	assert.Contains(t, server.Attributes, attribute.String("http.route", "/items/:id"))
+	assert.Contains(t, server.Attributes, attribute.Int("http.response.status_code", http.StatusInternalServerError))
+	assert.Equal(t, codes.Error, server.Status.Code)
+}
+
+func TestFaultInjector(t *testing.T) {
+	t.Run("異常系: 割合が範囲外", func(t *testing.T) {
+		_, err := newFaultInjector(101, []int{500}, 0, 0, 1)
+		assert.Error(t, err)
+		_, err = newFaultInjector(0, []int{500}, -1, 0, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("正常系: 同じシードでは同じ順序で注入する", func(t *testing.T) {
+		first, err := newFaultInjector(50, []int{500, 503}, 50, 0, 42)
+		require.NoError(t, err)
+		second, err := newFaultInjector(50, []int{500, 503}, 50, 0, 42)
+		require.NoError(t, err)
+
+		for i := 0; i < 100; i++ {
+			firstDelay, firstStatus := first.draw()
+			secondDelay, secondStatus := second.draw()
+			assert.Equal(t, firstDelay, secondDelay)
+			assert.Equal(t, firstStatus, secondStatus)
+		}
+	})
+
+	t.Run("正常系: 100%の場合はすべてエラーにする", func(t *testing.T) {
+		faults, err := newFaultInjector(100, []int{http.StatusServiceUnavailable}, 0, 0, 1)
+		require.NoError(t, err)
+
+		e := echo.New()
+		e.Use(faults.middleware())
+		e.GET("/items", func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, "error", rec.Header().Get(headerFaultInjected))
+		assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "SERVER_BUSY", body["code"])
+	})
+
+	t.Run("正常系: 遅延させた後にハンドラーを呼ぶ", func(t *testing.T) {
+		faults, err := newFaultInjector(0, nil, 100, 10*time.Millisecond, 1)
+		require.NoError(t, err)
+
+		e := echo.New()
+		e.Use(faults.middleware())
+		e.GET("/items", func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+		rec := httptest.NewRecorder()
+		started := time.Now()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "delay", rec.Header().Get(headerFaultInjected))
+		assert.GreaterOrEqual(t, time.Since(started), 10*time.Millisecond)
+	})
+
+	t.Run("正常系: 0%の場合は何もしない", func(t *testing.T) {
+		faults, err := newFaultInjector(0, []int{500}, 0, time.Second, 1)
+		require.NoError(t, err)
+
+		e := echo.New()
+		e.Use(faults.middleware())
+		e.GET("/items", func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get(headerFaultInjected))
+	})
+}
+
+func TestContentTypeMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(newContentTypeMiddleware())
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusNoContent) }
+	e.POST("/items", ok)
+	e.PATCH("/items/:id", ok)
+	e.DELETE("/items/:id", ok)
+	e.POST("/items/:id/archive", ok)
+	e.POST("/items/import", ok)
+	e.POST("/items/:id/images", ok)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		contentType    string
+		body           string
+		expectedStatus int
+	}{
+		{name: "正常系: JSON", method: http.MethodPost, path: "/items", contentType: "application/json", body: "{}", expectedStatus: http.StatusNoContent},
+		{name: "正常系: charset付きのJSON", method: http.MethodPatch, path: "/items/1", contentType: "application/json; charset=utf-8", body: "{}", expectedStatus: http.StatusNoContent},
+		{name: "正常系: ボディの無いPOST", method: http.MethodPost, path: "/items/1/archive", expectedStatus: http.StatusNoContent},
+		{name: "正常系: ボディの無いDELETE", method: http.MethodDelete, path: "/items/1", expectedStatus: http.StatusNoContent},
+		{name: "正常系: CSVの一括登録", method: http.MethodPost, path: "/items/import", contentType: "text/csv", body: "name\n", expectedStatus: http.StatusNoContent},
+		{name: "正常系: 画像をそのまま送る", method: http.MethodPost, path: "/items/1/images", contentType: "image/png", body: "png", expectedStatus: http.StatusNoContent},
+		{name: "異常系: Content-Typeなし", method: http.MethodPost, path: "/items", body: "{}", expectedStatus: http.StatusUnsupportedMediaType},
+		{name: "異常系: フォーム形式", method: http.MethodPost, path: "/items", contentType: "application/x-www-form-urlencoded", body: "{}", expectedStatus: http.StatusUnsupportedMediaType},
+		{name: "異常系: 画像のエンドポイントにJSON", method: http.MethodPost, path: "/items/1/images", contentType: "application/json", body: "{}", expectedStatus: http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set(echo.HeaderContentType, tt.contentType)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusUnsupportedMediaType {
+				var body map[string]interface{}
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, "UNSUPPORTED_MEDIA_TYPE", body["code"])
+			}
+		})
+	}
+}
+
+func TestPrimaryReadsMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(newPrimaryReadsMiddleware())
+	recordPrimary := func(c echo.Context) error {
+		return c.String(http.StatusOK, strconv.FormatBool(database.UsePrimaryReads(c.Request().Context())))
+	}
+	e.GET("/items", recordPrimary)
+	e.POST("/items", recordPrimary)
+	e.PATCH("/items/:id", recordPrimary)
+	e.GET("/items/:id", recordPrimary, primaryReads)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+		expected       string
+	}{
+		{name: "正常系: GETはレプリカで読む", method: http.MethodGet, path: "/items", expected: "false"},
+		{name: "正常系: consistent=trueのGETはプライマリーで読む", method: http.MethodGet, path: "/items?consistent=true", expected: "true"},
+		{name: "正常系: consistent=falseのGETはレプリカで読む", method: http.MethodGet, path: "/items?consistent=false", expected: "false"},
+		{name: "正常系: POSTはプライマリーで読む", method: http.MethodPost, path: "/items", expected: "true"},
+		{name: "正常系: PATCHはプライマリーで読む", method: http.MethodPatch, path: "/items/1", expected: "true"},
+		{name: "正常系: 指定したルートはGETでもプライマリーで読む", method: http.MethodGet, path: "/items/1", expected: "true"},
+		{name: "異常系: consistentが真偽値でない", method: http.MethodGet, path: "/items?consistent=yes", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if tt.expectedStatus == http.StatusBadRequest {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+				assert.Contains(t, rec.Body.String(), "INVALID_PARAMETER")
+				return
+			}
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.expected, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/infrastructure/config"
+	"Aicon-assignment/internal/infrastructure/metrics"
+	"Aicon-assignment/internal/interfaces/controller/admin"
+	"Aicon-assignment/internal/interfaces/controller/debug"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/system"
+)
+
+// ルーティングに使うハンドラー（debug, adminはnilの場合は登録しない）
+type routeHandlers struct {
+	system *system.SystemHandler
+	items  *itemController.ItemHandler
+	debug  *debug.DebugHandler
+	admin  *admin.AdminHandler
+}
+
+// ルートグループごとに適用するミドルウェア
+type middlewareStack struct {
+	// すべてのリクエストに適用する（panicからの復帰、CORSのプリフライトなど）
+	global []echo.MiddlewareFunc
+	// APIのエンドポイントのみに適用する（同時実行数の制限、タイムアウトなど）
+	// 認証やレート制限を追加する場合もここに追加し、公開エンドポイントには適用しない
+	api []echo.MiddlewareFunc
+	// 管理者用のエンドポイントのみに適用する（apiに加えて認証）
+	admin []echo.MiddlewareFunc
+}
+
+// ミドルウェアの構成に従ってルートを登録したEchoを返す
+//
+//	global ─┬─ 公開: /health, /version, /metrics
+//	        ├─ /items/stream（長時間の接続のため、apiのミドルウェアは適用しない）
+//	        └─ api ─┬─ /items, /categories, /brands, /util, /debug
+//	                └─ admin ── /admin
+func newRouter(handlers routeHandlers, stack middlewareStack) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = httpErrorHandler
+	// JSON_FIELD_CASE は起動時に検証している
+	fieldCase, _ := parseJSONFieldCase(config.JSONFieldCase)
+	e.JSONSerializer = jsonSerializer{prettyByDefault: config.JSONPrettyEnabled(), fieldCase: fieldCase}
+	e.Use(stack.global...)
+
+	registerPublicRoutes(e, handlers.system)
+	if handlers.items.EventStreamEnabled() {
+		// タイムアウトはレスポンスをバッファリングし、同時実行数の枠も占有し続けるため適用しない
+		e.GET("/items/stream", handlers.items.StreamItemEvents) // GET /items/stream?category= (Server-Sent Events)
+	}
+
+	// Echoのグループは登録したルート（と、グループ内で一致しないパス）にのみミドルウェアを適用する
+	api := e.Group("", stack.api...)
+	registerItemRoutes(api, handlers.items)
+	if handlers.debug != nil {
+		api.GET("/debug/explain", handlers.debug.Explain) // GET /debug/explain?q=list
+	}
+	if handlers.admin != nil {
+		adminGroup := api.Group("/admin", stack.admin...)
+		adminGroup.POST("/restore", handlers.admin.RestoreItems)       // POST /admin/restore?mode=replace|merge&file=
+		adminGroup.POST("/backfill", handlers.admin.Backfill)          // POST /admin/backfill?field=&after_id=
+		adminGroup.GET("/integrity", handlers.admin.CheckIntegrity)    // GET /admin/integrity?limit=
+		adminGroup.POST("/integrity/fix", handlers.admin.FixIntegrity) // POST /admin/integrity/fix?check=
+	}
+
+	return e
+}
+
+// 監視用の公開エンドポイントを登録する（APIのミドルウェアは適用しない）
+func registerPublicRoutes(e *echo.Echo, systemHandler *system.SystemHandler) {
+	// ヘルスチェック
+	e.GET("/health", func(c echo.Context) error {
+		systemHandler.Health(c)
+		return nil
+	})
+
+	// ビルド情報（バージョン、コミット、ビルド日時）
+	e.GET("/version", systemHandler.Version)
+
+	// メトリクス（expvar形式のJSON）
+	e.GET("/metrics", echo.WrapHandler(metrics.Handler()))
+}
+
+// アイテム・カテゴリー・ブランドのエンドポイントを登録する
+// OPTIONSにはEchoのルーターが204と、そのパスで使えるメソッドを列挙したAllowヘッダーを返す
+func registerItemRoutes(g *echo.Group, itemHandler *itemController.ItemHandler) {
+	// アイテムに関するエンドポイント
+	itemsGroup := g.Group("/items")
+	{
+		itemsGroup.GET("", itemHandler.GetItems)                             // GET /items
+		itemsGroup.HEAD("", itemHandler.GetItems)                            // HEAD /items (件数とETagのみ)
+		itemsGroup.POST("", itemHandler.CreateItem)                          // POST /items
+		itemsGroup.PUT("", itemHandler.UpsertItem)                           // PUT /items (serial_numberでupsert)
+		itemsGroup.POST("/import", itemHandler.ImportItems)                  // POST /items/import?mode=
+		itemsGroup.POST("/bulk", itemHandler.BulkCreateItems)                // POST /items/bulk (JSON配列で一括登録)
+		itemsGroup.POST("/search", itemHandler.SearchItems)                  // POST /items/search (JSONの絞り込み条件)
+		itemsGroup.GET("/export", itemHandler.ExportItems)                   // GET /items/export?format=pdf&category= (印刷用の一覧)
+		itemsGroup.GET("/:id", itemHandler.GetItem, primaryReads)            // GET /items/{id} (更新直後のETagを返すためプライマリーで読む)
+		itemsGroup.GET("/:id/card", itemHandler.GetItemCard)                 // GET /items/{id}/card (共有用)
+		itemsGroup.GET("/:id/depreciation", itemHandler.GetItemDepreciation) // GET /items/{id}/depreciation?years=&salvage=
+		itemsGroup.GET("/:id/siblings", itemHandler.GetItemSiblings)         // GET /items/{id}/siblings?sort= (前後のアイテム)
+		itemsGroup.PATCH("/:id", itemHandler.UpdateItem)                     // PATCH /items/{id}
+		itemsGroup.DELETE("/:id", itemHandler.DeleteItem)                    // DELETE /items/{id}
+		itemsGroup.POST("/:id/archive", itemHandler.ArchiveItem)             // POST /items/{id}/archive
+		itemsGroup.POST("/:id/unarchive", itemHandler.UnarchiveItem)         // POST /items/{id}/unarchive
+		itemsGroup.GET("/summary", itemHandler.GetSummary)                   // GET /items/summary (bonus)
+		itemsGroup.GET("/summary/compare", itemHandler.CompareSummary)       // GET /items/summary/compare?as_of=
+		itemsGroup.GET("/summary/jobs/:jobId", itemHandler.GetSummaryJob)    // GET /items/summary/jobs/{jobId} (非同期の集計)
+		itemsGroup.GET("/changes", itemHandler.GetChanges)                   // GET /items/changes?since=|sync_token=
+		itemsGroup.GET("/changelog", itemHandler.GetChangeLog)               // GET /items/changelog?since=&limit= (変更履歴)
+		itemsGroup.GET("/incomplete", itemHandler.GetIncompleteItems)        // GET /items/incomplete (要見直し)
+		itemsGroup.GET("/trash", itemHandler.GetTrash)                       // GET /items/trash?category=&sort=&limit=&offset=
+		itemsGroup.GET("/timeline", itemHandler.GetTimeline)                 // GET /items/timeline?category= (購入年別)
+		itemsGroup.GET("/trends", itemHandler.GetTrends)                     // GET /items/trends?category= (購入年別の前年比)
+		itemsGroup.GET("/schema", itemHandler.GetItemSchema)                 // GET /items/schema (フィールドの定義)
+		itemsGroup.GET("/suggest-category", itemHandler.SuggestCategory)     // GET /items/suggest-category?name= (入力補助)
+		itemsGroup.GET("/group", itemHandler.GetGroupSummary)                // GET /items/group?by=category|brand|status
+		itemsGroup.GET("/diversity", itemHandler.GetDiversity)               // GET /items/diversity?category= (価格の集中度)
+		itemsGroup.GET("/dashboard", itemHandler.GetDashboard)               // GET /items/dashboard?include=summary,top&limit=
+
+		// アイテムの画像（表示順に複数）
+		itemsGroup.GET("/:id/image", itemHandler.GetItemCoverImage)            // GET /items/{id}/image?size=thumb (最初の画像)
+		itemsGroup.GET("/:id/images", itemHandler.GetItemImages)               // GET /items/{id}/images
+		itemsGroup.POST("/:id/images", itemHandler.AddItemImage)               // POST /items/{id}/images
+		itemsGroup.GET("/:id/images/:imageId", itemHandler.GetItemImage)       // GET /items/{id}/images/{imageId}?size=thumb
+		itemsGroup.DELETE("/:id/images/:imageId", itemHandler.DeleteItemImage) // DELETE /items/{id}/images/{imageId}
+
+		// 名前を付けて保存した検索条件（POST /items/search のリクエストボディ）
+		itemsGroup.GET("/search/presets", itemHandler.ListSearchPresets)          // GET /items/search/presets
+		itemsGroup.PUT("/search/preset/:name", itemHandler.SaveSearchPreset)      // PUT /items/search/preset/{name}
+		itemsGroup.GET("/search/preset/:name", itemHandler.RunSearchPreset)       // GET /items/search/preset/{name}?limit=&offset= (保存した条件で検索)
+		itemsGroup.DELETE("/search/preset/:name", itemHandler.DeleteSearchPreset) // DELETE /items/search/preset/{name}
+
+		// 絞り込み条件に一致するアイテムの一括削除（confirm必須、条件が空の場合はdelete_allも必要）
+		itemsGroup.POST("/delete-by-filter", itemHandler.DeleteItemsByFilter) // POST /items/delete-by-filter
+	}
+
+	// カテゴリーに関するエンドポイント
+	g.GET("/categories/used", itemHandler.GetUsedCategories) // GET /categories/used
+
+	// ブランドに関するエンドポイント
+	g.GET("/brands/summary", itemHandler.GetBrandSummary)    // GET /brands/summary?category=
+	g.GET("/brands/:brand/items", itemHandler.GetBrandItems) // GET /brands/{brand}/items?limit=&offset=
+
+	// 入力補助
+	g.POST("/util/parse-date", itemHandler.ParseDate) // POST /util/parse-date (購入日の正規化)
+}
@@ -2,7 +2,9 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -10,7 +12,16 @@ import (
 
 	"github.com/labstack/echo/v4"
 
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/infrastructure/backup"
+	"Aicon-assignment/internal/infrastructure/buildinfo"
+	"Aicon-assignment/internal/infrastructure/config"
 	databaseInfra "Aicon-assignment/internal/infrastructure/database"
+	"Aicon-assignment/internal/infrastructure/tracing"
+	"Aicon-assignment/internal/infrastructure/webhook"
+	"Aicon-assignment/internal/infrastructure/worker"
+	"Aicon-assignment/internal/interfaces/controller/admin"
+	"Aicon-assignment/internal/interfaces/controller/debug"
 	itemController "Aicon-assignment/internal/interfaces/controller/items"
 	"Aicon-assignment/internal/interfaces/controller/system"
 	itemDatabase "Aicon-assignment/internal/interfaces/database"
@@ -26,47 +37,335 @@ func NewServer() *Server {
 
 // サーバー起動
 func (s *Server) Run(ctx context.Context) error {
-	e := echo.New()
+	location, err := config.GetAppLocation()
+	if err != nil {
+		return fmt.Errorf("invalid APP_TIMEZONE: %w", err)
+	}
+	entity.SetTimeZone(location)
+
+	if err := config.CheckServerPort(); err != nil {
+		return fmt.Errorf("invalid PORT: %w", err)
+	}
+	if err := config.CheckServerTLSFiles(); err != nil {
+		return fmt.Errorf("invalid TLS configuration: %w", err)
+	}
+	// 証明書を読めない場合は、待ち受けを始める前に起動をやめる
+	var tlsConfig *tls.Config
+	if config.ServerTLSEnabled() {
+		if tlsConfig, err = newServerTLSConfig(); err != nil {
+			return fmt.Errorf("invalid TLS configuration: %w", err)
+		}
+	}
+
+	if config.TracingEnabled {
+		shutdownTracing, err := tracing.Setup(ctx, buildinfo.Get().Version)
+		if err != nil {
+			return fmt.Errorf("failed to set up tracing: %w", err)
+		}
+		defer func() {
+			// 未送信のスパンを送ってから終了する
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdownTracing(shutdownCtx); err != nil {
+				slog.Warn("failed to flush traces", "error", err)
+			}
+		}()
+		fmt.Println("🔭 Tracing enabled (OTLP/HTTP)")
+	}
 
 	// 依存性注入
-	dbHandler := databaseInfra.NewSqlHandler()
+	dbHandler := databaseInfra.NewTracingHandler(
+		databaseInfra.NewSlowQueryHandler(
+			databaseInfra.NewSqlHandler(),
+			config.SlowQueryThreshold,
+			config.SlowQueryLogSQL,
+		),
+		config.TracingEnabled,
+		config.TracingRedactSQL,
+	)
 	defer dbHandler.Close()
 
 	itemRepo := &itemDatabase.ItemRepository{
 		SqlHandler: dbHandler,
 	}
 
-	itemUsecase := usecase.NewItemUsecase(itemRepo)
+	// バックグラウンドワーカーはサーバー停止時に止める
+	workerCtx, stopWorkers := context.WithCancel(ctx)
+	defer stopWorkers()
+
+	usecaseOpts := []usecase.Option{
+		usecase.WithTransactor(&itemDatabase.Transactor{SqlHandler: dbHandler}),
+	}
+	var broadcaster *usecase.EventBroadcaster
+	if config.EventsEnabled() {
+		// 変更と同じトランザクションでoutboxに記録し、ワーカーがWebhookとストリームへ配信する
+		outboxRepo := &itemDatabase.OutboxRepository{SqlHandler: dbHandler}
+		usecaseOpts = append(usecaseOpts, usecase.WithEventPublisher(outboxRepo))
+
+		var senders, observers []usecase.EventSender
+		if config.WebhooksEnabled() {
+			senders = append(senders, webhook.NewDispatcher(config.WebhookURLs, config.WebhookSecret, config.WebhookTimeout, config.WebhookMaxRetries))
+		}
+		if config.ItemStreamEnabled {
+			// Webhookの配信の失敗を待たずに配信し、Webhookの再送では重複して配信しない
+			broadcaster = usecase.NewEventBroadcaster(config.ItemStreamMaxClients)
+			observers = append(observers, broadcaster)
+		}
+		relay := usecase.NewEventRelay(outboxRepo, usecase.ChainEventSenders(senders...), config.OutboxBatchSize, config.OutboxMaxAttempts, observers...)
+		go worker.Run(workerCtx, "outbox-relay", config.OutboxPollInterval, func(ctx context.Context) error {
+			delivered, err := relay.RelayPending(ctx)
+			if delivered > 0 {
+				slog.Info("delivered outbox events", "count", delivered)
+			}
+			return err
+		})
+	}
 
-	systemHandler := system.NewSystemHandler()
-	itemHandler := itemController.NewItemHandler(itemUsecase)
+	if config.ImportDefaultCategory != "" {
+		category, err := entity.NewCategory(config.ImportDefaultCategory)
+		if err != nil {
+			return fmt.Errorf("invalid IMPORT_DEFAULT_CATEGORY: %w", err)
+		}
+		usecaseOpts = append(usecaseOpts, usecase.WithImportDefaultCategory(category))
+	}
 
-	// ヘルスチェック
-	e.GET("/health", func(c echo.Context) error {
-		systemHandler.Health(c)
-		return nil
+	priceAction, err := usecase.ParseSuspiciousPriceAction(config.ImportSuspiciousPriceAction)
+	if err != nil {
+		return fmt.Errorf("invalid IMPORT_SUSPICIOUS_PRICE_ACTION: %w", err)
+	}
+	if config.ImportSuspiciousPriceFloor < 0 {
+		return fmt.Errorf("invalid IMPORT_SUSPICIOUS_PRICE_FLOOR: %d (must not be negative)", config.ImportSuspiciousPriceFloor)
+	}
+	usecaseOpts = append(usecaseOpts, usecase.WithImportPriceCheck(priceAction, int64(config.ImportSuspiciousPriceFloor)))
+
+	brandCategories, err := usecase.ParseBrandCategories(config.BrandCategories)
+	if err != nil {
+		return fmt.Errorf("invalid BRAND_CATEGORIES: %w", err)
+	}
+	if len(brandCategories) > 0 {
+		usecaseOpts = append(usecaseOpts, usecase.WithBrandCategories(brandCategories))
+		// カテゴリーの推定でも、設定したブランドを既定のキーワードに加えて使う
+		keywords := append(usecase.DefaultCategoryKeywords(), usecase.BrandCategoryKeywords(brandCategories)...)
+		usecaseOpts = append(usecaseOpts, usecase.WithCategorySuggester(usecase.NewKeywordCategorySuggester(keywords)))
+	}
+
+	brandCase, err := usecase.ParseBrandCase(config.BrandCase)
+	if err != nil {
+		return fmt.Errorf("invalid BRAND_CASE: %w", err)
+	}
+	usecaseOpts = append(usecaseOpts, usecase.WithBrandCase(brandCase), usecase.WithTextSanitization(config.TextSanitization))
+
+	requiredFields, err := usecase.ParseCategoryRequiredFields(config.CategoryRequiredFields)
+	if err != nil {
+		return fmt.Errorf("invalid CATEGORY_REQUIRED_FIELDS: %w", err)
+	}
+	if len(requiredFields) > 0 {
+		usecaseOpts = append(usecaseOpts, usecase.WithCategoryRequiredFields(requiredFields))
+	}
+
+	defaultSort := usecase.SortOrder(config.ListDefaultSort)
+	if !defaultSort.IsValidDefault() {
+		return fmt.Errorf("invalid LIST_DEFAULT_SORT: %s (must be one of: id, -id, created_at, -created_at, purchase_date, -purchase_date)", config.ListDefaultSort)
+	}
+	usecaseOpts = append(usecaseOpts, usecase.WithDefaultSort(defaultSort), usecase.WithMaxPageOffset(config.PageMaxOffset))
+	usecaseOpts = append(usecaseOpts, usecase.WithMaxSearchResults(config.SearchMaxResults))
+	usecaseOpts = append(usecaseOpts, usecase.WithDeleteReasons(config.DeleteReasons))
+	if config.AverageDecimals < 0 || config.AverageDecimals > usecase.MaxAverageDecimals {
+		return fmt.Errorf("invalid AVERAGE_PRICE_DECIMALS: %d (must be between 0 and %d)", config.AverageDecimals, usecase.MaxAverageDecimals)
+	}
+	usecaseOpts = append(usecaseOpts, usecase.WithAverageDecimals(config.AverageDecimals))
+	idType, err := usecase.ParseItemIDType(config.IDType)
+	if err != nil {
+		return fmt.Errorf("invalid ID_TYPE: %w", err)
+	}
+	usecaseOpts = append(usecaseOpts, usecase.WithItemIDType(idType))
+	var coalescer *usecase.UpdateCoalescer
+	if config.PatchCoalesceWindow > 0 {
+		coalescer = usecase.NewUpdateCoalescer(config.PatchCoalesceWindow)
+		usecaseOpts = append(usecaseOpts, usecase.WithUpdateCoalescer(coalescer))
+		fmt.Printf("🧮 PATCH coalescing enabled (window: %s)\n", config.PatchCoalesceWindow)
+	}
+	// 管理者用の一括の書き込みでも破棄できるよう、キャッシュはユースケースの外で作る
+	itemCache := usecase.NewItemCache(config.ItemCacheSize, config.ItemCacheTTL)
+	if itemCache != nil {
+		usecaseOpts = append(usecaseOpts, usecase.WithItemCache(itemCache))
+		fmt.Printf("🗃️  Item cache enabled (size: %d, ttl: %s)\n", config.ItemCacheSize, config.ItemCacheTTL)
+	}
+
+	imageRepo := &itemDatabase.ItemImageRepository{SqlHandler: dbHandler}
+	usecaseOpts = append(usecaseOpts, usecase.WithCompletenessImages(imageRepo))
+
+	itemUsecase := usecase.NewItemUsecase(itemRepo, usecaseOpts...)
+	if config.TracingEnabled {
+		itemUsecase = tracing.WrapItemUsecase(itemUsecase)
+	}
+	imageSettings := usecase.ItemImageSettings{
+		MaxImagesPerItem:      config.ImageMaxPerItem,
+		ThumbnailMaxDimension: config.ImageThumbnailMaxDimension,
+		ThumbnailTimeout:      config.ImageThumbnailTimeout,
+	}
+	imageUsecase := usecase.NewItemImageUsecase(itemRepo, imageRepo, &itemDatabase.Transactor{SqlHandler: dbHandler}, imageSettings)
+	presetUsecase := usecase.NewSearchPresetUsecase(&itemDatabase.SearchPresetRepository{SqlHandler: dbHandler})
+
+	if config.PurgeEnabled {
+		purger := usecase.NewItemPurger(itemRepo, config.PurgeRetention)
+		go worker.Run(workerCtx, "soft-delete-purge", config.PurgeInterval, func(ctx context.Context) error {
+			purged, err := purger.PurgeDeletedItems(ctx)
+			if err != nil {
+				return err
+			}
+			slog.Info("purged soft-deleted items", "count", purged, "retention", config.PurgeRetention.String())
+			return nil
+		})
+	}
+
+	if config.BackupEnabled {
+		backupWriter := backup.NewWriter(usecase.NewItemExporter(itemRepo), config.BackupDir, config.BackupKeep)
+		go worker.Run(workerCtx, "item-backup", config.BackupInterval, func(ctx context.Context) error {
+			path, count, err := backupWriter.Backup(ctx)
+			if err != nil {
+				return err
+			}
+			slog.Info("backed up items", "count", count, "path", path)
+			return nil
+		})
+	}
+
+	build := buildinfo.Get()
+	systemHandler := system.NewSystemHandler(system.VersionResponse{
+		Version:   build.Version,
+		Commit:    build.Commit,
+		BuildTime: build.BuildTime,
+		GoVersion: build.GoVersion,
 	})
+	cardFields, err := itemController.ParseCardFields(config.ItemCardFields)
+	if err != nil {
+		return fmt.Errorf("invalid ITEM_CARD_FIELDS: %w", err)
+	}
+	hiddenFields, err := itemController.ParseHiddenFields(config.HiddenFields)
+	if err != nil {
+		return fmt.Errorf("invalid HIDDEN_FIELDS: %w", err)
+	}
+	priceLocale, err := itemController.ParsePriceLocale(config.PriceLocale)
+	if err != nil {
+		return fmt.Errorf("invalid PRICE_LOCALE: %w", err)
+	}
+	handlerOpts := []itemController.HandlerOption{
+		itemController.WithImportLimits(itemController.ImportLimits{
+			MaxBytes: config.ImportMaxBytes,
+			MaxRows:  config.ImportMaxRows,
+		}),
+		itemController.WithBulkMaxItems(config.BulkMaxItems),
+		itemController.WithItemImages(imageUsecase, config.ImageMaxBytes),
+		itemController.WithSearchPresets(presetUsecase),
+		itemController.WithCardFields(cardFields),
+		itemController.WithIdempotentDelete(config.DeleteIdempotent),
+		itemController.WithEmptyPatchNoOp(config.EmptyPatchNoOp),
+		itemController.WithOptionalCategory(len(brandCategories) > 0),
+		itemController.WithHiddenFields(hiddenFields, config.HiddenFieldsRevealToken),
+		itemController.WithPriceLocale(priceLocale),
+		itemController.WithUUIDItemIDs(idType == usecase.ItemIDUUID),
+	}
+	if broadcaster != nil {
+		handlerOpts = append(handlerOpts, itemController.WithEventStream(broadcaster, config.ItemStreamHeartbeat))
+	}
+	if config.SummaryAsyncThreshold > 0 {
+		summaryJobs := usecase.NewSummaryJobs(itemUsecase.GetCategorySummary, config.SummaryJobTTL)
+		handlerOpts = append(handlerOpts, itemController.WithSummaryJobs(summaryJobs, config.SummaryAsyncThreshold))
+	}
+	itemHandler := itemController.NewItemHandler(itemUsecase, handlerOpts...)
 
-	// アイテムに関するエンドポイント
-	itemsGroup := e.Group("/items")
-	{
-		itemsGroup.GET("", itemHandler.GetItems)           // GET /items
-		itemsGroup.POST("", itemHandler.CreateItem)        // POST /items
-		itemsGroup.GET("/:id", itemHandler.GetItem)        // GET /items/{id}
-		itemsGroup.PATCH("/:id", itemHandler.UpdateItem)    // PATCH /items/{id}
-		itemsGroup.DELETE("/:id", itemHandler.DeleteItem)  // DELETE /items/{id}
-		itemsGroup.GET("/summary", itemHandler.GetSummary) // GET /items/summary (bonus)
+	handlers := routeHandlers{system: systemHandler, items: itemHandler}
+	handlers.debug = newDebugHandler(itemRepo)
+	if handlers.debug != nil {
+		fmt.Println("⚠️  Debug endpoints are enabled")
+	}
+	// 管理者用のエンドポイント（ADMIN_TOKENを設定した場合のみ）
+	if config.AdminEndpointsEnabled() {
+		restorer := usecase.NewItemRestorer(itemRepo, &itemDatabase.Transactor{SqlHandler: dbHandler},
+			usecase.WithTimestampTolerance(config.RestoreMaxFutureSkew, config.RestoreMaxAge), usecase.WithRestoreItemCache(itemCache))
+		// 派生カラムを追加した場合は、再計算の方法をここに登録する
+		backfiller := usecase.NewBackfiller(map[string]usecase.DerivedField{
+			"thumbnail": usecase.NewThumbnailField(imageRepo, imageSettings),
+			"brand":     usecase.NewBrandField(itemRepo, brandCase),
+			"uuid":      usecase.NewItemUUIDField(itemRepo),
+		}, &itemDatabase.Transactor{SqlHandler: dbHandler}, config.BackfillBatchSize, usecase.WithBackfillItemCache(itemCache))
+		integrity := usecase.NewIntegrityChecker(&itemDatabase.IntegrityRepository{SqlHandler: dbHandler}, usecase.WithIntegrityItemCache(itemCache))
+		handlers.admin = admin.NewAdminHandler(restorer, backfiller, integrity, os.DirFS(config.BackupDir), config.ImportMaxBytes)
+	}
+
+	// クライアントのリトライ確認用の障害注入（FAULT_INJECTION_ENABLED=true かつ本番以外のみ）
+	var faults *faultInjector
+	if config.FaultInjectionEnabled() {
+		if faults, err = newFaultInjectorFromConfig(); err != nil {
+			return err
+		}
+		fmt.Printf("⚠️  Fault injection is enabled (%s)\n", faults)
+	}
+
+	if _, err := parseJSONFieldCase(config.JSONFieldCase); err != nil {
+		return fmt.Errorf("invalid JSON_FIELD_CASE: %w", err)
 	}
 
+	e := newRouter(handlers, newMiddlewareStack(faults))
+	e.TLSServer.TLSConfig = tlsConfig
+	if broadcaster != nil {
+		// 停止時に待ち続けないよう、ストリームの接続を閉じる
+		e.Server.RegisterOnShutdown(broadcaster.Close)
+		e.TLSServer.RegisterOnShutdown(broadcaster.Close)
+	}
+	if coalescer != nil {
+		// まとめている更新を待たずに書き込み、停止を待っているPATCHに応答する
+		e.Server.RegisterOnShutdown(coalescer.Flush)
+		e.TLSServer.RegisterOnShutdown(coalescer.Flush)
+	}
 	return s.startWithGracefulShutdown(ctx, e)
 }
 
+// 設定に応じたミドルウェアの構成
+func newMiddlewareStack(faults *faultInjector) middlewareStack {
+	stack := middlewareStack{
+		global: []echo.MiddlewareFunc{newRecoverMiddleware()},
+		api: []echo.MiddlewareFunc{
+			newResponseSizeMiddleware(config.ResponseSizeWarnBytes),
+			newConcurrencyLimitMiddleware(config.MaxInFlightRequests, config.RequestQueueTimeout),
+			newTimeoutMiddleware(config.RequestTimeout),
+			newPrimaryReadsMiddleware(),
+		},
+		admin: []echo.MiddlewareFunc{newAdminAuthMiddleware(config.AdminToken)},
+	}
+	if config.TracingEnabled {
+		// panicから復帰した500もスパンに記録するため、最も外側に置く
+		stack.global = append([]echo.MiddlewareFunc{newTracingMiddleware()}, stack.global...)
+	}
+	if config.ContentTypeStrict {
+		stack.api = append(stack.api, newContentTypeMiddleware())
+	}
+	if faults != nil {
+		// タイムアウトや同時実行数の制限を受けた後の、ハンドラーの直前で注入する
+		stack.api = append(stack.api, faults.middleware())
+	}
+	// プリフライトはルートの登録に関わらず処理する必要があるため全体に適用する
+	if len(config.CORSAllowedOrigins) > 0 {
+		stack.global = append(stack.global, newCORSMiddleware(config.CORSAllowedOrigins, config.CORSMaxAge))
+	}
+	return stack
+}
+
 func (s *Server) startWithGracefulShutdown(ctx context.Context, e *echo.Echo) error {
 	go func() {
-		port := ":8080"
-		fmt.Printf("🚀 Server starting on port %s\n", port)
+		address := config.GetServerAddress()
 
-		if err := e.Start(port); err != nil && err != http.ErrServerClosed {
+		var err error
+		if config.ServerTLSEnabled() {
+			fmt.Printf("🔒 Server starting with TLS on %s\n", address)
+			err = startTLS(e, address)
+		} else {
+			fmt.Printf("🚀 Server starting on %s\n", address)
+			err = e.Start(address)
+		}
+
+		if err != nil && err != http.ErrServerClosed {
 			e.Logger.Fatal("Server startup failed:", err)
 		}
 	}()
@@ -91,3 +390,36 @@ func (s *Server) startWithGracefulShutdown(ctx context.Context, e *echo.Echo) er
 	fmt.Println("✅ Server exited gracefully")
 	return nil
 }
+
+// 証明書を読み込み、最小TLSバージョンを指定したHTTPSの設定を作る
+func newServerTLSConfig() (*tls.Config, error) {
+	minVersion, err := config.GetTLSMinVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.LoadX509KeyPair(config.TLSCertFile, config.TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	return &tls.Config{
+		MinVersion:   minVersion,
+		Certificates: []tls.Certificate{cert},
+	}, nil
+}
+
+// Runで設定したTLSServer.TLSConfigでHTTPSで起動する
+func startTLS(e *echo.Echo, address string) error {
+	// e.Shutdownで停止できるようにEcho管理下のTLSServerを使う
+	e.TLSServer.Addr = address
+	return e.StartServer(e.TLSServer)
+}
+
+// 開発用のクエリ診断エンドポイントのハンドラー（DEBUG_ENDPOINTS=true かつ本番以外のみ、それ以外はnilでルートを登録しない）
+func newDebugHandler(explainer usecase.QueryExplainer) *debug.DebugHandler {
+	if !config.DebugEndpointsEnabled() {
+		return nil
+	}
+	return debug.NewDebugHandler(usecase.NewDiagnosticsUsecase(explainer))
+}
@@ -0,0 +1,480 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/infrastructure/config"
+	"Aicon-assignment/internal/interfaces/controller/admin"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/system"
+	"Aicon-assignment/internal/usecase"
+)
+
+// テスト用のルーター（OPTIONSなどハンドラーが呼ばれないリクエストのみのため、ユースケースは不要）
+func newTestRouter(stack middlewareStack) *echo.Echo {
+	return newRouter(routeHandlers{
+		system: system.NewSystemHandler(system.VersionResponse{Version: "test"}),
+		items:  itemController.NewItemHandler(nil),
+	}, stack)
+}
+
+// どのグループのミドルウェアを通ったかをヘッダーに記録する
+func markMiddleware(name string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Response().Header().Add("X-Middleware", name)
+			return next(c)
+		}
+	}
+}
+
+func TestNewRouter_MiddlewareTopology(t *testing.T) {
+	e := newTestRouter(middlewareStack{
+		global: []echo.MiddlewareFunc{markMiddleware("global")},
+		api:    []echo.MiddlewareFunc{markMiddleware("api")},
+	})
+
+	tests := []struct {
+		name               string
+		method             string
+		path               string
+		expectedStatus     int
+		expectedMiddleware []string
+	}{
+		{name: "公開: ヘルスチェック", method: http.MethodGet, path: "/health", expectedStatus: http.StatusOK, expectedMiddleware: []string{"global"}},
+		{name: "公開: ビルド情報", method: http.MethodGet, path: "/version", expectedStatus: http.StatusOK, expectedMiddleware: []string{"global"}},
+		{name: "公開: メトリクス", method: http.MethodGet, path: "/metrics", expectedStatus: http.StatusOK, expectedMiddleware: []string{"global"}},
+		{name: "API: アイテム取得", method: http.MethodGet, path: "/items/abc", expectedStatus: http.StatusBadRequest, expectedMiddleware: []string{"global", "api"}},
+		{name: "API: 未登録のパス", method: http.MethodGet, path: "/unknown", expectedStatus: http.StatusNotFound, expectedMiddleware: []string{"global", "api"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedMiddleware, rec.Header().Values("X-Middleware"))
+		})
+	}
+}
+
+func TestNewRouter_DebugRoutes(t *testing.T) {
+	e := newTestRouter(middlewareStack{})
+
+	// デバッグ用のハンドラーを渡さない場合は登録しない
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/explain", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestNewDebugHandler(t *testing.T) {
+	defaultEnabled, defaultEnv := config.DebugEndpoints, config.AppEnv
+	t.Cleanup(func() { config.DebugEndpoints, config.AppEnv = defaultEnabled, defaultEnv })
+
+	tests := []struct {
+		name           string
+		enabled        bool
+		appEnv         string
+		expectedStatus int
+	}{
+		// q が無いためEXPLAINは実行せずに400を返す
+		{name: "正常系: 開発環境で有効にした場合は登録する", enabled: true, appEnv: "development", expectedStatus: http.StatusBadRequest},
+		{name: "正常系: 無効の場合は登録しない", enabled: false, appEnv: "development", expectedStatus: http.StatusNotFound},
+		{name: "正常系: 本番環境では有効にしても登録しない", enabled: true, appEnv: "production", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.DebugEndpoints, config.AppEnv = tt.enabled, tt.appEnv
+			debugHandler := newDebugHandler(nil)
+			assert.Equal(t, tt.expectedStatus == http.StatusNotFound, debugHandler == nil)
+
+			e := newRouter(routeHandlers{
+				system: system.NewSystemHandler(system.VersionResponse{Version: "test"}),
+				items:  itemController.NewItemHandler(nil),
+				debug:  debugHandler,
+			}, middlewareStack{})
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/explain", nil))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func TestNewRouter_ItemStream(t *testing.T) {
+	stack := middlewareStack{
+		global: []echo.MiddlewareFunc{markMiddleware("global")},
+		api:    []echo.MiddlewareFunc{markMiddleware("api")},
+	}
+
+	// 有効にしない場合は登録せず、GET /items/{id} として扱う
+	rec := httptest.NewRecorder()
+	newTestRouter(stack).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items/stream", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	e := newRouter(routeHandlers{
+		system: system.NewSystemHandler(system.VersionResponse{Version: "test"}),
+		items:  itemController.NewItemHandler(nil, itemController.WithEventStream(usecase.NewEventBroadcaster(0), 0)),
+	}, stack)
+
+	// 切断済みのリクエストのため、ヘッダーを送った後すぐに終了する
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items/stream", nil).WithContext(ctx))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/event-stream", rec.Header().Get(echo.HeaderContentType))
+	// タイムアウトなどのAPIのミドルウェアは適用しない
+	assert.Equal(t, []string{"global"}, rec.Header().Values("X-Middleware"))
+}
+
+func TestNewRouter_AdminRoutes(t *testing.T) {
+	// 管理者用のハンドラーを渡さない場合は登録しない
+	rec := httptest.NewRecorder()
+	newTestRouter(middlewareStack{}).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/restore", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	e := newRouter(routeHandlers{
+		system: system.NewSystemHandler(system.VersionResponse{Version: "test"}),
+		items:  itemController.NewItemHandler(nil),
+		admin:  admin.NewAdminHandler(nil, nil, nil, nil, 0),
+	}, middlewareStack{admin: []echo.MiddlewareFunc{newAdminAuthMiddleware("secret")}})
+
+	tests := []struct {
+		name           string
+		authorization  string
+		expectedStatus int
+	}{
+		{name: "異常系: トークンなし", expectedStatus: http.StatusUnauthorized},
+		{name: "異常系: トークンが一致しない", authorization: "Bearer wrong", expectedStatus: http.StatusUnauthorized},
+		{name: "異常系: Bearer以外の形式", authorization: "Basic secret", expectedStatus: http.StatusUnauthorized},
+		// 認証後のハンドラーでmodeの指定が無いため400になる
+		{name: "正常系: トークンが一致", authorization: "Bearer secret", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/admin/restore", nil)
+			if tt.authorization != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusUnauthorized {
+				assert.Equal(t, "Bearer", rec.Header().Get(echo.HeaderWWWAuthenticate))
+				assert.Contains(t, rec.Body.String(), itemController.CodeUnauthorized)
+			}
+		})
+	}
+}
+
+func TestRegisterItemRoutes_Options(t *testing.T) {
+	e := newTestRouter(middlewareStack{})
+
+	tests := []struct {
+		path            string
+		expectedMethods []string
+	}{
+		{"/items", []string{"GET", "HEAD", "POST", "PUT"}},
+		{"/items/1", []string{"GET", "PATCH", "DELETE"}},
+		{"/items/1/card", []string{"GET"}},
+		{"/items/1/depreciation", []string{"GET"}},
+		{"/items/import", []string{"POST"}},
+		{"/items/summary", []string{"GET"}},
+		{"/items/summary/compare", []string{"GET"}},
+		{"/items/changes", []string{"GET"}},
+		{"/items/incomplete", []string{"GET"}},
+		{"/categories/used", []string{"GET"}},
+		{"/brands/ROLEX/items", []string{"GET"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodOptions, tt.path, nil))
+
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+			allowed := strings.Split(rec.Header().Get(echo.HeaderAllow), ", ")
+			assert.ElementsMatch(t, append(tt.expectedMethods, http.MethodOptions), allowed)
+		})
+	}
+}
+
+func TestRegisterItemRoutes_MethodNotAllowed(t *testing.T) {
+	e := newTestRouter(middlewareStack{})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/items", nil))
+
+	// 405の場合も使えるメソッドをAllowヘッダーで返す
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderAllow), http.MethodPost)
+}
+
+func TestJSONSerializer_Pretty(t *testing.T) {
+	tests := []struct {
+		name            string
+		prettyByDefault bool
+		query           string
+		expectedPretty  bool
+	}{
+		{name: "既定では整形しない", query: "", expectedPretty: false},
+		{name: "?pretty=true で整形", query: "?pretty=true", expectedPretty: true},
+		{name: "値を省略した ?pretty は整形", query: "?pretty", expectedPretty: true},
+		{name: "?pretty=false は整形しない", query: "?pretty=false", expectedPretty: false},
+		{name: "JSON_PRETTY有効時は既定で整形", prettyByDefault: true, query: "", expectedPretty: true},
+		{name: "JSON_PRETTY有効時も ?pretty=0 で整形しない", prettyByDefault: true, query: "?pretty=0", expectedPretty: false},
+		{name: "不正な値は既定に従う", prettyByDefault: true, query: "?pretty=yes", expectedPretty: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestRouter(middlewareStack{})
+			e.JSONSerializer = jsonSerializer{prettyByDefault: tt.prettyByDefault}
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/version"+tt.query, nil))
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.expectedPretty, strings.Contains(rec.Body.String(), "\n  \"version\": \"test\""))
+		})
+	}
+}
+
+func TestJSONSerializer_Deserialize(t *testing.T) {
+	// リクエストボディの読み込みはEchoの既定と同じ
+	e := newTestRouter(middlewareStack{})
+
+	req := httptest.NewRequest(http.MethodPost, "/util/parse-date?pretty=true", strings.NewReader(`{"value":"2020/01/02"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{\n  \"value\": \"2020/01/02\",\n  \"purchase_date\": \"2020-01-02\"\n}\n", rec.Body.String())
+}
+
+// 表記の変換を確認するためのボディ（カテゴリー別の集計のように、値をキーにしたマップを含む）
+type fieldCaseTestBody struct {
+	Name          string         `json:"name"`
+	PurchasePrice int            `json:"purchase_price"`
+	PurchaseDate  string         `json:"purchase_date"`
+	Categories    map[string]int `json:"categories"`
+	Tags          []fieldCaseTag `json:"tags"`
+}
+
+type fieldCaseTag struct {
+	DisplayName string `json:"display_name"`
+}
+
+func TestJSONSerializer_FieldCase(t *testing.T) {
+	body := fieldCaseTestBody{
+		Name:          "デイトナ <限定>",
+		PurchasePrice: 1500000,
+		PurchaseDate:  "2023-01-15",
+		Categories:    map[string]int{"時計": 1, "Louis Vuitton": 2},
+		Tags:          []fieldCaseTag{{DisplayName: "a"}},
+	}
+
+	tests := []struct {
+		name         string
+		fieldCase    jsonFieldCase
+		accept       string
+		contentType  string
+		expectedJSON string
+	}{
+		{
+			name:         "正常系: 既定はsnake_case",
+			expectedJSON: `{"name":"デイトナ \u003c限定\u003e","purchase_price":1500000,"purchase_date":"2023-01-15","categories":{"Louis Vuitton":2,"時計":1},"tags":[{"display_name":"a"}]}`,
+		},
+		{
+			name:         "正常系: casing=camelでcamelCase",
+			accept:       "application/json; casing=camel",
+			contentType:  "application/json; casing=camel",
+			expectedJSON: `{"name":"デイトナ \u003c限定\u003e","purchasePrice":1500000,"purchaseDate":"2023-01-15","categories":{"Louis Vuitton":2,"時計":1},"tags":[{"displayName":"a"}]}`,
+		},
+		{
+			name:         "正常系: JSON_FIELD_CASE=camelの既定",
+			fieldCase:    fieldCaseCamel,
+			expectedJSON: `{"name":"デイトナ \u003c限定\u003e","purchasePrice":1500000,"purchaseDate":"2023-01-15","categories":{"Louis Vuitton":2,"時計":1},"tags":[{"displayName":"a"}]}`,
+		},
+		{
+			name:         "正常系: 既定がcamelでもcasing=snakeでsnake_case",
+			fieldCase:    fieldCaseCamel,
+			accept:       "text/html, application/json; casing=snake",
+			contentType:  "application/json; casing=snake",
+			expectedJSON: `{"name":"デイトナ \u003c限定\u003e","purchase_price":1500000,"purchase_date":"2023-01-15","categories":{"Louis Vuitton":2,"時計":1},"tags":[{"display_name":"a"}]}`,
+		},
+		{
+			name:         "正常系: 不正なcasingは既定に従う",
+			accept:       "application/json; casing=kebab",
+			expectedJSON: `{"name":"デイトナ \u003c限定\u003e","purchase_price":1500000,"purchase_date":"2023-01-15","categories":{"Louis Vuitton":2,"時計":1},"tags":[{"display_name":"a"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			serializer := jsonSerializer{fieldCase: tt.fieldCase}
+
+			// 書き出し
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echo.HeaderAccept, tt.accept)
+			rec := httptest.NewRecorder()
+			require.NoError(t, serializer.Serialize(e.NewContext(req, rec), body, ""))
+			assert.Equal(t, tt.expectedJSON+"\n", rec.Body.String())
+
+			// 書き出した表記のまま送り返して、同じ値として読み込める
+			contentType := tt.contentType
+			if contentType == "" {
+				contentType = echo.MIMEApplicationJSON
+			}
+			req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(rec.Body.String()))
+			req.Header.Set(echo.HeaderContentType, contentType)
+			var decoded fieldCaseTestBody
+			require.NoError(t, serializer.Deserialize(e.NewContext(req, httptest.NewRecorder()), &decoded))
+			assert.Equal(t, body, decoded)
+		})
+	}
+}
+
+func TestJSONSerializer_FieldCasePretty(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/?pretty=true", nil)
+	req.Header.Set(echo.HeaderAccept, "application/json; casing=camel")
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, jsonSerializer{}.Serialize(e.NewContext(req, rec), fieldCaseTag{DisplayName: "a"}, ""))
+	assert.Equal(t, "{\n  \"displayName\": \"a\"\n}\n", rec.Body.String())
+}
+
+func TestJSONSerializer_DeserializeInvalidCamel(t *testing.T) {
+	// 読めないJSONや型の違いは、snake_case のボディを既定で読み込んだ場合と同じエラーにする
+	e := echo.New()
+	deserialize := func(serializer echo.JSONSerializer, contentType, body string) error {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, contentType)
+		var decoded fieldCaseTestBody
+		return serializer.Deserialize(e.NewContext(req, httptest.NewRecorder()), &decoded)
+	}
+
+	tests := []struct {
+		name      string
+		camelBody string
+		snakeBody string
+	}{
+		{name: "異常系: 途中で終わっている", camelBody: `{"purchasePrice":`, snakeBody: `{"purchasePrice":`},
+		{name: "異常系: 型が違う", camelBody: `{"purchasePrice":"1500000"}`, snakeBody: `{"purchase_price":"1500000"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected := deserialize(&echo.DefaultJSONSerializer{}, echo.MIMEApplicationJSON, tt.snakeBody)
+			require.Error(t, expected)
+			assert.Equal(t, expected.Error(), deserialize(jsonSerializer{}, "application/json; casing=camel", tt.camelBody).Error())
+		})
+	}
+}
+
+func TestJSONSerializer_NormalizeRequestKeys(t *testing.T) {
+	e := echo.New()
+	normalizer := func(serializer jsonSerializer, contentType string) func([]byte) []byte {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set(echo.HeaderContentType, contentType)
+		return serializer.NormalizeRequestKeys(e.NewContext(req, httptest.NewRecorder()))
+	}
+
+	// c.Bind を使わないボディも Deserialize と同じ規則で書き換える
+	normalize := normalizer(jsonSerializer{}, "application/json; casing=camel")
+	require.NotNil(t, normalize)
+	assert.Equal(t, `[{"purchase_price":1,"categories":{"時計":1}}]`, string(normalize([]byte(`[{"purchasePrice":1,"categories":{"時計":1}}]`))))
+	// JSONとして読めない場合は書き換えない
+	assert.Equal(t, `{"purchasePrice":`, string(normalize([]byte(`{"purchasePrice":`))))
+
+	assert.Nil(t, normalizer(jsonSerializer{}, echo.MIMEApplicationJSON))
+	assert.NotNil(t, normalizer(jsonSerializer{fieldCase: fieldCaseCamel}, echo.MIMEApplicationJSON))
+}
+
+func TestParseJSONFieldCase(t *testing.T) {
+	fieldCase, err := parseJSONFieldCase("")
+	require.NoError(t, err)
+	assert.Equal(t, fieldCaseSnake, fieldCase)
+
+	fieldCase, err = parseJSONFieldCase(" Camel ")
+	require.NoError(t, err)
+	assert.Equal(t, fieldCaseCamel, fieldCase)
+
+	_, err = parseJSONFieldCase("kebab")
+	assert.Error(t, err)
+}
+
+func TestNewServerTLSConfig(t *testing.T) {
+	defaultCert, defaultKey, defaultVersion := config.TLSCertFile, config.TLSKeyFile, config.TLSMinVersion
+	t.Cleanup(func() {
+		config.TLSCertFile, config.TLSKeyFile, config.TLSMinVersion = defaultCert, defaultKey, defaultVersion
+	})
+
+	dir := t.TempDir()
+	config.TLSCertFile, config.TLSKeyFile = writeTestCertificate(t, dir)
+
+	config.TLSMinVersion = "1.3"
+	tlsConfig, err := newServerTLSConfig()
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), tlsConfig.MinVersion)
+	assert.Len(t, tlsConfig.Certificates, 1)
+
+	// 起動前に検出できるよう、非推奨のバージョンと読めない証明書はエラーにする
+	config.TLSMinVersion = "1.1"
+	_, err = newServerTLSConfig()
+	assert.ErrorContains(t, err, "TLS_MIN_VERSION")
+
+	config.TLSMinVersion = ""
+	config.TLSKeyFile = filepath.Join(dir, "missing.key")
+	_, err = newServerTLSConfig()
+	assert.ErrorContains(t, err, "failed to load TLS certificate")
+}
+
+// 自己署名の証明書と秘密鍵をdirに書き込み、そのパスを返す
+func writeTestCertificate(t *testing.T, dir string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
@@ -0,0 +1,66 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// 計装のスコープ名（各層のTracerに使う）
+const InstrumentationName = "Aicon-assignment"
+
+// OTEL_SERVICE_NAME を指定しない場合のサービス名
+const defaultServiceName = "aicon-assignment"
+
+// OTLP/HTTPでトレースを送信するTracerProviderを設定し、停止時に未送信のスパンを送る関数を返す
+// 送信先・ヘッダー・サンプリングはOpenTelemetryの標準の環境変数（OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_TRACES_SAMPLER など）で指定する
+func Setup(ctx context.Context, serviceVersion string) (func(context.Context) error, error) {
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	// 後に指定したものが優先されるため、OTEL_SERVICE_NAME / OTEL_RESOURCE_ATTRIBUTES で上書きできる
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			attribute.String("service.name", defaultServiceName),
+			attribute.String("service.version", serviceVersion),
+		),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	// 呼び出し元から traceparent / baggage ヘッダーでトレースを引き継ぐ
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// 設定したTracerProvider（Setupを呼んでいない場合は何も記録しない）のTracer
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// エラーを記録してスパンを終了する
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
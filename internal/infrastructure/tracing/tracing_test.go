@@ -0,0 +1,73 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 記録したスパンをメモリに保持するTracerProviderを設定する（テストの終了時に元に戻す）
+func setupTestProvider(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return exporter
+}
+
+// GetItemByIDのみを実装したユースケース
+type stubItemUsecase struct {
+	usecase.ItemUsecase
+	item *entity.Item
+	err  error
+}
+
+func (u *stubItemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
+	return u.item, u.err
+}
+
+func TestWrapItemUsecase(t *testing.T) {
+	exporter := setupTestProvider(t)
+	parentCtx, parent := Tracer().Start(context.Background(), "GET /items/:id")
+
+	t.Run("正常系: 呼び出し元のスパンの子として記録する", func(t *testing.T) {
+		exporter.Reset()
+		item := &entity.Item{ID: 1}
+		wrapped := WrapItemUsecase(&stubItemUsecase{item: item})
+
+		result, err := wrapped.GetItemByID(parentCtx, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, item, result)
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "ItemUsecase.GetItemByID", spans[0].Name)
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+		assert.Contains(t, spans[0].Attributes, attribute.Int64("item.id", 1))
+		assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	})
+
+	t.Run("異常系: エラーを記録する", func(t *testing.T) {
+		exporter.Reset()
+		wrapped := WrapItemUsecase(&stubItemUsecase{err: domainErrors.ErrDatabaseError})
+
+		_, err := wrapped.GetItemByID(parentCtx, 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+		assert.Len(t, spans[0].Events, 1)
+	})
+}
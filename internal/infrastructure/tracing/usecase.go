@@ -0,0 +1,222 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// ItemUsecaseの各メソッドをスパンで囲む（HTTPのスパンとSQLのスパンの間の層）
+type itemUsecase struct {
+	next   usecase.ItemUsecase
+	tracer trace.Tracer
+}
+
+func WrapItemUsecase(next usecase.ItemUsecase) usecase.ItemUsecase {
+	return &itemUsecase{next: next, tracer: Tracer()}
+}
+
+func (u *itemUsecase) start(ctx context.Context, method string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return u.tracer.Start(ctx, "ItemUsecase."+method, trace.WithAttributes(attrs...))
+}
+
+func itemID(id int64) attribute.KeyValue {
+	return attribute.Int64("item.id", id)
+}
+
+func (u *itemUsecase) GetAllItems(ctx context.Context, filter usecase.ItemFilter) (items []*entity.Item, err error) {
+	ctx, span := u.start(ctx, "GetAllItems")
+	defer func() { End(span, err) }()
+	return u.next.GetAllItems(ctx, filter)
+}
+
+func (u *itemUsecase) GetItemPage(ctx context.Context, filter usecase.ItemFilter, page usecase.Page) (result *usecase.ItemPage, err error) {
+	ctx, span := u.start(ctx, "GetItemPage", attribute.Int("page.limit", page.Limit), attribute.Int("page.offset", page.Offset))
+	defer func() { End(span, err) }()
+	return u.next.GetItemPage(ctx, filter, page)
+}
+
+func (u *itemUsecase) GetItemListVersion(ctx context.Context, filter usecase.ItemFilter) (version *usecase.ListVersion, err error) {
+	ctx, span := u.start(ctx, "GetItemListVersion")
+	defer func() { End(span, err) }()
+	return u.next.GetItemListVersion(ctx, filter)
+}
+
+func (u *itemUsecase) GetItemByID(ctx context.Context, id int64) (item *entity.Item, err error) {
+	ctx, span := u.start(ctx, "GetItemByID", itemID(id))
+	defer func() { End(span, err) }()
+	return u.next.GetItemByID(ctx, id)
+}
+
+func (u *itemUsecase) ResolveItemID(ctx context.Context, uuid string) (id int64, err error) {
+	ctx, span := u.start(ctx, "ResolveItemID")
+	defer func() { End(span, err) }()
+	return u.next.ResolveItemID(ctx, uuid)
+}
+
+func (u *itemUsecase) CreateItem(ctx context.Context, input usecase.CreateItemInput) (item *entity.Item, err error) {
+	ctx, span := u.start(ctx, "CreateItem")
+	defer func() { End(span, err) }()
+	return u.next.CreateItem(ctx, input)
+}
+
+func (u *itemUsecase) UpsertItem(ctx context.Context, input usecase.CreateItemInput) (item *entity.Item, created bool, err error) {
+	ctx, span := u.start(ctx, "UpsertItem")
+	defer func() { End(span, err) }()
+	return u.next.UpsertItem(ctx, input)
+}
+
+func (u *itemUsecase) ImportItems(ctx context.Context, rows []usecase.ImportRow, mode usecase.ImportMode) (result *usecase.ImportResult, err error) {
+	ctx, span := u.start(ctx, "ImportItems", attribute.Int("import.rows", len(rows)), attribute.String("import.mode", string(mode)))
+	defer func() { End(span, err) }()
+	return u.next.ImportItems(ctx, rows, mode)
+}
+
+func (u *itemUsecase) BulkCreateItems(ctx context.Context, inputs []usecase.CreateItemInput) (items []*entity.Item, err error) {
+	ctx, span := u.start(ctx, "BulkCreateItems", attribute.Int("bulk.items", len(inputs)))
+	defer func() { End(span, err) }()
+	return u.next.BulkCreateItems(ctx, inputs)
+}
+
+func (u *itemUsecase) BulkCreateItemsBestEffort(ctx context.Context, inputs []usecase.CreateItemInput) (results []usecase.BulkItemResult, err error) {
+	ctx, span := u.start(ctx, "BulkCreateItemsBestEffort", attribute.Int("bulk.items", len(inputs)))
+	defer func() { End(span, err) }()
+	return u.next.BulkCreateItemsBestEffort(ctx, inputs)
+}
+
+func (u *itemUsecase) UpdateItem(ctx context.Context, id int64, input usecase.UpdateItemInput) (item *entity.Item, err error) {
+	ctx, span := u.start(ctx, "UpdateItem", itemID(id))
+	defer func() { End(span, err) }()
+	return u.next.UpdateItem(ctx, id, input)
+}
+
+func (u *itemUsecase) DeleteItem(ctx context.Context, id int64, input usecase.DeleteItemInput) (err error) {
+	ctx, span := u.start(ctx, "DeleteItem", itemID(id))
+	defer func() { End(span, err) }()
+	return u.next.DeleteItem(ctx, id, input)
+}
+
+func (u *itemUsecase) DeleteItemsByFilter(ctx context.Context, filter usecase.ItemFilter, input usecase.DeleteByFilterInput) (deleted int, err error) {
+	ctx, span := u.start(ctx, "DeleteItemsByFilter")
+	defer func() { End(span, err) }()
+	return u.next.DeleteItemsByFilter(ctx, filter, input)
+}
+
+func (u *itemUsecase) ArchiveItem(ctx context.Context, id int64) (item *entity.Item, err error) {
+	ctx, span := u.start(ctx, "ArchiveItem", itemID(id))
+	defer func() { End(span, err) }()
+	return u.next.ArchiveItem(ctx, id)
+}
+
+func (u *itemUsecase) UnarchiveItem(ctx context.Context, id int64) (item *entity.Item, err error) {
+	ctx, span := u.start(ctx, "UnarchiveItem", itemID(id))
+	defer func() { End(span, err) }()
+	return u.next.UnarchiveItem(ctx, id)
+}
+
+func (u *itemUsecase) GetCategorySummary(ctx context.Context) (summary *usecase.CategorySummary, err error) {
+	ctx, span := u.start(ctx, "GetCategorySummary")
+	defer func() { End(span, err) }()
+	return u.next.GetCategorySummary(ctx)
+}
+
+func (u *itemUsecase) CompareCategorySummary(ctx context.Context, asOf time.Time) (comparison *usecase.SummaryComparison, err error) {
+	ctx, span := u.start(ctx, "CompareCategorySummary")
+	defer func() { End(span, err) }()
+	return u.next.CompareCategorySummary(ctx, asOf)
+}
+
+func (u *itemUsecase) GetCategorySummaries(ctx context.Context, categories []string) (entries []usecase.CategorySummaryEntry, err error) {
+	ctx, span := u.start(ctx, "GetCategorySummaries")
+	defer func() { End(span, err) }()
+	return u.next.GetCategorySummaries(ctx, categories)
+}
+
+func (u *itemUsecase) GetUsedCategories(ctx context.Context) (categories []usecase.CategoryCount, err error) {
+	ctx, span := u.start(ctx, "GetUsedCategories")
+	defer func() { End(span, err) }()
+	return u.next.GetUsedCategories(ctx)
+}
+
+func (u *itemUsecase) GetBrandSummary(ctx context.Context, filter usecase.ItemFilter) (entries []usecase.BrandSummaryEntry, err error) {
+	ctx, span := u.start(ctx, "GetBrandSummary")
+	defer func() { End(span, err) }()
+	return u.next.GetBrandSummary(ctx, filter)
+}
+
+func (u *itemUsecase) GetGroupSummary(ctx context.Context, filter usecase.ItemFilter, by usecase.GroupBy) (entries []usecase.GroupSummaryEntry, err error) {
+	ctx, span := u.start(ctx, "GetGroupSummary", attribute.String("group.by", string(by)))
+	defer func() { End(span, err) }()
+	return u.next.GetGroupSummary(ctx, filter, by)
+}
+
+func (u *itemUsecase) GetTimeline(ctx context.Context, filter usecase.ItemFilter) (entries []usecase.TimelineEntry, err error) {
+	ctx, span := u.start(ctx, "GetTimeline")
+	defer func() { End(span, err) }()
+	return u.next.GetTimeline(ctx, filter)
+}
+
+func (u *itemUsecase) GetTrends(ctx context.Context, filter usecase.ItemFilter) (trends []usecase.TrendEntry, err error) {
+	ctx, span := u.start(ctx, "GetTrends")
+	defer func() { End(span, err) }()
+	return u.next.GetTrends(ctx, filter)
+}
+
+func (u *itemUsecase) GetDiversity(ctx context.Context, filter usecase.ItemFilter) (diversity *usecase.Diversity, err error) {
+	ctx, span := u.start(ctx, "GetDiversity")
+	defer func() { End(span, err) }()
+	return u.next.GetDiversity(ctx, filter)
+}
+
+func (u *itemUsecase) GetIncompleteItems(ctx context.Context) (items []usecase.IncompleteItem, err error) {
+	ctx, span := u.start(ctx, "GetIncompleteItems")
+	defer func() { End(span, err) }()
+	return u.next.GetIncompleteItems(ctx)
+}
+
+func (u *itemUsecase) GetChangesSince(ctx context.Context, since time.Time) (changes *usecase.ItemChangeSet, err error) {
+	ctx, span := u.start(ctx, "GetChangesSince")
+	defer func() { End(span, err) }()
+	return u.next.GetChangesSince(ctx, since)
+}
+
+func (u *itemUsecase) SyncItems(ctx context.Context, token string) (sync *usecase.ItemSyncSet, err error) {
+	ctx, span := u.start(ctx, "SyncItems")
+	defer func() { End(span, err) }()
+	return u.next.SyncItems(ctx, token)
+}
+
+func (u *itemUsecase) GetChangeLog(ctx context.Context, since int64, limit int) (page *usecase.ChangeLogPage, err error) {
+	ctx, span := u.start(ctx, "GetChangeLog")
+	defer func() { End(span, err) }()
+	return u.next.GetChangeLog(ctx, since, limit)
+}
+
+func (u *itemUsecase) SuggestCategory(ctx context.Context, name string) (suggestion *usecase.CategorySuggestion, err error) {
+	ctx, span := u.start(ctx, "SuggestCategory")
+	defer func() { End(span, err) }()
+	return u.next.SuggestCategory(ctx, name)
+}
+
+func (u *itemUsecase) GetDashboard(ctx context.Context, sections []usecase.DashboardSection, limit int) (dashboard *usecase.Dashboard, err error) {
+	ctx, span := u.start(ctx, "GetDashboard")
+	defer func() { End(span, err) }()
+	return u.next.GetDashboard(ctx, sections, limit)
+}
+
+func (u *itemUsecase) GetDepreciationSchedule(ctx context.Context, id int64, years int, salvage int64) (schedule *usecase.DepreciationSchedule, err error) {
+	ctx, span := u.start(ctx, "GetDepreciationSchedule", itemID(id))
+	defer func() { End(span, err) }()
+	return u.next.GetDepreciationSchedule(ctx, id, years, salvage)
+}
+
+func (u *itemUsecase) GetItemSiblings(ctx context.Context, id int64, filter usecase.ItemFilter) (siblings *usecase.ItemSiblings, err error) {
+	ctx, span := u.start(ctx, "GetItemSiblings", itemID(id), attribute.String("list.sort", string(filter.Sort)))
+	defer func() { End(span, err) }()
+	return u.next.GetItemSiblings(ctx, id, filter)
+}
@@ -0,0 +1,123 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+const (
+	// 署名を格納するヘッダー（"sha256=<hex>" 形式）
+	SignatureHeader = "X-Webhook-Signature"
+	// イベント種別を格納するヘッダー
+	EventHeader = "X-Webhook-Event"
+
+	// リトライ間隔の初期値と上限（失敗ごとに2倍にする）
+	defaultBaseBackoff = 500 * time.Millisecond
+	maxBackoff         = 30 * time.Second
+)
+
+// アイテムのイベントを設定されたURLへPOSTするEventSender
+type Dispatcher struct {
+	urls        []string
+	secret      []byte
+	maxRetries  int
+	baseBackoff time.Duration
+	client      *http.Client
+}
+
+func NewDispatcher(urls []string, secret string, timeout time.Duration, maxRetries int) *Dispatcher {
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+	return &Dispatcher{
+		urls:        urls,
+		secret:      []byte(secret),
+		maxRetries:  maxRetries,
+		baseBackoff: defaultBaseBackoff,
+		client:      &http.Client{Timeout: timeout},
+	}
+}
+
+// イベントを全てのURLへ送信する（いずれかが失敗した場合はエラーを返し、呼び出し元で再送する）
+func (d *Dispatcher) Send(ctx context.Context, event usecase.ItemEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	var errs []error
+	for _, url := range d.urls {
+		if err := d.deliver(ctx, url, event.Type, body); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", url, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// 2xxが返るまでバックオフしながら送信する
+func (d *Dispatcher) deliver(ctx context.Context, url string, eventType usecase.EventType, body []byte) error {
+	backoff := d.baseBackoff
+	for attempt := 0; ; attempt++ {
+		err := d.send(ctx, url, eventType, body)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= d.maxRetries {
+			return fmt.Errorf("giving up after %d attempts: %w", attempt+1, err)
+		}
+
+		slog.Warn("webhook delivery failed, retrying", "url", url, "event", eventType, "attempt", attempt+1, "retry_in", backoff, "error", err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func (d *Dispatcher) send(ctx context.Context, url string, eventType usecase.EventType, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(eventType))
+	if len(d.secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(d.secret, body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// ペイロードのHMAC-SHA256署名を "sha256=<hex>" 形式で返す
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestDispatcher_Send(t *testing.T) {
+	secret := "test-secret"
+	received := make(chan *http.Request, 1)
+	var body []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		received <- r
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	dispatcher := NewDispatcher([]string{server.URL}, secret, time.Second, 0)
+	err := dispatcher.Send(context.Background(), usecase.ItemEvent{Type: usecase.EventItemCreated, ItemID: 1})
+	require.NoError(t, err)
+
+	r := <-received
+	assert.Equal(t, http.MethodPost, r.Method)
+	assert.Equal(t, "item.created", r.Header.Get(EventHeader))
+	assert.Equal(t, Sign([]byte(secret), body), r.Header.Get(SignatureHeader))
+
+	var event usecase.ItemEvent
+	require.NoError(t, json.Unmarshal(body, &event))
+	assert.Equal(t, usecase.EventItemCreated, event.Type)
+	assert.Equal(t, int64(1), event.ItemID)
+}
+
+func TestDispatcher_Retry(t *testing.T) {
+	tests := []struct {
+		name             string
+		failures         int32
+		maxRetries       int
+		expectedAttempts int32
+		wantErr          bool
+	}{
+		{
+			name:             "正常系: 2xx以外はリトライして成功",
+			failures:         2,
+			maxRetries:       3,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "異常系: リトライ上限で諦める",
+			failures:         10,
+			maxRetries:       2,
+			expectedAttempts: 3,
+			wantErr:          true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&attempts, 1) <= tt.failures {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			dispatcher := NewDispatcher([]string{server.URL}, "", time.Second, tt.maxRetries)
+			dispatcher.baseBackoff = time.Millisecond
+			err := dispatcher.Send(context.Background(), usecase.ItemEvent{Type: usecase.EventItemUpdated, ItemID: 1})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedAttempts, atomic.LoadInt32(&attempts))
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// 定期実行する処理
+type Job func(ctx context.Context) error
+
+// intervalごとにjobを実行する（ctxがキャンセルされるまでブロックする）
+// jobのエラーはログに出力し、次の実行を続ける
+func Run(ctx context.Context, name string, interval time.Duration, job Job) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	slog.Info("worker started", "worker", name, "interval", interval.String())
+	for {
+		if err := job(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("worker job failed", "worker", name, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("worker stopped", "worker", name)
+			return
+		case <-ticker.C:
+		}
+	}
+}
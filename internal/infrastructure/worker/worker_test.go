@@ -0,0 +1,35 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int32
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Run(ctx, "test", time.Millisecond, func(ctx context.Context) error {
+			// エラーが返っても実行は継続する
+			if atomic.AddInt32(&calls, 1) >= 3 {
+				cancel()
+			}
+			return errors.New("job failed")
+		})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker did not stop after context cancellation")
+	}
+	assert.GreaterOrEqual(t, atomic.LoadInt32(&calls), int32(3))
+}
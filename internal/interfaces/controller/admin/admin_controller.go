@@ -0,0 +1,178 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 管理者用のハンドラー（ADMIN_TOKENを設定した場合のみ登録し、認証はミドルウェアで行う）
+type AdminHandler struct {
+	restorer   usecase.ItemRestorer
+	backfiller usecase.Backfiller
+	integrity  usecase.IntegrityChecker
+	backups    fs.FS // バックアップファイルのディレクトリ（nilの場合はファイル名での指定を受け付けない）
+	maxBytes   int64 // アップロードするファイルの最大サイズ（0以下の場合は無制限）
+}
+
+func NewAdminHandler(restorer usecase.ItemRestorer, backfiller usecase.Backfiller, integrity usecase.IntegrityChecker, backups fs.FS, maxBytes int64) *AdminHandler {
+	return &AdminHandler{
+		restorer:   restorer,
+		backfiller: backfiller,
+		integrity:  integrity,
+		backups:    backups,
+		maxBytes:   maxBytes,
+	}
+}
+
+// POST /admin/restore?mode=replace|merge&file=items-20240101T000000Z.json
+// file を指定しない場合は、multipartの file フィールドまたはリクエストボディのJSONを読み込む
+func (h *AdminHandler) RestoreItems(c echo.Context) error {
+	mode := usecase.RestoreMode(c.QueryParam("mode"))
+	if !mode.IsValid() {
+		return c.JSON(http.StatusBadRequest, itemController.ErrorResponse{
+			Code:    itemController.CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{"mode must be one of: replace, merge"},
+		})
+	}
+
+	var items []*entity.Item
+	var err error
+	if name := c.QueryParam("file"); name != "" {
+		items, err = h.readBackupFile(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			return c.JSON(http.StatusNotFound, itemController.ErrorResponse{
+				Code:  itemController.CodeBackupNotFound,
+				Error: "backup file not found",
+			})
+		}
+	} else {
+		items, err = h.readUpload(c)
+		if isMaxBytesError(err) {
+			return c.JSON(http.StatusRequestEntityTooLarge, itemController.ErrorResponse{
+				Code:    itemController.CodePayloadTooLarge,
+				Error:   "request body too large",
+				Details: []string{fmt.Sprintf("backup must be %d bytes or less", h.maxBytes)},
+			})
+		}
+	}
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, itemController.ErrorResponse{
+			Code:    itemController.CodeInvalidRequest,
+			Error:   "invalid backup",
+			Details: []string{err.Error()},
+		})
+	}
+
+	result, err := h.restorer.RestoreItems(c.Request().Context(), items, mode)
+	if err != nil {
+		if domainErrors.IsTimestampOutOfRangeError(err) {
+			return c.JSON(http.StatusUnprocessableEntity, itemController.ErrorResponse{
+				Code:    itemController.CodeTimestampOutOfRange,
+				Error:   "timestamp out of range",
+				Details: []string{err.Error()},
+			})
+		}
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, itemController.ErrorResponse{
+				Code:    itemController.CodeValidationFailed,
+				Error:   "validation failed",
+				Details: []string{err.Error()},
+			})
+		}
+		if domainErrors.IsDuplicateEntryError(err) {
+			var dupErr *domainErrors.DuplicateEntryError
+			response := itemController.ErrorResponse{Code: itemController.CodeItemAlreadyExists, Error: "item already exists"}
+			if errors.As(err, &dupErr) {
+				response.Details = []string{dupErr.Message}
+			}
+			return c.JSON(http.StatusConflict, response)
+		}
+		if domainErrors.IsDatabaseBusyError(err) {
+			c.Response().Header().Set(echo.HeaderRetryAfter, "1")
+			return c.JSON(http.StatusServiceUnavailable, itemController.ErrorResponse{
+				Code:  itemController.CodeDatabaseBusy,
+				Error: "database is busy, please retry later",
+			})
+		}
+		if domainErrors.IsDatabaseUnavailableError(err) {
+			c.Response().Header().Set(echo.HeaderRetryAfter, "5")
+			return c.JSON(http.StatusServiceUnavailable, itemController.ErrorResponse{
+				Code:  itemController.CodeDatabaseUnavailable,
+				Error: "database is temporarily unavailable, please retry later",
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, itemController.ErrorResponse{
+			Code:  itemController.CodeInternalError,
+			Error: "failed to restore items",
+		})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// バックアップのディレクトリ直下のファイルを読み込む（サブディレクトリや親ディレクトリは指定できない）
+func (h *AdminHandler) readBackupFile(name string) ([]*entity.Item, error) {
+	if h.backups == nil || !fs.ValidPath(name) || strings.Contains(name, "/") || !strings.HasSuffix(name, ".json") {
+		return nil, fs.ErrNotExist
+	}
+	file, err := h.backups.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return decodeBackup(file)
+}
+
+// multipart/form-data の場合は file フィールド、それ以外はリクエストボディを読み込む
+func (h *AdminHandler) readUpload(c echo.Context) ([]*entity.Item, error) {
+	if h.maxBytes > 0 {
+		c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, h.maxBytes)
+	}
+	if !strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		return decodeBackup(c.Request().Body)
+	}
+
+	reader, err := c.Request().MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, errors.New("file is required")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == "file" {
+			return decodeBackup(part)
+		}
+	}
+}
+
+// バックアップ（アイテムのJSONの配列）を読み込む
+func decodeBackup(r io.Reader) ([]*entity.Item, error) {
+	var items []*entity.Item
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, fmt.Errorf("backup must be a JSON array of items: %w", err)
+	}
+	return items, nil
+}
+
+func isMaxBytesError(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
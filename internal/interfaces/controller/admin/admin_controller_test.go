@@ -0,0 +1,285 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+type MockItemRestorer struct {
+	mock.Mock
+}
+
+func (m *MockItemRestorer) RestoreItems(ctx context.Context, items []*entity.Item, mode usecase.RestoreMode) (*usecase.RestoreResult, error) {
+	args := m.Called(ctx, items, mode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.RestoreResult), args.Error(1)
+}
+
+func TestAdminHandler_RestoreItems(t *testing.T) {
+	backup := `[{"id":1,"name":"デイトナ","category":"時計","brand":"ROLEX","purchase_price":{"amount":1500000,"currency":"JPY"},"purchase_date":"2023-01-15","status":"active"}]`
+	backups := fstest.MapFS{
+		"items-20240101T000000Z.json": {Data: []byte(backup)},
+		"nested/items.json":           {Data: []byte(backup)},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		body           string
+		expectRestore  bool
+		expectedStatus int
+	}{
+		{name: "正常系: バックアップファイルから復元", query: "?mode=replace&file=items-20240101T000000Z.json", expectRestore: true, expectedStatus: http.StatusOK},
+		{name: "正常系: リクエストボディから復元", query: "?mode=merge", body: backup, expectRestore: true, expectedStatus: http.StatusOK},
+		{name: "異常系: 存在しないファイル", query: "?mode=replace&file=items-20230101T000000Z.json", expectedStatus: http.StatusNotFound},
+		{name: "異常系: 親ディレクトリのファイル", query: "?mode=replace&file=../items.json", expectedStatus: http.StatusNotFound},
+		{name: "異常系: サブディレクトリのファイル", query: "?mode=replace&file=nested/items.json", expectedStatus: http.StatusNotFound},
+		{name: "異常系: JSONの配列ではない", query: "?mode=merge", body: `{"name":"デイトナ"}`, expectedStatus: http.StatusBadRequest},
+		{name: "異常系: 不正なモード", query: "?mode=append", body: backup, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRestorer := new(MockItemRestorer)
+			if tt.expectRestore {
+				mockRestorer.On("RestoreItems", mock.Anything, mock.MatchedBy(func(items []*entity.Item) bool {
+					return len(items) == 1 && items[0].ID == 1 && items[0].PurchasePrice.Amount == 1500000
+				}), mock.Anything).Return(&usecase.RestoreResult{Total: 1, Created: 1}, nil)
+			}
+			handler := NewAdminHandler(mockRestorer, nil, nil, backups, 1<<20)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/admin/restore"+tt.query, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, handler.RestoreItems(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			mockRestorer.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAdminHandler_RestoreItems_TimestampOutOfRange(t *testing.T) {
+	mockRestorer := new(MockItemRestorer)
+	mockRestorer.On("RestoreItems", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("%w: items[0]: created_at must not be more than 5m0s in the future", domainErrors.ErrTimestampOutOfRange))
+	handler := NewAdminHandler(mockRestorer, nil, nil, nil, 1<<20)
+
+	e := echo.New()
+	body := `[{"name":"デイトナ","category":"時計","brand":"ROLEX","purchase_price":{"amount":1500000,"currency":"JPY"},"purchase_date":"2023-01-15","created_at":"2099-01-01T00:00:00Z"}]`
+	req := httptest.NewRequest(http.MethodPost, "/admin/restore?mode=merge", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	assert.NoError(t, handler.RestoreItems(c))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"TIMESTAMP_OUT_OF_RANGE"`)
+}
+
+type MockBackfiller struct {
+	mock.Mock
+}
+
+func (m *MockBackfiller) Backfill(ctx context.Context, field string, afterID int64) (*usecase.BackfillResult, error) {
+	args := m.Called(ctx, field, afterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.BackfillResult), args.Error(1)
+}
+
+func TestAdminHandler_Backfill(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockBackfiller)
+		expectedStatus int
+	}{
+		{
+			name:  "正常系: 再計算した件数を返す",
+			query: "?field=thumbnail&after_id=10",
+			setupMock: func(m *MockBackfiller) {
+				m.On("Backfill", mock.Anything, "thumbnail", int64(10)).Return(&usecase.BackfillResult{Field: "thumbnail", Batches: 1, Scanned: 3, LastID: 13}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "異常系: 登録していないフィールド",
+			query: "?field=gain_loss",
+			setupMock: func(m *MockBackfiller) {
+				m.On("Backfill", mock.Anything, "gain_loss", int64(0)).Return(nil, fmt.Errorf("%w: field must be one of: thumbnail", domainErrors.ErrInvalidInput))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: after_idが整数でない",
+			query:          "?field=thumbnail&after_id=abc",
+			setupMock:      func(m *MockBackfiller) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "異常系: 途中で失敗",
+			query: "?field=thumbnail",
+			setupMock: func(m *MockBackfiller) {
+				m.On("Backfill", mock.Anything, "thumbnail", int64(0)).Return(nil, fmt.Errorf("backfill of thumbnail failed after id 100: boom"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBackfiller := new(MockBackfiller)
+			tt.setupMock(mockBackfiller)
+			handler := NewAdminHandler(nil, mockBackfiller, nil, nil, 0)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/admin/backfill"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			assert.NoError(t, handler.Backfill(e.NewContext(req, rec)))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			mockBackfiller.AssertExpectations(t)
+		})
+	}
+}
+
+type MockIntegrityChecker struct {
+	mock.Mock
+}
+
+func (m *MockIntegrityChecker) CheckIntegrity(ctx context.Context, limit int) (*usecase.IntegrityReport, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.IntegrityReport), args.Error(1)
+}
+
+func (m *MockIntegrityChecker) FixIntegrity(ctx context.Context, check usecase.IntegrityCheck) (*usecase.IntegrityFixResult, error) {
+	args := m.Called(ctx, check)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.IntegrityFixResult), args.Error(1)
+}
+
+func TestAdminHandler_CheckIntegrity(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockIntegrityChecker)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "正常系: 項目ごとのIDを返す",
+			setupMock: func(m *MockIntegrityChecker) {
+				m.On("CheckIntegrity", mock.Anything, usecase.DefaultIntegrityLimit).Return(&usecase.IntegrityReport{Issues: []usecase.IntegrityIssue{
+					{Check: usecase.IntegrityNegativePrice, Count: 1, IDs: []int64{7}},
+				}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"ok":false,"issues":[{"check":"negative_price","count":1,"ids":[7],"fixable":false}]}`,
+		},
+		{
+			name:           "異常系: limitが整数でない",
+			query:          "?limit=all",
+			setupMock:      func(m *MockIntegrityChecker) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "異常系: limitが範囲外",
+			query: "?limit=5000",
+			setupMock: func(m *MockIntegrityChecker) {
+				m.On("CheckIntegrity", mock.Anything, 5000).Return(nil, fmt.Errorf("%w: limit must be between 1 and 1000", domainErrors.ErrInvalidInput))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockChecker := new(MockIntegrityChecker)
+			tt.setupMock(mockChecker)
+			handler := NewAdminHandler(nil, nil, mockChecker, nil, 0)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/admin/integrity"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			assert.NoError(t, handler.CheckIntegrity(e.NewContext(req, rec)))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+			mockChecker.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAdminHandler_FixIntegrity(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockIntegrityChecker)
+		expectedStatus int
+	}{
+		{
+			name:  "正常系: 修正した行数を返す",
+			query: "?check=orphaned_image",
+			setupMock: func(m *MockIntegrityChecker) {
+				m.On("FixIntegrity", mock.Anything, usecase.IntegrityOrphanedImage).Return(&usecase.IntegrityFixResult{Check: usecase.IntegrityOrphanedImage, Fixed: 2}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "異常系: 自動で修正できない項目",
+			query: "?check=negative_price",
+			setupMock: func(m *MockIntegrityChecker) {
+				m.On("FixIntegrity", mock.Anything, usecase.IntegrityNegativePrice).Return(nil, fmt.Errorf("%w: negative_price cannot be fixed automatically", domainErrors.ErrInvalidInput))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "異常系: 修正に失敗",
+			query: "?check=invalid_category",
+			setupMock: func(m *MockIntegrityChecker) {
+				m.On("FixIntegrity", mock.Anything, usecase.IntegrityInvalidCategory).Return(nil, fmt.Errorf("boom"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockChecker := new(MockIntegrityChecker)
+			tt.setupMock(mockChecker)
+			handler := NewAdminHandler(nil, nil, mockChecker, nil, 0)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/admin/integrity/fix"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			assert.NoError(t, handler.FixIntegrity(e.NewContext(req, rec)))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			mockChecker.AssertExpectations(t)
+		})
+	}
+}
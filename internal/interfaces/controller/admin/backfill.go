@@ -0,0 +1,54 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+)
+
+// POST /admin/backfill?field=thumbnail&after_id=0
+// 派生カラムをID順に最後まで再計算し、件数を返す（進捗はバッチごとにログに出力する）
+func (h *AdminHandler) Backfill(c echo.Context) error {
+	var afterID int64
+	if value := c.QueryParam("after_id"); value != "" {
+		var err error
+		if afterID, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return c.JSON(http.StatusBadRequest, itemController.ErrorResponse{
+				Code:    itemController.CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{"after_id must be an integer"},
+			})
+		}
+	}
+
+	result, err := h.backfiller.Backfill(c.Request().Context(), c.QueryParam("field"), afterID)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, itemController.ErrorResponse{
+				Code:    itemController.CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{err.Error()},
+			})
+		}
+		if domainErrors.IsDatabaseUnavailableError(err) {
+			c.Response().Header().Set(echo.HeaderRetryAfter, "5")
+			return c.JSON(http.StatusServiceUnavailable, itemController.ErrorResponse{
+				Code:    itemController.CodeDatabaseUnavailable,
+				Error:   "database is temporarily unavailable, please retry later",
+				Details: []string{err.Error()},
+			})
+		}
+		// 再開するIDを含むメッセージを返す
+		return c.JSON(http.StatusInternalServerError, itemController.ErrorResponse{
+			Code:    itemController.CodeInternalError,
+			Error:   "failed to backfill",
+			Details: []string{err.Error()},
+		})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
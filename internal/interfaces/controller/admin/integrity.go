@@ -0,0 +1,68 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+// GET /admin/integrity?limit=100
+// 現在の検証のルールに違反している行を項目ごとに数え、IDを返す（読み取りのみ）
+func (h *AdminHandler) CheckIntegrity(c echo.Context) error {
+	limit := usecase.DefaultIntegrityLimit
+	if value := c.QueryParam("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, itemController.ErrorResponse{
+				Code:    itemController.CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{"limit must be an integer"},
+			})
+		}
+		limit = parsed
+	}
+
+	report, err := h.integrity.CheckIntegrity(c.Request().Context(), limit)
+	if err != nil {
+		return respondIntegrityError(c, err, "failed to check integrity")
+	}
+	return c.JSON(http.StatusOK, report)
+}
+
+// POST /admin/integrity/fix?check=orphaned_image
+// 自動で修正できる項目の、違反しているすべての行を修正し、修正した行数を返す
+func (h *AdminHandler) FixIntegrity(c echo.Context) error {
+	result, err := h.integrity.FixIntegrity(c.Request().Context(), usecase.IntegrityCheck(c.QueryParam("check")))
+	if err != nil {
+		return respondIntegrityError(c, err, "failed to fix integrity")
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+func respondIntegrityError(c echo.Context, err error, message string) error {
+	if domainErrors.IsValidationError(err) {
+		return c.JSON(http.StatusBadRequest, itemController.ErrorResponse{
+			Code:    itemController.CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{err.Error()},
+		})
+	}
+	if domainErrors.IsDatabaseUnavailableError(err) {
+		c.Response().Header().Set(echo.HeaderRetryAfter, "5")
+		return c.JSON(http.StatusServiceUnavailable, itemController.ErrorResponse{
+			Code:    itemController.CodeDatabaseUnavailable,
+			Error:   "database is temporarily unavailable, please retry later",
+			Details: []string{err.Error()},
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, itemController.ErrorResponse{
+		Code:    itemController.CodeInternalError,
+		Error:   message,
+		Details: []string{err.Error()},
+	})
+}
@@ -0,0 +1,76 @@
+package debug
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 開発環境専用のデバッグ用ハンドラー（本番では登録しない）
+type DebugHandler struct {
+	diagnosticsUsecase usecase.DiagnosticsUsecase
+}
+
+func NewDebugHandler(diagnosticsUsecase usecase.DiagnosticsUsecase) *DebugHandler {
+	return &DebugHandler{
+		diagnosticsUsecase: diagnosticsUsecase,
+	}
+}
+
+type ExplainResponse struct {
+	Query string      `json:"query"`
+	Plan  interface{} `json:"plan"`
+}
+
+// GET /debug/explain?q=list&category=時計
+func (h *DebugHandler) Explain(c echo.Context) error {
+	input := usecase.ExplainInput{
+		Query: c.QueryParam("q"),
+	}
+
+	filter, err := itemController.ParseItemFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, itemController.ErrorResponse{
+			Code:    itemController.CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{err.Error()},
+		})
+	}
+	input.Filter = filter
+
+	if idStr := c.QueryParam("id"); idStr != "" {
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, itemController.ErrorResponse{
+				Code:  itemController.CodeInvalidParameter,
+				Error: "invalid item ID",
+			})
+		}
+		input.ID = id
+	}
+
+	plan, err := h.diagnosticsUsecase.ExplainQuery(c.Request().Context(), input)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, itemController.ErrorResponse{
+				Code:    itemController.CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{err.Error()},
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, itemController.ErrorResponse{
+			Code:  itemController.CodeInternalError,
+			Error: "failed to explain query",
+		})
+	}
+
+	return c.JSON(http.StatusOK, ExplainResponse{
+		Query: input.Query,
+		Plan:  plan,
+	})
+}
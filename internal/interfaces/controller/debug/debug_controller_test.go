@@ -0,0 +1,103 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 受け取った入力を記録し、固定の結果を返すDiagnosticsUsecase
+type fakeDiagnosticsUsecase struct {
+	plan  json.RawMessage
+	err   error
+	input *usecase.ExplainInput
+}
+
+func (f *fakeDiagnosticsUsecase) ExplainQuery(ctx context.Context, input usecase.ExplainInput) (json.RawMessage, error) {
+	f.input = &input
+	return f.plan, f.err
+}
+
+func TestDebugHandler_Explain(t *testing.T) {
+	plan := json.RawMessage(`{"query_block":{"select_id":1}}`)
+
+	tests := []struct {
+		name           string
+		query          string
+		err            error
+		expectedStatus int
+		expectedCode   string
+		expectExplain  bool
+	}{
+		{name: "正常系: 絞り込み条件を渡して実行計画を返す", query: "q=list&category=時計", expectedStatus: http.StatusOK, expectExplain: true},
+		{name: "正常系: IDを渡す", query: "q=item&id=3", expectedStatus: http.StatusOK, expectExplain: true},
+		{name: "異常系: 不正なID", query: "q=item&id=abc", expectedStatus: http.StatusBadRequest, expectedCode: itemController.CodeInvalidParameter},
+		{name: "異常系: 不正な絞り込み条件", query: "q=list&created_since=last+week", expectedStatus: http.StatusBadRequest, expectedCode: itemController.CodeInvalidParameter},
+		{
+			name:           "異常系: 不明なクエリ名",
+			query:          "q=delete",
+			err:            fmt.Errorf("%w: q must be one of: list, item, summary", domainErrors.ErrInvalidInput),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   itemController.CodeInvalidParameter,
+			expectExplain:  true,
+		},
+		{
+			name:           "異常系: EXPLAINの失敗",
+			query:          "q=summary",
+			err:            errors.New("connection refused"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   itemController.CodeInternalError,
+			expectExplain:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics := &fakeDiagnosticsUsecase{plan: plan, err: tt.err}
+			handler := NewDebugHandler(diagnostics)
+
+			req := httptest.NewRequest(http.MethodGet, "/debug/explain?"+(&url.URL{RawQuery: tt.query}).Query().Encode(), nil)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			require.NoError(t, handler.Explain(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectExplain, diagnostics.input != nil)
+			if tt.expectedCode != "" {
+				var response itemController.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedCode, response.Code)
+				// DBのエラーの内容は返さない
+				assert.NotContains(t, rec.Body.String(), "connection refused")
+				return
+			}
+			assert.JSONEq(t, `{"query":"`+diagnostics.input.Query+`","plan":{"query_block":{"select_id":1}}}`, rec.Body.String())
+		})
+	}
+
+	t.Run("正常系: 一覧の絞り込み条件とIDを入力に含める", func(t *testing.T) {
+		diagnostics := &fakeDiagnosticsUsecase{plan: plan}
+		req := httptest.NewRequest(http.MethodGet, "/debug/explain?q=list&category="+url.QueryEscape("時計")+"&id=3", nil)
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+
+		require.NoError(t, NewDebugHandler(diagnostics).Explain(c))
+		require.NotNil(t, diagnostics.input)
+		assert.Equal(t, usecase.ExplainQueryList, diagnostics.input.Query)
+		assert.Equal(t, []entity.Category{entity.CategoryWatch}, diagnostics.input.Filter.Categories)
+		assert.Equal(t, int64(3), diagnostics.input.ID)
+	})
+}
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func (h *ItemHandler) ArchiveItem(c echo.Context) error {
+	return h.changeItemStatus(c, h.itemUsecase.ArchiveItem)
+}
+
+func (h *ItemHandler) UnarchiveItem(c echo.Context) error {
+	return h.changeItemStatus(c, h.itemUsecase.UnarchiveItem)
+}
+
+// アーカイブ・アーカイブ解除で共通のレスポンス
+func (h *ItemHandler) changeItemStatus(c echo.Context, change func(ctx context.Context, id int64) (*entity.Item, error)) error {
+	id, err := h.parseItemID(c)
+	if err != nil {
+		return respondItemIDError(c, err, "invalid item ID")
+	}
+
+	item, err := change(c.Request().Context(), id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return c.JSON(http.StatusNotFound, ErrorResponse{
+				Code:  CodeItemNotFound,
+				Error: "item not found",
+			})
+		}
+		if domainErrors.IsInvalidStatusTransitionError(err) {
+			return c.JSON(http.StatusConflict, ErrorResponse{
+				Code:    CodeInvalidStatusTransition,
+				Error:   "invalid status transition",
+				Details: []string{err.Error()},
+			})
+		}
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:  CodeInvalidParameter,
+				Error: "invalid item ID",
+			})
+		}
+		return respondInternalError(c, err, "failed to update item")
+	}
+
+	return respondWithItem(c, http.StatusOK, item, newItemResponse(item, h.priceLocaleFor(c)))
+}
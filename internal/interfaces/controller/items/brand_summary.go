@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// ブランド別集計のレスポンス
+type BrandSummaryResponse struct {
+	Brands []usecase.BrandSummaryEntry `json:"brands"`
+}
+
+// GET /brands/summary?category=
+func (h *ItemHandler) GetBrandSummary(c echo.Context) error {
+	filter, err := ParseItemFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{err.Error()},
+		})
+	}
+
+	entries, err := h.itemUsecase.GetBrandSummary(c.Request().Context(), filter)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{err.Error()},
+			})
+		}
+		return respondInternalError(c, err, "failed to retrieve summary")
+	}
+
+	return c.JSON(http.StatusOK, BrandSummaryResponse{Brands: entries})
+}
@@ -0,0 +1,212 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// WithBulkMaxItemsを指定しない場合の、一括操作1回あたりの最大件数
+const DefaultBulkMaxItems = 500
+
+// 配列の要素数が上限を超えた
+var errTooManyBulkItems = errors.New("too many items")
+
+// 一括操作1回あたりの最大件数を指定する（0以下の場合は無制限）
+func WithBulkMaxItems(maxItems int) HandlerOption {
+	return func(h *ItemHandler) {
+		h.bulkMaxItems = maxItems
+	}
+}
+
+// 一括登録のモード（?mode=）
+const (
+	bulkModeAtomic     = "atomic"      // 全件成功した場合のみ登録する（デフォルト）
+	bulkModeBestEffort = "best_effort" // 有効な要素のみ登録し、要素ごとの結果を返す
+)
+
+type BulkCreateItemsResponse struct {
+	Items []ItemResponse `json:"items"`
+}
+
+// best_effort での要素ごとの結果（登録した場合はid、失敗した場合はerror）
+type BulkItemResultResponse struct {
+	Index int            `json:"index"`
+	ID    *int64         `json:"id,omitempty"`
+	Error *ErrorResponse `json:"error,omitempty"`
+}
+
+type BulkCreateItemsResultsResponse struct {
+	Created int                      `json:"created"`
+	Failed  int                      `json:"failed"`
+	Results []BulkItemResultResponse `json:"results"`
+}
+
+// JSON配列で受け取った複数のアイテムを1トランザクションで登録する
+// ?mode=best_effort の場合は、有効な要素のみを登録する
+func (h *ItemHandler) BulkCreateItems(c echo.Context) error {
+	mode := c.QueryParam("mode")
+	if mode != "" && mode != bulkModeAtomic && mode != bulkModeBestEffort {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{"mode must be one of: atomic, best_effort"},
+		})
+	}
+
+	inputs, err := decodeBulkItems(jsonRequestBody(c, c.Request().Body), h.bulkMaxItems)
+	if err != nil {
+		if errors.Is(err, errTooManyBulkItems) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeValidationFailed,
+				Error:   "validation failed",
+				Details: []string{fmt.Sprintf("items must contain %d entries or less", h.bulkMaxItems)},
+			})
+		}
+		if detail, ok := priceTypeError(err); ok {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeValidationFailed,
+				Error:   "validation failed",
+				Details: []string{detail},
+			})
+		}
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidRequest,
+			Error:   "invalid request format",
+			Details: []string{err.Error()},
+		})
+	}
+
+	if mode == bulkModeBestEffort {
+		return h.bulkCreateItemsBestEffort(c, inputs)
+	}
+
+	var validationErrors []string
+	for index, input := range inputs {
+		for _, message := range h.validateCreateItemInput(input) {
+			validationErrors = append(validationErrors, fmt.Sprintf("items[%d]: %s", index, message))
+		}
+	}
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeValidationFailed,
+			Error:   "validation failed",
+			Details: validationErrors,
+		})
+	}
+
+	items, err := h.itemUsecase.BulkCreateItems(c.Request().Context(), inputs)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeValidationFailed,
+				Error:   "validation failed",
+				Details: []string{err.Error()},
+			})
+		}
+		if domainErrors.IsDuplicateEntryError(err) {
+			return c.JSON(http.StatusConflict, duplicateEntryResponse(err))
+		}
+		return respondInternalError(c, err, "failed to create items")
+	}
+
+	return c.JSON(http.StatusCreated, BulkCreateItemsResponse{Items: newItemResponses(items, h.priceLocaleFor(c))})
+}
+
+// 要素ごとに登録し、登録できなかった要素はエラーを結果に含める
+// 1件でも登録した場合は201、1件も登録できなかった場合は200を返す
+func (h *ItemHandler) bulkCreateItemsBestEffort(c echo.Context, inputs []usecase.CreateItemInput) error {
+	results, err := h.itemUsecase.BulkCreateItemsBestEffort(c.Request().Context(), inputs)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeValidationFailed,
+				Error:   "validation failed",
+				Details: []string{err.Error()},
+			})
+		}
+		return respondInternalError(c, err, "failed to create items")
+	}
+
+	response := BulkCreateItemsResultsResponse{Results: make([]BulkItemResultResponse, len(results))}
+	for index, result := range results {
+		response.Results[index].Index = index
+		if result.Err != nil {
+			response.Failed++
+			itemErr := bulkItemErrorResponse(result.Err)
+			response.Results[index].Error = &itemErr
+			continue
+		}
+		response.Created++
+		response.Results[index].ID = &result.Item.ID
+	}
+
+	if response.Created > 0 {
+		return c.JSON(http.StatusCreated, response)
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// 要素ごとのエラー（予期しないエラーの内容は返さない）
+func bulkItemErrorResponse(err error) ErrorResponse {
+	switch {
+	case domainErrors.IsValidationError(err):
+		return ErrorResponse{Code: CodeValidationFailed, Error: err.Error()}
+	case domainErrors.IsDuplicateEntryError(err):
+		response := duplicateEntryResponse(err)
+		if len(response.Details) > 0 {
+			return ErrorResponse{Code: response.Code, Error: response.Details[0]}
+		}
+		return response
+	case domainErrors.IsDatabaseUnavailableError(err):
+		return ErrorResponse{Code: CodeDatabaseUnavailable, Error: "database is temporarily unavailable"}
+	case domainErrors.IsDatabaseBusyError(err):
+		return ErrorResponse{Code: CodeDatabaseBusy, Error: "database is busy"}
+	default:
+		return ErrorResponse{Code: CodeInternalError, Error: "failed to create item"}
+	}
+}
+
+// リクエストボディのJSON配列を1件ずつ読み込む
+// maxItemsを超えた時点で読み込みをやめ、errTooManyBulkItemsを返す（0以下の場合は無制限）
+func decodeBulkItems(r io.Reader, maxItems int) ([]usecase.CreateItemInput, error) {
+	decoder := json.NewDecoder(r)
+
+	token, err := decoder.Token()
+	if err == io.EOF {
+		return nil, errors.New("request body is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, errors.New("request body must be a JSON array")
+	}
+
+	var inputs []usecase.CreateItemInput
+	for decoder.More() {
+		if maxItems > 0 && len(inputs) >= maxItems {
+			return nil, errTooManyBulkItems
+		}
+		var input usecase.CreateItemInput
+		if err := decoder.Decode(&input); err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, input)
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	if len(inputs) == 0 {
+		return nil, errors.New("request body must contain at least one item")
+	}
+	return inputs, nil
+}
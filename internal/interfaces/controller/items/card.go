@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 共有用カードに含められるフィールド
+// 購入価格やシリアル番号などの内部情報は含めない
+var cardFieldValues = map[string]func(*entity.Item) interface{}{
+	"id":            func(item *entity.Item) interface{} { return responseItemID(item.ID, item.UUID) },
+	"name":          func(item *entity.Item) interface{} { return item.Name },
+	"brand":         func(item *entity.Item) interface{} { return item.Brand },
+	"category":      func(item *entity.Item) interface{} { return item.Category },
+	"purchase_date": func(item *entity.Item) interface{} { return item.PurchaseDate },
+}
+
+// カードのフィールドが指定されていない場合に含めるフィールド
+var DefaultCardFields = []string{"name", "brand", "category"}
+
+// カードに含めるフィールドを指定する
+func WithCardFields(fields []string) HandlerOption {
+	return func(h *ItemHandler) {
+		h.cardFields = fields
+	}
+}
+
+// カードに含めるフィールドを検証する（空の場合はDefaultCardFields）
+func ParseCardFields(fields []string) ([]string, error) {
+	if len(fields) == 0 {
+		return DefaultCardFields, nil
+	}
+
+	parsed := make([]string, 0, len(fields))
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if _, ok := cardFieldValues[field]; !ok {
+			return nil, fmt.Errorf("unknown card field: %s (must be one of: id, name, brand, category, purchase_date)", field)
+		}
+		if !seen[field] {
+			seen[field] = true
+			parsed = append(parsed, field)
+		}
+	}
+	return parsed, nil
+}
+
+// 共有用に項目を絞り込んだアイテムの表現
+func newItemCard(item *entity.Item, fields []string) map[string]interface{} {
+	card := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		card[field] = cardFieldValues[field](item)
+	}
+	return card
+}
+
+func (h *ItemHandler) GetItemCard(c echo.Context) error {
+	id, err := h.parseItemID(c)
+	if err != nil {
+		return respondItemIDError(c, err, "invalid item ID")
+	}
+
+	item, err := h.itemUsecase.GetItemByID(c.Request().Context(), id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return c.JSON(http.StatusNotFound, ErrorResponse{
+				Code:  CodeItemNotFound,
+				Error: "item not found",
+			})
+		}
+		return respondInternalError(c, err, "failed to retrieve item")
+	}
+
+	return h.respondProjected(c, http.StatusOK, newItemCard(item, h.cardFields))
+}
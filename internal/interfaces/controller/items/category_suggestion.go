@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// カテゴリーの推定のレスポンス（推定できない場合はcategoryがnull、confidenceが0）
+type CategorySuggestionResponse struct {
+	Name            string   `json:"name"`
+	Category        *string  `json:"category"`
+	Confidence      float64  `json:"confidence"`
+	MatchedKeywords []string `json:"matched_keywords"`
+}
+
+func newCategorySuggestionResponse(name string, suggestion *usecase.CategorySuggestion) CategorySuggestionResponse {
+	res := CategorySuggestionResponse{Name: name, MatchedKeywords: []string{}}
+	if suggestion != nil {
+		category := suggestion.Category.String()
+		res.Category = &category
+		res.Confidence = suggestion.Confidence
+		if suggestion.MatchedKeywords != nil {
+			res.MatchedKeywords = suggestion.MatchedKeywords
+		}
+	}
+	return res
+}
+
+// GET /items/suggest-category?name=（登録時の入力補助で、推定したカテゴリーは保存しない）
+func (h *ItemHandler) SuggestCategory(c echo.Context) error {
+	name := c.QueryParam("name")
+	suggestion, err := h.itemUsecase.SuggestCategory(c.Request().Context(), name)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{err.Error()},
+			})
+		}
+		return respondInternalError(c, err, "failed to suggest category")
+	}
+
+	return c.JSON(http.StatusOK, newCategorySuggestionResponse(name, suggestion))
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestItemHandler_SuggestCategory(t *testing.T) {
+	tests := []struct {
+		name           string
+		itemName       string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:     "正常系: 推定したカテゴリー",
+			itemName: "Submariner",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("SuggestCategory", mock.Anything, "Submariner").Return(&usecase.CategorySuggestion{
+					Category: entity.CategoryWatch, Confidence: 0.9, MatchedKeywords: []string{"submariner"},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":"Submariner","category":"時計","confidence":0.9,"matched_keywords":["submariner"]}`,
+		},
+		{
+			name:     "正常系: 推定できない場合はnull",
+			itemName: "Paperweight",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("SuggestCategory", mock.Anything, "Paperweight").Return(nil, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":"Paperweight","category":null,"confidence":0,"matched_keywords":[]}`,
+		},
+		{
+			name:     "異常系: 名前が空",
+			itemName: "",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("SuggestCategory", mock.Anything, "").Return(nil, domainErrors.ErrInvalidInput)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/items/suggest-category?name="+url.QueryEscape(tt.itemName), nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, handler.SuggestCategory(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
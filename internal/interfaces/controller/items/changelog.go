@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// GET /items/changelog?since=&limit=（sinceは前回の最後のエントリーのseq、省略時は先頭から）
+func (h *ItemHandler) GetChangeLog(c echo.Context) error {
+	var since int64
+	if value := c.QueryParam("since"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{"since must be a sequence number"},
+			})
+		}
+		since = parsed
+	}
+	limit := usecase.DefaultPageLimit
+	if value := c.QueryParam("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{"limit must be an integer"},
+			})
+		}
+		limit = parsed
+	}
+
+	page, err := h.itemUsecase.GetChangeLog(c.Request().Context(), since, limit)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{err.Error()},
+			})
+		}
+		// 取りこぼしがあるため、GET /items/changes?sync_token= などで全件を取得し直す必要がある
+		if domainErrors.IsChangeLogExpiredError(err) {
+			return c.JSON(http.StatusGone, ErrorResponse{
+				Code:    CodeChangeLogExpired,
+				Error:   "change log expired",
+				Details: []string{err.Error()},
+			})
+		}
+		return respondInternalError(c, err, "failed to retrieve change log")
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
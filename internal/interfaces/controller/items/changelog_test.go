@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestItemHandler_GetChangeLog(t *testing.T) {
+	page := &usecase.ChangeLogPage{
+		Entries: []usecase.ChangeLogEntry{
+			{Seq: 11, ItemID: 1, Operation: usecase.ChangeUpdated, ChangedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		},
+		LatestSeq: 11,
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "正常系: sinceより後の変更履歴",
+			query: "?since=10&limit=50",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetChangeLog", mock.Anything, int64(10), 50).Return(page, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"entries":[{"seq":11,"item_id":1,"operation":"updated","changed_at":"2024-01-02T03:04:05Z"}],"latest_seq":11,"has_more":false}`,
+		},
+		{
+			name:  "正常系: 省略時は先頭から",
+			query: "",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetChangeLog", mock.Anything, int64(0), usecase.DefaultPageLimit).Return(page, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: 数値でないsince",
+			query:          "?since=abc",
+			setupMock:      func(m *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "異常系: 保持期間を過ぎて削除された変更履歴",
+			query: "?since=3",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetChangeLog", mock.Anything, int64(3), usecase.DefaultPageLimit).
+					Return(nil, fmt.Errorf("%w: entries up to 4 have been pruned", domainErrors.ErrChangeLogExpired))
+			},
+			expectedStatus: http.StatusGone,
+		},
+		{
+			name:  "異常系: 範囲外のlimit",
+			query: "?limit=0",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetChangeLog", mock.Anything, int64(0), 0).
+					Return(nil, fmt.Errorf("%w: limit must be between 1 and 100", domainErrors.ErrInvalidInput))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/items/changelog"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, handler.GetChangeLog(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+			if tt.expectedStatus == http.StatusGone {
+				assert.Contains(t, rec.Body.String(), CodeChangeLogExpired)
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// ダッシュボードのレスポンス（?include= で指定しなかった項目は含めない）
+type DashboardResponse struct {
+	Summary *usecase.CategorySummary `json:"summary,omitempty"`
+	Stats   *usecase.Diversity       `json:"stats,omitempty"`
+	Recent  *[]ItemResponse          `json:"recent,omitempty"` // 指定した場合はアイテムが無くても空の配列
+	Top     *[]ItemResponse          `json:"top,omitempty"`
+}
+
+// GET /items/dashboard?include=summary,stats,recent,top&limit=（includeの省略時はすべて、limitはrecent・topの件数）
+func (h *ItemHandler) GetDashboard(c echo.Context) error {
+	sections, err := usecase.ParseDashboardSections(strings.Split(c.QueryParam("include"), ","))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{err.Error()},
+		})
+	}
+	limit := usecase.DefaultDashboardItems
+	if value := c.QueryParam("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{"limit must be an integer"},
+			})
+		}
+		limit = parsed
+	}
+
+	dashboard, err := h.itemUsecase.GetDashboard(c.Request().Context(), sections, limit)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{err.Error()},
+			})
+		}
+		return respondInternalError(c, err, "failed to retrieve dashboard")
+	}
+
+	res := DashboardResponse{Summary: dashboard.Summary, Stats: dashboard.Stats}
+	locale := h.priceLocaleFor(c)
+	for _, section := range sections {
+		switch section {
+		case usecase.DashboardRecent:
+			recent := newItemResponses(dashboard.Recent, locale)
+			res.Recent = &recent
+		case usecase.DashboardTop:
+			top := newItemResponses(dashboard.Top, locale)
+			res.Top = &top
+		}
+	}
+	return h.respondProjected(c, http.StatusOK, res)
+}
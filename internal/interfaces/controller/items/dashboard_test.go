@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestItemHandler_GetDashboard(t *testing.T) {
+	summary := &usecase.CategorySummary{Categories: map[string]int{"時計": 1}, Total: 1, TotalPurchasePrice: entity.JPY(1000000)}
+
+	tests := []struct {
+		name             string
+		query            string
+		setupMock        func(*MockItemUsecase)
+		expectedStatus   int
+		expectedContains []string
+		expectedMissing  []string
+	}{
+		{
+			name:  "正常系: 省略時はすべての項目",
+			query: "",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetDashboard", mock.Anything, usecase.DashboardSections, usecase.DefaultDashboardItems).
+					Return(&usecase.Dashboard{Summary: summary, Stats: &usecase.Diversity{}}, nil)
+			},
+			expectedStatus:   http.StatusOK,
+			expectedContains: []string{`"summary":`, `"stats":`, `"recent":[]`, `"top":[]`},
+		},
+		{
+			name:  "正常系: 指定した項目のみ",
+			query: "?include=summary,top&limit=3",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetDashboard", mock.Anything, []usecase.DashboardSection{usecase.DashboardSummary, usecase.DashboardTop}, 3).
+					Return(&usecase.Dashboard{Summary: summary}, nil)
+			},
+			expectedStatus:   http.StatusOK,
+			expectedContains: []string{`"summary":`, `"top":[]`},
+			expectedMissing:  []string{`"stats"`, `"recent"`},
+		},
+		{
+			name:           "異常系: 不明な項目",
+			query:          "?include=summary,users",
+			setupMock:      func(m *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 数値でないlimit",
+			query:          "?limit=abc",
+			setupMock:      func(m *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "異常系: 範囲外のlimit",
+			query: "?limit=0",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetDashboard", mock.Anything, usecase.DashboardSections, 0).
+					Return(nil, fmt.Errorf("%w: limit must be between 1 and 20", domainErrors.ErrInvalidInput))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "異常系: データベースエラー",
+			query: "",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetDashboard", mock.Anything, usecase.DashboardSections, usecase.DefaultDashboardItems).
+					Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/items/dashboard"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, handler.GetDashboard(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			for _, expected := range tt.expectedContains {
+				assert.Contains(t, rec.Body.String(), expected)
+			}
+			for _, missing := range tt.expectedMissing {
+				assert.NotContains(t, rec.Body.String(), missing)
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_GetDashboard_HiddenFields(t *testing.T) {
+	item := &entity.Item{ID: 1, Name: "デイトナ", Category: entity.CategoryWatch, Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15", SerialNumber: "SN-1"}
+
+	tests := []struct {
+		name          string
+		revealToken   string
+		expectedShown bool
+	}{
+		{name: "正常系: 非表示のフィールドを除外する", expectedShown: false},
+		{name: "正常系: トークンを指定した場合は除外しない", revealToken: "secret", expectedShown: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			mockUsecase.On("GetDashboard", mock.Anything, []usecase.DashboardSection{usecase.DashboardRecent, usecase.DashboardTop}, usecase.DefaultDashboardItems).
+				Return(&usecase.Dashboard{Recent: []*entity.Item{item}, Top: []*entity.Item{item}}, nil)
+			handler := NewItemHandler(mockUsecase, WithHiddenFields([]string{"purchase_price", "serial_number"}, "secret"))
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/items/dashboard?include=recent,top", nil)
+			if tt.revealToken != "" {
+				req.Header.Set(headerRevealFields, tt.revealToken)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, handler.GetDashboard(c))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"name":"デイトナ"`)
+			for _, key := range []string{`"purchase_price"`, `"purchase_price_display"`, `"serial_number"`} {
+				if tt.expectedShown {
+					assert.Contains(t, rec.Body.String(), key)
+				} else {
+					assert.NotContains(t, rec.Body.String(), key)
+				}
+			}
+			assert.Contains(t, rec.Header().Values(echo.HeaderVary), headerRevealFields)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// POST /items/delete-by-filter のリクエスト形式
+// filterは POST /items/search と同じ形式（sort, limit, offset は指定できない）
+type DeleteByFilterRequest struct {
+	Filter    SearchItemsRequest `json:"filter"`
+	Confirm   bool               `json:"confirm"`
+	DeleteAll bool               `json:"delete_all"`
+	Reason    string             `json:"reason"`
+}
+
+type DeleteByFilterResponse struct {
+	Deleted int `json:"deleted"`
+}
+
+// 条件に一致するアイテムを1トランザクションで論理削除し、削除した件数を返す
+// "confirm": true が無い場合や、条件が空で "delete_all": true が無い場合は削除しない
+func (h *ItemHandler) DeleteItemsByFilter(c echo.Context) error {
+	req, err := decodeDeleteByFilterRequest(jsonRequestBody(c, c.Request().Body))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidRequest,
+			Error:   "invalid request format",
+			Details: []string{err.Error()},
+		})
+	}
+	if req.Filter.Sort != "" || req.Filter.Limit != nil || req.Filter.Offset != 0 {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid search condition",
+			Details: []string{"sort, limit and offset cannot be used to delete items"},
+		})
+	}
+
+	filter, _, err := req.Filter.toFilter()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid search condition",
+			Details: []string{err.Error()},
+		})
+	}
+
+	deleted, err := h.itemUsecase.DeleteItemsByFilter(c.Request().Context(), filter, usecase.DeleteByFilterInput{
+		Confirm:   req.Confirm,
+		DeleteAll: req.DeleteAll,
+		Reason:    strings.TrimSpace(req.Reason),
+	})
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeValidationFailed,
+				Error:   "validation failed",
+				Details: []string{err.Error()},
+			})
+		}
+		return respondInternalError(c, err, "failed to delete items")
+	}
+
+	return c.JSON(http.StatusOK, DeleteByFilterResponse{Deleted: deleted})
+}
+
+// 削除の条件のJSONを読み込む
+// 綴りを誤った条件を無視して削除する範囲が広がらないよう、未知のフィールドはエラーにする
+func decodeDeleteByFilterRequest(r io.Reader) (DeleteByFilterRequest, error) {
+	var req DeleteByFilterRequest
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&req)
+	return req, err
+}
@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 減価償却の見込みのレスポンス形式（ID_TYPE=uuid の場合はitem_idにUUIDを返す）
+type DepreciationResponse struct {
+	*usecase.DepreciationSchedule
+	ItemID interface{} `json:"item_id"`
+}
+
+// GET /items/:id/depreciation?years=5&salvage=100000
+// yearsは必須、salvage（残存価額）は省略時0
+func (h *ItemHandler) GetItemDepreciation(c echo.Context) error {
+	id, err := h.parseItemID(c)
+	if err != nil {
+		return respondItemIDError(c, err, "invalid item ID")
+	}
+
+	years, err := strconv.Atoi(c.QueryParam("years"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{"years must be an integer"},
+		})
+	}
+	var salvage int64
+	if value := c.QueryParam("salvage"); value != "" {
+		salvage, err = strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{"salvage must be an integer"},
+			})
+		}
+	}
+
+	schedule, err := h.itemUsecase.GetDepreciationSchedule(c.Request().Context(), id, years, salvage)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return c.JSON(http.StatusNotFound, ErrorResponse{
+				Code:  CodeItemNotFound,
+				Error: "item not found",
+			})
+		}
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{err.Error()},
+			})
+		}
+		return respondInternalError(c, err, "failed to compute depreciation")
+	}
+
+	return c.JSON(http.StatusOK, DepreciationResponse{
+		DepreciationSchedule: schedule,
+		ItemID:               h.pathResponseID(c, schedule.ItemID),
+	})
+}
@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// GET /items/diversity（絞り込みは GET /items と同じパラメータ）
+func (h *ItemHandler) GetDiversity(c echo.Context) error {
+	filter, err := ParseItemFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{err.Error()},
+		})
+	}
+
+	diversity, err := h.itemUsecase.GetDiversity(c.Request().Context(), filter)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{err.Error()},
+			})
+		}
+		return respondInternalError(c, err, "failed to retrieve diversity")
+	}
+
+	return c.JSON(http.StatusOK, diversity)
+}
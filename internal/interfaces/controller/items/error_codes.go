@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// エラーレスポンスの code（クライアントが分岐やメッセージの翻訳に使うため、既存の値は変更しない）
+const (
+	// 入力値のバリデーションエラー（domainErrors.ErrInvalidInput）
+	CodeValidationFailed = "VALIDATION_FAILED"
+	// JSONなどのリクエストボディの形式が不正
+	CodeInvalidRequest = "INVALID_REQUEST"
+	// CSVの形式が不正（必須列が無い、行数の上限超過など）
+	CodeInvalidCSV = "INVALID_CSV"
+	// パスパラメータ・クエリパラメータが不正
+	CodeInvalidParameter = "INVALID_PARAMETER"
+	// 日付として解釈できない（POST /util/parse-date）
+	CodeInvalidDate = "INVALID_DATE"
+	// カテゴリーごとに必須としたフィールドが無い（domainErrors.ErrMissingRequiredFields）
+	CodeMissingRequiredFields = "MISSING_REQUIRED_FIELDS"
+	// 復元するアイテムの日時がサーバーの時刻から許容範囲を外れている（domainErrors.ErrTimestampOutOfRange）
+	CodeTimestampOutOfRange = "TIMESTAMP_OUT_OF_RANGE"
+	// アイテムが存在しない（domainErrors.ErrItemNotFound）
+	CodeItemNotFound = "ITEM_NOT_FOUND"
+	// アイテムは存在したが削除されている（GET /items/{id}、domainErrors.ErrItemDeleted）
+	CodeItemDeleted = "ITEM_DELETED"
+	// 画像が存在しない（domainErrors.ErrImageNotFound）
+	CodeImageNotFound = "IMAGE_NOT_FOUND"
+	// 名前を付けて保存した検索条件が存在しない（domainErrors.ErrPresetNotFound）
+	CodePresetNotFound = "PRESET_NOT_FOUND"
+	// 保存した検索条件が現在の検索の形式として解釈できない（削除されたフィールドなど）
+	CodeInvalidPreset = "INVALID_PRESET"
+	// 指定したバックアップファイルが存在しない
+	CodeBackupNotFound = "BACKUP_NOT_FOUND"
+	// 集計のジョブが存在しない（保持期間が過ぎたものを含む）
+	CodeSummaryJobNotFound = "SUMMARY_JOB_NOT_FOUND"
+	// シリアル番号などが既存のアイテムと重複する（domainErrors.ErrDuplicateEntry）
+	CodeItemAlreadyExists = "ITEM_ALREADY_EXISTS"
+	// If-Match / If-Unmodified-Since の条件を満たさない（domainErrors.ErrPreconditionFailed）
+	CodePreconditionFailed = "PRECONDITION_FAILED"
+	// 条件付き更新（PATCHのif）で、アイテムの現在の値が条件に一致しない（domainErrors.ErrUpdateConditionFailed）
+	CodeConditionNotMet = "CONDITION_NOT_MET"
+	// 指定したシーケンス番号より後の変更履歴が保持期間を過ぎて削除されている（domainErrors.ErrChangeLogExpired）
+	CodeChangeLogExpired = "CHANGE_LOG_EXPIRED"
+	// 現在の状態からは変更できない（下書きのアーカイブなど、domainErrors.ErrInvalidStatusTransition）
+	CodeInvalidStatusTransition = "INVALID_STATUS_TRANSITION"
+	// アイテムに登録できる画像の上限に達している（domainErrors.ErrTooManyImages）
+	CodeTooManyImages = "TOO_MANY_IMAGES"
+	// リクエストボディが上限を超える
+	CodePayloadTooLarge = "PAYLOAD_TOO_LARGE"
+	// 書き込みのリクエストのContent-Typeが受け付ける形式でない
+	CodeUnsupportedMediaType = "UNSUPPORTED_MEDIA_TYPE"
+	// 管理者用のエンドポイントの認証に失敗した
+	CodeUnauthorized = "UNAUTHORIZED"
+	// 存在しないパス
+	CodeRouteNotFound = "ROUTE_NOT_FOUND"
+	// パスに対応していないメソッド
+	CodeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+	// 同時実行数の上限に達している
+	CodeServerBusy = "SERVER_BUSY"
+	// 処理がタイムアウトした
+	CodeRequestTimeout = "REQUEST_TIMEOUT"
+	// DBに接続できない一時的な障害（domainErrors.ErrDatabaseUnavailable）
+	CodeDatabaseUnavailable = "DATABASE_UNAVAILABLE"
+	// DBのコネクションプールが埋まっている過負荷（domainErrors.ErrDatabaseBusy）
+	CodeDatabaseBusy = "DATABASE_BUSY"
+	// サーバー内部のエラー（domainErrors.ErrDatabaseError など）
+	CodeInternalError = "INTERNAL_ERROR"
+)
+
+// 存在しないリソースの種類ごとの404レスポンス
+// サブリソースを追加する場合は、専用のドメインのエラーとコードを定義してここに追加する
+var notFoundResponses = []struct {
+	err     error
+	code    string
+	message string
+}{
+	{err: domainErrors.ErrItemNotFound, code: CodeItemNotFound, message: "item not found"},
+	{err: domainErrors.ErrImageNotFound, code: CodeImageNotFound, message: "image not found"},
+	{err: domainErrors.ErrPresetNotFound, code: CodePresetNotFound, message: "search preset not found"},
+}
+
+// 存在しないリソースを表すエラーの場合は、その種類のコードを含む404レスポンスを返す（それ以外はfalse）
+// 親のアイテムが存在しない場合はサブリソースのパスでも ITEM_NOT_FOUND になる
+func notFoundResponse(err error) (ErrorResponse, int, bool) {
+	for _, r := range notFoundResponses {
+		if errors.Is(err, r.err) {
+			return ErrorResponse{Code: r.code, Error: r.message}, http.StatusNotFound, true
+		}
+	}
+	return ErrorResponse{}, 0, false
+}
+
+// カテゴリーごとに必須としたフィールドが無いエラーの場合は、無いフィールドを列挙した422レスポンスを返す（それ以外はfalse）
+func missingRequiredFieldsResponse(err error) (ErrorResponse, bool) {
+	var missingErr *domainErrors.MissingRequiredFieldsError
+	if !errors.As(err, &missingErr) {
+		return ErrorResponse{}, false
+	}
+	return ErrorResponse{
+		Code:    CodeMissingRequiredFields,
+		Error:   "missing required fields for category",
+		Details: missingErr.Fields,
+	}, true
+}
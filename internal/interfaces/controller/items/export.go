@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 一覧の帳票の列（右端を揃える列はalignRight）
+type reportColumn struct {
+	title      string
+	x          float64 // 左端（alignRightの場合は右端）
+	width      float64
+	alignRight bool
+	field      string // HIDDEN_FIELDS で非表示にした場合に除外する列のキー（常に表示する列は空）
+	value      func(item *entity.Item, locale language.Tag) string
+}
+
+var inventoryReportColumns = []reportColumn{
+	{title: "ID", x: 70, width: 30, alignRight: true, value: func(item *entity.Item, _ language.Tag) string { return item.PublicID() }},
+	{title: "名前", x: 80, width: 160, value: func(item *entity.Item, _ language.Tag) string { return item.Name }},
+	{title: "カテゴリー", x: 246, width: 60, value: func(item *entity.Item, _ language.Tag) string { return item.Category.String() }},
+	{title: "ブランド", x: 312, width: 90, value: func(item *entity.Item, _ language.Tag) string { return item.Brand }},
+	{title: "購入日", x: 408, width: 55, field: "purchase_date", value: func(item *entity.Item, _ language.Tag) string { return item.PurchaseDate }},
+	{title: "購入価格", x: 555, width: 85, alignRight: true, field: "purchase_price", value: func(item *entity.Item, locale language.Tag) string {
+		return formatMoney(item.PurchasePrice, locale)
+	}},
+}
+
+// 帳票のレイアウト（ポイント）
+const (
+	reportMarginLeft  = 40.0
+	reportMarginRight = 555.0
+	reportTitleSize   = 16.0
+	reportFontSize    = 9.0
+	reportRowHeight   = 16.0
+	reportFirstRowY   = 740.0 // 表の最初の行のベースライン
+	reportLastRowY    = 60.0  // これより下には行を描画しない
+)
+
+// 書き出しの形式（?format=）
+const (
+	exportFormatPDF      = "pdf"       // 印刷用の一覧（デフォルト）
+	exportFormatFlatJSON = "flat-json" // ネストした値を持たないオブジェクトのJSON配列（スプレッドシート向け）
+)
+
+// 絞り込み条件に一致するアイテムを書き出す（?format=pdf|flat-json、省略時はPDF）
+// 保険の申請などに使う印刷用の一覧で、GET /items と同じ絞り込み条件を使える
+func (h *ItemHandler) ExportItems(c echo.Context) error {
+	format := strings.TrimSpace(c.QueryParam("format"))
+	if format != "" && format != exportFormatPDF && format != exportFormatFlatJSON {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{fmt.Sprintf("unsupported format: %s (must be one of: pdf, flat-json)", format)},
+		})
+	}
+	filter, err := ParseItemFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{err.Error()},
+		})
+	}
+
+	items, err := h.itemUsecase.GetAllItems(c.Request().Context(), filter)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{err.Error()},
+			})
+		}
+		return respondInternalError(c, err, "failed to retrieve items")
+	}
+
+	if format == exportFormatFlatJSON {
+		rows, err := h.newFlatItemResponses(c.Request().Context(), items, h.priceLocaleFor(c))
+		if err != nil {
+			return respondInternalError(c, err, "failed to retrieve images")
+		}
+		return h.respondProjected(c, http.StatusOK, rows)
+	}
+
+	now := entity.Now()
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "application/pdf")
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="items-%s.pdf"`, now.Format("20060102")))
+	// ヘッダーを送る前に、非表示のフィールドとVaryを決める
+	hidden := h.hiddenKeysFor(c)
+	res.WriteHeader(http.StatusOK)
+	return newInventoryReport(items, h.priceLocaleFor(c), now, hidden).writeTo(res)
+}
+
+// アイテムの一覧表（見出し・作成日時・合計）のPDFを組み立てる
+// hiddenに含まれる列は除外し、購入価格を除外した場合は金額の合計も表示しない
+func newInventoryReport(items []*entity.Item, locale language.Tag, now time.Time, hidden map[string]bool) *pdfDocument {
+	doc := &pdfDocument{title: "所持品一覧", createdAt: now}
+	columns := make([]reportColumn, 0, len(inventoryReportColumns))
+	for _, column := range inventoryReportColumns {
+		if column.field == "" || !hidden[column.field] {
+			columns = append(columns, column)
+		}
+	}
+
+	page := doc.addPage()
+	page.text(reportMarginLeft, 790, reportTitleSize, doc.title)
+	page.text(reportMarginLeft, 772, reportFontSize, fmt.Sprintf("作成日時: %s　件数: %d件", now.Format("2006-01-02 15:04"), len(items)))
+	y := startReportTable(page, columns)
+
+	for _, item := range items {
+		if y < reportLastRowY {
+			page = doc.addPage()
+			y = startReportTable(page, columns)
+		}
+		for _, column := range columns {
+			drawReportCell(page, column, y, column.value(item, locale))
+		}
+		y -= reportRowHeight
+	}
+
+	// 合計は通貨ごと（登録されている順）に表示する
+	var currencies []string
+	totals := make(map[string]entity.Money)
+	for _, item := range items {
+		if hidden["purchase_price"] {
+			break
+		}
+		total, exists := totals[item.PurchasePrice.Currency]
+		if !exists {
+			currencies = append(currencies, item.PurchasePrice.Currency)
+			total = entity.NewMoney(0, item.PurchasePrice.Currency)
+		}
+		totals[item.PurchasePrice.Currency], _ = total.Add(item.PurchasePrice)
+	}
+	if y-reportRowHeight*float64(len(currencies)) < reportLastRowY {
+		page = doc.addPage()
+		y = startReportTable(page, columns)
+	}
+	page.line(reportMarginLeft, y+reportRowHeight-4, reportMarginRight, y+reportRowHeight-4)
+	page.text(reportMarginLeft, y, reportFontSize, fmt.Sprintf("合計（%d件）", len(items)))
+	for _, code := range currencies {
+		page.textRight(reportMarginRight, y, reportFontSize, formatMoney(totals[code], locale))
+		y -= reportRowHeight
+	}
+
+	for i, p := range doc.pages {
+		p.textRight(reportMarginRight, 30, reportFontSize, fmt.Sprintf("%d / %d", i+1, len(doc.pages)))
+	}
+	return doc
+}
+
+// 表の見出しを描画して、最初の行のベースラインを返す
+func startReportTable(page *pdfPage, columns []reportColumn) float64 {
+	y := reportFirstRowY + reportRowHeight
+	for _, column := range columns {
+		drawReportCell(page, column, y, column.title)
+	}
+	page.line(reportMarginLeft, y-5, reportMarginRight, y-5)
+	return reportFirstRowY
+}
+
+// 列の幅に収まるように切り詰めて描画する
+func drawReportCell(page *pdfPage, column reportColumn, y float64, value string) {
+	value = pdfTruncate(value, reportFontSize, column.width)
+	if column.alignRight {
+		page.textRight(column.x, y, reportFontSize, value)
+		return
+	}
+	page.text(column.x, y, reportFontSize, value)
+}
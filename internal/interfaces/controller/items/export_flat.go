@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// ?format=flat-json の1件分（ネストした値と配列を持たない形式）
+// ネストした配列を扱えないスプレッドシートの取り込みツール向けで、すべてのアイテムが同じキーを同じ順序で持つ
+// HIDDEN_FIELDS で非表示にしたフィールドの列は、すべてのアイテムから除外する
+type FlatItemResponse struct {
+	ID                    interface{} `json:"id"` // ID_TYPE=uuid の場合はUUIDの文字列
+	Name                  string      `json:"name"`
+	Category              string      `json:"category"`
+	Brand                 string      `json:"brand"`
+	PurchasePriceAmount   int64       `json:"purchase_price_amount"`   // purchase_price.amount
+	PurchasePriceCurrency string      `json:"purchase_price_currency"` // purchase_price.currency
+	PurchasePriceDisplay  string      `json:"purchase_price_display"`
+	PurchaseDate          string      `json:"purchase_date"`
+	SerialNumber          string      `json:"serial_number"`
+	Status                string      `json:"status"`
+	CreatedAt             string      `json:"created_at"`
+	UpdatedAt             string      `json:"updated_at"`
+	DeletedAt             string      `json:"deleted_at"`
+	DeleteReason          string      `json:"delete_reason"`
+	ImageURL              string      `json:"image_url"` // 表示順で最初の画像（images[0]）
+}
+
+// アイテムを平坦な形式にする（値の無いフィールドはnullではなく空文字列）
+func newFlatItemResponse(item *entity.Item, imageURL string, locale language.Tag) FlatItemResponse {
+	flat := FlatItemResponse{
+		ID:                    responseItemID(item.ID, item.UUID),
+		Name:                  item.Name,
+		Category:              item.Category.String(),
+		Brand:                 item.Brand,
+		PurchasePriceAmount:   item.PurchasePrice.Amount,
+		PurchasePriceCurrency: item.PurchasePrice.Currency,
+		PurchasePriceDisplay:  formatMoney(item.PurchasePrice, locale),
+		PurchaseDate:          item.PurchaseDate,
+		SerialNumber:          item.SerialNumber,
+		Status:                item.Status.String(),
+		CreatedAt:             item.CreatedAt.In(entity.TimeZone()).Format(time.RFC3339),
+		UpdatedAt:             item.UpdatedAt.In(entity.TimeZone()).Format(time.RFC3339),
+		DeleteReason:          item.DeleteReason,
+		ImageURL:              imageURL,
+	}
+	if item.DeletedAt != nil {
+		flat.DeletedAt = item.DeletedAt.In(entity.TimeZone()).Format(time.RFC3339)
+	}
+	return flat
+}
+
+// 0件の場合も null ではなく [] になるよう空スライスを返す
+func (h *ItemHandler) newFlatItemResponses(ctx context.Context, items []*entity.Item, locale language.Tag) ([]FlatItemResponse, error) {
+	covers, err := h.coverImages(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]FlatItemResponse, 0, len(items))
+	for _, item := range items {
+		// 画像が無いアイテムは空文字列
+		var imageURL string
+		if cover, ok := covers[item.ID]; ok {
+			imageURL = cover.URL(item.PublicID())
+		}
+		responses = append(responses, newFlatItemResponse(item, imageURL, locale))
+	}
+	return responses, nil
+}
+
+// アイテムごとの最初の画像をまとめて取得する（画像を扱わない場合は空）
+func (h *ItemHandler) coverImages(ctx context.Context, items []*entity.Item) (map[int64]*entity.ItemImage, error) {
+	if h.imageUsecase == nil || len(items) == 0 {
+		return nil, nil
+	}
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return h.imageUsecase.ListCoverImages(ctx, ids)
+}
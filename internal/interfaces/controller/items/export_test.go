@@ -0,0 +1,238 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestItemHandler_ExportItems(t *testing.T) {
+	items := []*entity.Item{
+		{ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15"},
+		{ID: 2, Name: "Speedmaster", Category: "時計", Brand: "OMEGA", PurchasePrice: entity.NewMoney(650000, "USD"), PurchaseDate: "2022-06-01"},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+	}{
+		{
+			name:  "正常系: 絞り込んだアイテムのPDF",
+			query: "format=pdf&category=" + url.QueryEscape("時計"),
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetAllItems", mock.Anything, usecase.ItemFilter{Categories: []entity.Category{entity.CategoryWatch}}).Return(items, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "正常系: formatを省略",
+			query: "",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetAllItems", mock.Anything, usecase.ItemFilter{}).Return([]*entity.Item{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: 対応していない形式",
+			query:          "format=xlsx",
+			setupMock:      func(m *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 無効なカテゴリー",
+			query:          "category=" + url.QueryEscape("家電"),
+			setupMock:      func(m *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "異常系: 無効な絞り込み条件",
+			query: "month=1&q=" + url.QueryEscape("x"),
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetAllItems", mock.Anything, usecase.ItemFilter{PurchaseMonth: 1, Query: "x"}).Return(([]*entity.Item)(nil), domainErrors.ErrInvalidInput)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/items/export?"+tt.query, nil)
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, handler.ExportItems(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, "application/pdf", rec.Header().Get(echo.HeaderContentType))
+				assert.Regexp(t, `^attachment; filename="items-\d{8}\.pdf"$`, rec.Header().Get(echo.HeaderContentDisposition))
+				assert.True(t, bytes.HasPrefix(rec.Body.Bytes(), []byte("%PDF-1.4\n")))
+				assert.True(t, bytes.HasSuffix(rec.Body.Bytes(), []byte("%%EOF\n")))
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNewInventoryReport(t *testing.T) {
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.FixedZone("JST", 9*60*60))
+
+	t.Run("正常系: 1ページに収まらない場合はページを分ける", func(t *testing.T) {
+		items := make([]*entity.Item, 100)
+		for i := range items {
+			items[i] = &entity.Item{ID: int64(i + 1), Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1000), PurchaseDate: "2023-01-15"}
+		}
+
+		doc := newInventoryReport(items, DefaultPriceLocale, now, nil)
+		assert.Len(t, doc.pages, 3)
+		last := doc.pages[len(doc.pages)-1].content.String()
+		assert.Contains(t, last, pdfHexString("合計（100件）"))
+		assert.Contains(t, last, pdfHexString("¥100,000"))
+		assert.Contains(t, last, pdfHexString("3 / 3"))
+	})
+
+	t.Run("正常系: 非表示の購入価格は列も合計も表示しない", func(t *testing.T) {
+		items := []*entity.Item{{ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15"}}
+
+		doc := newInventoryReport(items, DefaultPriceLocale, now, map[string]bool{"purchase_price": true, "purchase_price_display": true})
+		content := doc.pages[0].content.String()
+		assert.NotContains(t, content, pdfHexString("購入価格"))
+		assert.NotContains(t, content, pdfHexString("¥1,500,000"))
+		assert.Contains(t, content, pdfHexString("合計（1件）"))
+		assert.Contains(t, content, pdfHexString("2023-01-15"))
+	})
+
+	t.Run("正常系: クロスリファレンスがオブジェクトの位置を指す", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, newInventoryReport(nil, DefaultPriceLocale, now, nil).writeTo(&buf))
+		pdf := buf.Bytes()
+
+		startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+		if assert.NotNil(t, startxref) {
+			offset, _ := strconv.Atoi(string(startxref[1]))
+			assert.True(t, bytes.HasPrefix(pdf[offset:], []byte("xref\n0 9\n")))
+		}
+		for i, match := range regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf, -1) {
+			offset, _ := strconv.Atoi(string(match[1]))
+			assert.True(t, bytes.HasPrefix(pdf[offset:], []byte(strconv.Itoa(i+1)+" 0 obj\n")))
+		}
+		assert.Contains(t, buf.String(), "/CreationDate (D:20240102150405+09'00')")
+	})
+}
+
+func TestPdfTruncate(t *testing.T) {
+	assert.Equal(t, "ROLEX", pdfTruncate("ROLEX", 10, 25))
+	assert.Equal(t, "RO…", pdfTruncate("ROLEX", 10, 24))
+	assert.Equal(t, "デイ…", pdfTruncate("デイトナ", 10, 30))
+	assert.Equal(t, 15.0, pdfTextWidth("A時", 10))
+}
+
+func TestItemHandler_ExportItems_FlatJSON(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	items := []*entity.Item{
+		{ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15", SerialNumber: "R123456", Status: entity.StatusActive, CreatedAt: createdAt, UpdatedAt: createdAt},
+		{ID: 2, Name: "Speedmaster", Category: "時計", Brand: "OMEGA", PurchasePrice: entity.NewMoney(650000, "USD"), PurchaseDate: "2022-06-01", Status: entity.StatusActive, CreatedAt: createdAt, UpdatedAt: createdAt},
+	}
+
+	mockUsecase := new(MockItemUsecase)
+	mockUsecase.On("GetAllItems", mock.Anything, usecase.ItemFilter{Query: "a"}).Return(items, nil)
+	mockImages := new(MockItemImageUsecase)
+	mockImages.On("ListCoverImages", mock.Anything, []int64{1, 2}).Return(map[int64]*entity.ItemImage{1: {ID: 7, ItemID: 1}}, nil).Once()
+	handler := NewItemHandler(mockUsecase, WithItemImages(mockImages, 0))
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/items/export?format=flat-json&q=a", nil)
+	c := e.NewContext(req, rec)
+
+	assert.NoError(t, handler.ExportItems(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var rows []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rows))
+	if assert.Len(t, rows, 2) {
+		expectedTime := createdAt.In(entity.TimeZone()).Format(time.RFC3339)
+		assert.Equal(t, map[string]interface{}{
+			"id": float64(1), "name": "デイトナ", "category": "時計", "brand": "ROLEX",
+			"purchase_price_amount": float64(1500000), "purchase_price_currency": "JPY", "purchase_price_display": "¥1,500,000",
+			"purchase_date": "2023-01-15", "serial_number": "R123456", "status": "active",
+			"created_at": expectedTime, "updated_at": expectedTime, "deleted_at": "", "delete_reason": "",
+			"image_url": "/items/1/images/7",
+		}, rows[0])
+		// 値の無いフィールドも空文字列で含め、すべての行が同じキーを持つ
+		assert.Equal(t, "", rows[1]["serial_number"])
+		assert.Equal(t, "", rows[1]["image_url"])
+		assert.Equal(t, "USD", rows[1]["purchase_price_currency"])
+		assert.Len(t, rows[1], len(rows[0]))
+	}
+	mockUsecase.AssertExpectations(t)
+	mockImages.AssertExpectations(t)
+}
+
+func TestItemHandler_ExportItems_FlatJSON_HiddenFields(t *testing.T) {
+	items := []*entity.Item{
+		{ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15", SerialNumber: "R123456", Status: entity.StatusActive},
+		{ID: 2, Name: "Speedmaster", Category: "時計", Brand: "OMEGA", PurchasePrice: entity.JPY(650000), PurchaseDate: "2022-06-01", Status: entity.StatusActive},
+	}
+
+	tests := []struct {
+		name          string
+		revealToken   string
+		expectedShown bool
+	}{
+		{name: "正常系: 非表示のフィールドの列をすべての行から除外する", expectedShown: false},
+		{name: "正常系: トークンを指定した場合は除外しない", revealToken: "secret", expectedShown: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			mockUsecase.On("GetAllItems", mock.Anything, usecase.ItemFilter{}).Return(items, nil)
+			handler := NewItemHandler(mockUsecase, WithHiddenFields([]string{"purchase_price", "serial_number"}, "secret"))
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/items/export?format=flat-json", nil)
+			if tt.revealToken != "" {
+				req.Header.Set(headerRevealFields, tt.revealToken)
+			}
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, handler.ExportItems(c))
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var rows []map[string]interface{}
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rows))
+			if assert.Len(t, rows, 2) {
+				for _, key := range []string{"purchase_price_amount", "purchase_price_currency", "purchase_price_display", "serial_number"} {
+					_, shown := rows[0][key]
+					assert.Equal(t, tt.expectedShown, shown, key)
+				}
+				assert.Equal(t, "2023-01-15", rows[0]["purchase_date"])
+				assert.Len(t, rows[1], len(rows[0]))
+			}
+			assert.Contains(t, rec.Header().Values(echo.HeaderVary), headerRevealFields)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
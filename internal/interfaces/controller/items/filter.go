@@ -0,0 +1,249 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// クエリパラメータから一覧の絞り込み条件を組み立てる
+// 一覧エンドポイントとクエリ診断エンドポイントで同じ条件を使うため公開している
+func ParseItemFilter(c echo.Context) (usecase.ItemFilter, error) {
+	var filter usecase.ItemFilter
+
+	// カンマ区切りで複数指定した場合は、いずれかに一致するアイテムを返す
+	categories, err := parseCategories(c.QueryParam("category"))
+	if err != nil {
+		return filter, err
+	}
+	filter.Categories = categories
+	// カンマ区切りで複数指定した場合は、いずれにも一致しないアイテムを返す
+	excluded, err := parseCategories(c.QueryParam("exclude_category"))
+	if err != nil {
+		return filter, err
+	}
+	filter.ExcludeCategories = excluded
+
+	if value := strings.TrimSpace(c.QueryParam("created_since")); value != "" {
+		since, err := parseCreatedSince(value, entity.Now())
+		if err != nil {
+			return filter, err
+		}
+		filter.CreatedSince = &since
+	}
+
+	// 指定しない場合は所有中（active）のアイテムのみ
+	if value := strings.TrimSpace(c.QueryParam("status")); value != "" {
+		status, err := entity.NewStatus(value)
+		if err != nil {
+			return filter, fmt.Errorf("unknown status: %s", value)
+		}
+		filter.Status = status
+	}
+
+	// 並び順の値はusecaseで検証する（ゴミ箱のみdeleted_atで並べ替えられる、省略時は既定の並び順）
+	filter.Sort = usecase.SortOrder(strings.TrimSpace(c.QueryParam("sort")))
+
+	filter.Query = strings.TrimSpace(c.QueryParam("q"))
+	// 文字数はusecaseで検証する
+	filter.SerialPrefix = strings.TrimSpace(c.QueryParam("serial_prefix"))
+	if value := strings.TrimSpace(c.QueryParam("fuzzy")); value != "" {
+		fuzzy, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, fmt.Errorf("fuzzy must be true or false")
+		}
+		filter.Fuzzy = fuzzy
+	}
+
+	// 購入日の月・曜日（年は問わない）。曜日はカンマ区切りで複数指定するといずれかに一致するアイテムを返す
+	if value := strings.TrimSpace(c.QueryParam("month")); value != "" {
+		month, err := strconv.Atoi(value)
+		if err != nil || month < 1 || month > 12 {
+			return filter, fmt.Errorf("month must be an integer between 1 and 12")
+		}
+		filter.PurchaseMonth = month
+	}
+	if filter.PurchaseWeekdays, err = parseWeekdayList(strings.Split(c.QueryParam("weekday"), ",")); err != nil {
+		return filter, err
+	}
+
+	// 範囲の検証（両方の指定、前後関係、件数の上限）はusecaseで行う
+	if filter.IDFrom, err = parseIDParam(c, "id_from"); err != nil {
+		return filter, err
+	}
+	if filter.IDTo, err = parseIDParam(c, "id_to"); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+// IDのクエリパラメータを解釈する（指定しない場合は0）
+func parseIDParam(c echo.Context, name string) (int64, error) {
+	value := strings.TrimSpace(c.QueryParam(name))
+	if value == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%s must be a positive integer", name)
+	}
+	return id, nil
+}
+
+// created_sinceを解釈する
+// 相対指定（"7d", "12h", "30m"）または絶対指定（RFC3339, YYYY-MM-DD）を受け付ける
+func parseCreatedSince(value string, now time.Time) (time.Time, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	} else if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("created_since must be a relative duration (e.g. 7d, 12h) or a timestamp (RFC3339 or YYYY-MM-DD)")
+}
+
+// as_ofを解釈する（RFC3339、またはYYYY-MM-DDの場合はその日の終わり）
+func parseAsOf(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if day, err := time.ParseInLocation("2006-01-02", value, entity.TimeZone()); err == nil {
+		// タイムスタンプは秒精度のため、翌日の0時の1秒前をその日の終わりとする
+		return day.AddDate(0, 0, 1).Add(-time.Second), nil
+	}
+	return time.Time{}, fmt.Errorf("as_of must be a timestamp (RFC3339 or YYYY-MM-DD)")
+}
+
+// カンマ区切りのカテゴリーを読み込む（空の要素と重複は無視する、未指定の場合はnil）
+func parseCategories(value string) ([]entity.Category, error) {
+	return parseCategoryList(strings.Split(value, ","))
+}
+
+// カテゴリーの配列を読み込む（空の要素と重複は無視する、未指定の場合はnil）
+func parseCategoryList(values []string) ([]entity.Category, error) {
+	var categories []entity.Category
+	seen := make(map[entity.Category]bool)
+	for _, part := range values {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		category, err := entity.NewCategory(part)
+		if err != nil {
+			return nil, fmt.Errorf("unknown category: %s", strings.TrimSpace(part))
+		}
+		if !seen[category] {
+			seen[category] = true
+			categories = append(categories, category)
+		}
+	}
+	return categories, nil
+}
+
+// 曜日の略称（大文字・小文字は区別しない）
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// 曜日の配列を読み込む（空の要素と重複は無視する、未指定の場合はnil）
+func parseWeekdayList(values []string) ([]time.Weekday, error) {
+	var weekdays []time.Weekday
+	seen := make(map[time.Weekday]bool)
+	for _, part := range values {
+		name := strings.ToLower(strings.TrimSpace(part))
+		if name == "" {
+			continue
+		}
+		weekday, ok := weekdayNames[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday: %s (use sun, mon, tue, wed, thu, fri or sat)", strings.TrimSpace(part))
+		}
+		if !seen[weekday] {
+			seen[weekday] = true
+			weekdays = append(weekdays, weekday)
+		}
+	}
+	return weekdays, nil
+}
+
+// ?limit= と ?offset= （または ?page= と ?per_page=）からページ指定を組み立てる（limitのデフォルトは DefaultPageLimit）
+func parsePage(c echo.Context) (usecase.Page, error) {
+	return parsePageOver(c, usecase.Page{Limit: usecase.DefaultPageLimit})
+}
+
+// ?limit= と ?offset= （または ?page= と ?per_page=）で指定した値のみpageを上書きする
+// ページ番号で指定した場合はlimit / offsetより優先する（per_pageはlimitを、pageはoffsetを置き換える）
+func parsePageOver(c echo.Context, page usecase.Page) (usecase.Page, error) {
+	if value := c.QueryParam("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			return page, errors.New("limit must be an integer")
+		}
+		page.Limit = limit
+	}
+	if value := c.QueryParam("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil {
+			return page, errors.New("offset must be an integer")
+		}
+		page.Offset = offset
+	}
+
+	if value := c.QueryParam("per_page"); value != "" {
+		perPage, err := strconv.Atoi(value)
+		if err != nil || perPage < 1 || perPage > usecase.MaxPageLimit {
+			return page, fmt.Errorf("per_page must be an integer between 1 and %d", usecase.MaxPageLimit)
+		}
+		page.Limit = perPage
+	}
+	if value := c.QueryParam("page"); value != "" {
+		number, err := strconv.Atoi(value)
+		if err != nil || number < 1 {
+			return page, errors.New("page must be an integer of 1 or greater")
+		}
+		// limitが不正な場合はusecaseのページ指定の検証でエラーにする
+		if page.Limit > 0 {
+			if number-1 > math.MaxInt32/page.Limit {
+				return page, errors.New("page is too large")
+			}
+			page.Offset = (number - 1) * page.Limit
+		}
+	}
+
+	return page, nil
+}
+
+// パスパラメータをデコードして返す
+// Echoは "%2F" などを含むパス（RawPathが設定される場合）ではパラメータをデコードしないため、その場合のみデコードする
+func pathParam(c echo.Context, name string) (string, error) {
+	value := c.Param(name)
+	if c.Request().URL.RawPath == "" {
+		return value, nil
+	}
+	return url.PathUnescape(value)
+}
@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// カラムの値ごとの集計のレスポンス
+type GroupSummaryResponse struct {
+	By     usecase.GroupBy             `json:"by"`
+	Groups []usecase.GroupSummaryEntry `json:"groups"`
+}
+
+// GET /items/group?by=category|brand|status（絞り込みは GET /items と同じパラメータ）
+func (h *ItemHandler) GetGroupSummary(c echo.Context) error {
+	by := usecase.GroupBy(strings.TrimSpace(c.QueryParam("by")))
+	if by == "" {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{"by is required"},
+		})
+	}
+
+	filter, err := ParseItemFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{err.Error()},
+		})
+	}
+
+	entries, err := h.itemUsecase.GetGroupSummary(c.Request().Context(), filter, by)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{err.Error()},
+			})
+		}
+		return respondInternalError(c, err, "failed to retrieve summary")
+	}
+
+	return c.JSON(http.StatusOK, GroupSummaryResponse{By: by, Groups: entries})
+}
@@ -0,0 +1,271 @@
+package controller
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// WithItemImagesで上限を指定しない場合の、画像1枚あたりの最大サイズ
+const DefaultImageMaxBytes = 5 << 20
+
+// 画像の管理に使うユースケースと、画像1枚あたりの最大サイズを指定する（0以下の場合はDefaultImageMaxBytes）
+func WithItemImages(imageUsecase usecase.ItemImageUsecase, maxBytes int64) HandlerOption {
+	return func(h *ItemHandler) {
+		h.imageUsecase = imageUsecase
+		if maxBytes > 0 {
+			h.imageMaxBytes = maxBytes
+		}
+	}
+}
+
+// 画像のレスポンス形式（本体はurlから取得する）
+type ItemImageResponse struct {
+	ID           int64     `json:"id"`
+	Position     int       `json:"position"`
+	ContentType  string    `json:"content_type"`
+	Size         int       `json:"size"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ItemImagesResponse struct {
+	Images []ItemImageResponse `json:"images"`
+}
+
+// アイテム詳細のレスポンス形式（画像のURLを表示順に含める）
+type ItemDetailResponse struct {
+	ItemResponse
+	Images []string `json:"images"`
+}
+
+// itemPathIDはURLのパスに含めるアイテムのID（ID_TYPE=uuid の場合はUUID）
+func newItemImageResponse(image *entity.ItemImage, itemPathID string) ItemImageResponse {
+	return ItemImageResponse{
+		ID:           image.ID,
+		Position:     image.Position,
+		ContentType:  image.ContentType,
+		Size:         image.Size,
+		URL:          image.URL(itemPathID),
+		ThumbnailURL: image.ThumbnailURL(itemPathID),
+		CreatedAt:    image.CreatedAt.In(entity.TimeZone()),
+	}
+}
+
+func imageURLs(images []*entity.ItemImage, itemPathID string) []string {
+	urls := make([]string, 0, len(images))
+	for _, image := range images {
+		urls = append(urls, image.URL(itemPathID))
+	}
+	return urls
+}
+
+// 画像を追加する（multipartのfileフィールド、またはリクエストボディに画像をそのまま指定する）
+func (h *ItemHandler) AddItemImage(c echo.Context) error {
+	itemID, err := h.parseItemID(c)
+	if err != nil {
+		return respondItemIDError(c, err, "invalid item ID")
+	}
+
+	// 画像をそのまま送る場合は、Content-Lengthで判定できれば読み込む前に拒否する
+	multipart := strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm)
+	if !multipart && c.Request().ContentLength > h.imageMaxBytes {
+		return imageTooLarge(c, h.imageMaxBytes)
+	}
+
+	body, err := importBody(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidRequest,
+			Error:   "invalid request format",
+			Details: []string{err.Error()},
+		})
+	}
+	// 上限を1バイト超えるまで読み込み、超えた場合は拒否する
+	data, err := io.ReadAll(io.LimitReader(body, h.imageMaxBytes+1))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidRequest,
+			Error:   "invalid request format",
+			Details: []string{err.Error()},
+		})
+	}
+	if int64(len(data)) > h.imageMaxBytes {
+		return imageTooLarge(c, h.imageMaxBytes)
+	}
+
+	image, err := h.imageUsecase.AddImage(c.Request().Context(), itemID, data)
+	if err != nil {
+		if resp, status, ok := notFoundResponse(err); ok {
+			return c.JSON(status, resp)
+		}
+		switch {
+		case domainErrors.IsValidationError(err):
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeValidationFailed,
+				Error:   "validation failed",
+				Details: []string{err.Error()},
+			})
+		case domainErrors.IsTooManyImagesError(err):
+			return c.JSON(http.StatusConflict, ErrorResponse{
+				Code:    CodeTooManyImages,
+				Error:   "too many images",
+				Details: []string{err.Error()},
+			})
+		}
+		return respondInternalError(c, err, "failed to add image")
+	}
+
+	itemPathID := h.itemPathID(c, itemID)
+	c.Response().Header().Set(echo.HeaderLocation, image.URL(itemPathID))
+	return c.JSON(http.StatusCreated, newItemImageResponse(image, itemPathID))
+}
+
+// 画像の一覧を表示順に返す
+func (h *ItemHandler) GetItemImages(c echo.Context) error {
+	itemID, err := h.parseItemID(c)
+	if err != nil {
+		return respondItemIDError(c, err, "invalid item ID")
+	}
+
+	images, err := h.imageUsecase.ListImages(c.Request().Context(), itemID)
+	if err != nil {
+		if resp, status, ok := notFoundResponse(err); ok {
+			return c.JSON(status, resp)
+		}
+		return respondInternalError(c, err, "failed to retrieve images")
+	}
+
+	itemPathID := h.itemPathID(c, itemID)
+	responses := make([]ItemImageResponse, 0, len(images))
+	for _, image := range images {
+		responses = append(responses, newItemImageResponse(image, itemPathID))
+	}
+	return c.JSON(http.StatusOK, ItemImagesResponse{Images: responses})
+}
+
+// 画像の本体を返す（?size=thumb の場合は縮小画像）
+func (h *ItemHandler) GetItemImage(c echo.Context) error {
+	itemID, imageID, err := h.parseImagePath(c)
+	if err != nil {
+		return respondItemIDError(c, err, "invalid item ID or image ID")
+	}
+	size, ok := parseImageSize(c)
+	if !ok {
+		return invalidImageSize(c)
+	}
+
+	image, err := h.imageUsecase.GetImage(c.Request().Context(), itemID, imageID)
+	if err != nil {
+		if resp, status, ok := notFoundResponse(err); ok {
+			return c.JSON(status, resp)
+		}
+		return respondInternalError(c, err, "failed to retrieve image")
+	}
+
+	return respondImage(c, image, size)
+}
+
+// アイテムの最初の画像（一覧のサムネイルなどに使う代表画像）を返す
+func (h *ItemHandler) GetItemCoverImage(c echo.Context) error {
+	itemID, err := h.parseItemID(c)
+	if err != nil {
+		return respondItemIDError(c, err, "invalid item ID")
+	}
+	size, ok := parseImageSize(c)
+	if !ok {
+		return invalidImageSize(c)
+	}
+
+	images, err := h.imageUsecase.ListImages(c.Request().Context(), itemID)
+	if err == nil && len(images) == 0 {
+		err = domainErrors.ErrImageNotFound
+	}
+	var image *entity.ItemImage
+	if err == nil {
+		image, err = h.imageUsecase.GetImage(c.Request().Context(), itemID, images[0].ID)
+	}
+	if err != nil {
+		if resp, status, ok := notFoundResponse(err); ok {
+			return c.JSON(status, resp)
+		}
+		return respondInternalError(c, err, "failed to retrieve image")
+	}
+
+	// 代表画像は並び替えや削除で変わるため、長期間のキャッシュはしない
+	data, contentType := image.Variant(size)
+	c.Response().Header().Set("Cache-Control", "no-cache")
+	return c.Blob(http.StatusOK, contentType, data)
+}
+
+// 画像の内容は変更されないため、長期間キャッシュできる
+func respondImage(c echo.Context, image *entity.ItemImage, size entity.ImageSize) error {
+	data, contentType := image.Variant(size)
+	c.Response().Header().Set("Cache-Control", "public, max-age=86400, immutable")
+	return c.Blob(http.StatusOK, contentType, data)
+}
+
+// ?size= を読み込む（省略時はoriginal）
+func parseImageSize(c echo.Context) (entity.ImageSize, bool) {
+	switch size := entity.ImageSize(c.QueryParam("size")); size {
+	case "", entity.ImageSizeOriginal:
+		return entity.ImageSizeOriginal, true
+	case entity.ImageSizeThumb:
+		return size, true
+	}
+	return "", false
+}
+
+func invalidImageSize(c echo.Context) error {
+	return c.JSON(http.StatusBadRequest, ErrorResponse{
+		Code:    CodeInvalidParameter,
+		Error:   "invalid query parameter",
+		Details: []string{"size must be one of: original, thumb"},
+	})
+}
+
+func (h *ItemHandler) DeleteItemImage(c echo.Context) error {
+	itemID, imageID, err := h.parseImagePath(c)
+	if err != nil {
+		return respondItemIDError(c, err, "invalid item ID or image ID")
+	}
+
+	if err := h.imageUsecase.DeleteImage(c.Request().Context(), itemID, imageID); err != nil {
+		if resp, status, ok := notFoundResponse(err); ok {
+			return c.JSON(status, resp)
+		}
+		return respondInternalError(c, err, "failed to delete image")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *ItemHandler) parseImagePath(c echo.Context) (int64, int64, error) {
+	imageID, err := strconv.ParseInt(c.Param("imageId"), 10, 64)
+	if err != nil {
+		return 0, 0, errInvalidItemID
+	}
+	itemID, err := h.parseItemID(c)
+	if err != nil {
+		return 0, 0, err
+	}
+	return itemID, imageID, nil
+}
+
+func imageTooLarge(c echo.Context, maxBytes int64) error {
+	return c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{
+		Code:    CodePayloadTooLarge,
+		Error:   "request body too large",
+		Details: []string{fmt.Sprintf("image must be %d bytes or less", maxBytes)},
+	})
+}
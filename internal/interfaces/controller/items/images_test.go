@@ -0,0 +1,266 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockItemImageUsecase はtestify/mockを使用した画像のモックユースケース
+type MockItemImageUsecase struct {
+	mock.Mock
+}
+
+func (m *MockItemImageUsecase) AddImage(ctx context.Context, itemID int64, data []byte) (*entity.ItemImage, error) {
+	args := m.Called(ctx, itemID, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageUsecase) ListImages(ctx context.Context, itemID int64) ([]*entity.ItemImage, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageUsecase) ListCoverImages(ctx context.Context, itemIDs []int64) (map[int64]*entity.ItemImage, error) {
+	args := m.Called(ctx, itemIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64]*entity.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageUsecase) GetImage(ctx context.Context, itemID, imageID int64) (*entity.ItemImage, error) {
+	args := m.Called(ctx, itemID, imageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageUsecase) DeleteImage(ctx context.Context, itemID, imageID int64) error {
+	args := m.Called(ctx, itemID, imageID)
+	return args.Error(0)
+}
+
+func TestItemHandler_AddItemImage(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	tests := []struct {
+		name           string
+		body           []byte
+		maxBytes       int64
+		setupMock      func(*MockItemImageUsecase)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "正常系: 画像を追加",
+			body: png,
+			setupMock: func(mockUsecase *MockItemImageUsecase) {
+				mockUsecase.On("AddImage", mock.Anything, int64(1), png).
+					Return(&entity.ItemImage{ID: 10, ItemID: 1, Position: 1, ContentType: "image/png", Size: len(png)}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "異常系: 上限サイズを超える",
+			body:           png,
+			maxBytes:       int64(len(png)) - 1,
+			setupMock:      func(mockUsecase *MockItemImageUsecase) {},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedCode:   CodePayloadTooLarge,
+		},
+		{
+			name: "異常系: 枚数の上限",
+			body: png,
+			setupMock: func(mockUsecase *MockItemImageUsecase) {
+				mockUsecase.On("AddImage", mock.Anything, int64(1), png).Return(nil, domainErrors.ErrTooManyImages)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   CodeTooManyImages,
+		},
+		{
+			name: "異常系: 存在しないアイテム",
+			body: png,
+			setupMock: func(mockUsecase *MockItemImageUsecase) {
+				mockUsecase.On("AddImage", mock.Anything, int64(1), png).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   CodeItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imageUsecase := new(MockItemImageUsecase)
+			tt.setupMock(imageUsecase)
+			handler := NewItemHandler(new(MockItemUsecase), WithItemImages(imageUsecase, tt.maxBytes))
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/items/1/images", bytes.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, "image/png")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			err := handler.AddItemImage(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedCode != "" {
+				var body ErrorResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, tt.expectedCode, body.Code)
+			} else {
+				assert.Equal(t, "/items/1/images/10", rec.Header().Get(echo.HeaderLocation))
+			}
+			imageUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_GetItem_WithImages(t *testing.T) {
+	item := &entity.Item{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	mockUsecase := new(MockItemUsecase)
+	mockUsecase.On("GetItemByID", mock.Anything, int64(1)).Return(item, nil)
+	imageUsecase := new(MockItemImageUsecase)
+	imageUsecase.On("ListImages", mock.Anything, int64(1)).
+		Return([]*entity.ItemImage{{ID: 5, ItemID: 1, Position: 1}, {ID: 3, ItemID: 1, Position: 2}}, nil)
+	handler := NewItemHandler(mockUsecase, WithItemImages(imageUsecase, 0))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	err := handler.GetItem(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, []interface{}{"/items/1/images/5", "/items/1/images/3"}, body["images"])
+	mockUsecase.AssertExpectations(t)
+	imageUsecase.AssertExpectations(t)
+}
+
+func TestItemHandler_GetItemImage(t *testing.T) {
+	data := []byte("\x89PNG\r\n\x1a\n")
+	thumbnail := []byte("\xff\xd8\xff")
+	imageUsecase := new(MockItemImageUsecase)
+	imageUsecase.On("GetImage", mock.Anything, int64(1), int64(10)).
+		Return(&entity.ItemImage{ID: 10, ItemID: 1, ContentType: "image/png", Size: len(data), Data: data, Thumbnail: thumbnail}, nil)
+	imageUsecase.On("GetImage", mock.Anything, int64(1), int64(99)).Return(nil, domainErrors.ErrImageNotFound)
+	handler := NewItemHandler(new(MockItemUsecase), WithItemImages(imageUsecase, 0))
+
+	e := echo.New()
+	get := func(imageID string, query ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/items/1/images/"+imageID+strings.Join(query, ""), nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id", "imageId")
+		c.SetParamValues("1", imageID)
+		assert.NoError(t, handler.GetItemImage(c))
+		return rec
+	}
+
+	rec := get("10")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image/png", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, data, rec.Body.Bytes())
+
+	rec = get("10", "?size=thumb")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, entity.ThumbnailContentType, rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, thumbnail, rec.Body.Bytes())
+
+	rec = get("10", "?size=huge")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = get("99")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), CodeImageNotFound)
+
+	rec = get("abc")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	imageUsecase.AssertExpectations(t)
+}
+
+func TestItemHandler_GetItemCoverImage(t *testing.T) {
+	data := []byte("\x89PNG\r\n\x1a\n")
+	imageUsecase := new(MockItemImageUsecase)
+	imageUsecase.On("ListImages", mock.Anything, int64(1)).Return([]*entity.ItemImage{{ID: 7, ItemID: 1, Position: 1}, {ID: 3, ItemID: 1, Position: 2}}, nil)
+	imageUsecase.On("GetImage", mock.Anything, int64(1), int64(7)).
+		Return(&entity.ItemImage{ID: 7, ItemID: 1, ContentType: "image/png", Data: data}, nil)
+	imageUsecase.On("ListImages", mock.Anything, int64(2)).Return([]*entity.ItemImage{}, nil)
+	handler := NewItemHandler(new(MockItemUsecase), WithItemImages(imageUsecase, 0))
+
+	e := echo.New()
+	get := func(itemID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/items/"+itemID+"/image?size=thumb", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(itemID)
+		assert.NoError(t, handler.GetItemCoverImage(c))
+		return rec
+	}
+
+	// 縮小画像が無い場合は元の画像を返す
+	rec := get("1")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image/png", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, data, rec.Body.Bytes())
+
+	rec = get("2")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), CodeImageNotFound)
+	imageUsecase.AssertExpectations(t)
+}
+
+func TestNotFoundResponse(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode string
+		expectedOK   bool
+	}{
+		{name: "正常系: アイテム", err: fmt.Errorf("failed to retrieve item: %w", domainErrors.ErrItemNotFound), expectedCode: CodeItemNotFound, expectedOK: true},
+		{name: "正常系: 画像", err: domainErrors.ErrImageNotFound, expectedCode: CodeImageNotFound, expectedOK: true},
+		{name: "異常系: 存在しないリソース以外のエラー", err: domainErrors.ErrDatabaseError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, status, ok := notFoundResponse(tt.err)
+
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedOK {
+				assert.Equal(t, http.StatusNotFound, status)
+			}
+		})
+	}
+}
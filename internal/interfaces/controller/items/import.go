@@ -0,0 +1,257 @@
+package controller
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// CSV一括登録で受け付ける上限（0以下の場合は無制限）
+type ImportLimits struct {
+	MaxBytes int64 // リクエストボディの最大サイズ
+	MaxRows  int   // ヘッダー行を除く最大行数
+}
+
+// WithImportLimitsを指定しない場合の上限
+var defaultImportLimits = ImportLimits{
+	MaxBytes: 10 << 20,
+	MaxRows:  10000,
+}
+
+// 行数（件数）が上限を超えた
+var errTooManyImportRows = errors.New("too many rows")
+
+// CSVの必須列（serial_numberは任意）
+var requiredImportColumns = []string{"name", "category", "brand", "purchase_price", "purchase_date"}
+
+// CSV・JSONからアイテムを一括登録する
+// ?mode=strict（デフォルト）/ dry_run / lenient で、エラーがある場合の扱いを選べる
+// ?format=csv（デフォルト）/ jsonl / json で入力の形式を選べる
+func (h *ItemHandler) ImportItems(c echo.Context) error {
+	format := strings.ToLower(strings.TrimSpace(c.QueryParam("format")))
+	if format == "" {
+		format = importFormatCSV
+	}
+	parse, ok := importParsers[format]
+	if !ok {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{"format must be one of: csv, jsonl, json"},
+		})
+	}
+
+	mode := usecase.ImportMode(c.QueryParam("mode"))
+	if mode == "" {
+		mode = usecase.ImportModeStrict
+	}
+	if !mode.IsValid() {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{"mode must be one of: strict, dry_run, lenient"},
+		})
+	}
+
+	limits := h.importLimits
+	if limits.MaxBytes > 0 {
+		// Content-Lengthで判定できる場合は読み込む前に拒否し、それ以外は読み込み中に打ち切る
+		if c.Request().ContentLength > limits.MaxBytes {
+			return importTooLarge(c, format, limits.MaxBytes)
+		}
+		c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, limits.MaxBytes)
+	}
+
+	body, err := importBody(c)
+	if err != nil {
+		if isMaxBytesError(err) {
+			return importTooLarge(c, format, limits.MaxBytes)
+		}
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidRequest,
+			Error:   "invalid request format",
+			Details: []string{err.Error()},
+		})
+	}
+
+	// camelCase のJSONは snake_case に書き換えてから読み込む（JSONLは行ごとに書き換える）
+	if normalize := requestKeyNormalizer(c); normalize != nil {
+		switch format {
+		case importFormatJSON:
+			body = jsonRequestBody(c, body)
+		case importFormatJSONL:
+			parse = func(r io.Reader, maxRows int) ([]usecase.ImportRow, error) {
+				return parseImportJSONLines(r, maxRows, normalize)
+			}
+		}
+	}
+
+	rows, err := parse(body, limits.MaxRows)
+	if err != nil {
+		if isMaxBytesError(err) {
+			return importTooLarge(c, format, limits.MaxBytes)
+		}
+		if errors.Is(err, errTooManyImportRows) {
+			return invalidImport(c, format, fmt.Sprintf("%s must have %d rows or less", format, limits.MaxRows))
+		}
+		return invalidImport(c, format, err.Error())
+	}
+
+	result, err := h.itemUsecase.ImportItems(c.Request().Context(), rows, mode)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeValidationFailed,
+				Error:   "validation failed",
+				Details: []string{err.Error()},
+			})
+		}
+		return respondInternalError(c, err, "failed to import items")
+	}
+
+	switch {
+	case mode == usecase.ImportModeDryRun:
+		return c.JSON(http.StatusOK, result)
+	case !result.Committed:
+		return c.JSON(http.StatusUnprocessableEntity, result)
+	case result.Imported > 0:
+		return c.JSON(http.StatusCreated, result)
+	default:
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// multipart/form-data の場合は file フィールド、それ以外はリクエストボディをそのまま読む
+// ファイル全体をメモリや一時ファイルに保存せず、先頭から順に読み込む
+func importBody(c echo.Context) (io.Reader, error) {
+	if !strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		return c.Request().Body, nil
+	}
+
+	multipartReader, err := c.Request().MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		part, err := multipartReader.NextPart()
+		if err == io.EOF {
+			return nil, errors.New("file is required")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == "file" {
+			return part, nil
+		}
+	}
+}
+
+// 入力の形式ごとの読み込み処理
+var importParsers = map[string]func(io.Reader, int) ([]usecase.ImportRow, error){
+	importFormatCSV:   parseImportCSV,
+	importFormatJSONL: parseImportJSONL,
+	importFormatJSON:  parseImportJSON,
+}
+
+func importTooLarge(c echo.Context, format string, maxBytes int64) error {
+	return c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{
+		Code:    CodePayloadTooLarge,
+		Error:   "request body too large",
+		Details: []string{fmt.Sprintf("%s must be %d bytes or less", format, maxBytes)},
+	})
+}
+
+// 入力全体の形式が不正（CSVはINVALID_CSV、JSONはINVALID_REQUEST）
+func invalidImport(c echo.Context, format, detail string) error {
+	if format == importFormatCSV {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidCSV,
+			Error:   "invalid csv",
+			Details: []string{detail},
+		})
+	}
+	return c.JSON(http.StatusBadRequest, ErrorResponse{
+		Code:    CodeInvalidRequest,
+		Error:   "invalid " + format,
+		Details: []string{detail},
+	})
+}
+
+func isMaxBytesError(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
+
+// ヘッダー行の列名に従ってCSVを1行ずつ読み込む（行番号はヘッダーを1行目とする）
+// maxRowsを超えた時点で読み込みをやめ、errTooManyImportRowsを返す（0以下の場合は無制限）
+func parseImportCSV(r io.Reader, maxRows int) ([]usecase.ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("csv is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for index, name := range header {
+		// Excelで保存したCSVのBOMを除去する
+		name = strings.TrimPrefix(name, "\ufeff")
+		columns[strings.ToLower(strings.TrimSpace(name))] = index
+	}
+	for _, required := range requiredImportColumns {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing column: %s", required)
+		}
+	}
+
+	var rows []usecase.ImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if maxRows > 0 && len(rows) >= maxRows {
+			return nil, errTooManyImportRows
+		}
+
+		line, _ := reader.FieldPos(0)
+		value := func(column string) string {
+			index, ok := columns[column]
+			if !ok || index >= len(record) {
+				return ""
+			}
+			return record[index]
+		}
+		rows = append(rows, usecase.ImportRow{
+			Line:          line,
+			Name:          value("name"),
+			Category:      value("category"),
+			Brand:         value("brand"),
+			PurchasePrice: value("purchase_price"),
+			PurchaseDate:  value("purchase_date"),
+			SerialNumber:  value("serial_number"),
+		})
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("csv has no data rows")
+	}
+
+	return rows, nil
+}
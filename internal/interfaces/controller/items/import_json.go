@@ -0,0 +1,173 @@
+package controller
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 一括登録の入力形式（?format=）
+const (
+	importFormatCSV   = "csv"
+	importFormatJSONL = "jsonl" // 1行に1件のJSONオブジェクト
+	importFormatJSON  = "json"  // JSONオブジェクトの配列
+)
+
+// JSONL の1行の最大サイズ
+const maxImportJSONLineBytes = 1 << 20
+
+// JSONの1件分（GET /items のレスポンスをそのまま読み込めるよう、それ以外のフィールドは無視する）
+type importRecord struct {
+	Name          string          `json:"name"`
+	Category      string          `json:"category"`
+	Brand         string          `json:"brand"`
+	PurchasePrice json.RawMessage `json:"purchase_price"`
+	PurchaseDate  string          `json:"purchase_date"`
+	SerialNumber  string          `json:"serial_number"`
+}
+
+// JSONの1件分の形式が不正（行番号を付ける）
+type importRecordError struct {
+	Line int
+	Err  error
+}
+
+func (e *importRecordError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *importRecordError) Unwrap() error {
+	return e.Err
+}
+
+// 1行に1件のJSONを読み込む（行番号は1始まり、空行は読み飛ばす）
+// 金額などの値の検証はCSVと同じく一括登録の処理で行い、行ごとのエラーとして返す
+func parseImportJSONL(r io.Reader, maxRows int) ([]usecase.ImportRow, error) {
+	return parseImportJSONLines(r, maxRows, nil)
+}
+
+// normalizeがnil以外の場合は、各行のフィールド名を書き換えてから読み込む（camelCase のJSONL）
+// ファイル全体ではなく行ごとに書き換えるため、行番号は書き換える前のファイルのまま
+func parseImportJSONLines(r io.Reader, maxRows int, normalize func([]byte) []byte) ([]usecase.ImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportJSONLineBytes)
+
+	var rows []usecase.ImportRow
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if line == 1 {
+			// BOM付きで保存されたファイルも受け付ける
+			data = bytes.TrimPrefix(data, []byte("\ufeff"))
+		}
+		if len(data) == 0 {
+			continue
+		}
+		if maxRows > 0 && len(rows) >= maxRows {
+			return nil, errTooManyImportRows
+		}
+
+		if normalize != nil {
+			data = normalize(data)
+		}
+		var record importRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			return nil, &importRecordError{Line: line, Err: err}
+		}
+		rows = append(rows, record.toImportRow(line))
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, &importRecordError{Line: line + 1, Err: fmt.Errorf("line must be %d bytes or less", maxImportJSONLineBytes)}
+		}
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("jsonl has no records")
+	}
+	return rows, nil
+}
+
+// JSONの配列を先頭から1件ずつ読み込む（行番号の代わりに1始まりの要素の位置を使う）
+func parseImportJSON(r io.Reader, maxRows int) ([]usecase.ImportRow, error) {
+	decoder := json.NewDecoder(r)
+	token, err := decoder.Token()
+	if err == io.EOF {
+		return nil, errors.New("json is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, errors.New("json must be an array of items")
+	}
+
+	var rows []usecase.ImportRow
+	for decoder.More() {
+		if maxRows > 0 && len(rows) >= maxRows {
+			return nil, errTooManyImportRows
+		}
+		var record importRecord
+		if err := decoder.Decode(&record); err != nil {
+			return nil, &importRecordError{Line: len(rows) + 1, Err: err}
+		}
+		rows = append(rows, record.toImportRow(len(rows)+1))
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("json has no items")
+	}
+	return rows, nil
+}
+
+// CSVと同じ一括登録の入力にする
+func (r importRecord) toImportRow(line int) usecase.ImportRow {
+	return usecase.ImportRow{
+		Line:          line,
+		Name:          r.Name,
+		Category:      r.Category,
+		Brand:         r.Brand,
+		PurchasePrice: importPriceString(r.PurchasePrice),
+		PurchaseDate:  r.PurchaseDate,
+		SerialNumber:  r.SerialNumber,
+	}
+}
+
+// 金額は数値、文字列（"¥1,000,000" など）、GET /items と同じオブジェクト形式を受け付ける
+// 日本円以外や解釈できない値はそのまま渡し、一括登録の処理で行のエラーにする
+func importPriceString(raw json.RawMessage) string {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return ""
+	}
+
+	switch raw[0] {
+	case '"':
+		var value string
+		if err := json.Unmarshal(raw, &value); err == nil {
+			return value
+		}
+	case '{':
+		var money entity.Money
+		if err := json.Unmarshal(raw, &money); err == nil {
+			if money.Currency == "" || money.Currency == entity.PriceCurrency {
+				return strconv.FormatInt(money.Amount, 10)
+			}
+			return money.String()
+		}
+	}
+	return strings.TrimSpace(string(raw))
+}
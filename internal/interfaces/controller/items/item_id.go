@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// パスの :id の形式が不正
+var errInvalidItemID = errors.New("invalid item ID")
+
+// :id をUUIDで指定する（ID_TYPE=uuid）
+// 数値のIDは登録件数や他のアイテムのIDを推測できるため、有効にした場合は受け付けない
+func WithUUIDItemIDs(enabled bool) HandlerOption {
+	return func(h *ItemHandler) {
+		h.uuidIDs = enabled
+	}
+}
+
+// パスの :id をアイテムの数値のIDにする（UUIDの場合は存在するアイテムのIDに変換する）
+func (h *ItemHandler) parseItemID(c echo.Context) (int64, error) {
+	if !h.uuidIDs {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			return 0, errInvalidItemID
+		}
+		return id, nil
+	}
+
+	id, err := h.itemUsecase.ResolveItemID(c.Request().Context(), c.Param("id"))
+	if domainErrors.IsValidationError(err) {
+		return 0, errInvalidItemID
+	}
+	return id, err
+}
+
+// パスで指定したアイテムを、レスポンスのURLに含める表記（UUIDは小文字にする）
+func (h *ItemHandler) itemPathID(c echo.Context, id int64) string {
+	if h.uuidIDs {
+		if uuid, ok := entity.ParseUUID(c.Param("id")); ok {
+			return uuid
+		}
+	}
+	return strconv.FormatInt(id, 10)
+}
+
+// パスで指定したアイテムのレスポンスのid（ID_TYPE=uuid の場合はUUIDの文字列）
+func (h *ItemHandler) pathResponseID(c echo.Context, id int64) interface{} {
+	if h.uuidIDs {
+		return h.itemPathID(c, id)
+	}
+	return id
+}
+
+// parseItemIDのエラーのレスポンス（形式が不正な場合はmessageの400、UUIDのアイテムが無い場合は404）
+func respondItemIDError(c echo.Context, err error, message string) error {
+	if errors.Is(err, errInvalidItemID) {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:  CodeInvalidParameter,
+			Error: message,
+		})
+	}
+	if domainErrors.IsNotFoundError(err) {
+		return c.JSON(http.StatusNotFound, ErrorResponse{
+			Code:  CodeItemNotFound,
+			Error: "item not found",
+		})
+	}
+	return respondInternalError(c, err, "failed to retrieve item")
+}
+
+// レスポンスのid（UUIDが振られている場合はUUIDの文字列、それ以外は数値）
+func responseItemID(id int64, uuid string) interface{} {
+	if uuid != "" {
+		return uuid
+	}
+	return id
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemHandler_GetItem_UUID(t *testing.T) {
+	const uuid = "5f0c7a4e-2b1d-4c3e-9a8f-1d2e3f4a5b6c"
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		id             string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+		expectedID     interface{}
+		expectedCode   string
+	}{
+		{
+			name: "正常系: UUIDで取得し、idもUUIDで返す",
+			id:   uuid,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("ResolveItemID", mock.Anything, uuid).Return(int64(7), nil)
+				mockUsecase.On("GetItemByID", mock.Anything, int64(7)).Return(&entity.Item{ID: 7, UUID: uuid, Name: "サブマリーナ"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedID:     uuid,
+		},
+		{
+			name: "異常系: 数値のIDは400",
+			id:   "7",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("ResolveItemID", mock.Anything, "7").Return(int64(0), domainErrors.ErrInvalidInput)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeInvalidParameter,
+		},
+		{
+			name: "異常系: 存在しないUUIDは404",
+			id:   uuid,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("ResolveItemID", mock.Anything, uuid).Return(int64(0), domainErrors.ErrItemNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   CodeItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase, WithUUIDItemIDs(true))
+
+			req := httptest.NewRequest(http.MethodGet, "/items/"+tt.id, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+
+			require.NoError(t, handler.GetItem(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			if tt.expectedCode != "" {
+				assert.Equal(t, tt.expectedCode, body["code"])
+			} else {
+				assert.Equal(t, tt.expectedID, body["id"])
+				assert.Equal(t, `"`+uuid+`-0"`, rec.Header().Get("ETag"))
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestResponseItemID(t *testing.T) {
+	assert.Equal(t, int64(7), responseItemID(7, ""))
+	assert.Equal(t, "5f0c7a4e-2b1d-4c3e-9a8f-1d2e3f4a5b6c", responseItemID(7, "5f0c7a4e-2b1d-4c3e-9a8f-1d2e3f4a5b6c"))
+}
@@ -1,77 +1,229 @@
 package controller
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
 	"Aicon-assignment/internal/usecase"
 
 	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
 )
 
 type ItemHandler struct {
-	itemUsecase usecase.ItemUsecase
+	itemUsecase  usecase.ItemUsecase
+	importLimits ImportLimits
+	bulkMaxItems int      // POST /items/bulk で受け付ける最大件数
+	cardFields   []string // GET /items/:id/card に含めるフィールド
+	// 存在しない（削除済みの）アイテムのDELETEを成功（204）として扱うか
+	idempotentDelete bool
+	// 更新するフィールドが無いPATCHを、変更せずに現在のアイテムを返す（200）として扱うか
+	emptyPatchNoOp bool
+	// 登録時のカテゴリーの省略を許可するか（ブランドから補えない場合はusecaseの検証で400）
+	categoryOptional bool
+	// 画像の管理（nilの場合はアイテム詳細に画像を含めない）
+	imageUsecase  usecase.ItemImageUsecase
+	imageMaxBytes int64
+	// GETレスポンスから除外するJSONのキーと、除外せずに返すためのトークン
+	hiddenKeys  map[string]bool
+	revealToken string
+	// 変更イベントの配信（nilの場合はGET /items/streamを登録しない）と、接続を保つためのコメントの間隔
+	eventSubscriber usecase.ItemEventSubscriber
+	streamHeartbeat time.Duration
+	// 集計をバックグラウンドで計算するジョブ（nilの場合は常に同期で返す）と、非同期にするアイテム数の下限
+	summaryJobs           *usecase.SummaryJobs
+	summaryAsyncThreshold int
+	// 名前を付けた検索条件（POST /items/search のリクエストボディ）の保存
+	searchPresets usecase.SearchPresetUsecase
+	// Accept-Languageが無い場合の表示用の金額のロケール
+	priceLocale language.Tag
+	// :id をUUIDで指定するか（ID_TYPE=uuid）
+	uuidIDs bool
 }
 
-func NewItemHandler(itemUsecase usecase.ItemUsecase) *ItemHandler {
-	return &ItemHandler{
-		itemUsecase: itemUsecase,
+// ItemHandlerの任意設定
+type HandlerOption func(*ItemHandler)
+
+// 更新するフィールドが無いPATCHを200（変更なし）とするか、従来どおり400とするかを指定する
+func WithEmptyPatchNoOp(enabled bool) HandlerOption {
+	return func(h *ItemHandler) {
+		h.emptyPatchNoOp = enabled
+	}
+}
+
+// ブランドからカテゴリーを補う設定がある場合に、登録時のカテゴリーの省略を許可する
+func WithOptionalCategory(enabled bool) HandlerOption {
+	return func(h *ItemHandler) {
+		h.categoryOptional = enabled
 	}
 }
 
+// CSV一括登録のサイズと行数の上限を指定する
+func WithImportLimits(limits ImportLimits) HandlerOption {
+	return func(h *ItemHandler) {
+		h.importLimits = limits
+	}
+}
+
+// 存在しない（削除済みの）アイテムのDELETEを204とするか、従来どおり404とするかを指定する
+func WithIdempotentDelete(enabled bool) HandlerOption {
+	return func(h *ItemHandler) {
+		h.idempotentDelete = enabled
+	}
+}
+
+func NewItemHandler(itemUsecase usecase.ItemUsecase, opts ...HandlerOption) *ItemHandler {
+	h := &ItemHandler{
+		itemUsecase:  itemUsecase,
+		importLimits: defaultImportLimits,
+		bulkMaxItems: DefaultBulkMaxItems,
+		cardFields:   DefaultCardFields,
+		// リトライが失敗に見えないよう、デフォルトでは削除済みでも成功とする
+		idempotentDelete: true,
+		imageMaxBytes:    DefaultImageMaxBytes,
+		priceLocale:      DefaultPriceLocale,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
 // エラーレスポンスの形式
 type ErrorResponse struct {
-	Error   string   `json:"error"`
+	Code    string   `json:"code"`  // 機械判定用のエラーコード（error_codes.go）
+	Error   string   `json:"error"` // 人が読むためのメッセージ
 	Details []string `json:"details,omitempty"`
 }
 
+// 登録レスポンスの形式（入力ミスの可能性がある場合はwarningsを含む）
+type CreateItemResponse struct {
+	ItemResponse
+	Warnings []string `json:"warnings,omitempty"`
+}
+
 func (h *ItemHandler) GetItems(c echo.Context) error {
-	items, err := h.itemUsecase.GetAllItems(c.Request().Context())
+	filter, err := ParseItemFilter(c)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to retrieve items",
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{err.Error()},
 		})
 	}
 
-	return c.JSON(http.StatusOK, items)
+	// 一覧より先に集計値を取得し、ETagが返す一覧より新しくならないようにする
+	version, err := h.itemUsecase.GetItemListVersion(c.Request().Context(), filter)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{err.Error()},
+			})
+		}
+		return respondInternalError(c, err, "failed to retrieve items")
+	}
+	etag := version.ETag(filter)
+	c.Response().Header().Set("ETag", etag)
+	c.Response().Header().Set(headerTotalCount, strconv.Itoa(version.ReturnedCount()))
+	// 本文は配列のため、検索語で上限まで切り詰めた場合はヘッダーで一致した件数を返す
+	if version.SearchWindow > 0 {
+		c.Response().Header().Set(headerTotalMatches, strconv.Itoa(version.Count))
+		if version.RefineSuggested() {
+			c.Response().Header().Set(headerRefineSuggested, "true")
+		}
+	}
+	// 304にも200と同じVaryを付け、キャッシュが非表示のフィールドの有無を区別できるようにする
+	h.hiddenKeysFor(c)
+	if ifNoneMatch(c.Request().Header.Get("If-None-Match"), etag) {
+		return c.NoContent(http.StatusNotModified)
+	}
+	// HEADでは一覧を取得せず、件数とETagのみを返す
+	if c.Request().Method == http.MethodHead {
+		return c.NoContent(http.StatusOK)
+	}
+
+	items, err := h.itemUsecase.GetAllItems(c.Request().Context(), filter)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{err.Error()},
+			})
+		}
+		return respondInternalError(c, err, "failed to retrieve items")
+	}
+
+	return h.respondProjected(c, http.StatusOK, newItemResponses(items, h.priceLocaleFor(c)))
 }
 
 func (h *ItemHandler) GetItem(c echo.Context) error {
-	idStr := c.Param("id")
-	id, err := strconv.ParseInt(idStr, 10, 64)
+	id, err := h.parseItemID(c)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid item ID",
-		})
+		return respondItemIDError(c, err, "invalid item ID")
 	}
 
 	item, err := h.itemUsecase.GetItemByID(c.Request().Context(), id)
 	if err != nil {
+		// 削除されたアイテムは、一度も存在しないIDの404と区別して410を返す（差分同期のクライアントが手元のコピーを破棄できる）
+		if domainErrors.IsItemDeletedError(err) {
+			return c.JSON(http.StatusGone, ErrorResponse{
+				Code:  CodeItemDeleted,
+				Error: "item has been deleted",
+			})
+		}
 		if domainErrors.IsNotFoundError(err) {
 			return c.JSON(http.StatusNotFound, ErrorResponse{
+				Code:  CodeItemNotFound,
 				Error: "item not found",
 			})
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to retrieve item",
-		})
+		return respondInternalError(c, err, "failed to retrieve item")
+	}
+
+	c.Response().Header().Set(echo.HeaderLastModified, item.UpdatedAt.UTC().Format(http.TimeFormat))
+	c.Response().Header().Set("ETag", item.ETag())
+	if h.imageUsecase == nil {
+		return h.respondProjected(c, http.StatusOK, newItemResponse(item, h.priceLocaleFor(c)))
 	}
 
-	return c.JSON(http.StatusOK, item)
+	images, err := h.imageUsecase.ListImages(c.Request().Context(), id)
+	if err != nil {
+		return respondInternalError(c, err, "failed to retrieve images")
+	}
+	return h.respondProjected(c, http.StatusOK, ItemDetailResponse{
+		ItemResponse: newItemResponse(item, h.priceLocaleFor(c)),
+		Images:       imageURLs(images, item.PublicID()),
+	})
 }
 
 func (h *ItemHandler) CreateItem(c echo.Context) error {
 	var input usecase.CreateItemInput
 	if err := c.Bind(&input); err != nil {
+		if detail, ok := priceTypeError(err); ok {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeValidationFailed,
+				Error:   "validation failed",
+				Details: []string{detail},
+			})
+		}
 		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:  CodeInvalidRequest,
 			Error: "invalid request format",
 		})
 	}
 
 	// バリデーション
-	if validationErrors := validateCreateItemInput(input); len(validationErrors) > 0 {
+	if validationErrors := h.validateCreateItemInput(input); len(validationErrors) > 0 {
 		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeValidationFailed,
 			Error:   "validation failed",
 			Details: validationErrors,
 		})
@@ -79,46 +231,119 @@ func (h *ItemHandler) CreateItem(c echo.Context) error {
 
 	item, err := h.itemUsecase.CreateItem(c.Request().Context(), input)
 	if err != nil {
+		if response, ok := missingRequiredFieldsResponse(err); ok {
+			return c.JSON(http.StatusUnprocessableEntity, response)
+		}
 		if domainErrors.IsValidationError(err) {
 			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeValidationFailed,
 				Error:   "validation failed",
 				Details: []string{err.Error()},
 			})
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to create item",
+		if domainErrors.IsDuplicateEntryError(err) {
+			return c.JSON(http.StatusConflict, duplicateEntryResponse(err))
+		}
+		return respondInternalError(c, err, "failed to create item")
+	}
+
+	return respondWithItem(c, http.StatusCreated, item, CreateItemResponse{
+		ItemResponse: newItemResponse(item, h.priceLocaleFor(c)),
+		Warnings:     item.Warnings(),
+	})
+}
+
+// シリアル番号で登録または更新する（登録時は201、更新時は200）
+func (h *ItemHandler) UpsertItem(c echo.Context) error {
+	var input usecase.CreateItemInput
+	if err := c.Bind(&input); err != nil {
+		if detail, ok := priceTypeError(err); ok {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeValidationFailed,
+				Error:   "validation failed",
+				Details: []string{detail},
+			})
+		}
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:  CodeInvalidRequest,
+			Error: "invalid request format",
+		})
+	}
+
+	validationErrors := h.validateCreateItemInput(input)
+	if input.SerialNumber == "" {
+		validationErrors = append(validationErrors, "serial_number is required")
+	}
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeValidationFailed,
+			Error:   "validation failed",
+			Details: validationErrors,
 		})
 	}
 
-	return c.JSON(http.StatusCreated, item)
+	item, created, err := h.itemUsecase.UpsertItem(c.Request().Context(), input)
+	if err != nil {
+		if response, ok := missingRequiredFieldsResponse(err); ok {
+			return c.JSON(http.StatusUnprocessableEntity, response)
+		}
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeValidationFailed,
+				Error:   "validation failed",
+				Details: []string{err.Error()},
+			})
+		}
+		if domainErrors.IsDuplicateEntryError(err) {
+			return c.JSON(http.StatusConflict, duplicateEntryResponse(err))
+		}
+		return respondInternalError(c, err, "failed to upsert item")
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	return respondWithItem(c, status, item, CreateItemResponse{
+		ItemResponse: newItemResponse(item, h.priceLocaleFor(c)),
+		Warnings:     item.Warnings(),
+	})
 }
 
 func (h *ItemHandler) UpdateItem(c echo.Context) error {
-	idStr := c.Param("id")
-	id, err := strconv.ParseInt(idStr, 10, 64)
+	id, err := h.parseItemID(c)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid item ID",
-		})
+		return respondItemIDError(c, err, "invalid item ID")
 	}
 
 	var input usecase.UpdateItemInput
 	if err := c.Bind(&input); err != nil {
+		if detail, ok := priceTypeError(err); ok {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeValidationFailed,
+				Error:   "validation failed",
+				Details: []string{detail},
+			})
+		}
 		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:  CodeInvalidRequest,
 			Error: "invalid request format",
 		})
 	}
 
 	// バリデーション: 少なくとも1つのフィールドが提供されている必要がある
-	if input.Name == nil && input.Brand == nil && input.PurchasePrice == nil {
+	// 変更なしとして扱う場合は、そのままユースケースに渡して現在のアイテムを返す（存在しない場合は404）
+	if input.Name == nil && input.Brand == nil && input.PurchasePrice == nil && input.Status == nil && !h.emptyPatchNoOp {
 		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "at least one field (name, brand, purchase_price) must be provided",
+			Code:  CodeValidationFailed,
+			Error: "at least one field (name, brand, purchase_price, status) must be provided",
 		})
 	}
 
 	// 個別フィールドのバリデーション
 	if validationErrors := validateUpdateItemInput(input); len(validationErrors) > 0 {
 		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeValidationFailed,
 			Error:   "validation failed",
 			Details: validationErrors,
 		})
@@ -126,68 +351,397 @@ func (h *ItemHandler) UpdateItem(c echo.Context) error {
 
 	item, err := h.itemUsecase.UpdateItem(c.Request().Context(), id, input)
 	if err != nil {
+		if response, ok := missingRequiredFieldsResponse(err); ok {
+			return c.JSON(http.StatusUnprocessableEntity, response)
+		}
 		if domainErrors.IsNotFoundError(err) {
 			return c.JSON(http.StatusNotFound, ErrorResponse{
+				Code:  CodeItemNotFound,
 				Error: "item not found",
 			})
 		}
+		if domainErrors.IsUpdateConditionFailedError(err) {
+			return c.JSON(http.StatusConflict, ErrorResponse{
+				Code:    CodeConditionNotMet,
+				Error:   "update condition not met",
+				Details: []string{err.Error()},
+			})
+		}
 		if domainErrors.IsValidationError(err) {
 			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeValidationFailed,
 				Error:   "validation failed",
 				Details: []string{err.Error()},
 			})
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to update item",
-		})
+		return respondInternalError(c, err, "failed to update item")
 	}
 
-	return c.JSON(http.StatusOK, item)
+	return respondWithItem(c, http.StatusOK, item, newItemResponse(item, h.priceLocaleFor(c)))
 }
 
 func (h *ItemHandler) DeleteItem(c echo.Context) error {
-	idStr := c.Param("id")
-	id, err := strconv.ParseInt(idStr, 10, 64)
+	id, err := h.parseItemID(c)
 	if err != nil {
+		return respondItemIDError(c, err, "invalid item ID")
+	}
+
+	preconditions := parseDeletePreconditions(c)
+	// 削除の理由は任意のため、ボディが無い場合もそのまま削除する
+	var body struct {
+		Reason string `json:"reason"`
+	}
+	if err := c.Bind(&body); err != nil {
 		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid item ID",
+			Code:  CodeInvalidRequest,
+			Error: "invalid request format",
 		})
 	}
+	preconditions.Reason = strings.TrimSpace(body.Reason)
 
-	err = h.itemUsecase.DeleteItem(c.Request().Context(), id)
+	err = h.itemUsecase.DeleteItem(c.Request().Context(), id, preconditions)
 	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeValidationFailed,
+				Error:   "validation failed",
+				Details: []string{err.Error()},
+			})
+		}
+		// 条件付き削除では対象が無いことを成功とはみなさない
+		if domainErrors.IsNotFoundError(err) && h.idempotentDelete && preconditions.IsEmpty() {
+			return c.NoContent(http.StatusNoContent)
+		}
 		if domainErrors.IsNotFoundError(err) {
 			return c.JSON(http.StatusNotFound, ErrorResponse{
+				Code:  CodeItemNotFound,
 				Error: "item not found",
 			})
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to delete item",
-		})
+		if domainErrors.IsPreconditionFailedError(err) {
+			return c.JSON(http.StatusPreconditionFailed, ErrorResponse{
+				Code:  CodePreconditionFailed,
+				Error: "item has been modified since the given precondition",
+			})
+		}
+		return respondInternalError(c, err, "failed to delete item")
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
 
+// 指定したカテゴリーのみの集計レスポンス
+type CategorySummariesResponse struct {
+	Categories []usecase.CategorySummaryEntry `json:"categories"`
+}
+
+// 集計は既定ではすべてのカテゴリーを0件でも含めて返し、?sparse=true の場合は1件以上のカテゴリーのみを返す
 func (h *ItemHandler) GetSummary(c echo.Context) error {
+	sparse := false
+	if value := strings.TrimSpace(c.QueryParam("sparse")); value != "" {
+		var err error
+		if sparse, err = strconv.ParseBool(value); err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{"sparse must be true or false"},
+			})
+		}
+	}
+
+	// ?categories=時計,バッグ の場合は指定したカテゴリーのみを1回のクエリで集計する
+	if value := strings.TrimSpace(c.QueryParam("categories")); value != "" {
+		var categories []string
+		for _, category := range strings.Split(value, ",") {
+			if category = strings.TrimSpace(category); category != "" {
+				categories = append(categories, category)
+			}
+		}
+
+		entries, err := h.itemUsecase.GetCategorySummaries(c.Request().Context(), categories)
+		if err != nil {
+			return respondInternalError(c, err, "failed to retrieve summary")
+		}
+		if sparse {
+			nonZero := make([]usecase.CategorySummaryEntry, 0, len(entries))
+			for _, entry := range entries {
+				if entry.Count > 0 {
+					nonZero = append(nonZero, entry)
+				}
+			}
+			entries = nonZero
+		}
+		return c.JSON(http.StatusOK, CategorySummariesResponse{Categories: entries})
+	}
+
+	// アイテムが多い場合はバックグラウンドで集計し、結果は GET /items/summary/jobs/{jobId} で取得する
+	if started, err := h.startSummaryJob(c); started {
+		return err
+	}
+
 	summary, err := h.itemUsecase.GetCategorySummary(c.Request().Context())
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to retrieve summary",
-		})
+		return respondInternalError(c, err, "failed to retrieve summary")
+	}
+	if sparse {
+		summary.Categories = nonZeroCategories(summary.Categories)
 	}
 
 	return c.JSON(http.StatusOK, summary)
 }
 
-func validateCreateItemInput(input usecase.CreateItemInput) []string {
+// GET /items/summary/compare?as_of=
+func (h *ItemHandler) CompareSummary(c echo.Context) error {
+	value := c.QueryParam("as_of")
+	if value == "" {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{"as_of is required"},
+		})
+	}
+	asOf, err := parseAsOf(value)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{err.Error()},
+		})
+	}
+
+	comparison, err := h.itemUsecase.CompareCategorySummary(c.Request().Context(), asOf)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{err.Error()},
+			})
+		}
+		return respondInternalError(c, err, "failed to compare summary")
+	}
+
+	return c.JSON(http.StatusOK, comparison)
+}
+
+// 差分同期のレスポンス形式
+type ChangesResponse struct {
+	Items      []ItemResponse `json:"items"`
+	DeletedIDs []int64        `json:"deleted_ids"`
+	ServerTime time.Time      `json:"server_time"`
+}
+
+// 同期トークンによる差分同期のレスポンス
+type SyncResponse struct {
+	Items      []ItemResponse `json:"items"`
+	DeletedIDs []int64        `json:"deleted_ids"`
+	SyncToken  string         `json:"sync_token"`
+	FullResync bool           `json:"full_resync"`
+}
+
+// GET /items/changes?since= または ?sync_token=（初回は空のsync_tokenで全件を取得する）
+func (h *ItemHandler) GetChanges(c echo.Context) error {
+	if c.QueryParams().Has("sync_token") {
+		if c.QueryParams().Has("since") {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{"since and sync_token cannot be used together"},
+			})
+		}
+		return h.syncItems(c, c.QueryParam("sync_token"))
+	}
+
+	value := c.QueryParam("since")
+	if value == "" {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{"since or sync_token is required"},
+		})
+	}
+	since, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{"since must be in RFC3339 format"},
+		})
+	}
+
+	changes, err := h.itemUsecase.GetChangesSince(c.Request().Context(), since)
+	if err != nil {
+		return respondInternalError(c, err, "failed to retrieve changes")
+	}
+
+	return h.respondProjected(c, http.StatusOK, ChangesResponse{
+		Items:      newItemResponses(changes.Items, h.priceLocaleFor(c)),
+		DeletedIDs: changes.DeletedIDs,
+		ServerTime: changes.ServerTime,
+	})
+}
+
+// 不正・期限切れのトークンはエラーにせず、full_resyncとして全件を返す
+func (h *ItemHandler) syncItems(c echo.Context, token string) error {
+	sync, err := h.itemUsecase.SyncItems(c.Request().Context(), token)
+	if err != nil {
+		return respondInternalError(c, err, "failed to retrieve changes")
+	}
+
+	return h.respondProjected(c, http.StatusOK, SyncResponse{
+		Items:      newItemResponses(sync.Items, h.priceLocaleFor(c)),
+		DeletedIDs: sync.DeletedIDs,
+		SyncToken:  sync.SyncToken,
+		FullResync: sync.FullResync,
+	})
+}
+
+// GET /items/incomplete
+func (h *ItemHandler) GetIncompleteItems(c echo.Context) error {
+	items, err := h.itemUsecase.GetIncompleteItems(c.Request().Context())
+	if err != nil {
+		return respondInternalError(c, err, "failed to retrieve incomplete items")
+	}
+
+	return h.respondProjected(c, http.StatusOK, newIncompleteItemsResponse(items, h.priceLocaleFor(c)))
+}
+
+// 使用中のカテゴリーの一覧レスポンス
+type UsedCategoriesResponse struct {
+	Categories []usecase.CategoryCount `json:"categories"`
+}
+
+func (h *ItemHandler) GetUsedCategories(c echo.Context) error {
+	categories, err := h.itemUsecase.GetUsedCategories(c.Request().Context())
+	if err != nil {
+		return respondInternalError(c, err, "failed to retrieve used categories")
+	}
+
+	return c.JSON(http.StatusOK, UsedCategoriesResponse{Categories: categories})
+}
+
+// ページ単位の一覧レスポンス
+// ページ番号で指定するUI向けに、limit / offsetから計算したページ番号の情報も含める
+type ItemPageResponse struct {
+	Items       []ItemResponse `json:"items"`
+	Total       int            `json:"total"` // 条件に一致する全件数
+	Limit       int            `json:"limit"`
+	Offset      int            `json:"offset"`
+	CurrentPage int            `json:"current_page"` // offsetを含むページ（1始まり）
+	TotalPages  int            `json:"total_pages"`  // 全件数をper_page件ずつに分けたページ数（0件の場合は0）
+	PerPage     int            `json:"per_page"`     // limitと同じ値
+	// 検索語（q）で絞り込んだ場合のみ。totalは上限（SEARCH_MAX_RESULTS）までの件数で、total_matchesは一致したすべての件数
+	TotalMatches    int  `json:"total_matches,omitempty"`
+	RefineSuggested bool `json:"refine_suggested,omitempty"` // 一致した件数が上限を超えた
+}
+
+// 全件数をヘッダーで受け取るクライアント（react-adminなど）のため、本文のtotalと同じ値を返すヘッダー
+const headerTotalCount = "X-Total-Count"
+
+// GET /items で検索語（q）を指定した場合の、上限で切り詰める前の一致した件数と、上限を超えたことを示すヘッダー
+const (
+	headerTotalMatches    = "X-Total-Matches"
+	headerRefineSuggested = "X-Refine-Suggested"
+)
+
+// ページ単位の一覧を返す（全件数は本文とX-Total-Countヘッダーの両方に含める）
+func (h *ItemHandler) respondItemPage(c echo.Context, result *usecase.ItemPage) error {
+	c.Response().Header().Set(headerTotalCount, strconv.Itoa(result.Total))
+	response := ItemPageResponse{
+		Items:           newItemResponses(result.Items, h.priceLocaleFor(c)),
+		Total:           result.Total,
+		Limit:           result.Limit,
+		Offset:          result.Offset,
+		PerPage:         result.Limit,
+		TotalMatches:    result.TotalMatches,
+		RefineSuggested: result.RefineSuggested,
+	}
+	if result.Limit > 0 {
+		response.CurrentPage = result.Offset/result.Limit + 1
+		response.TotalPages = (result.Total + result.Limit - 1) / result.Limit
+	}
+	return h.respondProjected(c, http.StatusOK, response)
+}
+
+// 指定したブランド（完全一致、大文字・小文字は区別しない）のアイテムをページ単位で返す
+func (h *ItemHandler) GetBrandItems(c echo.Context) error {
+	brand, err := pathParam(c, "brand")
+	if err != nil || strings.TrimSpace(brand) == "" {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:  CodeInvalidParameter,
+			Error: "invalid brand",
+		})
+	}
+
+	page, err := parsePage(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{err.Error()},
+		})
+	}
+
+	filter := usecase.ItemFilter{Brand: strings.TrimSpace(brand)}
+	result, err := h.itemUsecase.GetItemPage(c.Request().Context(), filter, page)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{err.Error()},
+			})
+		}
+		return respondInternalError(c, err, "failed to retrieve items")
+	}
+
+	return h.respondItemPage(c, result)
+}
+
+// If-None-Match ヘッダーのいずれかが etag と一致するか（弱い比較のため W/ の有無は区別しない）
+func ifNoneMatch(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// If-Match / If-Unmodified-Since ヘッダーから削除の事前条件を組み立てる
+func parseDeletePreconditions(c echo.Context) usecase.DeleteItemInput {
+	var input usecase.DeleteItemInput
+
+	if ifMatch := c.Request().Header.Get("If-Match"); ifMatch != "" {
+		for _, etag := range strings.Split(ifMatch, ",") {
+			if etag = strings.TrimSpace(etag); etag != "" {
+				input.IfMatch = append(input.IfMatch, etag)
+			}
+		}
+	}
+
+	// 解釈できない日付は RFC 9110 に従い無視する
+	if value := c.Request().Header.Get("If-Unmodified-Since"); value != "" {
+		if since, err := http.ParseTime(value); err == nil {
+			input.UnmodifiedSince = &since
+		}
+	}
+
+	return input
+}
+
+func (h *ItemHandler) validateCreateItemInput(input usecase.CreateItemInput) []string {
 	var errs []string
 
 	// Basic required field validation
 	if input.Name == "" {
 		errs = append(errs, "name is required")
 	}
-	if input.Category == "" {
+	if input.Category == "" && !h.categoryOptional {
 		errs = append(errs, "category is required")
 	}
 	if input.Brand == "" {
@@ -198,6 +752,8 @@ func validateCreateItemInput(input usecase.CreateItemInput) []string {
 	}
 	if input.PurchasePrice < 0 {
 		errs = append(errs, "purchase_price must be 0 or greater")
+	} else if input.PurchasePrice > entity.MaxPurchasePrice {
+		errs = append(errs, fmt.Sprintf("purchase_price must be %d or less", entity.MaxPurchasePrice))
 	}
 
 	return errs
@@ -228,8 +784,15 @@ func validateUpdateItemInput(input usecase.UpdateItemInput) []string {
 	if input.PurchasePrice != nil {
 		if *input.PurchasePrice < 0 {
 			errs = append(errs, "purchase_price must be 0 or greater")
+		} else if *input.PurchasePrice > entity.MaxPurchasePrice {
+			errs = append(errs, fmt.Sprintf("purchase_price must be %d or less", entity.MaxPurchasePrice))
 		}
 	}
 
+	// status フィールドのバリデーション
+	if input.Status != nil && !input.Status.IsValid() {
+		errs = append(errs, entity.ErrInvalidStatus.Error())
+	}
+
 	return errs
 }
@@ -4,13 +4,22 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"golang.org/x/text/language"
 
 	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
@@ -22,11 +31,19 @@ type MockItemUsecase struct {
 	mock.Mock
 }
 
-func (m *MockItemUsecase) GetAllItems(ctx context.Context) ([]*entity.Item, error) {
-	args := m.Called(ctx)
+func (m *MockItemUsecase) GetAllItems(ctx context.Context, filter usecase.ItemFilter) ([]*entity.Item, error) {
+	args := m.Called(ctx, filter)
 	return args.Get(0).([]*entity.Item), args.Error(1)
 }
 
+func (m *MockItemUsecase) GetItemPage(ctx context.Context, filter usecase.ItemFilter, page usecase.Page) (*usecase.ItemPage, error) {
+	args := m.Called(ctx, filter, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ItemPage), args.Error(1)
+}
+
 func (m *MockItemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -35,6 +52,11 @@ func (m *MockItemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.It
 	return args.Get(0).(*entity.Item), args.Error(1)
 }
 
+func (m *MockItemUsecase) ResolveItemID(ctx context.Context, uuid string) (int64, error) {
+	args := m.Called(ctx, uuid)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockItemUsecase) CreateItem(ctx context.Context, input usecase.CreateItemInput) (*entity.Item, error) {
 	args := m.Called(ctx, input)
 	if args.Get(0) == nil {
@@ -43,6 +65,38 @@ func (m *MockItemUsecase) CreateItem(ctx context.Context, input usecase.CreateIt
 	return args.Get(0).(*entity.Item), args.Error(1)
 }
 
+func (m *MockItemUsecase) UpsertItem(ctx context.Context, input usecase.CreateItemInput) (*entity.Item, bool, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).(*entity.Item), args.Bool(1), args.Error(2)
+}
+
+func (m *MockItemUsecase) ImportItems(ctx context.Context, rows []usecase.ImportRow, mode usecase.ImportMode) (*usecase.ImportResult, error) {
+	args := m.Called(ctx, rows, mode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ImportResult), args.Error(1)
+}
+
+func (m *MockItemUsecase) BulkCreateItemsBestEffort(ctx context.Context, inputs []usecase.CreateItemInput) ([]usecase.BulkItemResult, error) {
+	args := m.Called(ctx, inputs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]usecase.BulkItemResult), args.Error(1)
+}
+
+func (m *MockItemUsecase) BulkCreateItems(ctx context.Context, inputs []usecase.CreateItemInput) ([]*entity.Item, error) {
+	args := m.Called(ctx, inputs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
 func (m *MockItemUsecase) UpdateItem(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
 	args := m.Called(ctx, id, input)
 	if args.Get(0) == nil {
@@ -51,8 +105,24 @@ func (m *MockItemUsecase) UpdateItem(ctx context.Context, id int64, input usecas
 	return args.Get(0).(*entity.Item), args.Error(1)
 }
 
-func (m *MockItemUsecase) DeleteItem(ctx context.Context, id int64) error {
+func (m *MockItemUsecase) ArchiveItem(ctx context.Context, id int64) (*entity.Item, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Item), args.Error(1)
+}
+
+func (m *MockItemUsecase) UnarchiveItem(ctx context.Context, id int64) (*entity.Item, error) {
 	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Item), args.Error(1)
+}
+
+func (m *MockItemUsecase) DeleteItem(ctx context.Context, id int64, input usecase.DeleteItemInput) error {
+	args := m.Called(ctx, id, input)
 	return args.Error(0)
 }
 
@@ -64,6 +134,253 @@ func (m *MockItemUsecase) GetCategorySummary(ctx context.Context) (*usecase.Cate
 	return args.Get(0).(*usecase.CategorySummary), args.Error(1)
 }
 
+func (m *MockItemUsecase) GetChangesSince(ctx context.Context, since time.Time) (*usecase.ItemChangeSet, error) {
+	args := m.Called(ctx, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ItemChangeSet), args.Error(1)
+}
+
+func (m *MockItemUsecase) SyncItems(ctx context.Context, token string) (*usecase.ItemSyncSet, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ItemSyncSet), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetChangeLog(ctx context.Context, since int64, limit int) (*usecase.ChangeLogPage, error) {
+	args := m.Called(ctx, since, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ChangeLogPage), args.Error(1)
+}
+
+func (m *MockItemUsecase) SuggestCategory(ctx context.Context, name string) (*usecase.CategorySuggestion, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.CategorySuggestion), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetDashboard(ctx context.Context, sections []usecase.DashboardSection, limit int) (*usecase.Dashboard, error) {
+	args := m.Called(ctx, sections, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.Dashboard), args.Error(1)
+}
+
+func (m *MockItemUsecase) DeleteItemsByFilter(ctx context.Context, filter usecase.ItemFilter, input usecase.DeleteByFilterInput) (int, error) {
+	args := m.Called(ctx, filter, input)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetDepreciationSchedule(ctx context.Context, id int64, years int, salvage int64) (*usecase.DepreciationSchedule, error) {
+	args := m.Called(ctx, id, years, salvage)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.DepreciationSchedule), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetItemSiblings(ctx context.Context, id int64, filter usecase.ItemFilter) (*usecase.ItemSiblings, error) {
+	args := m.Called(ctx, id, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ItemSiblings), args.Error(1)
+}
+
+func (m *MockItemUsecase) CompareCategorySummary(ctx context.Context, asOf time.Time) (*usecase.SummaryComparison, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.SummaryComparison), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetIncompleteItems(ctx context.Context) ([]usecase.IncompleteItem, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]usecase.IncompleteItem), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetCategorySummaries(ctx context.Context, categories []string) ([]usecase.CategorySummaryEntry, error) {
+	args := m.Called(ctx, categories)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]usecase.CategorySummaryEntry), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetUsedCategories(ctx context.Context) ([]usecase.CategoryCount, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]usecase.CategoryCount), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetBrandSummary(ctx context.Context, filter usecase.ItemFilter) ([]usecase.BrandSummaryEntry, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]usecase.BrandSummaryEntry), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetItemListVersion(ctx context.Context, filter usecase.ItemFilter) (*usecase.ListVersion, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ListVersion), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetGroupSummary(ctx context.Context, filter usecase.ItemFilter, by usecase.GroupBy) ([]usecase.GroupSummaryEntry, error) {
+	args := m.Called(ctx, filter, by)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]usecase.GroupSummaryEntry), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetDiversity(ctx context.Context, filter usecase.ItemFilter) (*usecase.Diversity, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.Diversity), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetTimeline(ctx context.Context, filter usecase.ItemFilter) ([]usecase.TimelineEntry, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]usecase.TimelineEntry), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetTrends(ctx context.Context, filter usecase.ItemFilter) ([]usecase.TrendEntry, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]usecase.TrendEntry), args.Error(1)
+}
+
+func TestItemHandler_GetItems(t *testing.T) {
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "正常系: 0件の場合は空配列",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetItemListVersion", mock.Anything, usecase.ItemFilter{}).Return(&usecase.ListVersion{}, nil)
+				mockUsecase.On("GetAllItems", mock.Anything, usecase.ItemFilter{}).Return([]*entity.Item{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "[]",
+		},
+		{
+			name: "正常系: nilが返された場合も空配列",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetItemListVersion", mock.Anything, usecase.ItemFilter{}).Return(&usecase.ListVersion{}, nil)
+				mockUsecase.On("GetAllItems", mock.Anything, usecase.ItemFilter{}).Return(([]*entity.Item)(nil), nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "[]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			req := httptest.NewRequest(http.MethodGet, "/items", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := handler.GetItems(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedBody, strings.TrimSpace(rec.Body.String()))
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_GetItems_MaxSearchResults(t *testing.T) {
+	e := echo.New()
+	items := []*entity.Item{{ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX"}}
+	isQuery := mock.MatchedBy(func(filter usecase.ItemFilter) bool { return filter.Query == "rolex" })
+
+	tests := []struct {
+		name            string
+		version         *usecase.ListVersion
+		expectedTotal   string
+		expectedMatches string
+		expectedRefine  string
+	}{
+		{
+			name:            "正常系: 上限を超えた場合は一致した件数と絞り込みの案内をヘッダーで返す",
+			version:         &usecase.ListVersion{Count: 2500, SearchWindow: 1000},
+			expectedTotal:   "1000",
+			expectedMatches: "2500",
+			expectedRefine:  "true",
+		},
+		{
+			name:            "正常系: 上限以下の場合は絞り込みを促さない",
+			version:         &usecase.ListVersion{Count: 1, SearchWindow: 1000},
+			expectedTotal:   "1",
+			expectedMatches: "1",
+		},
+		{
+			name:          "正常系: 上限が無い場合は一致した件数を返さない",
+			version:       &usecase.ListVersion{Count: 2500},
+			expectedTotal: "2500",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			mockUsecase.On("GetItemListVersion", mock.Anything, isQuery).Return(tt.version, nil)
+			mockUsecase.On("GetAllItems", mock.Anything, isQuery).Return(items, nil)
+			handler := NewItemHandler(mockUsecase)
+
+			req := httptest.NewRequest(http.MethodGet, "/items?q=rolex", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, handler.GetItems(c))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.expectedTotal, rec.Header().Get(headerTotalCount))
+			assert.Equal(t, tt.expectedMatches, rec.Header().Get(headerTotalMatches))
+			assert.Equal(t, tt.expectedRefine, rec.Header().Get(headerRefineSuggested))
+			var body []map[string]interface{}
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Len(t, body, 1)
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
 func TestItemHandler_UpdateItem(t *testing.T) {
 	e := echo.New()
 
@@ -74,6 +391,15 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 		setupMock      func(*MockItemUsecase)
 		expectedStatus int
 	}{
+		{
+			name: "異常系: purchase_priceが小数",
+			id:   "1",
+			requestBody: map[string]interface{}{
+				"purchase_price": 1000.5,
+			},
+			setupMock:      func(mockUsecase *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name: "正常系: nameのみ更新",
 			id:   "1",
@@ -159,6 +485,20 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 			},
 			expectedStatus: http.StatusNotFound,
 		},
+		{
+			name: "異常系: 条件付き更新の条件に一致しない",
+			id:   "1",
+			requestBody: map[string]interface{}{
+				"status": "archived",
+				"if":     map[string]interface{}{"status": "active"},
+			},
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				archived, active := entity.StatusArchived, entity.StatusActive
+				input := usecase.UpdateItemInput{Status: &archived, If: &usecase.UpdateCondition{Status: &active}}
+				mockUsecase.On("UpdateItem", mock.Anything, int64(1), input).Return((*entity.Item)(nil), fmt.Errorf("%w: status is \"draft\"", domainErrors.ErrUpdateConditionFailed))
+			},
+			expectedStatus: http.StatusConflict,
+		},
 		{
 			name: "異常系: バリデーションエラー（空のname）",
 			id:   "1",
@@ -308,3 +648,2906 @@ func TestItemHandler_UpdateItem_HTTPResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestParseCreatedSince(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Time
+		wantErr  bool
+	}{
+		{name: "正常系: 日数指定", value: "7d", expected: now.AddDate(0, 0, -7)},
+		{name: "正常系: 時間指定", value: "12h", expected: now.Add(-12 * time.Hour)},
+		{name: "正常系: RFC3339", value: "2024-06-01T09:00:00Z", expected: time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)},
+		{name: "正常系: 日付のみ", value: "2024-06-01", expected: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "異常系: 負の日数", value: "-7d", wantErr: true},
+		{name: "異常系: 不正な形式", value: "last week", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			since, err := parseCreatedSince(tt.value, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, tt.expected.Equal(since), "expected %v, got %v", tt.expected, since)
+		})
+	}
+}
+
+func TestParseItemFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected []entity.Category
+		wantErr  bool
+	}{
+		{name: "正常系: 単一カテゴリー", query: "category=時計", expected: []entity.Category{entity.CategoryWatch}},
+		{name: "正常系: 複数カテゴリー（重複と空白は除く）", query: "category=" + url.QueryEscape("時計, バッグ,,時計"), expected: []entity.Category{entity.CategoryWatch, entity.CategoryBag}},
+		{name: "正常系: 指定なし", query: ""},
+		{name: "異常系: 無効なカテゴリーを含む", query: "category=" + url.QueryEscape("時計,家電"), wantErr: true},
+	}
+
+	t.Run("正常系: 状態を指定", func(t *testing.T) {
+		e := echo.New()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/items?status=draft", nil), httptest.NewRecorder())
+
+		filter, err := ParseItemFilter(c)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.StatusDraft, filter.Status)
+	})
+
+	t.Run("異常系: 無効な状態", func(t *testing.T) {
+		e := echo.New()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/items?status=sold", nil), httptest.NewRecorder())
+
+		_, err := ParseItemFilter(c)
+		assert.EqualError(t, err, "unknown status: sold")
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/items?"+tt.query, nil)
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			filter, err := ParseItemFilter(c)
+			if tt.wantErr {
+				assert.EqualError(t, err, "unknown category: 家電")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, filter.Categories)
+		})
+	}
+}
+
+func TestParseItemFilter_ExcludeCategories(t *testing.T) {
+	t.Run("正常系: 複数カテゴリーを除外", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/items?category="+url.QueryEscape("時計")+"&exclude_category="+url.QueryEscape("その他,バッグ"), nil)
+		c := e.NewContext(req, httptest.NewRecorder())
+
+		filter, err := ParseItemFilter(c)
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.Category{entity.CategoryWatch}, filter.Categories)
+		assert.Equal(t, []entity.Category{entity.CategoryOther, entity.CategoryBag}, filter.ExcludeCategories)
+	})
+
+	t.Run("正常系: 前後の空白を除いて重複をまとめる", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/items?category="+url.QueryEscape(" 時計 ,時計\t,バッグ "), nil)
+		c := e.NewContext(req, httptest.NewRecorder())
+
+		filter, err := ParseItemFilter(c)
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.Category{entity.CategoryWatch, entity.CategoryBag}, filter.Categories)
+	})
+
+	t.Run("異常系: 無効なカテゴリーを含む", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/items?exclude_category="+url.QueryEscape("その他,家電"), nil)
+		c := e.NewContext(req, httptest.NewRecorder())
+
+		_, err := ParseItemFilter(c)
+		assert.EqualError(t, err, "unknown category: 家電")
+	})
+}
+
+func TestParseItemFilter_PurchaseMonthAndWeekday(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		expectedMonth    int
+		expectedWeekdays []time.Weekday
+		expectedErr      string
+	}{
+		{name: "正常系: 月のみ", query: "month=12", expectedMonth: 12},
+		{name: "正常系: 週末（大文字・重複を含む）", query: "weekday=SAT,sun,sat", expectedWeekdays: []time.Weekday{time.Saturday, time.Sunday}},
+		{name: "正常系: 月と曜日", query: "month=1&weekday=mon", expectedMonth: 1, expectedWeekdays: []time.Weekday{time.Monday}},
+		{name: "異常系: 月が範囲外", query: "month=13", expectedErr: "month must be an integer between 1 and 12"},
+		{name: "異常系: 月が数値でない", query: "month=dec", expectedErr: "month must be an integer between 1 and 12"},
+		{name: "異常系: 不明な曜日", query: "weekday=saturday", expectedErr: "unknown weekday: saturday (use sun, mon, tue, wed, thu, fri or sat)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/items?"+tt.query, nil)
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			filter, err := ParseItemFilter(c)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedMonth, filter.PurchaseMonth)
+			assert.Equal(t, tt.expectedWeekdays, filter.PurchaseWeekdays)
+		})
+	}
+}
+
+func TestParseItemFilter_Query(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		expectedQuery string
+		expectedFuzzy bool
+		wantErr       bool
+	}{
+		{name: "正常系: qのみ", query: "q=" + url.QueryEscape(" デイトナ "), expectedQuery: "デイトナ"},
+		{name: "正常系: あいまい検索", query: "q=omoga&fuzzy=true", expectedQuery: "omoga", expectedFuzzy: true},
+		{name: "異常系: fuzzyが真偽値でない", query: "q=omoga&fuzzy=maybe", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/items?"+tt.query, nil)
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			filter, err := ParseItemFilter(c)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedQuery, filter.Query)
+			assert.Equal(t, tt.expectedFuzzy, filter.Fuzzy)
+		})
+	}
+}
+
+func TestParseItemFilter_SerialPrefix(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/items?serial_prefix="+url.QueryEscape(" ABC "), nil)
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	filter, err := ParseItemFilter(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ABC", filter.SerialPrefix)
+}
+
+func TestParseItemFilter_IDRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    usecase.ItemFilter
+		wantErr bool
+	}{
+		{name: "正常系: 範囲を指定", query: "id_from=100&id_to=200", want: usecase.ItemFilter{IDFrom: 100, IDTo: 200}},
+		{name: "異常系: 数値でない", query: "id_from=abc&id_to=200", wantErr: true},
+		{name: "異常系: 0以下", query: "id_from=0&id_to=200", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/items?"+tt.query, nil)
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			filter, err := ParseItemFilter(c)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, filter)
+		})
+	}
+}
+
+func TestNewItemResponse(t *testing.T) {
+	item, _ := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+	item.ID = 1
+
+	body, err := json.Marshal(newItemResponse(item, DefaultPriceLocale))
+	assert.NoError(t, err)
+
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(body, &response))
+	assert.Equal(t, map[string]interface{}{"amount": float64(1500000), "currency": "JPY"}, response["purchase_price"])
+	assert.Equal(t, "¥1,500,000", response["purchase_price_display"])
+	assert.Equal(t, "ロレックス デイトナ", response["name"])
+}
+
+func TestFormatMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		money    entity.Money
+		locale   language.Tag
+		expected string
+	}{
+		{name: "正常系: 日本語の円（半角の記号）", money: entity.JPY(1000000), locale: language.Japanese, expected: "¥1,000,000"},
+		{name: "正常系: 英語の円", money: entity.JPY(1000000), locale: language.AmericanEnglish, expected: "¥1,000,000"},
+		{name: "正常系: ドイツ語の桁区切り", money: entity.JPY(1000000), locale: language.German, expected: "¥1.000.000"},
+		{name: "正常系: 英語のドル（セント単位の金額）", money: entity.NewMoney(100000, "USD"), locale: language.AmericanEnglish, expected: "$1,000.00"},
+		{name: "正常系: 負の金額", money: entity.JPY(-1500), locale: language.Japanese, expected: "-¥1,500"},
+		{name: "正常系: 不明な通貨はそのまま", money: entity.NewMoney(1000, "XYZ1"), locale: language.Japanese, expected: "1000 XYZ1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatMoney(tt.money, tt.locale))
+		})
+	}
+}
+
+func TestItemHandler_PriceLocaleFor(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		expected       language.Tag
+	}{
+		{name: "正常系: 最も優先度の高い言語", acceptLanguage: "de;q=0.5, en-US, ja;q=0.8", expected: language.AmericanEnglish},
+		{name: "正常系: 指定なしは既定のロケール", acceptLanguage: "", expected: language.French},
+		{name: "正常系: ワイルドカードのみは既定のロケール", acceptLanguage: "*", expected: language.French},
+		{name: "異常系: 解釈できない場合は既定のロケール", acceptLanguage: "!!!", expected: language.French},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewItemHandler(new(MockItemUsecase), WithPriceLocale(language.French))
+			req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			assert.Equal(t, tt.expected, handler.priceLocaleFor(c))
+			assert.Equal(t, "Accept-Language", rec.Header().Get("Vary"))
+		})
+	}
+}
+
+func TestItemHandler_UpsertItem(t *testing.T) {
+	e := echo.New()
+	validBody := map[string]interface{}{
+		"name":           "ロレックス デイトナ",
+		"category":       "時計",
+		"brand":          "ROLEX",
+		"purchase_price": 1500000,
+		"purchase_date":  "2023-01-15",
+		"serial_number":  "SN-001",
+	}
+
+	tests := []struct {
+		name           string
+		requestBody    map[string]interface{}
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+	}{
+		{
+			name:        "正常系: 新規登録は201",
+			requestBody: validBody,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				item, _ := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+				mockUsecase.On("UpsertItem", mock.Anything, mock.AnythingOfType("usecase.CreateItemInput")).Return(item, true, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:        "正常系: 既存アイテムの更新は200",
+			requestBody: validBody,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				item, _ := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+				mockUsecase.On("UpsertItem", mock.Anything, mock.AnythingOfType("usecase.CreateItemInput")).Return(item, false, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "異常系: serial_numberが無い",
+			requestBody: map[string]interface{}{
+				"name":           "ロレックス デイトナ",
+				"category":       "時計",
+				"brand":          "ROLEX",
+				"purchase_price": 1500000,
+				"purchase_date":  "2023-01-15",
+			},
+			setupMock:      func(mockUsecase *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			reqBody, err := json.Marshal(tt.requestBody)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPut, "/items", bytes.NewReader(reqBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err = handler.UpsertItem(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestParseImportCSV(t *testing.T) {
+	tests := []struct {
+		name        string
+		csv         string
+		maxRows     int
+		expected    []usecase.ImportRow
+		expectedErr string
+	}{
+		{
+			name: "正常系: 列の順序に関係なく読み込み、行番号はヘッダーを1行目とする",
+			csv:  "\ufeffbrand,name,category,purchase_price,purchase_date,serial_number\nROLEX,デイトナ,時計,1500000,2023-01-15,SN-001\nHERMÈS,バーキン,バッグ,2000000,2023-02-20,\n",
+			expected: []usecase.ImportRow{
+				{Line: 2, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: "1500000", PurchaseDate: "2023-01-15", SerialNumber: "SN-001"},
+				{Line: 3, Name: "バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: "2000000", PurchaseDate: "2023-02-20"},
+			},
+		},
+		{
+			name:        "異常系: 必須列が無い",
+			csv:         "name,category,brand,purchase_price\nデイトナ,時計,ROLEX,1500000\n",
+			expectedErr: "missing column: purchase_date",
+		},
+		{
+			name:        "異常系: データ行が無い",
+			csv:         "name,category,brand,purchase_price,purchase_date\n",
+			expectedErr: "csv has no data rows",
+		},
+		{
+			name:        "異常系: 行数が上限を超える",
+			csv:         "name,category,brand,purchase_price,purchase_date\nA,時計,ROLEX,1,2023-01-15\nB,時計,ROLEX,1,2023-01-15\n",
+			maxRows:     1,
+			expectedErr: "too many rows",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := parseImportCSV(strings.NewReader(tt.csv), tt.maxRows)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, rows)
+		})
+	}
+}
+
+func TestItemHandler_ImportItems_Limits(t *testing.T) {
+	e := echo.New()
+	validCSV := "name,category,brand,purchase_price,purchase_date\nデイトナ,時計,ROLEX,1500000,2023-01-15\n"
+	limits := ImportLimits{MaxBytes: int64(len(validCSV)) + 512, MaxRows: 1}
+
+	multipartBody := func(content string) (*bytes.Buffer, string) {
+		buf := &bytes.Buffer{}
+		writer := multipart.NewWriter(buf)
+		part, _ := writer.CreateFormFile("file", "items.csv")
+		_, _ = part.Write([]byte(content))
+		_ = writer.Close()
+		return buf, writer.FormDataContentType()
+	}
+
+	tests := []struct {
+		name           string
+		newRequest     func() *http.Request
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+	}{
+		{
+			name: "正常系: 上限以内のmultipartファイル",
+			newRequest: func() *http.Request {
+				body, contentType := multipartBody(validCSV)
+				req := httptest.NewRequest(http.MethodPost, "/items/import", body)
+				req.Header.Set(echo.HeaderContentType, contentType)
+				return req
+			},
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("ImportItems", mock.Anything, mock.Anything, usecase.ImportModeStrict).
+					Return(&usecase.ImportResult{Mode: usecase.ImportModeStrict, TotalRows: 1, Imported: 1, Committed: true, Errors: []usecase.ImportError{}}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "異常系: Content-Lengthが上限を超える",
+			newRequest: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/items/import", strings.NewReader(validCSV+strings.Repeat("x", 1024)))
+			},
+			setupMock:      func(mockUsecase *MockItemUsecase) {},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "異常系: Content-Lengthが無く読み込み中に上限を超える",
+			newRequest: func() *http.Request {
+				body, contentType := multipartBody(validCSV + strings.Repeat("x", 1024))
+				req := httptest.NewRequest(http.MethodPost, "/items/import", body)
+				req.Header.Set(echo.HeaderContentType, contentType)
+				req.ContentLength = -1
+				return req
+			},
+			setupMock:      func(mockUsecase *MockItemUsecase) {},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "異常系: 行数が上限を超える",
+			newRequest: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/items/import", strings.NewReader(validCSV+"バーキン,バッグ,HERMÈS,2000000,2023-02-20\n"))
+			},
+			setupMock:      func(mockUsecase *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase, WithImportLimits(limits))
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(tt.newRequest(), rec)
+
+			err := handler.ImportItems(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_Prefer(t *testing.T) {
+	e := echo.New()
+	createBody := `{"name":"ロレックス デイトナ","category":"時計","brand":"ROLEX","purchase_price":1500000,"purchase_date":"2023-01-15"}`
+
+	tests := []struct {
+		name               string
+		method             string
+		prefer             string
+		setupMock          func(*MockItemUsecase)
+		expectedStatus     int
+		expectedBody       bool
+		expectedLocation   string
+		expectedPreference string
+	}{
+		{
+			name:   "正常系: 登録でreturn=minimalなら本文無しの201",
+			method: http.MethodPost,
+			prefer: "return=minimal",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("CreateItem", mock.Anything, mock.AnythingOfType("usecase.CreateItemInput")).Return(&entity.Item{ID: 5, Name: "ロレックス デイトナ"}, nil)
+			},
+			expectedStatus:     http.StatusCreated,
+			expectedLocation:   "/items/5",
+			expectedPreference: "return=minimal",
+		},
+		{
+			name:   "正常系: 登録でPrefer無しなら本文を返す",
+			method: http.MethodPost,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("CreateItem", mock.Anything, mock.AnythingOfType("usecase.CreateItemInput")).Return(&entity.Item{ID: 5, Name: "ロレックス デイトナ"}, nil)
+			},
+			expectedStatus:   http.StatusCreated,
+			expectedBody:     true,
+			expectedLocation: "/items/5",
+		},
+		{
+			name:   "正常系: 更新でreturn=minimalなら204",
+			method: http.MethodPatch,
+			prefer: `wait=10, return="minimal"`,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("UpdateItem", mock.Anything, int64(1), mock.AnythingOfType("usecase.UpdateItemInput")).Return(&entity.Item{ID: 1, Name: "更新後"}, nil)
+			},
+			expectedStatus:     http.StatusNoContent,
+			expectedPreference: "return=minimal",
+		},
+		{
+			name:   "正常系: 更新でreturn=representationなら本文を返す",
+			method: http.MethodPatch,
+			prefer: "return=representation",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("UpdateItem", mock.Anything, int64(1), mock.AnythingOfType("usecase.UpdateItemInput")).Return(&entity.Item{ID: 1, Name: "更新後"}, nil)
+			},
+			expectedStatus:     http.StatusOK,
+			expectedBody:       true,
+			expectedPreference: "return=representation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			body := createBody
+			path := "/items"
+			if tt.method == http.MethodPatch {
+				body = `{"name":"更新後"}`
+				path = "/items/1"
+			}
+			req := httptest.NewRequest(tt.method, path, strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tt.prefer != "" {
+				req.Header.Set("Prefer", tt.prefer)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			var err error
+			if tt.method == http.MethodPatch {
+				c.SetParamNames("id")
+				c.SetParamValues("1")
+				err = handler.UpdateItem(c)
+			} else {
+				err = handler.CreateItem(c)
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedBody, rec.Body.Len() > 0)
+			assert.Equal(t, tt.expectedLocation, rec.Header().Get(echo.HeaderLocation))
+			assert.Equal(t, tt.expectedPreference, rec.Header().Get("Preference-Applied"))
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_CreateItem_ServerFields(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	stored := &entity.Item{
+		ID: 7, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15",
+		Status: entity.StatusActive, CreatedAt: createdAt, UpdatedAt: createdAt,
+	}
+	mockUsecase := new(MockItemUsecase)
+	mockUsecase.On("CreateItem", mock.Anything, mock.AnythingOfType("usecase.CreateItemInput")).Return(stored, nil)
+	handler := NewItemHandler(mockUsecase)
+
+	e := echo.New()
+	body := `{"name":"ロレックス デイトナ","category":"時計","brand":"ROLEX","purchase_price":1500000,"purchase_date":"2023-01-15"}`
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	assert.NoError(t, handler.CreateItem(c))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	// サーバー側で決まる値がGETし直さなくても含まれること
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, float64(7), response["id"])
+	assert.NotEmpty(t, response["created_at"])
+	assert.NotEmpty(t, response["updated_at"])
+	assert.Equal(t, "active", response["status"])
+	assert.Equal(t, stored.PurchasePrice.String(), response["purchase_price_display"])
+	assert.Equal(t, stored.ETag(), rec.Header().Get("ETag"))
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestItemHandler_GetBrandItems(t *testing.T) {
+	items := []*entity.Item{{ID: 1, Name: "ネヴァーフル", Category: "バッグ", Brand: "LOUIS VUITTON", PurchasePrice: entity.JPY(200000), PurchaseDate: "2023-03-01"}}
+
+	tests := []struct {
+		name           string
+		path           string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+		expectedTotal  int
+	}{
+		{
+			name: "正常系: 空白を含むブランド",
+			path: "/brands/LOUIS%20VUITTON/items?limit=10&offset=0",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetItemPage", mock.Anything, usecase.ItemFilter{Brand: "LOUIS VUITTON"}, usecase.Page{Limit: 10, Offset: 0}).
+					Return(&usecase.ItemPage{Items: items, Total: 1, Limit: 10, Offset: 0}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedTotal:  1,
+		},
+		{
+			name: "正常系: スラッシュを含むブランドはデコードして渡す",
+			path: "/brands/A%2FB%20%25/items",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetItemPage", mock.Anything, usecase.ItemFilter{Brand: "A/B %"}, usecase.Page{Limit: usecase.DefaultPageLimit}).
+					Return(&usecase.ItemPage{Items: []*entity.Item{}, Total: 0, Limit: usecase.DefaultPageLimit}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedTotal:  0,
+		},
+		{
+			name:           "異常系: limitが数値でない",
+			path:           "/brands/ROLEX/items?limit=abc",
+			setupMock:      func(mockUsecase *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "異常系: limitが上限を超える",
+			path: "/brands/ROLEX/items?limit=1000",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetItemPage", mock.Anything, usecase.ItemFilter{Brand: "ROLEX"}, usecase.Page{Limit: 1000}).
+					Return(nil, domainErrors.ErrInvalidInput)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			e.GET("/brands/:brand/items", handler.GetBrandItems)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response ItemPageResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedTotal, response.Total)
+				assert.NotNil(t, response.Items)
+				assert.Equal(t, strconv.Itoa(tt.expectedTotal), rec.Header().Get("X-Total-Count"))
+			} else {
+				assert.Empty(t, rec.Header().Get("X-Total-Count"))
+			}
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_GetBrandItems_PageNumber(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedPage   usecase.Page
+		expectedStatus int
+		expectedMeta   [3]int // current_page, total_pages, per_page
+	}{
+		{name: "正常系: ページ番号で指定", query: "page=3&per_page=20", expectedPage: usecase.Page{Limit: 20, Offset: 40}, expectedStatus: http.StatusOK, expectedMeta: [3]int{3, 3, 20}},
+		{name: "正常系: pageのみの場合はlimitを1ページの件数にする", query: "page=2&limit=10", expectedPage: usecase.Page{Limit: 10, Offset: 10}, expectedStatus: http.StatusOK, expectedMeta: [3]int{2, 5, 10}},
+		{name: "正常系: ページ番号の指定をlimit / offsetより優先する", query: "page=2&per_page=25&limit=10&offset=5", expectedPage: usecase.Page{Limit: 25, Offset: 25}, expectedStatus: http.StatusOK, expectedMeta: [3]int{2, 2, 25}},
+		{name: "正常系: offsetで指定した場合もページ番号を返す", query: "limit=20&offset=20", expectedPage: usecase.Page{Limit: 20, Offset: 20}, expectedStatus: http.StatusOK, expectedMeta: [3]int{2, 3, 20}},
+		{name: "異常系: pageが0", query: "page=0", expectedStatus: http.StatusBadRequest},
+		{name: "異常系: per_pageが上限を超える", query: "per_page=101", expectedStatus: http.StatusBadRequest},
+		{name: "異常系: pageが大きすぎる", query: "page=999999999999&per_page=100", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			if tt.expectedStatus == http.StatusOK {
+				mockUsecase.On("GetItemPage", mock.Anything, usecase.ItemFilter{Brand: "ROLEX"}, tt.expectedPage).
+					Return(&usecase.ItemPage{Items: []*entity.Item{}, Total: 50, Limit: tt.expectedPage.Limit, Offset: tt.expectedPage.Offset}, nil)
+			}
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			e.GET("/brands/:brand/items", handler.GetBrandItems)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/brands/ROLEX/items?"+tt.query, nil))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response ItemPageResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedMeta, [3]int{response.CurrentPage, response.TotalPages, response.PerPage})
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_SearchItems(t *testing.T) {
+	items := []*entity.Item{{ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15"}}
+	minPrice, maxPrice := int64(500000), int64(2000000)
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+	}{
+		{
+			name: "正常系: 指定した条件のみで絞り込む",
+			body: `{"category":["時計"],"brand":" ROLEX ","min_purchase_price":500000,"max_purchase_price":2000000,"purchase_date_from":"2020-01-01","sort":"-purchase_price","limit":10,"offset":10}`,
+			setupMock: func(m *MockItemUsecase) {
+				filter := usecase.ItemFilter{
+					Categories:       []entity.Category{entity.CategoryWatch},
+					Brand:            "ROLEX",
+					MinPurchasePrice: &minPrice,
+					MaxPurchasePrice: &maxPrice,
+					PurchasedFrom:    "2020-01-01",
+					Sort:             usecase.SortPurchasePriceDesc,
+				}
+				m.On("GetItemPage", mock.Anything, filter, usecase.Page{Limit: 10, Offset: 10}).
+					Return(&usecase.ItemPage{Items: items, Total: 11, Limit: 10, Offset: 10}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "正常系: 条件なし",
+			body: `{}`,
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetItemPage", mock.Anything, usecase.ItemFilter{}, usecase.Page{Limit: usecase.DefaultPageLimit}).
+					Return(&usecase.ItemPage{Items: items, Total: 11, Limit: usecase.DefaultPageLimit}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: 対応していないフィールド",
+			body:           `{"tags":["vintage"]}`,
+			setupMock:      func(m *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "正常系: 購入日の月と曜日",
+			body: `{"month":12,"weekday":["sat","sun"]}`,
+			setupMock: func(m *MockItemUsecase) {
+				filter := usecase.ItemFilter{PurchaseMonth: 12, PurchaseWeekdays: []time.Weekday{time.Saturday, time.Sunday}}
+				m.On("GetItemPage", mock.Anything, filter, usecase.Page{Limit: usecase.DefaultPageLimit}).
+					Return(&usecase.ItemPage{Items: items, Total: 11, Limit: usecase.DefaultPageLimit}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: 不明な曜日",
+			body:           `{"weekday":["holiday"]}`,
+			setupMock:      func(m *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 無効なカテゴリー",
+			body:           `{"category":["家電"]}`,
+			setupMock:      func(m *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "異常系: 価格の範囲が逆",
+			body: `{"min_purchase_price":2000000,"max_purchase_price":500000}`,
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetItemPage", mock.Anything, usecase.ItemFilter{MinPurchasePrice: &maxPrice, MaxPurchasePrice: &minPrice}, usecase.Page{Limit: usecase.DefaultPageLimit}).
+					Return(nil, domainErrors.ErrInvalidInput)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/items/search", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, handler.SearchItems(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, "11", rec.Header().Get("X-Total-Count"))
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_DeleteItemsByFilter(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "正常系: 削除した件数を返す",
+			body: `{"filter":{"brand":" ROLEX ","category":["時計"]},"confirm":true,"reason":" 売却 "}`,
+			setupMock: func(m *MockItemUsecase) {
+				filter := usecase.ItemFilter{Brand: "ROLEX", Categories: []entity.Category{entity.CategoryWatch}}
+				m.On("DeleteItemsByFilter", mock.Anything, filter, usecase.DeleteByFilterInput{Confirm: true, Reason: "売却"}).Return(3, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"deleted":3}`,
+		},
+		{
+			name: "異常系: confirmが無い",
+			body: `{"filter":{"brand":"ROLEX"}}`,
+			setupMock: func(m *MockItemUsecase) {
+				m.On("DeleteItemsByFilter", mock.Anything, usecase.ItemFilter{Brand: "ROLEX"}, usecase.DeleteByFilterInput{}).
+					Return(0, fmt.Errorf("%w: confirm must be true to delete items by filter", domainErrors.ErrInvalidInput))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 条件に対応していないフィールド",
+			body:           `{"filter":{"brnad":"ROLEX"},"confirm":true}`,
+			setupMock:      func(m *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: limitは指定できない",
+			body:           `{"filter":{"brand":"ROLEX","limit":10},"confirm":true}`,
+			setupMock:      func(m *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 無効なカテゴリー",
+			body:           `{"filter":{"category":["家電"]},"confirm":true}`,
+			setupMock:      func(m *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "異常系: 削除に失敗",
+			body: `{"filter":{"brand":"ROLEX"},"confirm":true}`,
+			setupMock: func(m *MockItemUsecase) {
+				m.On("DeleteItemsByFilter", mock.Anything, usecase.ItemFilter{Brand: "ROLEX"}, usecase.DeleteByFilterInput{Confirm: true}).
+					Return(0, fmt.Errorf("failed to delete items: connection refused"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/items/delete-by-filter", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, handler.DeleteItemsByFilter(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_GetTrash(t *testing.T) {
+	deletedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := []*entity.Item{{ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15", DeletedAt: &deletedAt}}
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+	}{
+		{
+			name:  "正常系: カテゴリーで絞り込み、削除の新しい順",
+			query: "?category=" + url.QueryEscape("時計") + "&sort=-deleted_at&limit=10&offset=10",
+			setupMock: func(m *MockItemUsecase) {
+				filter := usecase.ItemFilter{Categories: []entity.Category{entity.CategoryWatch}, Deleted: true, Sort: usecase.SortDeletedAtDesc}
+				m.On("GetItemPage", mock.Anything, filter, usecase.Page{Limit: 10, Offset: 10}).
+					Return(&usecase.ItemPage{Items: items, Total: 11, Limit: 10, Offset: 10}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "異常系: 不明な並び順",
+			query: "?sort=name",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetItemPage", mock.Anything, usecase.ItemFilter{Deleted: true, Sort: "name"}, usecase.Page{Limit: usecase.DefaultPageLimit}).
+					Return(nil, domainErrors.ErrInvalidInput)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: offsetが数値でない",
+			query:          "?offset=abc",
+			setupMock:      func(m *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/items/trash"+tt.query, nil), rec)
+
+			assert.NoError(t, handler.GetTrash(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response map[string]interface{}
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, float64(11), response["total"])
+				assert.Equal(t, "11", rec.Header().Get("X-Total-Count"))
+				item := response["items"].([]interface{})[0].(map[string]interface{})
+				assert.NotEmpty(t, item["deleted_at"])
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_GetBrandSummary(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "正常系: カテゴリーで絞り込み",
+			query: "?category=" + url.QueryEscape("時計"),
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetBrandSummary", mock.Anything, usecase.ItemFilter{Categories: []entity.Category{entity.CategoryWatch}}).
+					Return([]usecase.BrandSummaryEntry{{Brand: "ROLEX", Count: 2, TotalPurchasePrice: entity.JPY(3000000), AveragePurchasePrice: 1500000}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"brands":[{"brand":"ROLEX","count":2,"total_purchase_price":{"amount":3000000,"currency":"JPY"},"average_purchase_price":1500000}]}`,
+		},
+		{
+			name:           "異常系: 無効なカテゴリー",
+			query:          "?category=" + url.QueryEscape("家電"),
+			setupMock:      func(m *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "異常系: データベースエラー",
+			query: "",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetBrandSummary", mock.Anything, usecase.ItemFilter{}).Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/brands/summary"+tt.query, nil), rec)
+
+			assert.NoError(t, handler.GetBrandSummary(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_ErrorCodes(t *testing.T) {
+	e := echo.New()
+	validBody := `{"name":"ロレックス デイトナ","category":"時計","brand":"ROLEX","purchase_price":1500000,"purchase_date":"2023-01-15","serial_number":"SN-001"}`
+
+	tests := []struct {
+		name           string
+		method         string
+		id             string
+		body           string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:   "存在しないアイテム",
+			method: http.MethodGet,
+			id:     "999",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetItemByID", mock.Anything, int64(999)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   CodeItemNotFound,
+		},
+		{
+			name:   "削除されたアイテム",
+			method: http.MethodGet,
+			id:     "5",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetItemByID", mock.Anything, int64(5)).
+					Return((*entity.Item)(nil), fmt.Errorf("%w: %w", domainErrors.ErrItemNotFound, domainErrors.ErrItemDeleted))
+			},
+			expectedStatus: http.StatusGone,
+			expectedCode:   CodeItemDeleted,
+		},
+		{
+			name:           "不正なID",
+			method:         http.MethodGet,
+			id:             "abc",
+			setupMock:      func(mockUsecase *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeInvalidParameter,
+		},
+		{
+			name:   "データベースエラー",
+			method: http.MethodGet,
+			id:     "1",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetItemByID", mock.Anything, int64(1)).Return((*entity.Item)(nil), domainErrors.ErrDatabaseError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   CodeInternalError,
+		},
+		{
+			name:   "シリアル番号の重複",
+			method: http.MethodPost,
+			body:   validBody,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("CreateItem", mock.Anything, mock.AnythingOfType("usecase.CreateItemInput")).Return((*entity.Item)(nil), domainErrors.ErrDuplicateEntry)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   CodeItemAlreadyExists,
+		},
+		{
+			name:           "バリデーションエラー",
+			method:         http.MethodPost,
+			body:           `{"name":""}`,
+			setupMock:      func(mockUsecase *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeValidationFailed,
+		},
+		{
+			name:           "不正なJSON",
+			method:         http.MethodPost,
+			body:           `{`,
+			setupMock:      func(mockUsecase *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			req := httptest.NewRequest(tt.method, "/items", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			var err error
+			if tt.method == http.MethodGet {
+				c.SetParamNames("id")
+				c.SetParamValues(tt.id)
+				err = handler.GetItem(c)
+			} else {
+				err = handler.CreateItem(c)
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			var response ErrorResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedCode, response.Code)
+			assert.NotEmpty(t, response.Error)
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDuplicateEntryResponse(t *testing.T) {
+	dupErr := &domainErrors.DuplicateEntryError{Field: "serial_number", Message: "An item with this serial number already exists."}
+
+	response := duplicateEntryResponse(fmt.Errorf("failed to create item: %w", dupErr))
+	assert.Equal(t, CodeItemAlreadyExists, response.Code)
+	assert.Equal(t, []string{"An item with this serial number already exists."}, response.Details)
+
+	// 制約が分からない場合はDBのエラーメッセージを返さない
+	response = duplicateEntryResponse(domainErrors.ErrDuplicateEntry)
+	assert.Equal(t, CodeItemAlreadyExists, response.Code)
+	assert.Empty(t, response.Details)
+}
+
+func TestItemHandler_GetItemCard(t *testing.T) {
+	item := &entity.Item{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15", SerialNumber: "SN-001"}
+
+	tests := []struct {
+		name           string
+		id             string
+		opts           []HandlerOption
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+	}{
+		{
+			name: "正常系: デフォルトのフィールド",
+			id:   "1",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetItemByID", mock.Anything, int64(1)).Return(item, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   map[string]interface{}{"name": "ロレックス デイトナ", "brand": "ROLEX", "category": "時計"},
+		},
+		{
+			name: "正常系: フィールドを指定",
+			id:   "1",
+			opts: []HandlerOption{WithCardFields([]string{"id", "name", "purchase_date"})},
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetItemByID", mock.Anything, int64(1)).Return(item, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   map[string]interface{}{"id": float64(1), "name": "ロレックス デイトナ", "purchase_date": "2023-01-15"},
+		},
+		{
+			name: "異常系: 存在しないアイテム",
+			id:   "999",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetItemByID", mock.Anything, int64(999)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "異常系: 無効なID",
+			id:             "abc",
+			setupMock:      func(mockUsecase *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase, tt.opts...)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/items/"+tt.id+"/card", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+
+			err := handler.GetItemCard(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != nil {
+				var body map[string]interface{}
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, tt.expectedBody, body)
+			}
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestParseCardFields(t *testing.T) {
+	fields, err := ParseCardFields(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultCardFields, fields)
+
+	fields, err = ParseCardFields([]string{"name", " brand ", "name"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"name", "brand"}, fields)
+
+	_, err = ParseCardFields([]string{"name", "purchase_price"})
+	assert.Error(t, err)
+}
+
+func TestItemHandler_DeleteItem_Idempotent(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           []HandlerOption
+		ifMatch        string
+		expectedStatus []int // 1回目と2回目のステータス
+	}{
+		{
+			name:           "正常系: 2回目の削除も成功とする",
+			expectedStatus: []int{http.StatusNoContent, http.StatusNoContent},
+		},
+		{
+			name:           "異常系: 厳密モードでは2回目は404",
+			opts:           []HandlerOption{WithIdempotentDelete(false)},
+			expectedStatus: []int{http.StatusNoContent, http.StatusNotFound},
+		},
+		{
+			name:           "異常系: 条件付き削除では2回目は404",
+			ifMatch:        `"abc"`,
+			expectedStatus: []int{http.StatusNoContent, http.StatusNotFound},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			mockUsecase.On("DeleteItem", mock.Anything, int64(1), mock.Anything).Return(nil).Once()
+			mockUsecase.On("DeleteItem", mock.Anything, int64(1), mock.Anything).Return(domainErrors.ErrItemNotFound).Once()
+			handler := NewItemHandler(mockUsecase, tt.opts...)
+
+			e := echo.New()
+			for _, expected := range tt.expectedStatus {
+				req := httptest.NewRequest(http.MethodDelete, "/items/1", nil)
+				if tt.ifMatch != "" {
+					req.Header.Set("If-Match", tt.ifMatch)
+				}
+				rec := httptest.NewRecorder()
+				c := e.NewContext(req, rec)
+				c.SetParamNames("id")
+				c.SetParamValues("1")
+
+				assert.NoError(t, handler.DeleteItem(c))
+				assert.Equal(t, expected, rec.Code)
+			}
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_UpdateItem_EmptyBody(t *testing.T) {
+	item := &entity.Item{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15"}
+
+	tests := []struct {
+		name           string
+		opts           []HandlerOption
+		body           string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "異常系: 既定では空のボディは400",
+			body:           "",
+			setupMock:      func(m *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeValidationFailed,
+		},
+		{
+			name:           "異常系: 既定では空のオブジェクトは400",
+			body:           "{}",
+			setupMock:      func(m *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeValidationFailed,
+		},
+		{
+			name: "正常系: 変更なしとして現在のアイテムを返す",
+			opts: []HandlerOption{WithEmptyPatchNoOp(true)},
+			body: "{}",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("UpdateItem", mock.Anything, int64(1), usecase.UpdateItemInput{}).Return(item, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "正常系: 空のボディも変更なしとして扱う",
+			opts: []HandlerOption{WithEmptyPatchNoOp(true)},
+			body: "",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("UpdateItem", mock.Anything, int64(1), usecase.UpdateItemInput{}).Return(item, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "異常系: 変更なしでも存在しないアイテムは404",
+			opts: []HandlerOption{WithEmptyPatchNoOp(true)},
+			body: "{}",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("UpdateItem", mock.Anything, int64(1), usecase.UpdateItemInput{}).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   CodeItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase, tt.opts...)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPatch, "/items/1", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			assert.NoError(t, handler.UpdateItem(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedCode != "" {
+				assert.Contains(t, rec.Body.String(), tt.expectedCode)
+			} else {
+				assert.Contains(t, rec.Body.String(), `"name":"ロレックス デイトナ"`)
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_ArchiveItem(t *testing.T) {
+	archivedItem := &entity.Item{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15", Status: entity.StatusArchived}
+
+	tests := []struct {
+		name           string
+		id             string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "正常系: アーカイブ",
+			id:   "1",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("ArchiveItem", mock.Anything, int64(1)).Return(archivedItem, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "異常系: 下書きはアーカイブできない",
+			id:   "1",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("ArchiveItem", mock.Anything, int64(1)).Return(nil, domainErrors.ErrInvalidStatusTransition)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   CodeInvalidStatusTransition,
+		},
+		{
+			name: "異常系: アイテムが存在しない",
+			id:   "1",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("ArchiveItem", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   CodeItemNotFound,
+		},
+		{
+			name:           "異常系: 無効なID",
+			id:             "abc",
+			setupMock:      func(m *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeInvalidParameter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/items/"+tt.id+"/archive", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+
+			assert.NoError(t, handler.ArchiveItem(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			var response map[string]interface{}
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			if tt.expectedCode != "" {
+				assert.Equal(t, tt.expectedCode, response["code"])
+			} else {
+				assert.Equal(t, "archived", response["status"])
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_GetItemDepreciation(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+	}{
+		{
+			name:  "正常系: 残存価額を指定",
+			query: "years=5&salvage=100000",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetDepreciationSchedule", mock.Anything, int64(1), 5, int64(100000)).
+					Return(&usecase.DepreciationSchedule{ItemID: 1, Years: 5}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "正常系: 残存価額は省略時0",
+			query: "years=5",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetDepreciationSchedule", mock.Anything, int64(1), 5, int64(0)).
+					Return(&usecase.DepreciationSchedule{ItemID: 1, Years: 5}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: yearsが未指定",
+			query:          "salvage=100000",
+			setupMock:      func(mockUsecase *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "異常系: 残存価額が購入価格を超える",
+			query: "years=5&salvage=99999999",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetDepreciationSchedule", mock.Anything, int64(1), 5, int64(99999999)).
+					Return(nil, domainErrors.ErrInvalidInput)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "異常系: 存在しないアイテム",
+			query: "years=5",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetDepreciationSchedule", mock.Anything, int64(1), 5, int64(0)).
+					Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/items/1/depreciation?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			assert.NoError(t, handler.GetItemDepreciation(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestParseAsOf(t *testing.T) {
+	defer entity.SetTimeZone(entity.TimeZone())
+	jst := time.FixedZone("JST", 9*60*60)
+	entity.SetTimeZone(jst)
+
+	asOf, err := parseAsOf("2024-01-15T10:00:00Z")
+	assert.NoError(t, err)
+	assert.True(t, time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC).Equal(asOf))
+
+	// 日付のみの場合は設定したタイムゾーンでのその日の終わり
+	asOf, err = parseAsOf("2024-01-15")
+	assert.NoError(t, err)
+	assert.True(t, time.Date(2024, 1, 15, 23, 59, 59, 0, jst).Equal(asOf))
+
+	_, err = parseAsOf("last month")
+	assert.Error(t, err)
+}
+
+func TestItemHandler_CreateItem_LargePrice(t *testing.T) {
+	maxPrice := strconv.FormatInt(entity.MaxPurchasePrice, 10)
+
+	tests := []struct {
+		name           string
+		price          string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+		expectedDetail string
+	}{
+		{
+			name:  "正常系: 上限の購入価格",
+			price: maxPrice,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				input := usecase.CreateItemInput{Name: "高額品", Category: "時計", Brand: "PATEK PHILIPPE", PurchasePrice: entity.MaxPurchasePrice, PurchaseDate: "2023-01-15"}
+				item := &entity.Item{ID: 1, Name: input.Name, Category: input.Category, Brand: input.Brand, PurchasePrice: entity.JPY(input.PurchasePrice), PurchaseDate: input.PurchaseDate}
+				mockUsecase.On("CreateItem", mock.Anything, input).Return(item, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			// 集計で合計があふれないよう、int64の範囲内でも上限を超える値は受け付けない
+			name:           "異常系: 上限を超える",
+			price:          "9223372036854775807",
+			setupMock:      func(mockUsecase *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedDetail: "purchase_price must be " + maxPrice + " or less",
+		},
+		{
+			name:           "異常系: int64の範囲を超える",
+			price:          "9223372036854775808",
+			setupMock:      func(mockUsecase *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedDetail: "purchase_price must be between 0 and " + maxPrice,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			body := `{"name":"高額品","category":"時計","brand":"PATEK PHILIPPE","purchase_price":` + tt.price + `,"purchase_date":"2023-01-15"}`
+			req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, handler.CreateItem(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedDetail != "" {
+				var response ErrorResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, []string{tt.expectedDetail}, response.Details)
+			} else {
+				assert.Contains(t, rec.Body.String(), `"purchase_price":{"amount":`+maxPrice+`,"currency":"JPY"}`)
+			}
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_GetSummary_Categories(t *testing.T) {
+	mockUsecase := new(MockItemUsecase)
+	mockUsecase.On("GetCategorySummaries", mock.Anything, []string{"時計", "バッグ"}).Return([]usecase.CategorySummaryEntry{
+		{Category: "時計", Count: 2, TotalPurchasePrice: entity.JPY(3000000)},
+		{Category: "バッグ", Count: 0, TotalPurchasePrice: entity.JPY(0)},
+	}, nil)
+	handler := NewItemHandler(mockUsecase)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/items/summary?categories="+url.QueryEscape("時計, バッグ,"), nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	assert.NoError(t, handler.GetSummary(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"categories":[
+		{"category":"時計","count":2,"total_purchase_price":{"amount":3000000,"currency":"JPY"}},
+		{"category":"バッグ","count":0,"total_purchase_price":{"amount":0,"currency":"JPY"}}
+	]}`, rec.Body.String())
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestItemHandler_DatabaseUnavailable(t *testing.T) {
+	tests := []struct {
+		name               string
+		err                error
+		expectedStatus     int
+		expectedCode       string
+		expectedRetryAfter string
+	}{
+		{
+			name:               "異常系: DBとの接続が切れている",
+			err:                fmt.Errorf("%w: %w: invalid connection", domainErrors.ErrDatabaseError, domainErrors.ErrDatabaseUnavailable),
+			expectedStatus:     http.StatusServiceUnavailable,
+			expectedCode:       CodeDatabaseUnavailable,
+			expectedRetryAfter: "5",
+		},
+		{
+			name:               "異常系: コネクションプールが埋まっている",
+			err:                fmt.Errorf("%w: %w: timed out waiting for a database connection", domainErrors.ErrDatabaseError, domainErrors.ErrDatabaseBusy),
+			expectedStatus:     http.StatusServiceUnavailable,
+			expectedCode:       CodeDatabaseBusy,
+			expectedRetryAfter: "1",
+		},
+		{
+			name:           "異常系: それ以外のDBエラー",
+			err:            fmt.Errorf("%w: syntax error", domainErrors.ErrDatabaseError),
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			mockUsecase.On("GetItemByID", mock.Anything, int64(1)).Return(nil, tt.err)
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			err := handler.GetItem(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedRetryAfter, rec.Header().Get("Retry-After"))
+			var body ErrorResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.expectedCode, body.Code)
+			assert.NotContains(t, rec.Body.String(), "invalid connection")
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_RequestTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	mockUsecase := new(MockItemUsecase)
+	mockUsecase.On("GetItemByID", mock.Anything, int64(1)).Return(nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, context.DeadlineExceeded))
+	handler := NewItemHandler(mockUsecase)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/items/1", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	assert.NoError(t, handler.GetItem(c))
+	// リクエストの期限でクエリが打ち切られた場合は、DBの障害ではなくタイムアウトとして返す
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.JSONEq(t, `{"code":"REQUEST_TIMEOUT","error":"request timed out"}`, rec.Body.String())
+}
+
+func TestItemHandler_BulkCreateItems_Limit(t *testing.T) {
+	bulkBody := func(count int) string {
+		elements := make([]string, count)
+		for i := range elements {
+			elements[i] = fmt.Sprintf(`{"name":"アイテム%d","category":"時計","brand":"ROLEX","purchase_price":1000,"purchase_date":"2023-01-15"}`, i)
+		}
+		return "[" + strings.Join(elements, ",") + "]"
+	}
+
+	tests := []struct {
+		name            string
+		opts            []HandlerOption
+		body            string
+		expectUsecase   bool
+		expectedStatus  int
+		expectedDetails []string
+	}{
+		{
+			name:           "正常系: 上限ちょうどの件数",
+			body:           bulkBody(DefaultBulkMaxItems),
+			expectUsecase:  true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:            "異常系: 上限を1件超える",
+			body:            bulkBody(DefaultBulkMaxItems + 1),
+			expectedStatus:  http.StatusBadRequest,
+			expectedDetails: []string{"items must contain 500 entries or less"},
+		},
+		{
+			name:            "異常系: 設定した上限を超える",
+			opts:            []HandlerOption{WithBulkMaxItems(2)},
+			body:            bulkBody(3),
+			expectedStatus:  http.StatusBadRequest,
+			expectedDetails: []string{"items must contain 2 entries or less"},
+		},
+		{
+			name:           "正常系: 0は無制限",
+			opts:           []HandlerOption{WithBulkMaxItems(0)},
+			body:           bulkBody(DefaultBulkMaxItems + 1),
+			expectUsecase:  true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "異常系: 空の配列",
+			body:           "[]",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 配列ではない",
+			body:           `{"name":"ロレックス デイトナ"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:            "異常系: 要素のバリデーションエラー",
+			body:            `[{"name":"ロレックス デイトナ","category":"時計","brand":"ROLEX","purchase_price":1000,"purchase_date":"2023-01-15"},{"category":"時計","brand":"ROLEX","purchase_date":"2023-01-15"}]`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedDetails: []string{"items[1]: name is required"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			if tt.expectUsecase {
+				mockUsecase.On("BulkCreateItems", mock.Anything, mock.Anything).
+					Return([]*entity.Item{{ID: 1, Name: "アイテム0", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1000), PurchaseDate: "2023-01-15"}}, nil)
+			}
+			handler := NewItemHandler(mockUsecase, tt.opts...)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/items/bulk", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := handler.BulkCreateItems(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedDetails != nil {
+				var body ErrorResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, tt.expectedDetails, body.Details)
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_GetTimeline(t *testing.T) {
+	year2023 := 2023
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "正常系: 購入年が不明な分はnull",
+			query: "",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetTimeline", mock.Anything, usecase.ItemFilter{}).Return([]usecase.TimelineEntry{
+					{Year: &year2023, Count: 2, TotalPurchasePrice: entity.JPY(3000000)},
+					{Year: nil, Count: 1, TotalPurchasePrice: entity.JPY(10000)},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"years":[
+				{"year":2023,"count":2,"total_purchase_price":{"amount":3000000,"currency":"JPY"}},
+				{"year":null,"count":1,"total_purchase_price":{"amount":10000,"currency":"JPY"}}
+			]}`,
+		},
+		{
+			name:           "異常系: 無効なカテゴリー",
+			query:          "?category=" + url.QueryEscape("家電"),
+			setupMock:      func(m *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/items/timeline"+tt.query, nil), rec)
+
+			assert.NoError(t, handler.GetTimeline(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_GetDiversity(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "正常系: 集中度",
+			query: "?category=" + url.QueryEscape("時計"),
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetDiversity", mock.Anything, usecase.ItemFilter{Categories: []entity.Category{entity.CategoryWatch}}).Return(&usecase.Diversity{
+					Count:              3,
+					TotalPurchasePrice: entity.JPY(4000000),
+					TopItem:            &usecase.DiversityTopItem{ID: 1, Name: "デイトナ", Share: 0.625},
+					Brands:             usecase.DiversityConcentration{Distinct: 2, Top: "ROLEX", TopShare: 0.75, HHI: 0.625, Gini: 0.25},
+					Categories:         usecase.DiversityConcentration{Distinct: 1, Top: "時計", TopShare: 1, HHI: 1},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{
+				"count":3,
+				"total_purchase_price":{"amount":4000000,"currency":"JPY"},
+				"top_item":{"id":1,"name":"デイトナ","share":0.625},
+				"brands":{"distinct":2,"top":"ROLEX","top_share":0.75,"hhi":0.625,"gini":0.25},
+				"categories":{"distinct":1,"top":"時計","top_share":1,"hhi":1,"gini":0}
+			}`,
+		},
+		{
+			name:           "異常系: 無効なカテゴリー",
+			query:          "?category=" + url.QueryEscape("家電"),
+			setupMock:      func(m *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/items/diversity"+tt.query, nil), rec)
+
+			assert.NoError(t, handler.GetDiversity(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_GetItems_ETag(t *testing.T) {
+	updatedAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	version := &usecase.ListVersion{Count: 1, LastUpdatedAt: &updatedAt}
+	filter := usecase.ItemFilter{Categories: []entity.Category{entity.CategoryWatch}}
+	etag := version.ETag(filter)
+
+	tests := []struct {
+		name           string
+		method         string
+		ifNoneMatch    string
+		expectList     bool
+		expectedStatus int
+	}{
+		{name: "正常系: If-None-Matchなし", expectList: true, expectedStatus: http.StatusOK},
+		{name: "正常系: HEADは一覧を取得せずに件数とETagのみ返す", method: http.MethodHead, expectedStatus: http.StatusOK},
+		{name: "正常系: HEADでも一致する場合は304", method: http.MethodHead, ifNoneMatch: etag, expectedStatus: http.StatusNotModified},
+		{name: "正常系: 一致する場合は304", ifNoneMatch: etag, expectedStatus: http.StatusNotModified},
+		{name: "正常系: 複数指定のいずれかに一致", ifNoneMatch: `W/"other", ` + etag, expectedStatus: http.StatusNotModified},
+		{name: "正常系: 弱い比較（W/なしでも一致）", ifNoneMatch: strings.TrimPrefix(etag, "W/"), expectedStatus: http.StatusNotModified},
+		{name: "正常系: 一致しない場合は一覧を返す", ifNoneMatch: `W/"stale"`, expectList: true, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			mockUsecase.On("GetItemListVersion", mock.Anything, filter).Return(version, nil)
+			if tt.expectList {
+				mockUsecase.On("GetAllItems", mock.Anything, filter).Return([]*entity.Item{}, nil)
+			}
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			method := http.MethodGet
+			if tt.method != "" {
+				method = tt.method
+			}
+			req := httptest.NewRequest(method, "/items?category="+url.QueryEscape("時計"), nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, handler.GetItems(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, etag, rec.Header().Get("ETag"))
+			assert.Equal(t, "1", rec.Header().Get("X-Total-Count"))
+			if !tt.expectList {
+				assert.Empty(t, rec.Body.String())
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_GetItems_NotModifiedVary(t *testing.T) {
+	version := &usecase.ListVersion{Count: 1, VersionSum: 2}
+	etag := version.ETag(usecase.ItemFilter{})
+
+	mockUsecase := new(MockItemUsecase)
+	mockUsecase.On("GetItemListVersion", mock.Anything, usecase.ItemFilter{}).Return(version, nil)
+	handler := NewItemHandler(mockUsecase, WithHiddenFields([]string{"purchase_price"}, "secret"))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	assert.NoError(t, handler.GetItems(c))
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	// 304でも200と同じく非表示のフィールドの有無でキャッシュを区別させる
+	assert.Equal(t, []string{headerRevealFields}, rec.Header().Values(echo.HeaderVary))
+	mockUsecase.AssertNotCalled(t, "GetAllItems", mock.Anything, mock.Anything)
+}
+
+func TestItemHandler_GetItems_HiddenFields(t *testing.T) {
+	item := &entity.Item{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15", SerialNumber: "SN-001"}
+
+	tests := []struct {
+		name         string
+		opts         []HandlerOption
+		revealHeader string
+		expectHidden bool
+	}{
+		{name: "正常系: 設定なしの場合はすべて返す"},
+		{
+			name:         "正常系: 指定したフィールドを除外",
+			opts:         []HandlerOption{WithHiddenFields([]string{"purchase_price", "serial_number"}, "secret")},
+			expectHidden: true,
+		},
+		{
+			name:         "正常系: トークンが一致する場合は除外しない",
+			opts:         []HandlerOption{WithHiddenFields([]string{"purchase_price", "serial_number"}, "secret")},
+			revealHeader: "secret",
+		},
+		{
+			name:         "異常系: トークンが一致しない場合は除外",
+			opts:         []HandlerOption{WithHiddenFields([]string{"purchase_price", "serial_number"}, "secret")},
+			revealHeader: "wrong",
+			expectHidden: true,
+		},
+		{
+			name:         "異常系: トークン未設定の場合は常に除外",
+			opts:         []HandlerOption{WithHiddenFields([]string{"purchase_price", "serial_number"}, "")},
+			revealHeader: "",
+			expectHidden: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			mockUsecase.On("GetItemListVersion", mock.Anything, usecase.ItemFilter{}).Return(&usecase.ListVersion{}, nil)
+			mockUsecase.On("GetAllItems", mock.Anything, usecase.ItemFilter{}).Return([]*entity.Item{item}, nil)
+			handler := NewItemHandler(mockUsecase, tt.opts...)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/items", nil)
+			if tt.revealHeader != "" {
+				req.Header.Set("X-Reveal-Fields", tt.revealHeader)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, handler.GetItems(c))
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var body []map[string]interface{}
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Len(t, body, 1)
+			assert.Equal(t, "ROLEX", body[0]["brand"])
+			assert.Equal(t, "2023-01-15", body[0]["purchase_date"])
+			for _, key := range []string{"purchase_price", "purchase_price_display", "serial_number"} {
+				_, exists := body[0][key]
+				assert.Equal(t, !tt.expectHidden, exists, key)
+			}
+			if len(tt.opts) > 0 {
+				assert.Contains(t, rec.Header().Values("Vary"), "X-Reveal-Fields")
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestParseHiddenFields(t *testing.T) {
+	fields, err := ParseHiddenFields(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, fields)
+
+	fields, err = ParseHiddenFields([]string{"purchase_price", " serial_number ", "purchase_price"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"purchase_price", "serial_number"}, fields)
+
+	_, err = ParseHiddenFields([]string{"name"})
+	assert.Error(t, err)
+}
+
+func TestParseImportJSON(t *testing.T) {
+	tests := []struct {
+		name        string
+		parse       func(io.Reader, int) ([]usecase.ImportRow, error)
+		input       string
+		maxRows     int
+		expected    []usecase.ImportRow
+		expectedErr string
+	}{
+		{
+			name:  "正常系: JSONLを1行ずつ読み込み、空行は読み飛ばす",
+			parse: parseImportJSONL,
+			input: `{"name":"デイトナ","category":"時計","brand":"ROLEX","purchase_price":1500000,"purchase_date":"2023-01-15","serial_number":"SN-001"}` + "\n\n" +
+				`{"id":2,"name":"バーキン","category":"バッグ","brand":"HERMÈS","purchase_price":{"amount":2000000,"currency":"JPY"},"purchase_date":"2023-02-20","status":"active"}` + "\n",
+			expected: []usecase.ImportRow{
+				{Line: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: "1500000", PurchaseDate: "2023-01-15", SerialNumber: "SN-001"},
+				{Line: 3, Name: "バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: "2000000", PurchaseDate: "2023-02-20"},
+			},
+		},
+		{
+			name:  "正常系: 金額の値は検証せずに渡す",
+			parse: parseImportJSONL,
+			input: `{"name":"A","purchase_price":"¥1,000"}` + "\n" + `{"name":"B","purchase_price":1000.5}` + "\n" + `{"name":"C","purchase_price":{"amount":10,"currency":"USD"}}` + "\n" + `{"name":"D"}`,
+			expected: []usecase.ImportRow{
+				{Line: 1, Name: "A", PurchasePrice: "¥1,000"},
+				{Line: 2, Name: "B", PurchasePrice: "1000.5"},
+				{Line: 3, Name: "C", PurchasePrice: "10 USD"},
+				{Line: 4, Name: "D"},
+			},
+		},
+		{
+			name:        "異常系: JSONLの行が不正",
+			parse:       parseImportJSONL,
+			input:       `{"name":"A"}` + "\n" + `{"name":`,
+			expectedErr: "line 2: unexpected end of JSON input",
+		},
+		{
+			name:        "異常系: JSONLの件数が上限を超える",
+			parse:       parseImportJSONL,
+			input:       `{"name":"A"}` + "\n" + `{"name":"B"}`,
+			maxRows:     1,
+			expectedErr: "too many rows",
+		},
+		{
+			name:        "異常系: JSONLが空",
+			parse:       parseImportJSONL,
+			input:       "\n",
+			expectedErr: "jsonl has no records",
+		},
+		{
+			name:  "正常系: JSONの配列を読み込み、位置を行番号とする",
+			parse: parseImportJSON,
+			input: `[{"name":"デイトナ","purchase_price":1500000},{"name":"バーキン","purchase_price":2000000}]`,
+			expected: []usecase.ImportRow{
+				{Line: 1, Name: "デイトナ", PurchasePrice: "1500000"},
+				{Line: 2, Name: "バーキン", PurchasePrice: "2000000"},
+			},
+		},
+		{
+			name:        "異常系: JSONが配列ではない",
+			parse:       parseImportJSON,
+			input:       `{"name":"デイトナ"}`,
+			expectedErr: "json must be an array of items",
+		},
+		{
+			name:        "異常系: JSONの要素が不正",
+			parse:       parseImportJSON,
+			input:       `[{"name":"A"},{"name":1}]`,
+			expectedErr: "line 2: json: cannot unmarshal number into Go struct field importRecord.name of type string",
+		},
+		{
+			name:        "異常系: JSONの件数が上限を超える",
+			parse:       parseImportJSON,
+			input:       `[{"name":"A"},{"name":"B"}]`,
+			maxRows:     1,
+			expectedErr: "too many rows",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := tt.parse(strings.NewReader(tt.input), tt.maxRows)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, rows)
+		})
+	}
+}
+
+func TestItemHandler_ImportItems_Format(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		body           string
+		expectedRows   []usecase.ImportRow
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "正常系: JSONLから登録",
+			query:          "?format=jsonl",
+			body:           `{"name":"デイトナ","category":"時計","brand":"ROLEX","purchase_price":1500000,"purchase_date":"2023-01-15"}`,
+			expectedRows:   []usecase.ImportRow{{Line: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: "1500000", PurchaseDate: "2023-01-15"}},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "異常系: JSONの形式が不正",
+			query:          "?format=json",
+			body:           `{"name":"デイトナ"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeInvalidRequest,
+		},
+		{
+			name:           "異常系: 対応していない形式",
+			query:          "?format=xml",
+			body:           "<items/>",
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeInvalidParameter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			if tt.expectedRows != nil {
+				mockUsecase.On("ImportItems", mock.Anything, tt.expectedRows, usecase.ImportModeStrict).
+					Return(&usecase.ImportResult{Mode: usecase.ImportModeStrict, TotalRows: 1, Imported: 1, Committed: true, Errors: []usecase.ImportError{}}, nil)
+			}
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/items/import"+tt.query, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, handler.ImportItems(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedCode != "" {
+				var body ErrorResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, tt.expectedCode, body.Code)
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+// casing=camel のボディのフィールド名を書き換えるシリアライザー（サーバーのJSONシリアライザーの代わり）
+type camelBodySerializer struct {
+	echo.DefaultJSONSerializer
+}
+
+func (camelBodySerializer) NormalizeRequestKeys(c echo.Context) func([]byte) []byte {
+	replacer := strings.NewReplacer(`"purchasePrice"`, `"purchase_price"`, `"purchaseDate"`, `"purchase_date"`,
+		`"minPurchasePrice"`, `"min_purchase_price"`, `"deleteAll"`, `"delete_all"`)
+	return func(data []byte) []byte {
+		return []byte(replacer.Replace(string(data)))
+	}
+}
+
+func TestItemHandler_CamelCaseBodies(t *testing.T) {
+	minPrice := int64(500000)
+	row := usecase.ImportRow{Line: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: "1500000", PurchaseDate: "2023-01-15"}
+	record := `{"name":"デイトナ","category":"時計","brand":"ROLEX","purchasePrice":1500000,"purchaseDate":"2023-01-15"}`
+
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		handle         func(*ItemHandler, echo.Context) error
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+	}{
+		{
+			name:   "正常系: POST /items/search",
+			path:   "/items/search",
+			body:   `{"minPurchasePrice":500000}`,
+			handle: (*ItemHandler).SearchItems,
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetItemPage", mock.Anything, usecase.ItemFilter{MinPurchasePrice: &minPrice}, usecase.Page{Limit: usecase.DefaultPageLimit}).
+					Return(&usecase.ItemPage{Items: []*entity.Item{}, Limit: usecase.DefaultPageLimit}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "正常系: POST /items/bulk",
+			path:   "/items/bulk?mode=best_effort",
+			body:   "[" + record + "]",
+			handle: (*ItemHandler).BulkCreateItems,
+			setupMock: func(m *MockItemUsecase) {
+				m.On("BulkCreateItemsBestEffort", mock.Anything, mock.MatchedBy(func(inputs []usecase.CreateItemInput) bool {
+					return len(inputs) == 1 && inputs[0].PurchasePrice == 1500000 && inputs[0].PurchaseDate == "2023-01-15"
+				})).Return([]usecase.BulkItemResult{{Item: &entity.Item{ID: 1}}}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:   "正常系: POST /items/delete-by-filter",
+			path:   "/items/delete-by-filter",
+			body:   `{"filter":{},"confirm":true,"deleteAll":true}`,
+			handle: (*ItemHandler).DeleteItemsByFilter,
+			setupMock: func(m *MockItemUsecase) {
+				m.On("DeleteItemsByFilter", mock.Anything, usecase.ItemFilter{}, usecase.DeleteByFilterInput{Confirm: true, DeleteAll: true}).Return(1, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "正常系: 一括登録（JSON）",
+			path:   "/items/import?format=json",
+			body:   "[" + record + "]",
+			handle: (*ItemHandler).ImportItems,
+			setupMock: func(m *MockItemUsecase) {
+				m.On("ImportItems", mock.Anything, []usecase.ImportRow{row}, usecase.ImportModeStrict).
+					Return(&usecase.ImportResult{Mode: usecase.ImportModeStrict, TotalRows: 1, Imported: 1, Committed: true, Errors: []usecase.ImportError{}}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:   "正常系: 一括登録（JSONL）は行ごとに書き換える",
+			path:   "/items/import?format=jsonl",
+			body:   "\n" + record + "\n",
+			handle: (*ItemHandler).ImportItems,
+			setupMock: func(m *MockItemUsecase) {
+				expected := row
+				expected.Line = 2
+				m.On("ImportItems", mock.Anything, []usecase.ImportRow{expected}, usecase.ImportModeStrict).
+					Return(&usecase.ImportResult{Mode: usecase.ImportModeStrict, TotalRows: 1, Imported: 1, Committed: true, Errors: []usecase.ImportError{}}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			e.JSONSerializer = camelBodySerializer{}
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, "application/json; casing=camel")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, tt.handle(handler, c))
+			assert.Equal(t, tt.expectedStatus, rec.Code, rec.Body.String())
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_BulkCreateItems_BestEffort(t *testing.T) {
+	body := `[{"name":"ロレックス デイトナ","category":"時計","brand":"ROLEX","purchase_price":1000,"purchase_date":"2023-01-15"},{"category":"時計","brand":"ROLEX","purchase_date":"2023-01-15"}]`
+
+	tests := []struct {
+		name           string
+		query          string
+		results        []usecase.BulkItemResult
+		expectedStatus int
+		expected       *BulkCreateItemsResultsResponse
+	}{
+		{
+			name:  "正常系: 有効な要素のみ登録し、要素ごとの結果を返す",
+			query: "?mode=best_effort",
+			results: []usecase.BulkItemResult{
+				{Item: &entity.Item{ID: 10}},
+				{Err: fmt.Errorf("%w: name is required", domainErrors.ErrInvalidInput)},
+			},
+			expectedStatus: http.StatusCreated,
+			expected: &BulkCreateItemsResultsResponse{Created: 1, Failed: 1, Results: []BulkItemResultResponse{
+				{Index: 0, ID: func() *int64 { id := int64(10); return &id }()},
+				{Index: 1, Error: &ErrorResponse{Code: CodeValidationFailed, Error: "invalid input: name is required"}},
+			}},
+		},
+		{
+			name:  "正常系: 1件も登録できない場合は200",
+			query: "?mode=best_effort",
+			results: []usecase.BulkItemResult{
+				{Err: &domainErrors.DuplicateEntryError{Field: "serial_number", Message: "An item with this serial number already exists."}},
+				{Err: errors.New("connection reset")},
+			},
+			expectedStatus: http.StatusOK,
+			expected: &BulkCreateItemsResultsResponse{Created: 0, Failed: 2, Results: []BulkItemResultResponse{
+				{Index: 0, Error: &ErrorResponse{Code: CodeItemAlreadyExists, Error: "An item with this serial number already exists."}},
+				{Index: 1, Error: &ErrorResponse{Code: CodeInternalError, Error: "failed to create item"}},
+			}},
+		},
+		{
+			name:           "異常系: デフォルトは全件のバリデーションエラーで400",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 不正なモード",
+			query:          "?mode=partial",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			if tt.results != nil {
+				mockUsecase.On("BulkCreateItemsBestEffort", mock.Anything, mock.Anything).Return(tt.results, nil)
+			}
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/items/bulk"+tt.query, strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, handler.BulkCreateItems(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expected != nil {
+				var response BulkCreateItemsResultsResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, *tt.expected, response)
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_ParseDate(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "正常系: YYYY-MM-DDに正規化",
+			body:           `{"value":"Jan 2 2020"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"value":"Jan 2 2020","purchase_date":"2020-01-02"}`,
+		},
+		{
+			name:           "正常系: スラッシュ区切り",
+			body:           `{"value":"2020/01/02"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"value":"2020/01/02","purchase_date":"2020-01-02"}`,
+		},
+		{
+			name:           "異常系: 解釈できない場合は422",
+			body:           `{"value":"01/02/2020"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "異常系: JSONの形式が不正",
+			body:           `{"value":`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewItemHandler(new(MockItemUsecase))
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/util/parse-date", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, handler.ParseDate(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+			if tt.expectedStatus == http.StatusUnprocessableEntity {
+				var response ErrorResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, CodeInvalidDate, response.Code)
+			}
+		})
+	}
+}
+
+func TestItemHandler_GetItemSchema(t *testing.T) {
+	handler := NewItemHandler(new(MockItemUsecase))
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/items/schema", nil), rec)
+	assert.NoError(t, handler.GetItemSchema(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, schemaCacheControl, rec.Header().Get("Cache-Control"))
+	etag := rec.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	var response ItemSchemaResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	fields := make(map[string]ItemSchemaField)
+	for _, field := range response.Fields {
+		fields[field.Name] = field
+	}
+	assert.True(t, fields["id"].ReadOnly)
+	assert.False(t, fields["id"].Updatable)
+	assert.False(t, fields["name"].ReadOnly)
+	assert.True(t, fields["name"].Updatable)
+	assert.False(t, fields["category"].Updatable)
+	assert.True(t, fields["created_at"].ReadOnly)
+	assert.Equal(t, "enum", fields["category"].Type)
+
+	// ETagが一致する場合は304
+	req := httptest.NewRequest(http.MethodGet, "/items/schema", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	assert.NoError(t, handler.GetItemSchema(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+}
+
+func TestItemHandler_GetGroupSummary(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+	}{
+		{
+			name:  "正常系: カテゴリーで絞り込んだブランドごとの集計",
+			query: "by=brand&category=時計",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				filter := usecase.ItemFilter{Categories: []entity.Category{entity.CategoryWatch}}
+				mockUsecase.On("GetGroupSummary", mock.Anything, filter, usecase.GroupByBrand).Return([]usecase.GroupSummaryEntry{
+					{Value: "ROLEX", Count: 2, TotalPurchasePrice: entity.JPY(3000000)},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: byが無い",
+			query:          "category=時計",
+			setupMock:      func(mockUsecase *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "異常系: 許可リストに無いカラム",
+			query: "by=name",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetGroupSummary", mock.Anything, usecase.ItemFilter{}, usecase.GroupBy("name")).
+					Return(nil, fmt.Errorf("%w: unknown by: name", domainErrors.ErrInvalidInput))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/items/group?"+tt.query, nil), rec)
+
+			assert.NoError(t, handler.GetGroupSummary(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response GroupSummaryResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, usecase.GroupByBrand, response.By)
+				assert.Len(t, response.Groups, 1)
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_DeleteItem_Reason(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+	}{
+		{
+			name: "正常系: ボディの理由を渡す",
+			body: `{"reason": " sold "}`,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("DeleteItem", mock.Anything, int64(1), usecase.DeleteItemInput{Reason: "sold"}).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "異常系: 許可されていない理由",
+			body: `{"reason": "broken"}`,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("DeleteItem", mock.Anything, int64(1), usecase.DeleteItemInput{Reason: "broken"}).
+					Return(fmt.Errorf("%w: reason must be one of: sold, lost", domainErrors.ErrInvalidInput))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 不正なJSON",
+			body:           `{"reason":`,
+			setupMock:      func(mockUsecase *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/items/1", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			assert.NoError(t, handler.DeleteItem(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_CreateItem_OptionalCategory(t *testing.T) {
+	body := `{"name":"デイトナ","brand":"ROLEX","purchase_price":1500000,"purchase_date":"2023-01-15"}`
+
+	tests := []struct {
+		name           string
+		opts           []HandlerOption
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+	}{
+		{
+			name: "正常系: 省略を許可した場合はusecaseでカテゴリーを補う",
+			opts: []HandlerOption{WithOptionalCategory(true)},
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				input := usecase.CreateItemInput{Name: "デイトナ", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"}
+				mockUsecase.On("CreateItem", mock.Anything, input).
+					Return(&entity.Item{ID: 1, Name: "デイトナ", Category: entity.CategoryWatch, Brand: "ROLEX"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "異常系: 既定ではカテゴリーは必須",
+			setupMock:      func(mockUsecase *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase, tt.opts...)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, handler.CreateItem(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_GetSummary_Sparse(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "正常系: 既定では0件のカテゴリーも含める",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"categories":{"時計":2,"バッグ":0,"ジュエリー":0,"靴":0,"その他":1},"total":3,"total_purchase_price":{"amount":3050000,"currency":"JPY"}}`,
+		},
+		{
+			name:           "正常系: sparseでは1件以上のカテゴリーのみ",
+			query:          "?sparse=true",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"categories":{"時計":2,"その他":1},"total":3,"total_purchase_price":{"amount":3050000,"currency":"JPY"}}`,
+		},
+		{
+			name:           "異常系: 真偽値でない",
+			query:          "?sparse=yes please",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			if tt.expectedStatus == http.StatusOK {
+				mockUsecase.On("GetCategorySummary", mock.Anything).Return(&usecase.CategorySummary{
+					Categories:         map[string]int{"時計": 2, "バッグ": 0, "ジュエリー": 0, "靴": 0, "その他": 1},
+					Total:              3,
+					TotalPurchasePrice: entity.JPY(3050000),
+				}, nil)
+			}
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/items/summary"+strings.ReplaceAll(tt.query, " ", "%20"), nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, handler.GetSummary(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_GetSummary_Async(t *testing.T) {
+	summary := &usecase.CategorySummary{
+		Categories:         map[string]int{"時計": 2, "バッグ": 0, "ジュエリー": 0, "靴": 0, "その他": 1},
+		Total:              3,
+		TotalPurchasePrice: entity.JPY(3050000),
+	}
+	computed := make(chan struct{})
+	jobs := usecase.NewSummaryJobs(func(ctx context.Context) (*usecase.CategorySummary, error) {
+		defer close(computed)
+		return summary, nil
+	}, time.Minute)
+
+	mockUsecase := new(MockItemUsecase)
+	mockUsecase.On("GetItemListVersion", mock.Anything, usecase.ItemFilter{}).Return(&usecase.ListVersion{Count: 1000}, nil)
+	handler := NewItemHandler(mockUsecase, WithSummaryJobs(jobs, 1000))
+	e := echo.New()
+
+	// 下限以上のアイテムがある場合は202とジョブのIDを返す
+	req := httptest.NewRequest(http.MethodGet, "/items/summary", nil)
+	rec := httptest.NewRecorder()
+	assert.NoError(t, handler.GetSummary(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	var job usecase.SummaryJob
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+	assert.NotEmpty(t, job.ID)
+	assert.Equal(t, "/items/summary/jobs/"+job.ID, rec.Header().Get(echo.HeaderLocation))
+
+	// 完了後は同期の場合と同じ形式で返す
+	<-computed
+	assert.Eventually(t, func() bool {
+		current, _ := jobs.Get(job.ID)
+		return current.Status == usecase.SummaryJobDone
+	}, time.Second, time.Millisecond)
+	req = httptest.NewRequest(http.MethodGet, "/items/summary/jobs/"+job.ID+"?sparse=true", nil)
+	rec = httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("jobId")
+	c.SetParamValues(job.ID)
+	assert.NoError(t, handler.GetSummaryJob(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"categories":{"時計":2,"その他":1},"total":3,"total_purchase_price":{"amount":3050000,"currency":"JPY"}}`, rec.Body.String())
+	// sparseで保持している結果は変更しない
+	assert.Len(t, summary.Categories, 5)
+
+	// 存在しないジョブは404
+	rec = httptest.NewRecorder()
+	c = e.NewContext(httptest.NewRequest(http.MethodGet, "/items/summary/jobs/unknown", nil), rec)
+	c.SetParamNames("jobId")
+	c.SetParamValues("unknown")
+	assert.NoError(t, handler.GetSummaryJob(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), CodeSummaryJobNotFound)
+}
+
+func TestItemHandler_GetSummary_AsyncBelowThreshold(t *testing.T) {
+	mockUsecase := new(MockItemUsecase)
+	mockUsecase.On("GetItemListVersion", mock.Anything, usecase.ItemFilter{}).Return(&usecase.ListVersion{Count: 10}, nil)
+	mockUsecase.On("GetCategorySummary", mock.Anything).Return(&usecase.CategorySummary{Categories: map[string]int{"時計": 10}, Total: 10}, nil)
+	jobs := usecase.NewSummaryJobs(func(ctx context.Context) (*usecase.CategorySummary, error) {
+		t.Fatal("少ない場合はジョブを開始しない")
+		return nil, nil
+	}, time.Minute)
+	handler := NewItemHandler(mockUsecase, WithSummaryJobs(jobs, 1000))
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	assert.NoError(t, handler.GetSummary(e.NewContext(httptest.NewRequest(http.MethodGet, "/items/summary", nil), rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestItemHandler_CreateItem_MissingRequiredFields(t *testing.T) {
+	mockUsecase := new(MockItemUsecase)
+	mockUsecase.On("CreateItem", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("items[0]: %w", &domainErrors.MissingRequiredFieldsError{Category: "時計", Fields: []string{"serial_number"}}))
+	handler := NewItemHandler(mockUsecase)
+
+	e := echo.New()
+	body := `{"name":"デイトナ","category":"時計","brand":"ROLEX","purchase_price":1500000,"purchase_date":"2023-01-15"}`
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	assert.NoError(t, handler.CreateItem(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.JSONEq(t, `{"code":"MISSING_REQUIRED_FIELDS","error":"missing required fields for category","details":["serial_number"]}`, rec.Body.String())
+}
+
+func TestItemHandler_GetItemSiblings(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		query          string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "正常系: 末尾のアイテムは次がnull",
+			id:    "5",
+			query: "?sort=-purchase_price&category=時計",
+			setupMock: func(m *MockItemUsecase) {
+				filter := usecase.ItemFilter{Categories: []entity.Category{entity.CategoryWatch}, Sort: usecase.SortPurchasePriceDesc}
+				m.On("GetItemSiblings", mock.Anything, int64(5), filter).Return(&usecase.ItemSiblings{
+					ID: 5, Sort: usecase.SortPurchasePriceDesc,
+					Previous: &usecase.ItemSibling{ID: 2, Name: "デイトナ", Category: entity.CategoryWatch, Brand: "ROLEX"},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":5,"sort":"-purchase_price","previous":{"id":2,"name":"デイトナ","category":"時計","brand":"ROLEX"},"next":null}`,
+		},
+		{
+			name:  "異常系: 条件に一致しないアイテム",
+			id:    "5",
+			query: "?status=archived",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetItemSiblings", mock.Anything, int64(5), usecase.ItemFilter{Status: entity.StatusArchived}).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:  "異常系: 不明な並び順",
+			id:    "5",
+			query: "?sort=price",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetItemSiblings", mock.Anything, int64(5), usecase.ItemFilter{Sort: "price"}).
+					Return(nil, fmt.Errorf("%w: unknown sort: price", domainErrors.ErrInvalidInput))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 不正なID",
+			id:             "abc",
+			setupMock:      func(m *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/items/"+tt.id+"/siblings"+strings.ReplaceAll(tt.query, "時計", url.QueryEscape("時計")), nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+
+			assert.NoError(t, handler.GetItemSiblings(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_GetChanges_SyncToken(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "正常系: トークン以降の差分",
+			query: "?sync_token=djE6MTA",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("SyncItems", mock.Anything, "djE6MTA").Return(&usecase.ItemSyncSet{
+					Items: []*entity.Item{}, DeletedIDs: []int64{3}, SyncToken: "djE6MTI",
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"items":[],"deleted_ids":[3],"sync_token":"djE6MTI","full_resync":false}`,
+		},
+		{
+			name:  "正常系: 空のトークンは全件の同期",
+			query: "?sync_token=",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("SyncItems", mock.Anything, "").Return(&usecase.ItemSyncSet{
+					Items: []*entity.Item{}, DeletedIDs: []int64{}, SyncToken: "djE6MTI", FullResync: true,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"items":[],"deleted_ids":[],"sync_token":"djE6MTI","full_resync":true}`,
+		},
+		{
+			name:           "異常系: sinceと同時に指定",
+			query:          "?sync_token=djE6MTA&since=2024-01-01T00:00:00Z",
+			setupMock:      func(m *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "異常系: データベースエラー",
+			query: "?sync_token=djE6MTA",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("SyncItems", mock.Anything, "djE6MTA").Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/items/changes"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, handler.GetChanges(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
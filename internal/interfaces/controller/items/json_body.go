@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/labstack/echo/v4"
+)
+
+// リクエストボディのフィールド名を snake_case に揃える（サーバーのJSONシリアライザーが実装する）
+// c.Bind を使わずにボディを読み込むハンドラーでも、Content-Type の casing=camel を受け付けるために使う
+type RequestKeyNormalizer interface {
+	// camelCase のボディの場合はフィールド名を snake_case に書き換える関数を返す（それ以外はnil）
+	NormalizeRequestKeys(c echo.Context) func([]byte) []byte
+}
+
+// camelCase のボディのフィールド名を書き換える関数（書き換えない場合はnil）
+func requestKeyNormalizer(c echo.Context) func([]byte) []byte {
+	if normalizer, ok := c.Echo().JSONSerializer.(RequestKeyNormalizer); ok {
+		return normalizer.NormalizeRequestKeys(c)
+	}
+	return nil
+}
+
+// camelCase のボディは、読み込む時点で全体を読んでフィールド名を snake_case に書き換える
+// snake_case のボディはそのまま先頭から順に読み込む
+func jsonRequestBody(c echo.Context, body io.Reader) io.Reader {
+	normalize := requestKeyNormalizer(c)
+	if normalize == nil {
+		return body
+	}
+	return &normalizedBody{body: body, normalize: normalize}
+}
+
+type normalizedBody struct {
+	body       io.Reader
+	normalize  func([]byte) []byte
+	normalized io.Reader
+}
+
+// 読み込みのエラー（http.MaxBytesError など）はそのまま返す
+func (b *normalizedBody) Read(p []byte) (int, error) {
+	if b.normalized == nil {
+		data, err := io.ReadAll(b.body)
+		if err != nil {
+			return 0, err
+		}
+		b.normalized = bytes.NewReader(b.normalize(data))
+	}
+	return b.normalized.Read(p)
+}
@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// POST /util/parse-date のリクエスト形式
+type ParseDateRequest struct {
+	Value string `json:"value"`
+}
+
+// POST /util/parse-date のレスポンス形式
+type ParseDateResponse struct {
+	Value        string `json:"value"`         // 入力された値
+	PurchaseDate string `json:"purchase_date"` // YYYY-MM-DD 形式
+}
+
+// 購入日の入力を解釈し、YYYY-MM-DD 形式にして返す（解釈できない場合は422）
+// 一括登録と同じ形式（entity.PurchaseDateLayouts）を受け付ける
+func (h *ItemHandler) ParseDate(c echo.Context) error {
+	var req ParseDateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:  CodeInvalidRequest,
+			Error: "invalid request format",
+		})
+	}
+
+	date, err := entity.ParsePurchaseDate(req.Value)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Code:    CodeInvalidDate,
+			Error:   "unparseable date",
+			Details: []string{"value must be a date such as 2020-01-02, 2020/01/02 or Jan 2 2020"},
+		})
+	}
+
+	return c.JSON(http.StatusOK, ParseDateResponse{
+		Value:        req.Value,
+		PurchaseDate: date,
+	})
+}
@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// 帳票用の最小限のPDF（テキストと罫線のみ）を組み立てる
+// 日本語を表示するため、PDFビューアーに標準で用意されている日本語フォント（埋め込みなし）を使う
+
+// A4縦のページサイズ（ポイント）
+const (
+	pdfPageWidth  = 595.0
+	pdfPageHeight = 842.0
+)
+
+// 1ページ分の描画内容
+type pdfPage struct {
+	content bytes.Buffer
+}
+
+// 左端をxとして、ベースラインyにテキストを描画する
+func (p *pdfPage) text(x, y, size float64, s string) {
+	fmt.Fprintf(&p.content, "BT /F1 %.1f Tf %.2f %.2f Td <%s> Tj ET\n", size, x, y, pdfHexString(s))
+}
+
+// 右端をxとして、テキストを右寄せで描画する
+func (p *pdfPage) textRight(x, y, size float64, s string) {
+	p.text(x-pdfTextWidth(s, size), y, size, s)
+}
+
+// (x1, y1) から (x2, y2) に細い線を引く
+func (p *pdfPage) line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(&p.content, "0.5 w %.2f %.2f m %.2f %.2f l S\n", x1, y1, x2, y2)
+}
+
+// PDF文書
+type pdfDocument struct {
+	title     string
+	createdAt time.Time
+	pages     []*pdfPage
+}
+
+// ページを追加して返す
+func (d *pdfDocument) addPage() *pdfPage {
+	page := &pdfPage{}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// フォントの定義（HeiseiKakuGo-W5はAdobe-Japan1の標準フォント）
+// ASCIIは半角の字形（CID 231〜）にする符号化を使い、半角の幅を500、それ以外を1000とする
+const pdfFontObjects = `<< /Type /Font /Subtype /Type0 /BaseFont /HeiseiKakuGo-W5 /Encoding /UniJIS-UCS2-HW-H /DescendantFonts [4 0 R] >>
+<< /Type /Font /Subtype /CIDFontType0 /BaseFont /HeiseiKakuGo-W5 /CIDSystemInfo << /Registry (Adobe) /Ordering (Japan1) /Supplement 2 >> /FontDescriptor 5 0 R /DW 1000 /W [231 389 500] >>
+<< /Type /FontDescriptor /FontName /HeiseiKakuGo-W5 /Flags 4 /FontBBox [-92 -250 1010 922] /ItalicAngle 0 /Ascent 752 /Descent -221 /CapHeight 737 /StemV 114 >>`
+
+// PDFを書き出す
+// オブジェクトの番号は 1: カタログ, 2: ページツリー, 3〜5: フォント, 6: 文書情報, 7以降: ページと描画内容の組
+func (d *pdfDocument) writeTo(w io.Writer) error {
+	pw := &pdfWriter{w: w}
+	pw.printf("%%PDF-1.4\n%%\xe2\xe3\xcf\xd3\n")
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 7+2*i)
+	}
+	pw.object("<< /Type /Catalog /Pages 2 0 R >>")
+	pw.object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	for _, font := range strings.Split(pdfFontObjects, "\n") {
+		pw.object(font)
+	}
+	pw.object(fmt.Sprintf("<< /Title <%s> /CreationDate (%s) >>", "FEFF"+pdfHexString(d.title), pdfDate(d.createdAt)))
+
+	for i, page := range d.pages {
+		pw.object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 8+2*i))
+
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		_, _ = zw.Write(page.content.Bytes())
+		_ = zw.Close()
+		pw.stream(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>", compressed.Len()), compressed.Bytes())
+	}
+
+	xref := pw.offset
+	pw.printf("xref\n0 %d\n0000000000 65535 f \n", len(pw.offsets)+1)
+	for _, offset := range pw.offsets {
+		pw.printf("%010d 00000 n \n", offset)
+	}
+	pw.printf("trailer\n<< /Size %d /Root 1 0 R /Info 6 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(pw.offsets)+1, xref)
+	return pw.err
+}
+
+// 書き込んだバイト数とオブジェクトの位置（クロスリファレンス用）を記録する
+// 最初のエラー以降は書き込まない
+type pdfWriter struct {
+	w       io.Writer
+	offset  int
+	offsets []int
+	err     error
+}
+
+func (pw *pdfWriter) write(b []byte) {
+	if pw.err != nil {
+		return
+	}
+	n, err := pw.w.Write(b)
+	pw.offset += n
+	pw.err = err
+}
+
+func (pw *pdfWriter) printf(format string, args ...interface{}) {
+	pw.write([]byte(fmt.Sprintf(format, args...)))
+}
+
+// 次の番号のオブジェクトを書き込む
+func (pw *pdfWriter) object(body string) {
+	pw.offsets = append(pw.offsets, pw.offset)
+	pw.printf("%d 0 obj\n%s\nendobj\n", len(pw.offsets), body)
+}
+
+// 次の番号のストリームオブジェクトを書き込む
+func (pw *pdfWriter) stream(dict string, data []byte) {
+	pw.offsets = append(pw.offsets, pw.offset)
+	pw.printf("%d 0 obj\n%s\nstream\n", len(pw.offsets), dict)
+	pw.write(data)
+	pw.printf("\nendstream\nendobj\n")
+}
+
+// テキストをフォントの符号（UTF-16BE）の16進文字列にする
+// 半角の円記号はフォントに半角の字形が無いため全角にし、BMP以外の文字は "?" にする
+func pdfHexString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r == '¥' {
+			r = '￥'
+		}
+		if r > 0xFFFF {
+			r = '?'
+		}
+		for _, unit := range utf16.Encode([]rune{r}) {
+			fmt.Fprintf(&b, "%04X", unit)
+		}
+	}
+	return b.String()
+}
+
+// テキストの幅（ポイント）（ASCIIは半角、それ以外は全角の幅）
+func pdfTextWidth(s string, size float64) float64 {
+	width := 0.0
+	for _, r := range s {
+		if r < 0x80 {
+			width += 0.5
+		} else {
+			width += 1
+		}
+	}
+	return width * size
+}
+
+// 幅に収まらないテキストを末尾を "…" にして切り詰める
+func pdfTruncate(s string, size, maxWidth float64) string {
+	if pdfTextWidth(s, size) <= maxWidth {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && pdfTextWidth(string(runes)+"…", size) > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+// 文書情報の日時の形式（D:YYYYMMDDHHmmSS+HH'mm'）
+func pdfDate(t time.Time) string {
+	zone := t.Format("-0700")
+	return "D:" + t.Format("20060102150405") + zone[:3] + "'" + zone[3:] + "'"
+}
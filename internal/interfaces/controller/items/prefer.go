@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// Prefer ヘッダーの return の値（RFC 7240）
+const (
+	preferReturnMinimal        = "minimal"
+	preferReturnRepresentation = "representation"
+)
+
+// Prefer ヘッダーから return の値を取り出す（指定が無い場合は空文字）
+func preferredReturn(c echo.Context) string {
+	for _, header := range c.Request().Header.Values("Prefer") {
+		for _, preference := range strings.Split(header, ",") {
+			// "return=minimal; foo=bar" のようなパラメータは無視する
+			token, _, _ := strings.Cut(preference, ";")
+			name, value, ok := strings.Cut(token, "=")
+			if !ok || !strings.EqualFold(strings.TrimSpace(name), "return") {
+				continue
+			}
+			value = strings.ToLower(strings.Trim(strings.TrimSpace(value), `"`))
+			if value == preferReturnMinimal || value == preferReturnRepresentation {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// 登録・更新したアイテムを返す
+// itemは書き込み後にDBから読み直したもので、IDや登録日時などサーバー側で決まる値を含む
+// ETagも付けるため、続けて If-Match で更新する場合にGETし直す必要はない
+// Prefer: return=minimal の場合は本文を返さず、登録時は201、更新時は204とする（デフォルトはreturn=representation）
+func respondWithItem(c echo.Context, status int, item *entity.Item, body interface{}) error {
+	if status == http.StatusCreated {
+		c.Response().Header().Set(echo.HeaderLocation, "/items/"+item.PublicID())
+	}
+	c.Response().Header().Set("ETag", item.ETag())
+
+	preference := preferredReturn(c)
+	if preference != "" {
+		c.Response().Header().Set("Preference-Applied", "return="+preference)
+	}
+
+	if preference == preferReturnMinimal {
+		if status == http.StatusCreated {
+			return c.NoContent(http.StatusCreated)
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+	return c.JSON(status, body)
+}
@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// アイテムのレスポンス形式（表示用に整形した金額を含む）
+type ItemResponse struct {
+	*entity.Item
+	ID                   interface{} `json:"id"` // ID_TYPE=uuid の場合はUUIDの文字列（entity.Itemのidより優先される）
+	PurchasePriceDisplay string      `json:"purchase_price_display"`
+}
+
+// 日時は設定したタイムゾーンで、金額はlocaleの形式で表示する（元のエンティティは変更しない）
+func newItemResponse(item *entity.Item, locale language.Tag) ItemResponse {
+	display := *item
+	display.CreatedAt = item.CreatedAt.In(entity.TimeZone())
+	display.UpdatedAt = item.UpdatedAt.In(entity.TimeZone())
+	if item.DeletedAt != nil {
+		deletedAt := item.DeletedAt.In(entity.TimeZone())
+		display.DeletedAt = &deletedAt
+	}
+
+	return ItemResponse{
+		Item:                 &display,
+		ID:                   responseItemID(item.ID, item.UUID),
+		PurchasePriceDisplay: formatMoney(item.PurchasePrice, locale),
+	}
+}
+
+// 0件の場合も null ではなく [] になるよう空スライスを返す
+func newItemResponses(items []*entity.Item, locale language.Tag) []ItemResponse {
+	responses := make([]ItemResponse, 0, len(items))
+	for _, item := range items {
+		responses = append(responses, newItemResponse(item, locale))
+	}
+	return responses
+}
+
+// 見直しが必要なアイテムのレスポンス形式
+type IncompleteItemResponse struct {
+	Item          ItemResponse          `json:"item"`
+	MissingFields []entity.MissingField `json:"missing_fields"`
+}
+
+type IncompleteItemsResponse struct {
+	Items []IncompleteItemResponse `json:"items"`
+}
+
+func newIncompleteItemsResponse(items []usecase.IncompleteItem, locale language.Tag) IncompleteItemsResponse {
+	responses := make([]IncompleteItemResponse, 0, len(items))
+	for _, item := range items {
+		responses = append(responses, IncompleteItemResponse{
+			Item:          newItemResponse(item.Item, locale),
+			MissingFields: item.MissingFields,
+		})
+	}
+	return IncompleteItemsResponse{Items: responses}
+}
+
+// DBに接続できない場合にクライアントへ再試行を促す秒数（Retry-After）
+const databaseRetryAfterSeconds = "5"
+
+// プールの空きは接続の切断より早く回復するため、短めに再試行させる
+const databaseBusyRetryAfterSeconds = "1"
+
+// 予期しないエラーのレスポンスを返す
+// DBとの接続が切れている・コネクションプールが埋まっている場合は一時的な障害として503とRetry-Afterを、それ以外は500を返す
+func respondInternalError(c echo.Context, err error, message string) error {
+	// REQUEST_TIMEOUT のdeadlineでクエリが打ち切られた場合は、DBの障害ではなくタイムアウトとして返す
+	if errors.Is(err, context.DeadlineExceeded) && c.Request().Context().Err() != nil {
+		return c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Code:  CodeRequestTimeout,
+			Error: "request timed out",
+		})
+	}
+	if domainErrors.IsDatabaseBusyError(err) {
+		c.Response().Header().Set(echo.HeaderRetryAfter, databaseBusyRetryAfterSeconds)
+		return c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Code:  CodeDatabaseBusy,
+			Error: "database is busy, please retry later",
+		})
+	}
+	if domainErrors.IsDatabaseUnavailableError(err) {
+		c.Response().Header().Set(echo.HeaderRetryAfter, databaseRetryAfterSeconds)
+		return c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Code:  CodeDatabaseUnavailable,
+			Error: "database is temporarily unavailable, please retry later",
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Code:  CodeInternalError,
+		Error: message,
+	})
+}
+
+// 一意制約違反（409）のレスポンス（DBのエラーメッセージは返さず、制約ごとのメッセージを返す）
+func duplicateEntryResponse(err error) ErrorResponse {
+	response := ErrorResponse{
+		Code:  CodeItemAlreadyExists,
+		Error: "item already exists",
+	}
+	var dupErr *domainErrors.DuplicateEntryError
+	if errors.As(err, &dupErr) {
+		response.Details = []string{dupErr.Message}
+	}
+	return response
+}
+
+// 金額に小数やint64の範囲を超える値などが指定された場合のエラーメッセージ（それ以外はfalse）
+func priceTypeError(err error) (string, bool) {
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) || typeErr.Field != "purchase_price" {
+		return "", false
+	}
+
+	// 整数として読めるのに代入できない場合はint64の範囲外
+	if number, ok := strings.CutPrefix(typeErr.Value, "number "); ok && isIntegerLiteral(number) {
+		return "purchase_price must be between 0 and " + strconv.FormatInt(entity.MaxPurchasePrice, 10), true
+	}
+	return "purchase_price must be an integer in minor currency units (whole yen)", true
+}
+
+// 符号付きの整数の表記かどうか
+func isIntegerLiteral(value string) bool {
+	value = strings.TrimPrefix(value, "-")
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 表示用の金額のロケールを決めるリクエストヘッダー
+const headerAcceptLanguage = "Accept-Language"
+
+// Accept-Languageが無い場合の表示用の金額のロケール
+var DefaultPriceLocale = language.Japanese
+
+// Accept-Languageのワイルドカード（*）を解釈した言語タグ
+var languageMultiple = language.Make("mul")
+
+// Accept-Languageが無い、または解釈できない場合に使う表示用の金額のロケールを指定する
+func WithPriceLocale(tag language.Tag) HandlerOption {
+	return func(h *ItemHandler) {
+		h.priceLocale = tag
+	}
+}
+
+// ロケール（BCP 47の言語タグ、例: ja, en-US）を解釈する
+func ParsePriceLocale(value string) (language.Tag, error) {
+	tag, err := language.Parse(strings.TrimSpace(value))
+	if err != nil {
+		return language.Und, fmt.Errorf("unknown locale: %s", value)
+	}
+	return tag, nil
+}
+
+// このリクエストで表示用の金額に使うロケール（Accept-Languageで最も優先度の高い言語）
+func (h *ItemHandler) priceLocaleFor(c echo.Context) language.Tag {
+	// 同じURLでもヘッダーによって内容が変わるため、キャッシュに区別させる
+	c.Response().Header().Add(echo.HeaderVary, headerAcceptLanguage)
+
+	// 優先度の順に並んでいる（"*" は特定の言語ではない "mul" になるため使わない）
+	tags, _, err := language.ParseAcceptLanguage(c.Request().Header.Get(headerAcceptLanguage))
+	if err == nil {
+		for _, tag := range tags {
+			if !tag.IsRoot() && tag != languageMultiple {
+				return tag
+			}
+		}
+	}
+	return h.priceLocale
+}
+
+// 金額をロケールの記号と桁区切りで表示用の文字列にする（例: ja → "¥1,000,000"、en → "$1,000.00"）
+// 通貨の小数点以下の桁数はISO 4217に従う（金額は通貨の最小単位のため、USDの100000は$1,000.00）
+func formatMoney(m entity.Money, tag language.Tag) string {
+	unit, err := currency.ParseISO(m.Currency)
+	if err != nil {
+		return m.String()
+	}
+
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	scale, _ := currency.Standard.Rounding(unit)
+	printer := message.NewPrinter(tag)
+	symbol := printer.Sprint(currency.NarrowSymbol(unit))
+	// CLDRの日本語の円記号は全角（￥）だが、既存の表示（entity.FormatPrice）と揃えて半角にする
+	symbol = strings.ReplaceAll(symbol, "￥", "¥")
+
+	return sign + symbol + printer.Sprintf("%.*f", scale, float64(amount)/math.Pow10(scale))
+}
@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 非表示のフィールドを含めて返すためのヘッダー（値は設定したトークン）
+const headerRevealFields = "X-Reveal-Fields"
+
+// 通常のGETレスポンスから除外できるフィールドと、合わせて除外するJSONのキー
+var omittableFields = map[string][]string{
+	// purchase_price_amount・purchase_price_currency は ?format=flat-json の書き出しのキー
+	"purchase_price": {"purchase_price", "purchase_price_display", "purchase_price_amount", "purchase_price_currency"},
+	"purchase_date":  {"purchase_date"},
+	"serial_number":  {"serial_number"},
+}
+
+// GETレスポンスから除外するフィールドと、除外せずに返すためのトークンを指定する
+// トークンが空の場合は、常に除外する
+func WithHiddenFields(fields []string, revealToken string) HandlerOption {
+	return func(h *ItemHandler) {
+		keys := make(map[string]bool)
+		for _, field := range fields {
+			for _, key := range omittableFields[field] {
+				keys[key] = true
+			}
+		}
+		h.hiddenKeys = keys
+		h.revealToken = revealToken
+	}
+}
+
+// 除外するフィールドを検証する
+func ParseHiddenFields(fields []string) ([]string, error) {
+	parsed := make([]string, 0, len(fields))
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if _, ok := omittableFields[field]; !ok {
+			return nil, fmt.Errorf("unknown hidden field: %s (must be one of: purchase_price, purchase_date, serial_number)", field)
+		}
+		if !seen[field] {
+			seen[field] = true
+			parsed = append(parsed, field)
+		}
+	}
+	return parsed, nil
+}
+
+// このリクエストで除外するJSONのキー（除外しない場合はnil）
+func (h *ItemHandler) hiddenKeysFor(c echo.Context) map[string]bool {
+	if len(h.hiddenKeys) == 0 {
+		return nil
+	}
+	// 同じURLでもヘッダーによって内容が変わるため、キャッシュに区別させる（同じレスポンスで何度呼んでも1回だけ付ける）
+	if !slices.Contains(c.Response().Header().Values(echo.HeaderVary), headerRevealFields) {
+		c.Response().Header().Add(echo.HeaderVary, headerRevealFields)
+	}
+
+	token := c.Request().Header.Get(headerRevealFields)
+	if h.revealToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(h.revealToken)) == 1 {
+		return nil
+	}
+	return h.hiddenKeys
+}
+
+// アイテムを含むGETレスポンスを返す
+// 非表示のフィールドが設定されている場合は、レスポンスに含まれるすべてのオブジェクトからそのキーを除外する
+func (h *ItemHandler) respondProjected(c echo.Context, status int, body interface{}) error {
+	hidden := h.hiddenKeysFor(c)
+	if hidden == nil {
+		return c.JSON(status, body)
+	}
+
+	projected, err := project(body, hidden)
+	if err != nil {
+		return err
+	}
+	return c.JSON(status, projected)
+}
+
+// JSONに変換した値から、指定したキーを除外する
+func project(body interface{}, hidden map[string]bool) (interface{}, error) {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	// IDや金額がfloat64で丸められないよう、数値はそのまま扱う
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var projected interface{}
+	if err := decoder.Decode(&projected); err != nil {
+		return nil, err
+	}
+	return omitKeys(projected, hidden), nil
+}
+
+// JSONの値に含まれるオブジェクトから、指定したキーを再帰的に除外する
+func omitKeys(value interface{}, keys map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if keys[key] {
+				delete(v, key)
+				continue
+			}
+			v[key] = omitKeys(child, keys)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = omitKeys(child, keys)
+		}
+	}
+	return value
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// スキーマはデプロイするまで変わらないため、ETagで再検証させつつ1時間キャッシュさせる
+const schemaCacheControl = "public, max-age=3600"
+
+// アイテムの1フィールドの定義
+type ItemSchemaField struct {
+	entity.FieldSchema
+	ReadOnly  bool `json:"read_only"` // 登録時に指定できない（サーバーが設定する）
+	Updatable bool `json:"updatable"` // PATCH /items/{id} で変更できる
+}
+
+type ItemSchemaResponse struct {
+	Fields []ItemSchemaField `json:"fields"`
+}
+
+// 本文とETagは起動中に変わらないため、初回のみ生成する
+var itemSchema = sync.OnceValues(func() (ItemSchemaResponse, string) {
+	creatable := jsonFieldNames(usecase.CreateItemInput{})
+	updatable := jsonFieldNames(usecase.UpdateItemInput{})
+
+	schema := entity.ItemSchema()
+	fields := make([]ItemSchemaField, len(schema))
+	for i, field := range schema {
+		fields[i] = ItemSchemaField{
+			FieldSchema: field,
+			ReadOnly:    !creatable[field.Name],
+			Updatable:   updatable[field.Name],
+		}
+	}
+	response := ItemSchemaResponse{Fields: fields}
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		// 固定の構造体のため発生しない
+		panic(err)
+	}
+	sum := sha256.Sum256(body)
+	return response, `"` + hex.EncodeToString(sum[:16]) + `"`
+})
+
+// 構造体のJSONでのフィールド名
+func jsonFieldNames(v interface{}) map[string]bool {
+	t := reflect.TypeOf(v)
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ","); name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// GET /items/schema: アイテムのフィールド名・型・必須かどうか・選択肢を返す（フォームの自動生成用）
+// エンティティの定義から生成するため、フィールドやカテゴリーを追加すると自動的に反映される
+func (h *ItemHandler) GetItemSchema(c echo.Context) error {
+	response, etag := itemSchema()
+
+	c.Response().Header().Set("Cache-Control", schemaCacheControl)
+	c.Response().Header().Set("ETag", etag)
+	if ifNoneMatch(c.Request().Header.Get("If-None-Match"), etag) {
+		return c.NoContent(http.StatusNotModified)
+	}
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// POST /items/search のリクエスト形式（省略したフィールドは絞り込まない）
+// 値の意味は GET /items の同名のクエリパラメータと同じ
+type SearchItemsRequest struct {
+	Category         []string `json:"category"`
+	ExcludeCategory  []string `json:"exclude_category"`
+	Brand            string   `json:"brand"`
+	Q                string   `json:"q"`
+	SerialPrefix     string   `json:"serial_prefix"`
+	Status           string   `json:"status"`
+	CreatedSince     string   `json:"created_since"`
+	MinPurchasePrice *int64   `json:"min_purchase_price"`
+	MaxPurchasePrice *int64   `json:"max_purchase_price"`
+	PurchaseDateFrom string   `json:"purchase_date_from"` // YYYY-MM-DD（この日を含む）
+	PurchaseDateTo   string   `json:"purchase_date_to"`   // YYYY-MM-DD（この日を含む）
+	Month            int      `json:"month"`
+	Weekday          []string `json:"weekday"`
+	IDFrom           int64    `json:"id_from"`
+	IDTo             int64    `json:"id_to"`
+	Sort             string   `json:"sort"`
+	Limit            *int     `json:"limit"` // 省略時は DefaultPageLimit
+	Offset           int      `json:"offset"`
+}
+
+// 絞り込み条件をJSONで受け取り、一致するアイテムをページ単位で返す
+// 条件が多くクエリ文字列が長くなる検索フォーム向け（レスポンスはブランド別一覧と同じ形式）
+func (h *ItemHandler) SearchItems(c echo.Context) error {
+	req, err := decodeSearchItemsRequest(jsonRequestBody(c, c.Request().Body))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidRequest,
+			Error:   "invalid request format",
+			Details: []string{err.Error()},
+		})
+	}
+
+	filter, page, err := req.toFilter()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid search condition",
+			Details: []string{err.Error()},
+		})
+	}
+
+	result, err := h.itemUsecase.GetItemPage(c.Request().Context(), filter, page)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid search condition",
+				Details: []string{err.Error()},
+			})
+		}
+		return respondInternalError(c, err, "failed to search items")
+	}
+
+	return h.respondItemPage(c, result)
+}
+
+// 検索条件のJSONを読み込む
+// 対応していない条件を無視して全件を返さないよう、未知のフィールドはエラーにする
+func decodeSearchItemsRequest(r io.Reader) (SearchItemsRequest, error) {
+	var req SearchItemsRequest
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&req)
+	return req, err
+}
+
+// リクエストを絞り込み条件とページ指定に変換する（範囲などの検証はusecaseで行う）
+func (r SearchItemsRequest) toFilter() (usecase.ItemFilter, usecase.Page, error) {
+	filter := usecase.ItemFilter{
+		Brand:            strings.TrimSpace(r.Brand),
+		Query:            strings.TrimSpace(r.Q),
+		SerialPrefix:     strings.TrimSpace(r.SerialPrefix),
+		MinPurchasePrice: r.MinPurchasePrice,
+		MaxPurchasePrice: r.MaxPurchasePrice,
+		PurchasedFrom:    strings.TrimSpace(r.PurchaseDateFrom),
+		PurchasedTo:      strings.TrimSpace(r.PurchaseDateTo),
+		PurchaseMonth:    r.Month,
+		IDFrom:           r.IDFrom,
+		IDTo:             r.IDTo,
+		Sort:             usecase.SortOrder(strings.TrimSpace(r.Sort)),
+	}
+	page := usecase.Page{Limit: usecase.DefaultPageLimit, Offset: r.Offset}
+	if r.Limit != nil {
+		page.Limit = *r.Limit
+	}
+
+	var err error
+	if filter.Categories, err = parseCategoryList(r.Category); err != nil {
+		return filter, page, err
+	}
+	if filter.ExcludeCategories, err = parseCategoryList(r.ExcludeCategory); err != nil {
+		return filter, page, err
+	}
+	if filter.PurchaseWeekdays, err = parseWeekdayList(r.Weekday); err != nil {
+		return filter, page, err
+	}
+	if value := strings.TrimSpace(r.Status); value != "" {
+		status, err := entity.NewStatus(value)
+		if err != nil {
+			return filter, page, fmt.Errorf("unknown status: %s", value)
+		}
+		filter.Status = status
+	}
+	if value := strings.TrimSpace(r.CreatedSince); value != "" {
+		since, err := parseCreatedSince(value, entity.Now())
+		if err != nil {
+			return filter, page, err
+		}
+		filter.CreatedSince = &since
+	}
+	return filter, page, nil
+}
@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 名前を付けた検索条件の保存に使うユースケースを指定する
+func WithSearchPresets(presets usecase.SearchPresetUsecase) HandlerOption {
+	return func(h *ItemHandler) {
+		h.searchPresets = presets
+	}
+}
+
+// 検索条件の一覧のレスポンス形式
+type SearchPresetsResponse struct {
+	Presets []*entity.SearchPreset `json:"presets"`
+}
+
+// 検索条件（POST /items/search と同じ形式）に名前を付けて保存する（同じ名前の場合は置き換える）
+// 保存する時点で解釈・検証できない条件は400にする
+func (h *ItemHandler) SaveSearchPreset(c echo.Context) error {
+	name, err := pathParam(c, "name")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:  CodeInvalidParameter,
+			Error: "invalid preset name",
+		})
+	}
+
+	// 保存する条件も snake_case にそろえるため、camelCase のボディは書き換えてから読み込む
+	body, err := io.ReadAll(jsonRequestBody(c, c.Request().Body))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:  CodeInvalidRequest,
+			Error: "invalid request format",
+		})
+	}
+	req, err := decodeSearchItemsRequest(bytes.NewReader(body))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidRequest,
+			Error:   "invalid request format",
+			Details: []string{err.Error()},
+		})
+	}
+	filter, page, err := req.toFilter()
+	if err == nil {
+		err = filter.Validate()
+	}
+	if err == nil {
+		err = page.Validate()
+	}
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid search condition",
+			Details: []string{err.Error()},
+		})
+	}
+
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, body); err != nil {
+		return respondInternalError(c, err, "failed to save search preset")
+	}
+	preset, err := h.searchPresets.SavePreset(c.Request().Context(), name, compacted.Bytes())
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid preset name",
+				Details: []string{err.Error()},
+			})
+		}
+		return respondInternalError(c, err, "failed to save search preset")
+	}
+
+	return c.JSON(http.StatusOK, preset)
+}
+
+// 保存した検索条件で検索する（?limit= と ?offset= を指定した場合は保存した値より優先する）
+// 保存した後に検索の形式が変わり、条件を解釈できなくなった場合は422を返す
+func (h *ItemHandler) RunSearchPreset(c echo.Context) error {
+	name, err := pathParam(c, "name")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:  CodeInvalidParameter,
+			Error: "invalid preset name",
+		})
+	}
+
+	preset, err := h.searchPresets.GetPreset(c.Request().Context(), name)
+	if err != nil {
+		if resp, status, ok := notFoundResponse(err); ok {
+			return c.JSON(status, resp)
+		}
+		return respondInternalError(c, err, "failed to retrieve search preset")
+	}
+
+	req, err := decodeSearchItemsRequest(bytes.NewReader(preset.Filter))
+	if err != nil {
+		return invalidPreset(c, err)
+	}
+	filter, page, err := req.toFilter()
+	if err != nil {
+		return invalidPreset(c, err)
+	}
+	if page, err = parsePageOver(c, page); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{err.Error()},
+		})
+	}
+
+	result, err := h.itemUsecase.GetItemPage(c.Request().Context(), filter, page)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return invalidPreset(c, err)
+		}
+		return respondInternalError(c, err, "failed to search items")
+	}
+
+	return h.respondItemPage(c, result)
+}
+
+// 名前の順に保存した検索条件を返す
+func (h *ItemHandler) ListSearchPresets(c echo.Context) error {
+	presets, err := h.searchPresets.ListPresets(c.Request().Context())
+	if err != nil {
+		return respondInternalError(c, err, "failed to retrieve search presets")
+	}
+	return c.JSON(http.StatusOK, SearchPresetsResponse{Presets: presets})
+}
+
+func (h *ItemHandler) DeleteSearchPreset(c echo.Context) error {
+	name, err := pathParam(c, "name")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:  CodeInvalidParameter,
+			Error: "invalid preset name",
+		})
+	}
+
+	if err := h.searchPresets.DeletePreset(c.Request().Context(), name); err != nil {
+		if resp, status, ok := notFoundResponse(err); ok {
+			return c.JSON(status, resp)
+		}
+		return respondInternalError(c, err, "failed to delete search preset")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// 保存した検索条件を解釈できない場合の422レスポンス（検索条件を保存し直す必要がある）
+func invalidPreset(c echo.Context, err error) error {
+	return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+		Code:    CodeInvalidPreset,
+		Error:   "stored search condition is no longer valid",
+		Details: []string{err.Error()},
+	})
+}
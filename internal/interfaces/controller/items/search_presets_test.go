@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// MockSearchPresetUsecase はtestify/mockを使用した検索条件のモックユースケース
+type MockSearchPresetUsecase struct {
+	mock.Mock
+}
+
+func (m *MockSearchPresetUsecase) SavePreset(ctx context.Context, name string, filter json.RawMessage) (*entity.SearchPreset, error) {
+	args := m.Called(ctx, name, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.SearchPreset), args.Error(1)
+}
+
+func (m *MockSearchPresetUsecase) GetPreset(ctx context.Context, name string) (*entity.SearchPreset, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.SearchPreset), args.Error(1)
+}
+
+func (m *MockSearchPresetUsecase) ListPresets(ctx context.Context) ([]*entity.SearchPreset, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.SearchPreset), args.Error(1)
+}
+
+func (m *MockSearchPresetUsecase) DeletePreset(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+func TestItemHandler_SaveSearchPreset(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*MockSearchPresetUsecase)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "正常系: 空白を除いたJSONで保存",
+			body: `{"brand": "ROLEX", "min_purchase_price": 500000}`,
+			setupMock: func(m *MockSearchPresetUsecase) {
+				filter := json.RawMessage(`{"brand":"ROLEX","min_purchase_price":500000}`)
+				m.On("SavePreset", mock.Anything, "rolex", filter).Return(&entity.SearchPreset{Name: "rolex", Filter: filter}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: 対応していないフィールド",
+			body:           `{"tags":["vintage"]}`,
+			setupMock:      func(m *MockSearchPresetUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeInvalidRequest,
+		},
+		{
+			name:           "異常系: 検証できない条件",
+			body:           `{"purchase_date_from":"2023-01-01","purchase_date_to":"2020-01-01"}`,
+			setupMock:      func(m *MockSearchPresetUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeInvalidParameter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPresets := new(MockSearchPresetUsecase)
+			tt.setupMock(mockPresets)
+			handler := NewItemHandler(new(MockItemUsecase), WithSearchPresets(mockPresets))
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, "/items/search/preset/rolex", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetParamNames("name")
+			c.SetParamValues("rolex")
+
+			assert.NoError(t, handler.SaveSearchPreset(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedCode != "" {
+				assert.Contains(t, rec.Body.String(), `"code":"`+tt.expectedCode+`"`)
+			}
+			mockPresets.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_RunSearchPreset(t *testing.T) {
+	items := []*entity.Item{{ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15"}}
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockSearchPresetUsecase, *MockItemUsecase)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:  "正常系: 保存した条件で検索し、offsetはクエリパラメータを優先する",
+			query: "?offset=10",
+			setupMock: func(p *MockSearchPresetUsecase, m *MockItemUsecase) {
+				p.On("GetPreset", mock.Anything, "rolex").
+					Return(&entity.SearchPreset{Name: "rolex", Filter: json.RawMessage(`{"brand":"ROLEX","limit":5}`)}, nil)
+				m.On("GetItemPage", mock.Anything, usecase.ItemFilter{Brand: "ROLEX"}, usecase.Page{Limit: 5, Offset: 10}).
+					Return(&usecase.ItemPage{Items: items, Total: 11, Limit: 5, Offset: 10}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "異常系: 存在しない",
+			setupMock: func(p *MockSearchPresetUsecase, m *MockItemUsecase) {
+				p.On("GetPreset", mock.Anything, "rolex").Return(nil, domainErrors.ErrPresetNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   CodePresetNotFound,
+		},
+		{
+			name: "異常系: 保存した条件を解釈できない",
+			setupMock: func(p *MockSearchPresetUsecase, m *MockItemUsecase) {
+				p.On("GetPreset", mock.Anything, "rolex").
+					Return(&entity.SearchPreset{Name: "rolex", Filter: json.RawMessage(`{"condition":"mint"}`)}, nil)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedCode:   CodeInvalidPreset,
+		},
+		{
+			name: "異常系: 保存した条件が現在の検証を通らない",
+			setupMock: func(p *MockSearchPresetUsecase, m *MockItemUsecase) {
+				p.On("GetPreset", mock.Anything, "rolex").
+					Return(&entity.SearchPreset{Name: "rolex", Filter: json.RawMessage(`{"sort":"name"}`)}, nil)
+				m.On("GetItemPage", mock.Anything, usecase.ItemFilter{Sort: "name"}, usecase.Page{Limit: usecase.DefaultPageLimit}).
+					Return(nil, domainErrors.ErrInvalidInput)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedCode:   CodeInvalidPreset,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPresets := new(MockSearchPresetUsecase)
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockPresets, mockUsecase)
+			handler := NewItemHandler(mockUsecase, WithSearchPresets(mockPresets))
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/items/search/preset/rolex"+tt.query, nil), rec)
+			c.SetParamNames("name")
+			c.SetParamValues("rolex")
+
+			assert.NoError(t, handler.RunSearchPreset(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedCode != "" {
+				assert.Contains(t, rec.Body.String(), `"code":"`+tt.expectedCode+`"`)
+			}
+			mockPresets.AssertExpectations(t)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_DeleteSearchPreset(t *testing.T) {
+	mockPresets := new(MockSearchPresetUsecase)
+	mockPresets.On("DeletePreset", mock.Anything, "rolex").Return(domainErrors.ErrPresetNotFound)
+	handler := NewItemHandler(new(MockItemUsecase), WithSearchPresets(mockPresets))
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodDelete, "/items/search/preset/rolex", nil), rec)
+	c.SetParamNames("name")
+	c.SetParamValues("rolex")
+
+	assert.NoError(t, handler.DeleteSearchPreset(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
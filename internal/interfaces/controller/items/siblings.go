@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 前後のアイテムのレスポンス形式（ID_TYPE=uuid の場合はidにUUIDを返す）
+type ItemSiblingsResponse struct {
+	*usecase.ItemSiblings
+	ID       interface{}          `json:"id"`
+	Previous *ItemSiblingResponse `json:"previous"`
+	Next     *ItemSiblingResponse `json:"next"`
+}
+
+type ItemSiblingResponse struct {
+	*usecase.ItemSibling
+	ID interface{} `json:"id"`
+}
+
+func newItemSiblingResponse(sibling *usecase.ItemSibling) *ItemSiblingResponse {
+	if sibling == nil {
+		return nil
+	}
+	return &ItemSiblingResponse{ItemSibling: sibling, ID: responseItemID(sibling.ID, sibling.UUID)}
+}
+
+// GET /items/:id/siblings?sort=purchase_price
+// GET /items と同じ絞り込み条件と並び順で、直前・直後のアイテムを返す（先頭・末尾の場合はnull）
+func (h *ItemHandler) GetItemSiblings(c echo.Context) error {
+	id, err := h.parseItemID(c)
+	if err != nil {
+		return respondItemIDError(c, err, "invalid item ID")
+	}
+
+	filter, err := ParseItemFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{err.Error()},
+		})
+	}
+
+	siblings, err := h.itemUsecase.GetItemSiblings(c.Request().Context(), id, filter)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return c.JSON(http.StatusNotFound, ErrorResponse{
+				Code:  CodeItemNotFound,
+				Error: "item not found",
+			})
+		}
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{err.Error()},
+			})
+		}
+		return respondInternalError(c, err, "failed to retrieve item siblings")
+	}
+
+	return c.JSON(http.StatusOK, ItemSiblingsResponse{
+		ItemSiblings: siblings,
+		ID:           h.pathResponseID(c, siblings.ID),
+		Previous:     newItemSiblingResponse(siblings.Previous),
+		Next:         newItemSiblingResponse(siblings.Next),
+	})
+}
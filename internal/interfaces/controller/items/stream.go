@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 購読者が上限に達している場合に、再接続まで待つ秒数
+const streamRetryAfterSeconds = "5"
+
+// GET /items/stream でアイテムの変更イベントを配信する
+// heartbeatの間隔（0の場合は送らない）でコメントを送り、プロキシなどに接続を切られないようにする
+func WithEventStream(subscriber usecase.ItemEventSubscriber, heartbeat time.Duration) HandlerOption {
+	return func(h *ItemHandler) {
+		h.eventSubscriber = subscriber
+		h.streamHeartbeat = heartbeat
+	}
+}
+
+// GET /items/stream を登録するか
+func (h *ItemHandler) EventStreamEnabled() bool {
+	return h.eventSubscriber != nil
+}
+
+// アイテムの登録・更新・削除をServer-Sent Eventsで配信する（?category= で絞り込める）
+// イベント名はWebhookの event と同じで、data はWebhookと同じJSON（非表示のフィールドは除外する）
+func (h *ItemHandler) StreamItemEvents(c echo.Context) error {
+	categories, err := parseCategories(c.QueryParam("category"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{err.Error()},
+		})
+	}
+
+	events, unsubscribe, err := h.eventSubscriber.Subscribe()
+	if err != nil {
+		c.Response().Header().Set("Retry-After", streamRetryAfterSeconds)
+		return c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Code:  CodeServerBusy,
+			Error: "too many stream clients, please retry later",
+		})
+	}
+	defer unsubscribe()
+
+	hidden := h.hiddenKeysFor(c)
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	// nginxなどのプロキシにバッファリングさせない
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	var heartbeat <-chan time.Time
+	if h.streamHeartbeat > 0 {
+		ticker := time.NewTicker(h.streamHeartbeat)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			// クライアントが切断した
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				// 受信が遅れた、またはサーバーの停止（クライアントは再接続し、GET /items/changes で取りこぼしを取得する）
+				return nil
+			}
+			if !eventMatchesCategories(event, categories) {
+				continue
+			}
+			if err := writeItemEvent(res, event, hidden); err != nil {
+				slog.Warn("failed to write stream event", "event", event.Type, "item_id", event.ItemID, "error", err)
+				return nil
+			}
+		case <-heartbeat:
+			if _, err := fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
+
+// カテゴリーを指定しない場合はすべてのイベントを配信する
+func eventMatchesCategories(event usecase.ItemEvent, categories []entity.Category) bool {
+	if len(categories) == 0 {
+		return true
+	}
+	return event.Item != nil && slices.Contains(categories, event.Item.Category)
+}
+
+// 1件のイベントを書き込んで送信する
+func writeItemEvent(res *echo.Response, event usecase.ItemEvent, hidden map[string]bool) error {
+	var body interface{} = event
+	if hidden != nil {
+		projected, err := project(event, hidden)
+		if err != nil {
+			return err
+		}
+		body = projected
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+		return err
+	}
+	res.Flush()
+	return nil
+}
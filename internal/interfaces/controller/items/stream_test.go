@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// テスト用の購読（eventsを閉じるとストリームが終了する）
+type fakeEventSubscriber struct {
+	events       chan usecase.ItemEvent
+	err          error
+	unsubscribed bool
+}
+
+func (s *fakeEventSubscriber) Subscribe() (<-chan usecase.ItemEvent, func(), error) {
+	if s.err != nil {
+		return nil, nil, s.err
+	}
+	return s.events, func() { s.unsubscribed = true }, nil
+}
+
+func newStreamEvent(eventType usecase.EventType, id int64, category entity.Category) usecase.ItemEvent {
+	return usecase.ItemEvent{
+		Type:       eventType,
+		ItemID:     id,
+		Item:       &entity.Item{ID: id, Name: "アイテム", Category: category, Brand: "ROLEX", PurchasePrice: entity.JPY(1000), PurchaseDate: "2023-01-01", SerialNumber: "SN-1"},
+		OccurredAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func serveStream(ctx context.Context, h *ItemHandler, query string) *httptest.ResponseRecorder {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/items/stream?"+query, nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	_ = h.StreamItemEvents(e.NewContext(req, rec))
+	return rec
+}
+
+func TestItemHandler_StreamItemEvents(t *testing.T) {
+	t.Run("正常系: 指定したカテゴリーのイベントを配信", func(t *testing.T) {
+		subscriber := &fakeEventSubscriber{events: make(chan usecase.ItemEvent, 2)}
+		subscriber.events <- newStreamEvent(usecase.EventItemCreated, 1, entity.CategoryWatch)
+		subscriber.events <- newStreamEvent(usecase.EventItemDeleted, 2, entity.CategoryBag)
+		close(subscriber.events)
+		h := NewItemHandler(nil, WithEventStream(subscriber, 0))
+
+		rec := serveStream(context.Background(), h, "category="+url.QueryEscape("時計"))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/event-stream", rec.Header().Get(echo.HeaderContentType))
+		assert.Contains(t, rec.Body.String(), "event: item.created\ndata: {\"event\":\"item.created\",\"item_id\":1,")
+		assert.NotContains(t, rec.Body.String(), "item.deleted")
+		assert.True(t, subscriber.unsubscribed)
+	})
+
+	t.Run("正常系: 非表示のフィールドを除外", func(t *testing.T) {
+		subscriber := &fakeEventSubscriber{events: make(chan usecase.ItemEvent, 1)}
+		subscriber.events <- newStreamEvent(usecase.EventItemUpdated, 1, entity.CategoryWatch)
+		close(subscriber.events)
+		h := NewItemHandler(nil, WithEventStream(subscriber, 0), WithHiddenFields([]string{"serial_number"}, ""))
+
+		rec := serveStream(context.Background(), h, "")
+
+		assert.Contains(t, rec.Body.String(), "event: item.updated")
+		assert.NotContains(t, rec.Body.String(), "serial_number")
+	})
+
+	t.Run("正常系: 接続を保つためのコメントを送る", func(t *testing.T) {
+		subscriber := &fakeEventSubscriber{events: make(chan usecase.ItemEvent)}
+		h := NewItemHandler(nil, WithEventStream(subscriber, time.Millisecond))
+		time.AfterFunc(50*time.Millisecond, func() { close(subscriber.events) })
+
+		rec := serveStream(context.Background(), h, "")
+
+		assert.Contains(t, rec.Body.String(), ": heartbeat\n\n")
+	})
+
+	t.Run("正常系: クライアントが切断した場合は終了する", func(t *testing.T) {
+		subscriber := &fakeEventSubscriber{events: make(chan usecase.ItemEvent)}
+		h := NewItemHandler(nil, WithEventStream(subscriber, 0))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		rec := serveStream(ctx, h, "")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.True(t, subscriber.unsubscribed)
+	})
+
+	t.Run("異常系: 不明なカテゴリー", func(t *testing.T) {
+		h := NewItemHandler(nil, WithEventStream(&fakeEventSubscriber{}, 0))
+
+		rec := serveStream(context.Background(), h, "category=unknown")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), CodeInvalidParameter)
+	})
+
+	t.Run("異常系: 購読者の上限", func(t *testing.T) {
+		h := NewItemHandler(nil, WithEventStream(&fakeEventSubscriber{err: usecase.ErrTooManySubscribers}, 0))
+
+		rec := serveStream(context.Background(), h, "")
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, streamRetryAfterSeconds, rec.Header().Get("Retry-After"))
+		assert.Contains(t, rec.Body.String(), CodeServerBusy)
+	})
+}
+
+func TestItemHandler_EventStreamEnabled(t *testing.T) {
+	assert.False(t, NewItemHandler(nil).EventStreamEnabled())
+	assert.True(t, NewItemHandler(nil, WithEventStream(&fakeEventSubscriber{err: errors.New("closed")}, 0)).EventStreamEnabled())
+}
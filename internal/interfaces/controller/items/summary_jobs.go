@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// 集計をバックグラウンドで計算するジョブと、非同期にするアイテム数の下限を指定する
+// 下限以上のアイテムがある場合、GET /items/summary は202とジョブのIDを返す
+func WithSummaryJobs(jobs *usecase.SummaryJobs, threshold int) HandlerOption {
+	return func(h *ItemHandler) {
+		h.summaryJobs = jobs
+		h.summaryAsyncThreshold = threshold
+	}
+}
+
+// 非同期で集計する場合は、ジョブを開始して202を返す（同期で集計する場合はfalse）
+func (h *ItemHandler) startSummaryJob(c echo.Context) (bool, error) {
+	if h.summaryJobs == nil {
+		return false, nil
+	}
+	version, err := h.itemUsecase.GetItemListVersion(c.Request().Context(), usecase.ItemFilter{})
+	if err != nil {
+		return true, respondInternalError(c, err, "failed to retrieve summary")
+	}
+	if version.Count < h.summaryAsyncThreshold {
+		return false, nil
+	}
+
+	job := h.summaryJobs.Start()
+	c.Response().Header().Set(echo.HeaderLocation, "/items/summary/jobs/"+job.ID)
+	return true, c.JSON(http.StatusAccepted, job)
+}
+
+// GET /items/summary/jobs/{jobId}?sparse=true
+// 計算中は202、完了後は GET /items/summary と同じ形式の集計を返す
+func (h *ItemHandler) GetSummaryJob(c echo.Context) error {
+	var job usecase.SummaryJob
+	exists := false
+	if h.summaryJobs != nil {
+		job, exists = h.summaryJobs.Get(c.Param("jobId"))
+	}
+	if !exists {
+		return c.JSON(http.StatusNotFound, ErrorResponse{
+			Code:  CodeSummaryJobNotFound,
+			Error: "summary job not found",
+		})
+	}
+
+	switch job.Status {
+	case usecase.SummaryJobPending:
+		return c.JSON(http.StatusAccepted, job)
+	case usecase.SummaryJobFailed:
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Code:  CodeInternalError,
+			Error: "failed to retrieve summary",
+		})
+	}
+
+	sparse := false
+	if value := strings.TrimSpace(c.QueryParam("sparse")); value != "" {
+		var err error
+		if sparse, err = strconv.ParseBool(value); err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{"sparse must be true or false"},
+			})
+		}
+	}
+	summary := *job.Summary
+	if sparse {
+		// 保持している結果を変更しないよう、コピーから除く
+		summary.Categories = nonZeroCategories(summary.Categories)
+	}
+	return c.JSON(http.StatusOK, summary)
+}
+
+// 件数が0のカテゴリーを除いたコピーを返す
+func nonZeroCategories(categories map[string]int) map[string]int {
+	nonZero := make(map[string]int, len(categories))
+	for category, count := range categories {
+		if count > 0 {
+			nonZero[category] = count
+		}
+	}
+	return nonZero
+}
@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 購入年ごとの集計のレスポンス
+type TimelineResponse struct {
+	Years []usecase.TimelineEntry `json:"years"`
+}
+
+// GET /items/timeline?category=
+func (h *ItemHandler) GetTimeline(c echo.Context) error {
+	filter, err := ParseItemFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{err.Error()},
+		})
+	}
+
+	entries, err := h.itemUsecase.GetTimeline(c.Request().Context(), filter)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{err.Error()},
+			})
+		}
+		return respondInternalError(c, err, "failed to retrieve timeline")
+	}
+
+	return c.JSON(http.StatusOK, TimelineResponse{Years: entries})
+}
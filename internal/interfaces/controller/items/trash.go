@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 論理削除済みのアイテム（ゴミ箱）をページ単位で返す
+// 一覧と同じ絞り込み条件（category など）と、deleted_at での並べ替え（?sort=deleted_at / -deleted_at）を使える
+func (h *ItemHandler) GetTrash(c echo.Context) error {
+	filter, err := ParseItemFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{err.Error()},
+		})
+	}
+	page, err := parsePage(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{err.Error()},
+		})
+	}
+
+	filter.Deleted = true
+	result, err := h.itemUsecase.GetItemPage(c.Request().Context(), filter, page)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{err.Error()},
+			})
+		}
+		return respondInternalError(c, err, "failed to retrieve items")
+	}
+
+	return h.respondItemPage(c, result)
+}
@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 購入年ごとの推移のレスポンス
+type TrendsResponse struct {
+	Years []usecase.TrendEntry `json:"years"`
+}
+
+// GET /items/trends?category=
+func (h *ItemHandler) GetTrends(c echo.Context) error {
+	filter, err := ParseItemFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeInvalidParameter,
+			Error:   "invalid query parameter",
+			Details: []string{err.Error()},
+		})
+	}
+
+	trends, err := h.itemUsecase.GetTrends(c.Request().Context(), filter)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    CodeInvalidParameter,
+				Error:   "invalid query parameter",
+				Details: []string{err.Error()},
+			})
+		}
+		return respondInternalError(c, err, "failed to retrieve trends")
+	}
+
+	return c.JSON(http.StatusOK, TrendsResponse{Years: trends})
+}
@@ -6,12 +6,27 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
-type SystemHandler struct{}
+type SystemHandler struct {
+	version VersionResponse
+}
+
+// ビルド情報のレスポンス形式
+type VersionResponse struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+}
 
 func (handler *SystemHandler) Health(ctx echo.Context) {
 	ctx.NoContent(http.StatusOK)
 }
 
-func NewSystemHandler() *SystemHandler {
-	return &SystemHandler{}
+// デプロイされているビルドの情報を返す
+func (handler *SystemHandler) Version(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, handler.version)
+}
+
+func NewSystemHandler(version VersionResponse) *SystemHandler {
+	return &SystemHandler{version: version}
 }
@@ -0,0 +1,48 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+
+	"github.com/go-sql-driver/mysql"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// コネクションプールが埋まっていて、待っても接続を取得できなかった（SqlHandlerの実装が返す）
+var ErrPoolTimeout = errors.New("timed out waiting for a database connection")
+
+// DBのエラーをドメインのエラーに変換する
+// 接続が切れている・接続できない場合は、一時的な障害としてErrDatabaseUnavailableとしても判定できるようにする
+// プールが埋まっている場合は、過負荷としてErrDatabaseBusyとしても判定できるようにする
+// contextの期限切れで打ち切られた場合は、呼び出し元がタイムアウトと判定できるよう元のエラーを残す
+func databaseError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	if errors.Is(err, ErrPoolTimeout) {
+		return fmt.Errorf("%w: %w: %s", domainErrors.ErrDatabaseError, domainErrors.ErrDatabaseBusy, err.Error())
+	}
+	if isConnectionError(err) {
+		return fmt.Errorf("%w: %w: %s", domainErrors.ErrDatabaseError, domainErrors.ErrDatabaseUnavailable, err.Error())
+	}
+	return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+}
+
+// DBとの接続に関するエラーかどうか（SQLや制約のエラーはfalse）
+// database/sqlはプールの接続がErrBadConnを返した場合に新しい接続で再試行するため、ここに届くのは再試行しても失敗した場合
+func isConnectionError(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, mysql.ErrInvalidConn) || errors.Is(err, sql.ErrConnDone) {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
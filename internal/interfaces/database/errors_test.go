@@ -0,0 +1,47 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestDatabaseError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		unavailable bool
+		busy        bool
+	}{
+		{name: "切断された接続", err: driver.ErrBadConn, unavailable: true},
+		{name: "MySQLの無効な接続", err: mysql.ErrInvalidConn, unavailable: true},
+		{name: "閉じられた接続", err: sql.ErrConnDone, unavailable: true},
+		{name: "接続の拒否", err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, unavailable: true},
+		{name: "ラップされた接続のリセット", err: fmt.Errorf("read: %w", syscall.ECONNRESET), unavailable: true},
+		{name: "コネクションプールの取得待ちのタイムアウト", err: fmt.Errorf("%w (waited 5s)", ErrPoolTimeout), busy: true},
+		{name: "contextの期限切れ", err: context.DeadlineExceeded},
+		{name: "SQLのエラー", err: &mysql.MySQLError{Number: 1064, Message: "You have an error in your SQL syntax"}},
+		{name: "その他のエラー", err: errors.New("unexpected")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := databaseError(tt.err)
+			assert.True(t, domainErrors.IsDatabaseError(err))
+			assert.Equal(t, tt.unavailable, domainErrors.IsDatabaseUnavailableError(err))
+			assert.Equal(t, tt.busy, domainErrors.IsDatabaseBusyError(err))
+			// 呼び出し元がリクエストのタイムアウトと判定できるよう、元のエラーを残す
+			assert.Equal(t, errors.Is(tt.err, context.DeadlineExceeded), errors.Is(err, context.DeadlineExceeded))
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// アイテムの画像をitem_imagesテーブルに保存するリポジトリ
+type ItemImageRepository struct {
+	SqlHandler
+}
+
+func (r *ItemImageRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemImage, error) {
+	query := `
+        SELECT id, item_id, position, content_type, size, created_at
+        FROM item_images
+        WHERE item_id = ?
+        ORDER BY position ASC, id ASC
+    `
+
+	rows, err := r.Query(ctx, query, itemID)
+	if err != nil {
+		return nil, databaseError(err)
+	}
+	defer rows.Close()
+
+	var images []*entity.ItemImage
+	for rows.Next() {
+		var image entity.ItemImage
+		var createdAt sql.NullTime
+		if err := rows.Scan(&image.ID, &image.ItemID, &image.Position, &image.ContentType, &image.Size, &createdAt); err != nil {
+			return nil, databaseError(err)
+		}
+		image.CreatedAt = createdAt.Time
+		images = append(images, &image)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, databaseError(err)
+	}
+
+	return images, nil
+}
+
+// アイテムごとに表示順で最初の画像を選ぶため、idx_item_images_item_positionの順に読んで先頭以外を読み飛ばす
+func (r *ItemImageRepository) FindCoversByItemIDs(ctx context.Context, itemIDs []int64) ([]*entity.ItemImage, error) {
+	if len(itemIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(itemIDs))
+	args := make([]interface{}, len(itemIDs))
+	for i, id := range itemIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	query := `
+        SELECT id, item_id, position, content_type, size, created_at
+        FROM item_images
+        WHERE item_id IN (` + strings.Join(placeholders, ", ") + `)
+        ORDER BY item_id ASC, position ASC, id ASC
+    `
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, databaseError(err)
+	}
+	defer rows.Close()
+
+	var images []*entity.ItemImage
+	for rows.Next() {
+		var image entity.ItemImage
+		var createdAt sql.NullTime
+		if err := rows.Scan(&image.ID, &image.ItemID, &image.Position, &image.ContentType, &image.Size, &createdAt); err != nil {
+			return nil, databaseError(err)
+		}
+		if len(images) > 0 && images[len(images)-1].ItemID == image.ItemID {
+			continue
+		}
+		image.CreatedAt = createdAt.Time
+		images = append(images, &image)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, databaseError(err)
+	}
+
+	return images, nil
+}
+
+func (r *ItemImageRepository) FindByID(ctx context.Context, itemID, imageID int64) (*entity.ItemImage, error) {
+	query := `
+        SELECT id, item_id, position, content_type, size, created_at, data, thumbnail
+        FROM item_images
+        WHERE id = ? AND item_id = ?
+    `
+
+	// created_atはNOT NULLではないため、NULLの場合はゼロ値とする（thumbnailのNULLはnilになる）
+	var image entity.ItemImage
+	var createdAt sql.NullTime
+	err := r.QueryRow(ctx, query, imageID, itemID).Scan(
+		&image.ID, &image.ItemID, &image.Position, &image.ContentType, &image.Size, &createdAt, &image.Data, &image.Thumbnail,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrImageNotFound
+		}
+		return nil, databaseError(err)
+	}
+
+	image.CreatedAt = createdAt.Time
+
+	return &image, nil
+}
+
+func (r *ItemImageRepository) Create(ctx context.Context, image *entity.ItemImage) (*entity.ItemImage, error) {
+	query := `
+        INSERT INTO item_images (item_id, position, content_type, size, data, thumbnail, created_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query,
+		image.ItemID,
+		image.Position,
+		image.ContentType,
+		image.Size,
+		image.Data,
+		image.Thumbnail,
+		image.CreatedAt,
+	)
+	if err != nil {
+		return nil, databaseError(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	created := *image
+	created.ID = id
+	return &created, nil
+}
+
+func (r *ItemImageRepository) FindBatchAfter(ctx context.Context, afterID int64, limit int) ([]*entity.ItemImage, error) {
+	query := `
+        SELECT id, item_id, position, content_type, size, created_at, data, thumbnail
+        FROM item_images
+        WHERE id > ?
+        ORDER BY id
+        LIMIT ?
+    `
+
+	rows, err := r.Query(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, databaseError(err)
+	}
+	defer rows.Close()
+
+	var images []*entity.ItemImage
+	for rows.Next() {
+		var image entity.ItemImage
+		var createdAt sql.NullTime
+		if err := rows.Scan(
+			&image.ID, &image.ItemID, &image.Position, &image.ContentType, &image.Size, &createdAt, &image.Data, &image.Thumbnail,
+		); err != nil {
+			return nil, databaseError(err)
+		}
+		image.CreatedAt = createdAt.Time
+		images = append(images, &image)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, databaseError(err)
+	}
+
+	return images, nil
+}
+
+func (r *ItemImageRepository) UpdateThumbnail(ctx context.Context, imageID int64, thumbnail []byte) error {
+	if _, err := r.Execute(ctx, `UPDATE item_images SET thumbnail = ? WHERE id = ?`, thumbnail, imageID); err != nil {
+		return databaseError(err)
+	}
+	return nil
+}
+
+func (r *ItemImageRepository) Delete(ctx context.Context, itemID, imageID int64) error {
+	query := `DELETE FROM item_images WHERE id = ? AND item_id = ?`
+
+	result, err := r.Execute(ctx, query, imageID, itemID)
+	if err != nil {
+		return databaseError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrImageNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 現在の検証のルールに違反している行を探し、修正するリポジトリ
+type IntegrityRepository struct {
+	SqlHandler
+}
+
+// カラムの値が許可リストに無い条件（照合順序による大文字・小文字や末尾の空白の同一視をしないよう、バイト列で比較する）
+func notInAllowlist(column string, values []string) (string, []interface{}) {
+	placeholders := make([]string, len(values))
+	args := make([]interface{}, len(values))
+	for i, value := range values {
+		placeholders[i] = "?"
+		args[i] = value
+	}
+	return "BINARY " + column + " NOT IN (" + strings.Join(placeholders, ", ") + ")", args
+}
+
+func validCategoryValues() []string {
+	categories := entity.GetValidCategories()
+	values := make([]string, len(categories))
+	for i, category := range categories {
+		values[i] = category.String()
+	}
+	return values
+}
+
+func validStatusValues() []string {
+	values := make([]string, len(entity.ValidStatuses))
+	for i, status := range entity.ValidStatuses {
+		values[i] = status.String()
+	}
+	return values
+}
+
+// 確認項目ごとの、違反している行のIDと総数を返すクエリ（最後のバインド引数はlimit）
+func buildFindViolationsQuery(check usecase.IntegrityCheck, limit int) (string, []interface{}, error) {
+	var condition string
+	var args []interface{}
+	switch check {
+	case usecase.IntegrityInvalidCategory:
+		condition, args = notInAllowlist("category", validCategoryValues())
+	case usecase.IntegrityInvalidStatus:
+		condition, args = notInAllowlist("status", validStatusValues())
+	case usecase.IntegrityNegativePrice:
+		condition = "purchase_price < 0"
+	case usecase.IntegrityInvalidPurchaseDate:
+		// 0000-00-00 や 2023-00-15 のような日付は、月・日（または年）が0になる
+		condition = "purchase_date IS NULL OR YEAR(purchase_date) = 0 OR MONTH(purchase_date) = 0 OR DAYOFMONTH(purchase_date) = 0 OR purchase_date > ?"
+		args = []interface{}{entity.Today()}
+	case usecase.IntegrityOrphanedImage:
+		query := `
+        SELECT images.id, COUNT(*) OVER ()
+        FROM item_images images
+        LEFT JOIN items ON items.id = images.item_id
+        WHERE items.id IS NULL
+        ORDER BY images.id
+        LIMIT ?
+    `
+		return query, []interface{}{limit}, nil
+	default:
+		return "", nil, fmt.Errorf("unknown integrity check: %s", check)
+	}
+
+	query := `
+        SELECT id, COUNT(*) OVER ()
+        FROM items
+        WHERE ` + condition + `
+        ORDER BY id
+        LIMIT ?
+    `
+	return query, append(args, limit), nil
+}
+
+func (r *IntegrityRepository) FindViolations(ctx context.Context, check usecase.IntegrityCheck, limit int) ([]int64, int, error) {
+	query, args, err := buildFindViolationsQuery(check, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, databaseError(err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	var total int
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id, &total); err != nil {
+			return nil, 0, databaseError(err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, databaseError(err)
+	}
+
+	return ids, total, nil
+}
+
+// 自動で修正できる確認項目ごとの、修正するクエリ
+// アイテムを書き換える場合はupdated_atも更新し、差分同期のクライアントにも反映されるようにする
+func buildFixViolationsQuery(check usecase.IntegrityCheck) (string, []interface{}, error) {
+	switch check {
+	case usecase.IntegrityInvalidCategory:
+		categories := validCategoryValues()
+		condition, conditionArgs := notInAllowlist("category", categories)
+		trimmed, trimmedArgs := notInAllowlist("TRIM(category)", categories)
+		query := `
+        UPDATE items
+        SET category = CASE WHEN NOT (` + trimmed + `) THEN TRIM(category) ELSE ? END, updated_at = NOW(), version = version + 1
+        WHERE ` + condition
+		args := append(trimmedArgs, entity.CategoryOther.String())
+		return query, append(args, conditionArgs...), nil
+	case usecase.IntegrityInvalidStatus:
+		condition, args := notInAllowlist("status", validStatusValues())
+		query := `UPDATE items SET status = ?, updated_at = NOW(), version = version + 1 WHERE ` + condition
+		return query, append([]interface{}{entity.StatusActive.String()}, args...), nil
+	case usecase.IntegrityOrphanedImage:
+		query := `
+        DELETE images FROM item_images images
+        LEFT JOIN items ON items.id = images.item_id
+        WHERE items.id IS NULL
+    `
+		return query, nil, nil
+	}
+	return "", nil, fmt.Errorf("integrity check cannot be fixed automatically: %s", check)
+}
+
+func (r *IntegrityRepository) FixViolations(ctx context.Context, check usecase.IntegrityCheck) (int64, error) {
+	query, args, err := buildFixViolationsQuery(check)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := r.Execute(ctx, query, args...)
+	if err != nil {
+		return 0, databaseError(err)
+	}
+
+	fixed, err := result.RowsAffected()
+	if err != nil {
+		return 0, databaseError(err)
+	}
+	return fixed, nil
+}
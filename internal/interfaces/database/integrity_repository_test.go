@@ -0,0 +1,37 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestBuildFindViolationsQuery(t *testing.T) {
+	query, args, err := buildFindViolationsQuery(usecase.IntegrityInvalidCategory, 10)
+	require.NoError(t, err)
+	// "時計 " のような末尾に空白のある値も照合順序で一致させずに見つける
+	assert.Contains(t, query, "WHERE BINARY category NOT IN (?, ?, ?, ?, ?)")
+	assert.Equal(t, []interface{}{"時計", "バッグ", "ジュエリー", "靴", "その他", 10}, args)
+
+	query, args, err = buildFindViolationsQuery(usecase.IntegrityOrphanedImage, 10)
+	require.NoError(t, err)
+	assert.Contains(t, query, "LEFT JOIN items ON items.id = images.item_id")
+	assert.Equal(t, []interface{}{10}, args)
+
+	_, _, err = buildFindViolationsQuery("orphaned_tag", 10)
+	assert.Error(t, err)
+}
+
+func TestBuildFixViolationsQuery(t *testing.T) {
+	query, args, err := buildFixViolationsQuery(usecase.IntegrityInvalidCategory)
+	require.NoError(t, err)
+	assert.Contains(t, query, "SET category = CASE WHEN NOT (BINARY TRIM(category) NOT IN (?, ?, ?, ?, ?)) THEN TRIM(category) ELSE ? END, updated_at = NOW(), version = version + 1")
+	assert.Equal(t, []interface{}{"時計", "バッグ", "ジュエリー", "靴", "その他", "その他", "時計", "バッグ", "ジュエリー", "靴", "その他"}, args)
+
+	// 価格や購入日は正しい値が分からないため修正しない
+	_, _, err = buildFixViolationsQuery(usecase.IntegrityNegativePrice)
+	assert.Error(t, err)
+}
@@ -3,27 +3,411 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
 )
 
 type ItemRepository struct {
 	SqlHandler
 }
 
-func (r *ItemRepository) FindAll(ctx context.Context) ([]*entity.Item, error) {
+const itemColumns = `id, name, category, brand, purchase_price, purchase_date, serial_number, status, created_at, updated_at, deleted_at, delete_reason, version, uuid`
+
+// 状態が空の場合はactiveとして保存する
+func itemStatus(item *entity.Item) string {
+	if item.Status == "" {
+		return entity.StatusActive.String()
+	}
+	return item.Status.String()
+}
+
+// 空文字はNULLとして保存する（ユニークインデックスで重複扱いにしないため）
+func nullableString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+const findByIDQuery = `
+        SELECT ` + itemColumns + `
+        FROM items
+        WHERE id = ? AND deleted_at IS NULL
+    `
+
+const summaryByCategoryQuery = `
+        SELECT category, COUNT(*) as count, COALESCE(SUM(purchase_price), 0) as total_purchase_price
+        FROM items
+        WHERE deleted_at IS NULL AND status = 'active'
+        GROUP BY category
+    `
+
+// 指定時刻に存在していた（登録済みかつ未削除の）アイテムのカテゴリー別集計
+// 物理削除済みのアイテムと、現在activeでないアイテムは含まれない（状態の履歴は持たないため）
+const summaryByCategoryAsOfQuery = `
+        SELECT category, COUNT(*) as count, COALESCE(SUM(purchase_price), 0) as total_purchase_price
+        FROM items
+        WHERE created_at <= ? AND (deleted_at IS NULL OR deleted_at > ?) AND status = 'active'
+        GROUP BY category
+    `
+
+const usedCategoriesQuery = `
+        SELECT category, COUNT(*) as count
+        FROM items
+        WHERE deleted_at IS NULL AND status <> 'draft'
+        GROUP BY category
+        ORDER BY count DESC, category ASC
+    `
+
+// 絞り込み条件からWHERE句とバインド引数を組み立てる（Deletedでない限り論理削除済みのアイテムは除外）
+// 状態を指定しない場合はactiveのアイテムのみ（ゴミ箱ではすべての状態）
+func buildWhereClause(filter usecase.ItemFilter) (string, []interface{}) {
+	conditions := []string{"deleted_at IS NULL"}
+	var args []interface{}
+
+	status := filter.Status
+	if filter.Deleted {
+		conditions = []string{"deleted_at IS NOT NULL"}
+	} else if status == "" && !filter.AnyStatus {
+		status = entity.StatusActive
+	}
+	if status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, status.String())
+	}
+
+	if len(filter.Categories) > 0 {
+		placeholders := make([]string, len(filter.Categories))
+		for i, category := range filter.Categories {
+			placeholders[i] = "?"
+			args = append(args, category.String())
+		}
+		conditions = append(conditions, "category IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if len(filter.ExcludeCategories) > 0 {
+		placeholders := make([]string, len(filter.ExcludeCategories))
+		for i, category := range filter.ExcludeCategories {
+			placeholders[i] = "?"
+			args = append(args, category.String())
+		}
+		conditions = append(conditions, "category NOT IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if filter.Brand != "" {
+		// カラムの照合順序（utf8mb4_unicode_ci）により大文字・小文字を区別せずに比較される
+		conditions = append(conditions, "brand = ?")
+		args = append(args, filter.Brand)
+	}
+	if filter.CreatedSince != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, *filter.CreatedSince)
+	}
+	if filter.Query != "" {
+		pattern := "%" + escapeLike(filter.Query) + "%"
+		conditions = append(conditions, "(name LIKE ? OR brand LIKE ?)")
+		args = append(args, pattern, pattern)
+	}
+	if filter.SerialPrefix != "" {
+		// 前方一致のためuniq_serial_numberの範囲検索になる
+		conditions = append(conditions, "serial_number LIKE ?")
+		args = append(args, escapeLike(filter.SerialPrefix)+"%")
+	}
+	if filter.IDFrom > 0 && filter.IDTo > 0 {
+		// 主キーの範囲検索になる
+		conditions = append(conditions, "id BETWEEN ? AND ?")
+		args = append(args, filter.IDFrom, filter.IDTo)
+	}
+	if filter.MinPurchasePrice != nil {
+		conditions = append(conditions, "purchase_price >= ?")
+		args = append(args, *filter.MinPurchasePrice)
+	}
+	if filter.MaxPurchasePrice != nil {
+		conditions = append(conditions, "purchase_price <= ?")
+		args = append(args, *filter.MaxPurchasePrice)
+	}
+	if filter.PurchasedFrom != "" {
+		conditions = append(conditions, "purchase_date >= ?")
+		args = append(args, filter.PurchasedFrom)
+	}
+	if filter.PurchasedTo != "" {
+		conditions = append(conditions, "purchase_date <= ?")
+		args = append(args, filter.PurchasedTo)
+	}
+	// 関数を適用するためidx_purchase_dateは使われない（他の条件で絞り込んだ行を判定する）
+	if filter.PurchaseMonth > 0 {
+		conditions = append(conditions, "MONTH(purchase_date) = ?")
+		args = append(args, filter.PurchaseMonth)
+	}
+	if len(filter.PurchaseWeekdays) > 0 {
+		placeholders := make([]string, len(filter.PurchaseWeekdays))
+		for i, weekday := range filter.PurchaseWeekdays {
+			placeholders[i] = "?"
+			// DAYOFWEEKは日曜日が1、土曜日が7
+			args = append(args, int(weekday)+1)
+		}
+		conditions = append(conditions, "DAYOFWEEK(purchase_date) IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// LIKEのワイルドカード（%, _）とエスケープ文字をエスケープする
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// 並び順ごとのORDER BY句（同じ値の場合はIDで順序を固定する）
+var orderByClauses = map[usecase.SortOrder]string{
+	"":                            "created_at DESC, id DESC",
+	usecase.SortIDAsc:             "id ASC",
+	usecase.SortIDDesc:            "id DESC",
+	usecase.SortCreatedAtAsc:      "created_at ASC, id ASC",
+	usecase.SortCreatedAtDesc:     "created_at DESC, id DESC",
+	usecase.SortPurchaseDateAsc:   "purchase_date ASC, id ASC",
+	usecase.SortPurchaseDateDesc:  "purchase_date DESC, id DESC",
+	usecase.SortPurchasePriceAsc:  "purchase_price ASC, id ASC",
+	usecase.SortPurchasePriceDesc: "purchase_price DESC, id DESC",
+	usecase.SortSerialNumberAsc:   "serial_number ASC, id ASC",
+	usecase.SortSerialNumberDesc:  "serial_number DESC, id DESC",
+	usecase.SortDeletedAtAsc:      "deleted_at ASC, id ASC",
+	usecase.SortDeletedAtDesc:     "deleted_at DESC, id DESC",
+}
+
+func buildFindAllQuery(filter usecase.ItemFilter) (string, []interface{}) {
+	where, args := buildWhereClause(filter)
+	orderBy, ok := orderByClauses[filter.Sort]
+	if !ok {
+		orderBy = orderByClauses[""]
+	}
+	query := `
+        SELECT ` + itemColumns + `
+        FROM items
+        ` + where + `
+        ORDER BY ` + orderBy + `
+    `
+	return query, args
+}
+
+// 一覧と同じ条件と並び順で、ウィンドウ関数により指定したアイテムの前後の行を取得する
+func buildFindSiblingsQuery(id int64, filter usecase.ItemFilter) (string, []interface{}) {
+	where, args := buildWhereClause(filter)
+	orderBy, ok := orderByClauses[filter.Sort]
+	if !ok {
+		orderBy = orderByClauses[""]
+	}
+	query := `
+        SELECT prev_id, prev_uuid, prev_name, prev_category, prev_brand, next_id, next_uuid, next_name, next_category, next_brand
+        FROM (
+            SELECT id,
+                LAG(id) OVER w AS prev_id, LAG(uuid) OVER w AS prev_uuid, LAG(name) OVER w AS prev_name,
+                LAG(category) OVER w AS prev_category, LAG(brand) OVER w AS prev_brand,
+                LEAD(id) OVER w AS next_id, LEAD(uuid) OVER w AS next_uuid, LEAD(name) OVER w AS next_name,
+                LEAD(category) OVER w AS next_category, LEAD(brand) OVER w AS next_brand
+            FROM items
+            ` + where + `
+            WINDOW w AS (ORDER BY ` + orderBy + `)
+        ) AS ordered
+        WHERE id = ?
+    `
+	return query, append(args, id)
+}
+
+func buildFindPageQuery(filter usecase.ItemFilter, page usecase.Page) (string, []interface{}) {
+	query, args := buildFindAllQuery(filter)
+	return query + "LIMIT ? OFFSET ?", append(args, page.Limit, page.Offset)
+}
+
+func (r *ItemRepository) FindAll(ctx context.Context, filter usecase.ItemFilter) ([]*entity.Item, error) {
+	query, args := buildFindAllQuery(filter)
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, databaseError(err)
+	}
+	defer rows.Close()
+
+	// 0件の場合もnilではなく空スライスを返す（JSONでnullにならないように）
+	items := []*entity.Item{}
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, databaseError(err)
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, databaseError(err)
+	}
+
+	return items, nil
+}
+
+func (r *ItemRepository) FindPage(ctx context.Context, filter usecase.ItemFilter, page usecase.Page) ([]*entity.Item, error) {
+	query, args := buildFindPageQuery(filter, page)
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, databaseError(err)
+	}
+	defer rows.Close()
+
+	items := []*entity.Item{}
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, databaseError(err)
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, databaseError(err)
+	}
+
+	return items, nil
+}
+
+func (r *ItemRepository) Count(ctx context.Context, filter usecase.ItemFilter) (int, error) {
+	where, args := buildWhereClause(filter)
+
+	var count int
+	if err := r.QueryRow(ctx, `SELECT COUNT(*) FROM items `+where, args...).Scan(&count); err != nil {
+		return 0, databaseError(err)
+	}
+	return count, nil
+}
+
+// 書き込みはすべてversionを増やすため、一致するアイテムが変わればバージョンの合計かチェックサムに反映される
+// チェックサムはIDとバージョンの組から計算するため、一覧から外れたアイテムと入った別のアイテムも区別できる
+func (r *ItemRepository) GetListVersion(ctx context.Context, filter usecase.ItemFilter) (*usecase.ListVersion, error) {
+	where, args := buildWhereClause(filter)
+	query := `SELECT COUNT(*), MAX(updated_at), COALESCE(SUM(version), 0), COALESCE(BIT_XOR(CRC32(CONCAT(id, '-', version))), 0) FROM items ` + where
+
+	var version usecase.ListVersion
+	var lastUpdatedAt sql.NullTime
+	if err := r.QueryRow(ctx, query, args...).Scan(&version.Count, &lastUpdatedAt, &version.VersionSum, &version.Checksum); err != nil {
+		return nil, databaseError(err)
+	}
+	if lastUpdatedAt.Valid {
+		version.LastUpdatedAt = &lastUpdatedAt.Time
+	}
+	return &version, nil
+}
+
+func (r *ItemRepository) FindChangedSince(ctx context.Context, since time.Time) ([]*entity.Item, error) {
 	query := `
-        SELECT id, name, category, brand, purchase_price, purchase_date, created_at, updated_at
+        SELECT ` + itemColumns + `
         FROM items
-        ORDER BY created_at DESC
+        WHERE updated_at >= ? AND deleted_at IS NULL
+        ORDER BY updated_at, id
     `
 
-	rows, err := r.Query(ctx, query)
+	rows, err := r.Query(ctx, query, since)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, databaseError(err)
+	}
+	defer rows.Close()
+
+	items := []*entity.Item{}
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, databaseError(err)
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, databaseError(err)
+	}
+
+	return items, nil
+}
+
+func (r *ItemRepository) FindDeletedIDsSince(ctx context.Context, since time.Time) ([]int64, error) {
+	query := `
+        SELECT id
+        FROM items
+        WHERE deleted_at >= ?
+        ORDER BY deleted_at, id
+    `
+
+	rows, err := r.Query(ctx, query, since)
+	if err != nil {
+		return nil, databaseError(err)
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, databaseError(err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, databaseError(err)
+	}
+
+	return ids, nil
+}
+
+// 論理削除済みの行か、変更履歴のエントリーがあれば存在したアイテム
+// 呼び出し元は未削除の行が無いことを確認しているため、変更履歴にあれば削除されている
+func (r *ItemRepository) WasDeleted(ctx context.Context, id int64) (bool, error) {
+	query := `
+        SELECT EXISTS(SELECT 1 FROM items WHERE id = ? AND deleted_at IS NOT NULL)
+            OR EXISTS(SELECT 1 FROM item_changes WHERE item_id = ?)
+    `
+
+	var deleted bool
+	if err := r.QueryRow(ctx, query, id, id).Scan(&deleted); err != nil {
+		return false, databaseError(err)
+	}
+	return deleted, nil
+}
+
+func (r *ItemRepository) GetChangeLogRange(ctx context.Context) (usecase.ChangeLogRange, error) {
+	var changeLog usecase.ChangeLogRange
+	// 経過時間はchanged_atと同じDBの時計で判定する
+	query := `
+        SELECT COALESCE(MIN(seq), 0), COALESCE(MAX(seq), 0),
+               COALESCE(MAX(CASE WHEN changed_at < NOW() - INTERVAL ? SECOND THEN seq END), 0)
+        FROM item_changes
+    `
+	window := int64(usecase.ChangeLogSettleWindow / time.Second)
+	if err := r.QueryRow(ctx, query, window).Scan(&changeLog.Oldest, &changeLog.Latest, &changeLog.Settled); err != nil {
+		return changeLog, databaseError(err)
+	}
+	// 最も古いエントリーより前は保持期間を過ぎて削除された範囲で、書き込み中のものは無い
+	if changeLog.Oldest > 0 && changeLog.Settled < changeLog.Oldest-1 {
+		changeLog.Settled = changeLog.Oldest - 1
+	}
+	return changeLog, nil
+}
+
+func (r *ItemRepository) FindBatchAfter(ctx context.Context, afterID int64, limit int) ([]*entity.Item, error) {
+	query := `
+        SELECT ` + itemColumns + `
+        FROM items
+        WHERE id > ? AND deleted_at IS NULL
+        ORDER BY id
+        LIMIT ?
+    `
+
+	rows, err := r.Query(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, databaseError(err)
 	}
 	defer rows.Close()
 
@@ -31,53 +415,228 @@ func (r *ItemRepository) FindAll(ctx context.Context) ([]*entity.Item, error) {
 	for rows.Next() {
 		item, err := scanItem(rows)
 		if err != nil {
-			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+			return nil, databaseError(err)
 		}
 		items = append(items, item)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, databaseError(err)
 	}
 
 	return items, nil
 }
 
-func (r *ItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
+func (r *ItemRepository) FindChangedAfter(ctx context.Context, seq int64) ([]*entity.Item, error) {
 	query := `
-        SELECT id, name, category, brand, purchase_price, purchase_date, created_at, updated_at
+        SELECT ` + itemColumns + `
         FROM items
-        WHERE id = ?
+        WHERE id IN (SELECT item_id FROM item_changes WHERE seq > ?) AND deleted_at IS NULL
+        ORDER BY id
     `
 
-	row := r.QueryRow(ctx, query, id)
+	rows, err := r.Query(ctx, query, seq)
+	if err != nil {
+		return nil, databaseError(err)
+	}
+	defer rows.Close()
+
+	items := []*entity.Item{}
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, databaseError(err)
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, databaseError(err)
+	}
+
+	return items, nil
+}
+
+// 物理削除されたアイテムはitemsに行が無いため、変更履歴のアイテムIDから求める
+func (r *ItemRepository) FindDeletedIDsAfter(ctx context.Context, seq int64) ([]int64, error) {
+	query := `
+        SELECT DISTINCT c.item_id
+        FROM item_changes c
+        LEFT JOIN items i ON i.id = c.item_id
+        WHERE c.seq > ? AND (i.id IS NULL OR i.deleted_at IS NOT NULL)
+        ORDER BY c.item_id
+    `
+
+	rows, err := r.Query(ctx, query, seq)
+	if err != nil {
+		return nil, databaseError(err)
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, databaseError(err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, databaseError(err)
+	}
+
+	return ids, nil
+}
+
+func (r *ItemRepository) FindChangeLog(ctx context.Context, seq int64, limit int) ([]usecase.ChangeLogEntry, error) {
+	query := `
+        SELECT seq, item_id, operation, changed_at
+        FROM item_changes
+        WHERE seq > ?
+        ORDER BY seq
+        LIMIT ?
+    `
+
+	rows, err := r.Query(ctx, query, seq, limit)
+	if err != nil {
+		return nil, databaseError(err)
+	}
+	defer rows.Close()
+
+	entries := []usecase.ChangeLogEntry{}
+	for rows.Next() {
+		var entry usecase.ChangeLogEntry
+		var operation string
+		if err := rows.Scan(&entry.Seq, &entry.ItemID, &operation, &entry.ChangedAt); err != nil {
+			return nil, databaseError(err)
+		}
+		entry.Operation = usecase.ChangeOperation(operation)
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, databaseError(err)
+	}
+
+	return entries, nil
+}
+
+// 最新の1件は残し、変更履歴が空になってもトークンの期限切れを判定できるようにする
+func (r *ItemRepository) PruneChangeLog(ctx context.Context, before time.Time) (int64, error) {
+	query := `
+        DELETE FROM item_changes
+        WHERE changed_at < ?
+          AND seq < (SELECT latest FROM (SELECT MAX(seq) AS latest FROM item_changes) AS change_log)
+    `
+
+	result, err := r.Execute(ctx, query, before)
+	if err != nil {
+		return 0, databaseError(err)
+	}
+
+	pruned, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return pruned, nil
+}
+
+func (r *ItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
+	row := r.QueryRow(ctx, findByIDQuery, id)
 
 	item, err := scanItem(row)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, domainErrors.ErrItemNotFound
 		}
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, databaseError(err)
 	}
 
 	return item, nil
 }
 
+func (r *ItemRepository) FindIDByUUID(ctx context.Context, uuid string) (int64, error) {
+	var id int64
+	if err := r.QueryRow(ctx, `SELECT id FROM items WHERE uuid = ?`, uuid).Scan(&id); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, domainErrors.ErrItemNotFound
+		}
+		return 0, databaseError(err)
+	}
+	return id, nil
+}
+
+// 振ったUUIDは変えないため、既に振られている行は書き換えない（updated_at とバージョンも変えない）
+func (r *ItemRepository) AssignUUID(ctx context.Context, id int64, uuid string) (bool, error) {
+	result, err := r.Execute(ctx, `UPDATE items SET uuid = ?, updated_at = updated_at WHERE id = ? AND uuid IS NULL`, uuid, id)
+	if err != nil {
+		return false, databaseError(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return rowsAffected > 0, nil
+}
+
+func (r *ItemRepository) FindSiblings(ctx context.Context, id int64, filter usecase.ItemFilter) (*usecase.ItemSiblings, error) {
+	query, args := buildFindSiblingsQuery(id, filter)
+
+	var prevID, nextID sql.NullInt64
+	var prevUUID, prevName, prevCategory, prevBrand, nextUUID, nextName, nextCategory, nextBrand sql.NullString
+	err := r.QueryRow(ctx, query, args...).Scan(
+		&prevID, &prevUUID, &prevName, &prevCategory, &prevBrand, &nextID, &nextUUID, &nextName, &nextCategory, &nextBrand,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, databaseError(err)
+	}
+
+	return &usecase.ItemSiblings{
+		Previous: newItemSibling(prevID, prevUUID, prevName, prevCategory, prevBrand),
+		Next:     newItemSibling(nextID, nextUUID, nextName, nextCategory, nextBrand),
+	}, nil
+}
+
+// 先頭・末尾で前後の行が無い場合（IDがNULL）はnilを返す
+func newItemSibling(id sql.NullInt64, uuid, name, category, brand sql.NullString) *usecase.ItemSibling {
+	if !id.Valid {
+		return nil
+	}
+	return &usecase.ItemSibling{
+		ID:       id.Int64,
+		UUID:     uuid.String,
+		Name:     name.String,
+		Category: entity.Category(category.String),
+		Brand:    brand.String,
+	}
+}
+
 func (r *ItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
 	query := `
-        INSERT INTO items (name, category, brand, purchase_price, purchase_date)
-        VALUES (?, ?, ?, ?, ?)
+        INSERT INTO items (name, category, brand, purchase_price, purchase_date, serial_number, status, uuid)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?)
     `
 
 	result, err := r.Execute(ctx, query,
 		item.Name,
-		item.Category,
+		item.Category.String(),
 		item.Brand,
-		item.PurchasePrice,
+		item.PurchasePrice.Amount,
 		item.PurchaseDate,
+		nullableString(item.SerialNumber),
+		itemStatus(item),
+		nullableString(item.UUID),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		if dupErr, ok := duplicateEntryError(err); ok {
+			return nil, dupErr
+		}
+		return nil, databaseError(err)
 	}
 
 	id, err := result.LastInsertId()
@@ -85,24 +644,126 @@ func (r *ItemRepository) Create(ctx context.Context, item *entity.Item) (*entity
 		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
 
-	return r.FindByID(ctx, id)
+	// 書き込んだ行はレプリカにまだ届いていないことがあるため、プライマリーから読み直す
+	return r.FindByID(WithPrimaryReads(ctx), id)
 }
 
-func (r *ItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+// シリアル番号が一致するアイテムがあれば更新し、無ければ登録する
+// category と purchase_date は不変のため、既存アイテムでは更新しない（論理削除済みの場合は復元する）
+func (r *ItemRepository) Upsert(ctx context.Context, item *entity.Item) (*entity.Item, bool, error) {
 	query := `
-        UPDATE items 
-        SET name = ?, brand = ?, purchase_price = ?, updated_at = NOW()
-        WHERE id = ?
+        INSERT INTO items (name, category, brand, purchase_price, purchase_date, serial_number, status, uuid)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+        ON DUPLICATE KEY UPDATE
+            id = LAST_INSERT_ID(id),
+            version = version + IF(name <=> VALUES(name) AND brand <=> VALUES(brand) AND purchase_price <=> VALUES(purchase_price)
+                AND status <=> VALUES(status) AND deleted_at IS NULL, 0, 1),
+            name = VALUES(name),
+            brand = VALUES(brand),
+            purchase_price = VALUES(purchase_price),
+            status = VALUES(status),
+            deleted_at = NULL,
+            delete_reason = NULL,
+            uuid = COALESCE(uuid, VALUES(uuid))
     `
 
+	// 既存のアイテムのUUIDは変えない（UUIDを振る前に登録したアイテムには振る）
 	result, err := r.Execute(ctx, query,
 		item.Name,
+		item.Category.String(),
 		item.Brand,
-		item.PurchasePrice,
-		item.ID,
+		item.PurchasePrice.Amount,
+		item.PurchaseDate,
+		item.SerialNumber,
+		itemStatus(item),
+		nullableString(item.UUID),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		if dupErr, ok := duplicateEntryError(err); ok {
+			return nil, false, dupErr
+		}
+		return nil, false, databaseError(err)
+	}
+
+	// LAST_INSERT_ID(id) により更新時も既存行のIDが返る
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	// 影響行数は 登録: 1 / 更新: 2 / 変更なし: 0
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	upserted, err := r.FindByID(WithPrimaryReads(ctx), id)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return upserted, rowsAffected == 1, nil
+}
+
+// 変更されたカラムのみを更新するUPDATE文とバインド引数を組み立てる
+func buildUpdateQuery(id int64, changes usecase.ItemChanges) (string, []interface{}) {
+	var sets []string
+	var args []interface{}
+
+	if changes.Name != nil {
+		sets = append(sets, "name = ?")
+		args = append(args, *changes.Name)
+	}
+	if changes.Brand != nil {
+		sets = append(sets, "brand = ?")
+		args = append(args, *changes.Brand)
+	}
+	if changes.PurchasePrice != nil {
+		sets = append(sets, "purchase_price = ?")
+		args = append(args, changes.PurchasePrice.Amount)
+	}
+	if changes.Status != nil {
+		sets = append(sets, "status = ?")
+		args = append(args, changes.Status.String())
+	}
+	sets = append(sets, "updated_at = NOW()", "version = version + 1")
+
+	conditions := []string{"id = ?", "deleted_at IS NULL"}
+	args = append(args, id)
+	// 条件付き更新では、読み込んだ後に他の更新で値が変わっていないことを書き込みと同時に確認する
+	if cond := changes.If; cond != nil {
+		if cond.Name != nil {
+			conditions = append(conditions, "name = ?")
+			args = append(args, strings.TrimSpace(*cond.Name))
+		}
+		if cond.Brand != nil {
+			conditions = append(conditions, "brand = ?")
+			args = append(args, strings.TrimSpace(*cond.Brand))
+		}
+		if cond.PurchasePrice != nil {
+			conditions = append(conditions, "purchase_price = ?")
+			args = append(args, *cond.PurchasePrice)
+		}
+		if cond.Status != nil {
+			conditions = append(conditions, "status = ?")
+			args = append(args, cond.Status.String())
+		}
+	}
+
+	query := `UPDATE items SET ` + strings.Join(sets, ", ") + ` WHERE ` + strings.Join(conditions, " AND ")
+	return query, args
+}
+
+func (r *ItemRepository) Update(ctx context.Context, id int64, changes usecase.ItemChanges) (*entity.Item, error) {
+	if changes.IsEmpty() {
+		return r.FindByID(ctx, id)
+	}
+
+	query, args := buildUpdateQuery(id, changes)
+
+	result, err := r.Execute(ctx, query, args...)
+	if err != nil {
+		return nil, databaseError(err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -111,18 +772,26 @@ func (r *ItemRepository) Update(ctx context.Context, item *entity.Item) (*entity
 	}
 
 	if rowsAffected == 0 {
-		return nil, domainErrors.ErrItemNotFound
+		if changes.If == nil {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		// 条件に一致しなかったのか、削除されたのかを区別する
+		if _, err := r.FindByID(WithPrimaryReads(ctx), id); err != nil {
+			return nil, err
+		}
+		return nil, domainErrors.ErrUpdateConditionFailed
 	}
 
-	return r.FindByID(ctx, item.ID)
+	return r.FindByID(WithPrimaryReads(ctx), id)
 }
 
-func (r *ItemRepository) Delete(ctx context.Context, id int64) error {
-	query := `DELETE FROM items WHERE id = ?`
+// 論理削除する（PurgeDeletedで物理削除されるまで行は残る）
+func (r *ItemRepository) Delete(ctx context.Context, id int64, reason string) error {
+	query := `UPDATE items SET deleted_at = NOW(), delete_reason = NULLIF(?, ''), version = version + 1 WHERE id = ? AND deleted_at IS NULL`
 
-	result, err := r.Execute(ctx, query, id)
+	result, err := r.Execute(ctx, query, reason, id)
 	if err != nil {
-		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return databaseError(err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -137,67 +806,426 @@ func (r *ItemRepository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
-func (r *ItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]int, error) {
+// 読み込んだ時点のバージョンのままの場合のみ論理削除する
+// 確認と削除を1つの文で行うため、確認した後に他のリクエストが更新していても削除しない
+func (r *ItemRepository) DeleteVersion(ctx context.Context, id, version int64, reason string) error {
+	query := `UPDATE items SET deleted_at = NOW(), delete_reason = NULLIF(?, ''), version = version + 1 WHERE id = ? AND deleted_at IS NULL AND version = ?`
+
+	result, err := r.Execute(ctx, query, reason, id, version)
+	if err != nil {
+		return databaseError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		// 更新されたのか、削除されたのかを区別する
+		if _, err := r.FindByID(WithPrimaryReads(ctx), id); err != nil {
+			return err
+		}
+		return domainErrors.ErrPreconditionFailed
+	}
+
+	return nil
+}
+
+func (r *ItemRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM items WHERE deleted_at IS NOT NULL AND deleted_at < ?`
+
+	result, err := r.Execute(ctx, query, before)
+	if err != nil {
+		return 0, databaseError(err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return purged, nil
+}
+
+func (r *ItemRepository) DeleteAll(ctx context.Context) (int64, error) {
+	result, err := r.Execute(ctx, `DELETE FROM items`)
+	if err != nil {
+		return 0, databaseError(err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return deleted, nil
+}
+
+// バックアップのアイテムをID・状態・日時を含めて書き込む
+// IDまたはシリアル番号が一致するアイテムがあれば上書きし、論理削除済みの場合は復元する
+func (r *ItemRepository) Restore(ctx context.Context, item *entity.Item) (usecase.RestoreOutcome, error) {
 	query := `
-        SELECT category, COUNT(*) as count
+        INSERT INTO items (id, name, category, brand, purchase_price, purchase_date, serial_number, status, created_at, updated_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+        ON DUPLICATE KEY UPDATE
+            version = version + 1,
+            name = VALUES(name),
+            category = VALUES(category),
+            brand = VALUES(brand),
+            purchase_price = VALUES(purchase_price),
+            purchase_date = VALUES(purchase_date),
+            serial_number = VALUES(serial_number),
+            status = VALUES(status),
+            created_at = VALUES(created_at),
+            updated_at = VALUES(updated_at),
+            deleted_at = NULL,
+            delete_reason = NULL
+    `
+
+	// IDが無い場合はAUTO_INCREMENTで採番する
+	var id interface{}
+	if item.ID > 0 {
+		id = item.ID
+	}
+	result, err := r.Execute(ctx, query,
+		id,
+		item.Name,
+		item.Category.String(),
+		item.Brand,
+		item.PurchasePrice.Amount,
+		item.PurchaseDate,
+		nullableString(item.SerialNumber),
+		itemStatus(item),
+		item.CreatedAt,
+		item.UpdatedAt,
+	)
+	if err != nil {
+		if dupErr, ok := duplicateEntryError(err); ok {
+			return 0, dupErr
+		}
+		return 0, databaseError(err)
+	}
+
+	// 影響行数は 登録: 1 / 更新: 2 / 変更なし: 0
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	switch rowsAffected {
+	case 1:
+		return usecase.RestoreCreated, nil
+	case 0:
+		return usecase.RestoreUnchanged, nil
+	default:
+		return usecase.RestoreUpdated, nil
+	}
+}
+
+func (r *ItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]usecase.CategoryTotal, error) {
+	rows, err := r.Query(ctx, summaryByCategoryQuery)
+	if err != nil {
+		return nil, databaseError(err)
+	}
+	defer rows.Close()
+
+	return scanCategoryTotals(rows)
+}
+
+func (r *ItemRepository) GetSummaryByCategories(ctx context.Context, categories []string) (map[string]usecase.CategoryTotal, error) {
+	if len(categories) == 0 {
+		return map[string]usecase.CategoryTotal{}, nil
+	}
+
+	query, args := buildSummaryByCategoriesQuery(categories)
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, databaseError(err)
+	}
+	defer rows.Close()
+
+	return scanCategoryTotals(rows)
+}
+
+// 指定したカテゴリーのみを集計するクエリ
+func buildSummaryByCategoriesQuery(categories []string) (string, []interface{}) {
+	placeholders := make([]string, len(categories))
+	args := make([]interface{}, len(categories))
+	for i, category := range categories {
+		placeholders[i] = "?"
+		args[i] = category
+	}
+
+	query := `
+        SELECT category, COUNT(*) as count, COALESCE(SUM(purchase_price), 0) as total_purchase_price
         FROM items
+        WHERE deleted_at IS NULL AND status = 'active' AND category IN (` + strings.Join(placeholders, ", ") + `)
         GROUP BY category
     `
+	return query, args
+}
 
-	rows, err := r.Query(ctx, query)
+func (r *ItemRepository) GetSummaryByCategoryAsOf(ctx context.Context, asOf time.Time) (map[string]usecase.CategoryTotal, error) {
+	rows, err := r.Query(ctx, summaryByCategoryAsOfQuery, asOf, asOf)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, databaseError(err)
 	}
 	defer rows.Close()
 
-	summary := make(map[string]int)
+	return scanCategoryTotals(rows)
+}
+
+// カテゴリー別集計の結果を読み込む
+func scanCategoryTotals(rows Rows) (map[string]usecase.CategoryTotal, error) {
+	summary := make(map[string]usecase.CategoryTotal)
 	for rows.Next() {
 		var category string
 		var count int
-		if err := rows.Scan(&category, &count); err != nil {
-			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		var totalPurchasePrice int64
+		if err := rows.Scan(&category, &count, &totalPurchasePrice); err != nil {
+			return nil, databaseError(err)
+		}
+		summary[category] = usecase.CategoryTotal{
+			Count:         count,
+			PurchasePrice: entity.JPY(totalPurchasePrice),
 		}
-		summary[category] = count
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	if err := rows.Err(); err != nil {
+		return nil, databaseError(err)
 	}
 
 	return summary, nil
 }
 
+func (r *ItemRepository) GetUsedCategories(ctx context.Context) ([]usecase.CategoryCount, error) {
+	rows, err := r.Query(ctx, usedCategoriesQuery)
+	if err != nil {
+		return nil, databaseError(err)
+	}
+	defer rows.Close()
+
+	var categories []usecase.CategoryCount
+	for rows.Next() {
+		var category usecase.CategoryCount
+		if err := rows.Scan(&category.Category, &category.Count); err != nil {
+			return nil, databaseError(err)
+		}
+		categories = append(categories, category)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, databaseError(err)
+	}
+
+	return categories, nil
+}
+
+// 集計に使えるカラム（GroupByの値をそのままSQLに埋め込まず、この対応表のカラム名のみを使う）
+var groupByColumns = map[usecase.GroupBy]string{
+	usecase.GroupByCategory: "category",
+	usecase.GroupByBrand:    "brand",
+	usecase.GroupByStatus:   "status",
+}
+
+// 絞り込み条件に一致するアイテムのカラムの値ごとの集計（件数の多い順、同数は値の順）
+func buildSummaryByGroupQuery(filter usecase.ItemFilter, by usecase.GroupBy) (string, []interface{}, error) {
+	column, ok := groupByColumns[by]
+	if !ok {
+		return "", nil, fmt.Errorf("%w: unknown group by column: %s", domainErrors.ErrInvalidInput, by)
+	}
+
+	where, args := buildWhereClause(filter)
+	query := `
+        SELECT ` + column + `, COUNT(*) as count, COALESCE(SUM(purchase_price), 0) as total_purchase_price
+        FROM items
+        ` + where + `
+        GROUP BY ` + column + `
+        ORDER BY count DESC, ` + column + ` ASC
+    `
+	return query, args, nil
+}
+
+// 絞り込み条件に一致するアイテムのブランド別集計（件数の多い順、同数はブランド名順）
+func buildSummaryByBrandQuery(filter usecase.ItemFilter) (string, []interface{}) {
+	// brandは許可リストに含まれるためエラーにならない
+	query, args, _ := buildSummaryByGroupQuery(filter, usecase.GroupByBrand)
+	return query, args
+}
+
+func (r *ItemRepository) GetSummaryByGroup(ctx context.Context, filter usecase.ItemFilter, by usecase.GroupBy) ([]usecase.GroupSummaryEntry, error) {
+	query, args, err := buildSummaryByGroupQuery(filter, by)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, databaseError(err)
+	}
+	defer rows.Close()
+
+	groups := []usecase.GroupSummaryEntry{}
+	for rows.Next() {
+		var entry usecase.GroupSummaryEntry
+		var totalPurchasePrice int64
+		if err := rows.Scan(&entry.Value, &entry.Count, &totalPurchasePrice); err != nil {
+			return nil, databaseError(err)
+		}
+		entry.TotalPurchasePrice = entity.JPY(totalPurchasePrice)
+		groups = append(groups, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, databaseError(err)
+	}
+
+	return groups, nil
+}
+
+func (r *ItemRepository) GetSummaryByBrand(ctx context.Context, filter usecase.ItemFilter) ([]usecase.BrandSummaryEntry, error) {
+	query, args := buildSummaryByBrandQuery(filter)
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, databaseError(err)
+	}
+	defer rows.Close()
+
+	brands := []usecase.BrandSummaryEntry{}
+	for rows.Next() {
+		var entry usecase.BrandSummaryEntry
+		var totalPurchasePrice int64
+		if err := rows.Scan(&entry.Brand, &entry.Count, &totalPurchasePrice); err != nil {
+			return nil, databaseError(err)
+		}
+		entry.TotalPurchasePrice = entity.JPY(totalPurchasePrice)
+		brands = append(brands, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, databaseError(err)
+	}
+
+	return brands, nil
+}
+
+// 購入年ごとの集計（DATE型のゼロ値など年を解釈できない購入日はNULLにまとめ、最後に並べる）
+func buildSummaryByPurchaseYearQuery(filter usecase.ItemFilter) (string, []interface{}) {
+	where, args := buildWhereClause(filter)
+	query := `
+        SELECT NULLIF(YEAR(purchase_date), 0) as purchase_year, COUNT(*) as count, COALESCE(SUM(purchase_price), 0) as total_purchase_price
+        FROM items
+        ` + where + `
+        GROUP BY purchase_year
+        ORDER BY purchase_year IS NULL, purchase_year ASC
+    `
+	return query, args
+}
+
+func (r *ItemRepository) GetSummaryByPurchaseYear(ctx context.Context, filter usecase.ItemFilter) ([]usecase.TimelineEntry, error) {
+	query, args := buildSummaryByPurchaseYearQuery(filter)
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, databaseError(err)
+	}
+	defer rows.Close()
+
+	years := []usecase.TimelineEntry{}
+	for rows.Next() {
+		var entry usecase.TimelineEntry
+		var year sql.NullInt64
+		var totalPurchasePrice int64
+		if err := rows.Scan(&year, &entry.Count, &totalPurchasePrice); err != nil {
+			return nil, databaseError(err)
+		}
+		if year.Valid {
+			value := int(year.Int64)
+			entry.Year = &value
+		}
+		entry.TotalPurchasePrice = entity.JPY(totalPurchasePrice)
+		years = append(years, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, databaseError(err)
+	}
+
+	return years, nil
+}
+
+func (r *ItemRepository) ExplainFindAll(ctx context.Context, filter usecase.ItemFilter) (json.RawMessage, error) {
+	query, args := buildFindAllQuery(filter)
+	return r.explain(ctx, query, args...)
+}
+
+func (r *ItemRepository) ExplainFindByID(ctx context.Context, id int64) (json.RawMessage, error) {
+	return r.explain(ctx, findByIDQuery, id)
+}
+
+func (r *ItemRepository) ExplainSummaryByCategory(ctx context.Context) (json.RawMessage, error) {
+	return r.explain(ctx, summaryByCategoryQuery)
+}
+
+// EXPLAIN FORMAT=JSON で実行計画を取得する
+func (r *ItemRepository) explain(ctx context.Context, query string, args ...interface{}) (json.RawMessage, error) {
+	var plan string
+	if err := r.QueryRow(ctx, "EXPLAIN FORMAT=JSON "+query, args...).Scan(&plan); err != nil {
+		return nil, databaseError(err)
+	}
+	return json.RawMessage(plan), nil
+}
+
+// NULLを取り得るカラム（スキーマでNOT NULLでない日時を含む）はsql.Null*で読み込み、
+// 値が無い場合はエンティティのゼロ値（ポインターのフィールドはnil）にする
 func scanItem(scanner interface {
 	Scan(dest ...interface{}) error
 }) (*entity.Item, error) {
 	var item entity.Item
-	var purchaseDate string
-	var createdAt, updatedAt time.Time
+	var purchasePrice int64
+	var purchaseDate sql.NullString
+	var serialNumber sql.NullString
+	var createdAt, updatedAt sql.NullTime
+	var deletedAt sql.NullTime
+	var deleteReason sql.NullString
+	var uuid sql.NullString
 
 	err := scanner.Scan(
 		&item.ID,
 		&item.Name,
 		&item.Category,
 		&item.Brand,
-		&item.PurchasePrice,
+		&purchasePrice,
 		&purchaseDate,
+		&serialNumber,
+		&item.Status,
 		&createdAt,
 		&updatedAt,
+		&deletedAt,
+		&deleteReason,
+		&item.Version,
+		&uuid,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	if purchaseDate != "" {
-		if parsedDate, err := time.Parse("2006-01-02", purchaseDate); err == nil {
+	if purchaseDate.String != "" {
+		if parsedDate, err := time.Parse("2006-01-02", purchaseDate.String); err == nil {
 			item.PurchaseDate = parsedDate.Format("2006-01-02")
 		} else {
-			item.PurchaseDate = purchaseDate
+			item.PurchaseDate = purchaseDate.String
 		}
 	}
 
-	item.CreatedAt = createdAt
-	item.UpdatedAt = updatedAt
+	// 通貨のカラムは無く、金額はすべて日本円で保存している
+	item.PurchasePrice = entity.JPY(purchasePrice)
+	item.SerialNumber = serialNumber.String
+	item.CreatedAt = createdAt.Time
+	item.UpdatedAt = updatedAt.Time
+	if deletedAt.Valid {
+		item.DeletedAt = &deletedAt.Time
+	}
+	item.DeleteReason = deleteReason.String
+	item.UUID = uuid.String
 
 	return &item, nil
 }
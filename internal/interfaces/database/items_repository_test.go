@@ -0,0 +1,381 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestBuildUpdateQuery(t *testing.T) {
+	name := "更新されたアイテム名"
+	brand := "更新されたブランド"
+	price := entity.JPY(2000000)
+	archived, active, amount := entity.StatusArchived, entity.StatusActive, int64(1000000)
+
+	tests := []struct {
+		name          string
+		changes       usecase.ItemChanges
+		expectedQuery string
+		expectedArgs  []interface{}
+	}{
+		{
+			name:          "正常系: nameのみ",
+			changes:       usecase.ItemChanges{Name: &name},
+			expectedQuery: "UPDATE items SET name = ?, updated_at = NOW(), version = version + 1 WHERE id = ? AND deleted_at IS NULL",
+			expectedArgs:  []interface{}{name, int64(1)},
+		},
+		{
+			name:          "正常系: purchase_priceのみ",
+			changes:       usecase.ItemChanges{PurchasePrice: &price},
+			expectedQuery: "UPDATE items SET purchase_price = ?, updated_at = NOW(), version = version + 1 WHERE id = ? AND deleted_at IS NULL",
+			expectedArgs:  []interface{}{price.Amount, int64(1)},
+		},
+		{
+			name:          "正常系: 全フィールド",
+			changes:       usecase.ItemChanges{Name: &name, Brand: &brand, PurchasePrice: &price},
+			expectedQuery: "UPDATE items SET name = ?, brand = ?, purchase_price = ?, updated_at = NOW(), version = version + 1 WHERE id = ? AND deleted_at IS NULL",
+			expectedArgs:  []interface{}{name, brand, price.Amount, int64(1)},
+		},
+		{
+			name:          "正常系: 条件付き更新",
+			changes:       usecase.ItemChanges{Status: &archived, If: &usecase.UpdateCondition{Status: &active, PurchasePrice: &amount}},
+			expectedQuery: "UPDATE items SET status = ?, updated_at = NOW(), version = version + 1 WHERE id = ? AND deleted_at IS NULL AND purchase_price = ? AND status = ?",
+			expectedArgs:  []interface{}{"archived", int64(1), amount, "active"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := buildUpdateQuery(1, tt.changes)
+
+			assert.Equal(t, tt.expectedQuery, query)
+			assert.Equal(t, tt.expectedArgs, args)
+		})
+	}
+}
+
+func TestBuildFindPageQuery(t *testing.T) {
+	query, args := buildFindPageQuery(usecase.ItemFilter{Brand: "ROLEX"}, usecase.Page{Limit: 20, Offset: 40})
+
+	assert.Contains(t, query, "WHERE deleted_at IS NULL AND status = ? AND brand = ?")
+	assert.True(t, strings.HasSuffix(query, "ORDER BY created_at DESC, id DESC\n    LIMIT ? OFFSET ?"))
+	assert.Equal(t, []interface{}{"active", "ROLEX", 20, 40}, args)
+}
+
+func TestBuildWhereClause_Categories(t *testing.T) {
+	where, args := buildWhereClause(usecase.ItemFilter{
+		Categories: []entity.Category{entity.CategoryWatch, entity.CategoryBag},
+		Brand:      "ROLEX",
+	})
+
+	assert.Equal(t, "WHERE deleted_at IS NULL AND status = ? AND category IN (?, ?) AND brand = ?", where)
+	assert.Equal(t, []interface{}{"active", "時計", "バッグ", "ROLEX"}, args)
+}
+
+func TestBuildWhereClause_ExcludeCategories(t *testing.T) {
+	where, args := buildWhereClause(usecase.ItemFilter{
+		Categories:        []entity.Category{entity.CategoryWatch, entity.CategoryBag},
+		ExcludeCategories: []entity.Category{entity.CategoryOther},
+	})
+
+	assert.Equal(t, "WHERE deleted_at IS NULL AND status = ? AND category IN (?, ?) AND category NOT IN (?)", where)
+	assert.Equal(t, []interface{}{"active", "時計", "バッグ", "その他"}, args)
+}
+
+func TestBuildWhereClause_PurchaseMonthAndWeekdays(t *testing.T) {
+	where, args := buildWhereClause(usecase.ItemFilter{
+		Categories:       []entity.Category{entity.CategoryWatch},
+		PurchaseMonth:    12,
+		PurchaseWeekdays: []time.Weekday{time.Saturday, time.Sunday},
+	})
+
+	assert.Equal(t, "WHERE deleted_at IS NULL AND status = ? AND category IN (?) AND MONTH(purchase_date) = ? AND DAYOFWEEK(purchase_date) IN (?, ?)", where)
+	assert.Equal(t, []interface{}{"active", "時計", 12, 7, 1}, args)
+}
+
+func TestBuildWhereClause_Query(t *testing.T) {
+	where, args := buildWhereClause(usecase.ItemFilter{Query: `50%_off\`})
+
+	assert.Equal(t, "WHERE deleted_at IS NULL AND status = ? AND (name LIKE ? OR brand LIKE ?)", where)
+	assert.Equal(t, []interface{}{"active", `%50\%\_off\\%`, `%50\%\_off\\%`}, args)
+}
+
+func TestBuildWhereClause_SerialPrefix(t *testing.T) {
+	where, args := buildWhereClause(usecase.ItemFilter{SerialPrefix: "AB_1"})
+
+	assert.Equal(t, "WHERE deleted_at IS NULL AND status = ? AND serial_number LIKE ?", where)
+	assert.Equal(t, []interface{}{"active", `AB\_1%`}, args)
+}
+
+func TestBuildWhereClause_IDRange(t *testing.T) {
+	where, args := buildWhereClause(usecase.ItemFilter{IDFrom: 100, IDTo: 200})
+
+	assert.Equal(t, "WHERE deleted_at IS NULL AND status = ? AND id BETWEEN ? AND ?", where)
+	assert.Equal(t, []interface{}{"active", int64(100), int64(200)}, args)
+}
+
+func TestBuildWhereClause_PurchaseRanges(t *testing.T) {
+	minPrice, maxPrice := int64(500000), int64(2000000)
+	where, args := buildWhereClause(usecase.ItemFilter{
+		MinPurchasePrice: &minPrice,
+		MaxPurchasePrice: &maxPrice,
+		PurchasedFrom:    "2020-01-01",
+		PurchasedTo:      "2023-12-31",
+	})
+
+	assert.Equal(t, "WHERE deleted_at IS NULL AND status = ? AND purchase_price >= ? AND purchase_price <= ? AND purchase_date >= ? AND purchase_date <= ?", where)
+	assert.Equal(t, []interface{}{"active", int64(500000), int64(2000000), "2020-01-01", "2023-12-31"}, args)
+}
+
+func TestBuildWhereClause_Status(t *testing.T) {
+	where, args := buildWhereClause(usecase.ItemFilter{Status: entity.StatusDraft})
+
+	assert.Equal(t, "WHERE deleted_at IS NULL AND status = ?", where)
+	assert.Equal(t, []interface{}{"draft"}, args)
+}
+
+func TestBuildFindPageQuery_Deleted(t *testing.T) {
+	query, args := buildFindPageQuery(usecase.ItemFilter{
+		Categories: []entity.Category{entity.CategoryWatch},
+		Deleted:    true,
+		Sort:       usecase.SortDeletedAtDesc,
+	}, usecase.Page{Limit: 20})
+
+	// ゴミ箱では状態で絞り込まない
+	assert.Contains(t, query, "WHERE deleted_at IS NOT NULL AND category IN (?)")
+	assert.Contains(t, query, "ORDER BY deleted_at DESC, id DESC")
+	assert.Equal(t, []interface{}{"時計", 20, 0}, args)
+}
+
+func TestBuildFindPageQuery_Sort(t *testing.T) {
+	query, _ := buildFindPageQuery(usecase.ItemFilter{Sort: usecase.SortPurchaseDateDesc}, usecase.Page{Limit: 20})
+	assert.Contains(t, query, "ORDER BY purchase_date DESC, id DESC")
+
+	query, _ = buildFindPageQuery(usecase.ItemFilter{}, usecase.Page{Limit: 20})
+	assert.Contains(t, query, "ORDER BY created_at DESC, id DESC")
+}
+
+func TestBuildFindSiblingsQuery(t *testing.T) {
+	query, args := buildFindSiblingsQuery(5, usecase.ItemFilter{
+		Categories: []entity.Category{entity.CategoryWatch},
+		Sort:       usecase.SortPurchasePriceDesc,
+	})
+
+	// 絞り込んだ行に並び順のウィンドウを適用してから、指定したアイテムの行を選ぶ
+	assert.Contains(t, query, "LAG(id) OVER w AS prev_id")
+	assert.Contains(t, query, "LEAD(id) OVER w AS next_id")
+	assert.Contains(t, query, "WHERE deleted_at IS NULL AND status = ? AND category IN (?)")
+	assert.Contains(t, query, "WINDOW w AS (ORDER BY purchase_price DESC, id DESC)")
+	assert.Contains(t, query, ") AS ordered\n        WHERE id = ?")
+	assert.Equal(t, []interface{}{"active", "時計", int64(5)}, args)
+}
+
+func TestNewItemSibling(t *testing.T) {
+	// 先頭・末尾ではLAG/LEADがNULLになる
+	assert.Nil(t, newItemSibling(sql.NullInt64{}, sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{}))
+
+	sibling := newItemSibling(
+		sql.NullInt64{Int64: 2, Valid: true},
+		sql.NullString{},
+		sql.NullString{String: "デイトナ", Valid: true},
+		sql.NullString{String: "時計", Valid: true},
+		sql.NullString{String: "ROLEX", Valid: true},
+	)
+	assert.Equal(t, &usecase.ItemSibling{ID: 2, Name: "デイトナ", Category: entity.CategoryWatch, Brand: "ROLEX"}, sibling)
+
+	// UUIDが振られている場合はAPIで使うIDとして返す
+	sibling = newItemSibling(
+		sql.NullInt64{Int64: 3, Valid: true},
+		sql.NullString{String: "0b8f5c7e-3a1d-4e2b-9c6f-1a2b3c4d5e6f", Valid: true},
+		sql.NullString{String: "サブマリーナー", Valid: true},
+		sql.NullString{String: "時計", Valid: true},
+		sql.NullString{String: "ROLEX", Valid: true},
+	)
+	assert.Equal(t, "0b8f5c7e-3a1d-4e2b-9c6f-1a2b3c4d5e6f", sibling.UUID)
+}
+
+func TestBuildSummaryByBrandQuery(t *testing.T) {
+	query, args := buildSummaryByBrandQuery(usecase.ItemFilter{Categories: []entity.Category{entity.CategoryWatch}})
+
+	assert.Contains(t, query, "WHERE deleted_at IS NULL AND status = ? AND category IN (?)")
+	assert.Contains(t, query, "GROUP BY brand")
+	assert.Contains(t, query, "ORDER BY count DESC, brand ASC")
+	assert.Equal(t, []interface{}{"active", "時計"}, args)
+}
+
+func TestBuildSummaryByPurchaseYearQuery(t *testing.T) {
+	query, args := buildSummaryByPurchaseYearQuery(usecase.ItemFilter{Categories: []entity.Category{entity.CategoryWatch}})
+
+	assert.Contains(t, query, "NULLIF(YEAR(purchase_date), 0) as purchase_year")
+	assert.Contains(t, query, "WHERE deleted_at IS NULL AND status = ? AND category IN (?)")
+	assert.Contains(t, query, "GROUP BY purchase_year")
+	assert.Contains(t, query, "ORDER BY purchase_year IS NULL, purchase_year ASC")
+	assert.Equal(t, []interface{}{"active", "時計"}, args)
+}
+
+func TestBuildSummaryByCategoriesQuery(t *testing.T) {
+	query, args := buildSummaryByCategoriesQuery([]string{"時計", "バッグ"})
+
+	assert.Contains(t, query, "WHERE deleted_at IS NULL AND status = 'active' AND category IN (?, ?)")
+	assert.Contains(t, query, "GROUP BY category")
+	assert.Equal(t, []interface{}{"時計", "バッグ"}, args)
+}
+
+// database/sqlと同じく、sql.Scanner以外にNULLを読み込むとエラーにする行
+type fakeRow []interface{}
+
+func (r fakeRow) Scan(dest ...interface{}) error {
+	if len(dest) != len(r) {
+		return fmt.Errorf("expected %d destination arguments, not %d", len(r), len(dest))
+	}
+	for i, value := range r {
+		if scanner, ok := dest[i].(sql.Scanner); ok {
+			if err := scanner.Scan(value); err != nil {
+				return err
+			}
+			continue
+		}
+		target := reflect.ValueOf(dest[i]).Elem()
+		if value == nil {
+			return fmt.Errorf("converting NULL to %s is unsupported", target.Kind())
+		}
+		target.Set(reflect.ValueOf(value).Convert(target.Type()))
+	}
+	return nil
+}
+
+func TestScanItem_Nulls(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	deletedAt := createdAt.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		row      fakeRow
+		expected *entity.Item
+	}{
+		{
+			name: "正常系: 任意のカラムがNULL",
+			row:  fakeRow{int64(1), "デイトナ", "時計", "ROLEX", int64(1500000), "2023-01-15", nil, "active", createdAt, createdAt, nil, nil, int64(1), nil},
+			expected: &entity.Item{
+				ID: 1, Name: "デイトナ", Category: entity.CategoryWatch, Brand: "ROLEX", PurchasePrice: entity.JPY(1500000),
+				PurchaseDate: "2023-01-15", Status: entity.StatusActive, CreatedAt: createdAt, UpdatedAt: createdAt,
+				Version: 1,
+			},
+		},
+		{
+			name: "正常系: 日時がNULLの場合はゼロ値",
+			row:  fakeRow{int64(2), "バーキン", "バッグ", "HERMÈS", int64(2000000), nil, nil, "active", nil, nil, nil, nil, int64(1), nil},
+			expected: &entity.Item{
+				ID: 2, Name: "バーキン", Category: entity.CategoryBag, Brand: "HERMÈS", PurchasePrice: entity.JPY(2000000),
+				Status: entity.StatusActive, Version: 1,
+			},
+		},
+		{
+			name: "正常系: NULLでない値はそのまま",
+			row:  fakeRow{int64(3), "デイトナ", "時計", "ROLEX", int64(1500000), "2023-01-15", "SN-001", "archived", createdAt, createdAt, deletedAt, "sold", int64(4), "0b8f5c7e-3a1d-4e2b-9c6f-1a2b3c4d5e6f"},
+			expected: &entity.Item{
+				ID: 3, Name: "デイトナ", Category: entity.CategoryWatch, Brand: "ROLEX", PurchasePrice: entity.JPY(1500000),
+				PurchaseDate: "2023-01-15", SerialNumber: "SN-001", Status: entity.StatusArchived, CreatedAt: createdAt, UpdatedAt: createdAt,
+				DeletedAt: &deletedAt, DeleteReason: "sold", Version: 4, UUID: "0b8f5c7e-3a1d-4e2b-9c6f-1a2b3c4d5e6f",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := scanItem(tt.row)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, item)
+		})
+	}
+}
+
+func TestFakeRow_NullIntoString(t *testing.T) {
+	// NULL対応をしない読み込みが失敗することを確認する（scanItemのテストの前提）
+	var value string
+	err := fakeRow{nil}.Scan(&value)
+
+	assert.EqualError(t, err, "converting NULL to string is unsupported")
+}
+
+func TestBuildSummaryByGroupQuery(t *testing.T) {
+	t.Run("正常系: 状態ごとの集計はすべての状態を対象にできる", func(t *testing.T) {
+		query, args, err := buildSummaryByGroupQuery(usecase.ItemFilter{AnyStatus: true}, usecase.GroupByStatus)
+
+		require.NoError(t, err)
+		assert.Contains(t, query, "SELECT status, COUNT(*)")
+		assert.Contains(t, query, "WHERE deleted_at IS NULL\n")
+		assert.Contains(t, query, "GROUP BY status")
+		assert.Contains(t, query, "ORDER BY count DESC, status ASC")
+		assert.Empty(t, args)
+	})
+
+	t.Run("異常系: 許可リストに無いカラム", func(t *testing.T) {
+		_, _, err := buildSummaryByGroupQuery(usecase.ItemFilter{}, usecase.GroupBy("name; DROP TABLE items"))
+
+		assert.True(t, domainErrors.IsValidationError(err))
+	})
+}
+
+// 実行したSQLを記録し、Executeは固定の結果、QueryRowは固定の行を返すSqlHandler
+type recordingSqlHandler struct {
+	SqlHandler
+	statements []string
+	result     fakeResult
+	row        fakeRow
+}
+
+func (h *recordingSqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	h.statements = append(h.statements, statement)
+	return h.result, nil
+}
+
+func (h *recordingSqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	h.statements = append(h.statements, statement)
+	return h.row
+}
+
+type fakeResult struct {
+	lastInsertID int64
+	rowsAffected int64
+}
+
+func (r fakeResult) LastInsertId() (int64, error) { return r.lastInsertID, nil }
+func (r fakeResult) RowsAffected() (int64, error) { return r.rowsAffected, nil }
+
+func TestItemRepository_Upsert_NoOp(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	// 同じ値のupsertはMySQLでは影響行数0で、versionも変わらない
+	handler := &recordingSqlHandler{
+		result: fakeResult{lastInsertID: 1, rowsAffected: 0},
+		row:    fakeRow{int64(1), "デイトナ", "時計", "ROLEX", int64(1500000), "2023-01-15", "SN-001", "active", createdAt, createdAt, nil, nil, int64(3), nil},
+	}
+	repo := &ItemRepository{SqlHandler: handler}
+	item, err := entity.NewItem("デイトナ", entity.CategoryWatch, "ROLEX", 1500000, "2023-01-15")
+	require.NoError(t, err)
+	item.SerialNumber = "SN-001"
+
+	upserted, created, err := repo.Upsert(context.Background(), item)
+
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, int64(3), upserted.Version)
+	require.Len(t, handler.statements, 2)
+	// 値がすべて同じ場合はversionを増やさない（変更履歴のトリガーはversionが変わった更新のみを記録する）
+	upsert := strings.Join(strings.Fields(handler.statements[0]), " ")
+	assert.Contains(t, upsert, "version = version + IF(name <=> VALUES(name) AND brand <=> VALUES(brand) AND purchase_price <=> VALUES(purchase_price) AND status <=> VALUES(status) AND deleted_at IS NULL, 0, 1)")
+	// versionは他のカラムより先に、書き込む前の値と比べる
+	assert.Less(t, strings.Index(upsert, "version = "), strings.Index(upsert, "name = VALUES(name)"))
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// 失敗理由として保存する最大文字数
+const maxOutboxErrorLength = 1000
+
+// アイテムのイベントをoutboxテーブルに記録するリポジトリ
+// Publishは呼び出し元のトランザクション内で実行される
+type OutboxRepository struct {
+	SqlHandler
+}
+
+func (r *OutboxRepository) Publish(ctx context.Context, event usecase.ItemEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	query := `
+        INSERT INTO outbox (event_type, payload)
+        VALUES (?, ?)
+    `
+
+	if _, err := r.Execute(ctx, query, string(event.Type), string(payload)); err != nil {
+		return databaseError(err)
+	}
+
+	return nil
+}
+
+func (r *OutboxRepository) FetchPending(ctx context.Context, limit int) ([]usecase.OutboxEntry, error) {
+	query := `
+        SELECT id, payload, attempts
+        FROM outbox
+        WHERE delivered_at IS NULL AND dead_lettered_at IS NULL
+        ORDER BY id
+        LIMIT ?
+    `
+
+	rows, err := r.Query(ctx, query, limit)
+	if err != nil {
+		return nil, databaseError(err)
+	}
+	defer rows.Close()
+
+	var entries []usecase.OutboxEntry
+	for rows.Next() {
+		var entry usecase.OutboxEntry
+		var payload string
+		if err := rows.Scan(&entry.ID, &payload, &entry.Attempts); err != nil {
+			return nil, databaseError(err)
+		}
+		if err := json.Unmarshal([]byte(payload), &entry.Event); err != nil {
+			return nil, fmt.Errorf("failed to decode event %d: %w", entry.ID, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, databaseError(err)
+	}
+
+	return entries, nil
+}
+
+func (r *OutboxRepository) MarkDelivered(ctx context.Context, id int64) error {
+	query := `UPDATE outbox SET delivered_at = NOW(), attempts = attempts + 1 WHERE id = ?`
+
+	if _, err := r.Execute(ctx, query, id); err != nil {
+		return databaseError(err)
+	}
+
+	return nil
+}
+
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id int64, reason string) error {
+	if len(reason) > maxOutboxErrorLength {
+		reason = reason[:maxOutboxErrorLength]
+	}
+
+	query := `UPDATE outbox SET attempts = attempts + 1, last_error = ? WHERE id = ?`
+
+	if _, err := r.Execute(ctx, query, reason, id); err != nil {
+		return databaseError(err)
+	}
+
+	return nil
+}
+
+func (r *OutboxRepository) MarkDeadLettered(ctx context.Context, id int64, reason string) error {
+	if len(reason) > maxOutboxErrorLength {
+		reason = reason[:maxOutboxErrorLength]
+	}
+
+	query := `UPDATE outbox SET attempts = attempts + 1, last_error = ?, dead_lettered_at = NOW() WHERE id = ?`
+
+	if _, err := r.Execute(ctx, query, reason, id); err != nil {
+		return databaseError(err)
+	}
+
+	return nil
+}
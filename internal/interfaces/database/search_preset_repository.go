@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 名前を付けた検索条件をsearch_presetsテーブルに保存するリポジトリ
+type SearchPresetRepository struct {
+	SqlHandler
+}
+
+func (r *SearchPresetRepository) Save(ctx context.Context, preset *entity.SearchPreset) (*entity.SearchPreset, error) {
+	// 同じ名前の場合は検索条件のみ置き換え、作成日時は残す
+	query := `
+        INSERT INTO search_presets (name, filter, created_at, updated_at)
+        VALUES (?, ?, ?, ?)
+        ON DUPLICATE KEY UPDATE
+            filter = VALUES(filter),
+            updated_at = VALUES(updated_at)
+    `
+
+	if _, err := r.Execute(ctx, query, preset.Name, []byte(preset.Filter), preset.CreatedAt, preset.UpdatedAt); err != nil {
+		return nil, databaseError(err)
+	}
+	return r.FindByName(ctx, preset.Name)
+}
+
+func (r *SearchPresetRepository) FindByName(ctx context.Context, name string) (*entity.SearchPreset, error) {
+	query := `
+        SELECT name, filter, created_at, updated_at
+        FROM search_presets
+        WHERE name = ?
+    `
+
+	preset, err := scanSearchPreset(r.QueryRow(ctx, query, name))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrPresetNotFound
+		}
+		return nil, databaseError(err)
+	}
+	return preset, nil
+}
+
+func (r *SearchPresetRepository) FindAll(ctx context.Context) ([]*entity.SearchPreset, error) {
+	query := `
+        SELECT name, filter, created_at, updated_at
+        FROM search_presets
+        ORDER BY name ASC
+    `
+
+	rows, err := r.Query(ctx, query)
+	if err != nil {
+		return nil, databaseError(err)
+	}
+	defer rows.Close()
+
+	var presets []*entity.SearchPreset
+	for rows.Next() {
+		preset, err := scanSearchPreset(rows)
+		if err != nil {
+			return nil, databaseError(err)
+		}
+		presets = append(presets, preset)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, databaseError(err)
+	}
+
+	return presets, nil
+}
+
+func (r *SearchPresetRepository) Delete(ctx context.Context, name string) error {
+	result, err := r.Execute(ctx, `DELETE FROM search_presets WHERE name = ?`, name)
+	if err != nil {
+		return databaseError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if rowsAffected == 0 {
+		return domainErrors.ErrPresetNotFound
+	}
+
+	return nil
+}
+
+// 1行分の検索条件を読み込む（created_at / updated_atのNULLはゼロ値とする）
+func scanSearchPreset(row Row) (*entity.SearchPreset, error) {
+	var preset entity.SearchPreset
+	// ドライバーのバッファを参照しないよう、[]byteに読み込んでから設定する
+	var filter []byte
+	var createdAt, updatedAt sql.NullTime
+	if err := row.Scan(&preset.Name, &filter, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	preset.Filter = filter
+	preset.CreatedAt = createdAt.Time
+	preset.UpdatedAt = updatedAt.Time
+	return &preset, nil
+}
@@ -1,14 +1,34 @@
 package database
 
-import "context"
+import (
+	"context"
+
+	"Aicon-assignment/internal/usecase"
+)
 
 type SqlHandler interface {
 	Execute(ctx context.Context, statement string, args ...interface{}) (Result, error)
+	// レプリカを設定した場合、トランザクション外の読み取りはレプリカで実行される（WithPrimaryReadsの場合を除く）
 	Query(ctx context.Context, statement string, args ...interface{}) (Rows, error)
 	QueryRow(ctx context.Context, statement string, args ...interface{}) Row
+	// fnに渡すctxを使ったクエリは同一トランザクション内で実行される（fnがエラーを返すとロールバック）
+	// トランザクションは読み取りも含めて常にプライマリーで実行される
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
 	Close() error
 }
 
+// ctxを使った読み取りを、レプリカではなくプライマリーで実行させる
+// レプリカの遅延で書き込み直後の値が見えないと困る処理（書き込みの前の確認など）に使う
+// ユースケース層からも指定できるよう、usecase.WithPrimaryReadsと同じキーを使う
+func WithPrimaryReads(ctx context.Context) context.Context {
+	return usecase.WithPrimaryReads(ctx)
+}
+
+// WithPrimaryReadsを指定したctxかどうか
+func UsePrimaryReads(ctx context.Context) bool {
+	return usecase.UsePrimaryReads(ctx)
+}
+
 type Result interface {
 	LastInsertId() (int64, error)
 	RowsAffected() (int64, error)
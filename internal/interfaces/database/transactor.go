@@ -0,0 +1,21 @@
+package database
+
+import (
+	"context"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// SqlHandlerのトランザクションをusecase.Transactorとして提供する
+type Transactor struct {
+	SqlHandler
+}
+
+// トランザクションの開始・コミット時に接続が切れた場合も、ドメインのエラーとして返す
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := t.Transaction(ctx, fn)
+	if err != nil && !domainErrors.IsDatabaseError(err) && isConnectionError(err) {
+		return databaseError(err)
+	}
+	return err
+}
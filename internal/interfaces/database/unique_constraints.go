@@ -0,0 +1,50 @@
+package database
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MySQLのユニーク制約違反のエラー番号（ER_DUP_ENTRY）
+const mysqlDuplicateEntryNumber = 1062
+
+// ユニーク制約ごとの、重複したフィールドとクライアントに返すメッセージ
+// インデックスを追加した場合はここにも追加する（未登録の制約は uniqueConstraintFallback を返す）
+var uniqueConstraintMessages = map[string]domainErrors.DuplicateEntryError{
+	"uniq_serial_number": {Field: "serial_number", Message: "An item with this serial number already exists."},
+}
+
+// 未登録のユニーク制約に違反した場合のメッセージ
+var uniqueConstraintFallback = domainErrors.DuplicateEntryError{Message: "An item with the same value already exists."}
+
+// ユニーク制約違反の場合は、制約に対応するDuplicateEntryErrorを返す
+func duplicateEntryError(err error) (*domainErrors.DuplicateEntryError, bool) {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) || mysqlErr.Number != mysqlDuplicateEntryNumber {
+		return nil, false
+	}
+
+	mapped, ok := uniqueConstraintMessages[constraintName(mysqlErr.Message)]
+	if !ok {
+		mapped = uniqueConstraintFallback
+	}
+	return &mapped, true
+}
+
+// "Duplicate entry 'SN-001' for key 'items.uniq_serial_number'" から制約名を取り出す
+// MySQL 8.0 より前はテーブル名が付かない（"for key 'uniq_serial_number'"）
+func constraintName(message string) string {
+	_, key, found := strings.Cut(message, " for key ")
+	if !found {
+		return ""
+	}
+	key = strings.Trim(key, "'")
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		key = key[i+1:]
+	}
+	return key
+}
@@ -0,0 +1,57 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestDuplicateEntryError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected *domainErrors.DuplicateEntryError
+	}{
+		{
+			name:     "MySQL 8.0（テーブル名付き）",
+			err:      &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'SN-001' for key 'items.uniq_serial_number'"},
+			expected: &domainErrors.DuplicateEntryError{Field: "serial_number", Message: "An item with this serial number already exists."},
+		},
+		{
+			name:     "MySQL 5.7（テーブル名なし）、ラップされたエラー",
+			err:      fmt.Errorf("exec: %w", &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'SN-001' for key 'uniq_serial_number'"}),
+			expected: &domainErrors.DuplicateEntryError{Field: "serial_number", Message: "An item with this serial number already exists."},
+		},
+		{
+			name:     "未登録の制約",
+			err:      &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'x' for key 'items.uniq_other'"},
+			expected: &domainErrors.DuplicateEntryError{Message: "An item with the same value already exists."},
+		},
+		{
+			name: "ユニーク制約違反以外",
+			err:  &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row"},
+		},
+		{
+			name: "MySQL以外のエラー",
+			err:  errors.New("Error 1062: Duplicate entry"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dupErr, ok := duplicateEntryError(tt.err)
+			if tt.expected == nil {
+				assert.False(t, ok)
+				return
+			}
+			assert.True(t, ok)
+			assert.Equal(t, tt.expected, dupErr)
+			assert.True(t, domainErrors.IsDuplicateEntryError(dupErr))
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 所有中のアイテムをアーカイブする（論理削除とは異なり、?status=archived で一覧に表示できる）
+// アーカイブ済みの場合はそのまま返す
+func (u *itemUsecase) ArchiveItem(ctx context.Context, id int64) (*entity.Item, error) {
+	return u.changeStatus(ctx, id, entity.StatusActive, entity.StatusArchived)
+}
+
+// アーカイブしたアイテムを所有中に戻す（所有中の場合はそのまま返す）
+func (u *itemUsecase) UnarchiveItem(ctx context.Context, id int64) (*entity.Item, error) {
+	return u.changeStatus(ctx, id, entity.StatusArchived, entity.StatusActive)
+}
+
+// 状態がfromのアイテムをtoに変更する（それ以外の状態からは変更できない）
+func (u *itemUsecase) changeStatus(ctx context.Context, id int64, from, to entity.Status) (*entity.Item, error) {
+	item, err := u.findItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	current := item.Status
+	if current == "" {
+		current = entity.StatusActive
+	}
+	if current == to {
+		return item, nil
+	}
+	if current != from {
+		return nil, fmt.Errorf("%w: cannot change status from %s to %s", domainErrors.ErrInvalidStatusTransition, current, to)
+	}
+
+	return u.UpdateItem(ctx, id, UpdateItemInput{Status: &to})
+}
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"math"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 平均値に指定できる小数点以下の桁数の上限
+const MaxAverageDecimals = 4
+
+// 集計の平均購入価格を丸める小数点以下の桁数を設定する（既定は0 = 円単位）
+func WithAverageDecimals(decimals int) Option {
+	return func(u *itemUsecase) {
+		u.averageDecimals = decimals
+	}
+}
+
+// 購入価格の合計と件数から平均を求め、指定した桁数に四捨五入する（0件の場合は0）
+// DBの浮動小数点の書式に依存しないよう、合計と件数からGoで計算する
+func averagePrice(total entity.Money, count, decimals int) float64 {
+	if count == 0 {
+		return 0
+	}
+	scale := math.Pow10(decimals)
+	return math.Round(float64(total.Amount)/float64(count)*scale) / scale
+}
@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// BackfillerでbatchSizeを指定しない場合の1バッチの件数
+const DefaultBackfillBatchSize = 100
+
+// 保存している値から計算できる（派生した）カラムの再計算
+// カラムを追加したときや計算方法を変えたときに、既存の行へ書き込み直すために使う
+type DerivedField interface {
+	// afterIDより大きいIDの行をID順にlimit件まで読み込み、値が変わる行のみを書き込む
+	RecomputeBatch(ctx context.Context, afterID int64, limit int) (BackfillBatch, error)
+}
+
+// 1バッチの結果
+type BackfillBatch struct {
+	LastID  int64 // 読み込んだ最後の行のID（行が無い場合は0）
+	Scanned int   // 読み込んだ行数
+	Updated int   // 値が変わり書き込んだ行数
+}
+
+// 再計算の結果
+type BackfillResult struct {
+	Field   string `json:"field"`
+	Batches int    `json:"batches"`
+	Scanned int    `json:"scanned"`
+	Updated int    `json:"updated"`
+	LastID  int64  `json:"last_id"` // 次に再開する場合の after_id
+}
+
+// 派生カラムを全件バッチごとに再計算するユースケース（管理者用）
+type Backfiller interface {
+	// ID順にafterIDより後の行から最後まで再計算する（バッチごとに1トランザクション）
+	// 途中で失敗した場合も、それまでのバッチの書き込みは残る（エラーに再開するIDを含む）
+	Backfill(ctx context.Context, field string, afterID int64) (*BackfillResult, error)
+}
+
+type backfiller struct {
+	fields     map[string]DerivedField
+	transactor Transactor
+	batchSize  int
+	// 行を書き換えたバッチの後に破棄するGetItemByIDのキャッシュ（nilの場合は使わない）
+	cache *ItemCache
+}
+
+type BackfillerOption func(*backfiller)
+
+// 行を書き換えたバッチをコミットするたびに、cacheが保持しているアイテムをすべて破棄する
+// 再計算ではアイテムごとのイベント（item.updated）は記録しない（変更履歴には記録されるため、差分同期には反映される）
+func WithBackfillItemCache(cache *ItemCache) BackfillerOption {
+	return func(b *backfiller) {
+		b.cache = cache
+	}
+}
+
+// fieldsは再計算できるフィールドの名前と計算方法（batchSizeが0以下の場合はDefaultBackfillBatchSize）
+func NewBackfiller(fields map[string]DerivedField, transactor Transactor, batchSize int, opts ...BackfillerOption) Backfiller {
+	if transactor == nil {
+		transactor = noopTransactor{}
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultBackfillBatchSize
+	}
+	b := &backfiller{
+		fields:     fields,
+		transactor: transactor,
+		batchSize:  batchSize,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+func (b *backfiller) Backfill(ctx context.Context, field string, afterID int64) (*BackfillResult, error) {
+	derived, exists := b.fields[field]
+	if !exists {
+		return nil, fmt.Errorf("%w: field must be one of: %s", domainErrors.ErrInvalidInput, strings.Join(b.fieldNames(), ", "))
+	}
+	if afterID < 0 {
+		return nil, fmt.Errorf("%w: after_id must be 0 or greater", domainErrors.ErrInvalidInput)
+	}
+
+	result := &BackfillResult{Field: field, LastID: afterID}
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("backfill of %s stopped after id %d: %w", field, result.LastID, err)
+		}
+
+		var batch BackfillBatch
+		err := b.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			batch, err = derived.RecomputeBatch(ctx, result.LastID, b.batchSize)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("backfill of %s failed after id %d: %w", field, result.LastID, err)
+		}
+		if batch.Updated > 0 {
+			b.cache.InvalidateAll()
+		}
+		if batch.Scanned == 0 {
+			break
+		}
+
+		result.Batches++
+		result.Scanned += batch.Scanned
+		result.Updated += batch.Updated
+		result.LastID = batch.LastID
+		slog.Info("backfill progress", "field", field, "batch", result.Batches, "last_id", result.LastID,
+			"scanned", result.Scanned, "updated", result.Updated)
+
+		if batch.Scanned < b.batchSize {
+			break
+		}
+	}
+
+	slog.Info("backfill completed", "field", field, "batches", result.Batches, "scanned", result.Scanned, "updated", result.Updated)
+	return result, nil
+}
+
+func (b *backfiller) fieldNames() []string {
+	names := make([]string, 0, len(b.fields))
+	for name := range b.fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// 画像の縮小画像（item_images.thumbnail）を現在の設定で作り直す
+type thumbnailField struct {
+	imageRepo ItemImageRepository
+	settings  ItemImageSettings
+}
+
+// 縮小画像の長辺の最大ピクセル数を変えた場合や、縮小画像のカラムを追加する前に登録した画像に使う
+func NewThumbnailField(imageRepo ItemImageRepository, settings ItemImageSettings) DerivedField {
+	return &thumbnailField{imageRepo: imageRepo, settings: settings}
+}
+
+func (f *thumbnailField) RecomputeBatch(ctx context.Context, afterID int64, limit int) (BackfillBatch, error) {
+	images, err := f.imageRepo.FindBatchAfter(ctx, afterID, limit)
+	if err != nil {
+		return BackfillBatch{}, err
+	}
+
+	batch := BackfillBatch{Scanned: len(images)}
+	for _, image := range images {
+		batch.LastID = image.ID
+		decoded, err := decodeImage(image.Data)
+		if err != nil {
+			// 登録時に検証しているため通常は起きない。他の画像の再計算は続ける
+			slog.Warn("skipped undecodable image", "image_id", image.ID, "error", err)
+			continue
+		}
+		thumbnail := createThumbnail(ctx, decoded, f.settings)
+		if bytes.Equal(thumbnail, image.Thumbnail) {
+			continue
+		}
+		if err := f.imageRepo.UpdateThumbnail(ctx, image.ID, thumbnail); err != nil {
+			return BackfillBatch{}, err
+		}
+		batch.Updated++
+	}
+	return batch, nil
+}
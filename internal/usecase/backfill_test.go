@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 呼び出しごとに決まった結果を返す派生フィールド
+type fakeDerivedField struct {
+	batches  []BackfillBatch
+	err      error
+	afterIDs []int64
+}
+
+func (f *fakeDerivedField) RecomputeBatch(ctx context.Context, afterID int64, limit int) (BackfillBatch, error) {
+	f.afterIDs = append(f.afterIDs, afterID)
+	if len(f.afterIDs) > len(f.batches) {
+		return BackfillBatch{}, f.err
+	}
+	return f.batches[len(f.afterIDs)-1], nil
+}
+
+func TestBackfiller_Backfill(t *testing.T) {
+	t.Run("正常系: 件数が満たないバッチまで続ける", func(t *testing.T) {
+		field := &fakeDerivedField{batches: []BackfillBatch{
+			{LastID: 2, Scanned: 2, Updated: 1},
+			{LastID: 5, Scanned: 2, Updated: 2},
+			{LastID: 6, Scanned: 1},
+		}}
+		backfiller := NewBackfiller(map[string]DerivedField{"thumbnail": field}, nil, 2)
+
+		result, err := backfiller.Backfill(context.Background(), "thumbnail", 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, &BackfillResult{Field: "thumbnail", Batches: 3, Scanned: 5, Updated: 3, LastID: 6}, result)
+		assert.Equal(t, []int64{0, 2, 5}, field.afterIDs)
+	})
+
+	t.Run("正常系: 行が無くなった時点で終える", func(t *testing.T) {
+		field := &fakeDerivedField{batches: []BackfillBatch{{LastID: 12, Scanned: 2}, {}}}
+		backfiller := NewBackfiller(map[string]DerivedField{"thumbnail": field}, nil, 2)
+
+		result, err := backfiller.Backfill(context.Background(), "thumbnail", 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, &BackfillResult{Field: "thumbnail", Batches: 1, Scanned: 2, LastID: 12}, result)
+	})
+
+	t.Run("正常系: 行を書き換えたバッチの後はキャッシュを破棄する", func(t *testing.T) {
+		cache := NewItemCache(10, time.Minute)
+		field := &fakeDerivedField{batches: []BackfillBatch{{LastID: 2, Scanned: 2}, {LastID: 3, Scanned: 1, Updated: 1}}}
+		backfiller := NewBackfiller(map[string]DerivedField{"brand": field}, nil, 2, WithBackfillItemCache(cache))
+
+		cache.put(cacheTestItem(1, "変更なし"), cache.currentGeneration())
+		_, err := backfiller.Backfill(context.Background(), "brand", 0)
+
+		require.NoError(t, err)
+		_, ok := cache.get(1)
+		assert.False(t, ok)
+	})
+
+	t.Run("正常系: 書き換えた行が無い場合はキャッシュを残す", func(t *testing.T) {
+		cache := NewItemCache(10, time.Minute)
+		field := &fakeDerivedField{batches: []BackfillBatch{{LastID: 1, Scanned: 1}}}
+		backfiller := NewBackfiller(map[string]DerivedField{"brand": field}, nil, 2, WithBackfillItemCache(cache))
+
+		cache.put(cacheTestItem(1, "変更なし"), cache.currentGeneration())
+		_, err := backfiller.Backfill(context.Background(), "brand", 0)
+
+		require.NoError(t, err)
+		_, ok := cache.get(1)
+		assert.True(t, ok)
+	})
+
+	t.Run("異常系: 途中で失敗した場合は再開するIDを返す", func(t *testing.T) {
+		field := &fakeDerivedField{batches: []BackfillBatch{{LastID: 2, Scanned: 2}}, err: errors.New("db error")}
+		backfiller := NewBackfiller(map[string]DerivedField{"thumbnail": field}, nil, 2)
+
+		_, err := backfiller.Backfill(context.Background(), "thumbnail", 0)
+
+		assert.ErrorContains(t, err, "failed after id 2")
+	})
+
+	t.Run("異常系: 登録していないフィールド", func(t *testing.T) {
+		backfiller := NewBackfiller(map[string]DerivedField{"thumbnail": &fakeDerivedField{}}, nil, 0)
+
+		_, err := backfiller.Backfill(context.Background(), "gain_loss", 0)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.ErrorContains(t, err, "field must be one of: thumbnail")
+	})
+}
+
+func TestThumbnailField_RecomputeBatch(t *testing.T) {
+	var large bytes.Buffer
+	require.NoError(t, png.Encode(&large, image.NewRGBA(image.Rect(0, 0, 40, 20))))
+	var small bytes.Buffer
+	require.NoError(t, png.Encode(&small, image.NewRGBA(image.Rect(0, 0, 8, 8))))
+
+	imageRepo := new(MockItemImageRepository)
+	imageRepo.On("FindBatchAfter", mock.Anything, int64(0), 10).Return([]*entity.ItemImage{
+		{ID: 1, Data: large.Bytes()},                       // 縮小画像が無い
+		{ID: 2, Data: small.Bytes()},                       // 十分小さいため縮小画像は不要
+		{ID: 3, Data: []byte("broken")},                    // 読み込めない画像は飛ばす
+		{ID: 4, Data: small.Bytes(), Thumbnail: []byte{1}}, // 不要な縮小画像は消す
+	}, nil)
+	imageRepo.On("UpdateThumbnail", mock.Anything, int64(1), mock.MatchedBy(func(thumbnail []byte) bool { return len(thumbnail) > 0 })).Return(nil)
+	imageRepo.On("UpdateThumbnail", mock.Anything, int64(4), []byte(nil)).Return(nil)
+	field := NewThumbnailField(imageRepo, ItemImageSettings{ThumbnailMaxDimension: 10})
+
+	batch, err := field.RecomputeBatch(context.Background(), 0, 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, BackfillBatch{LastID: 4, Scanned: 4, Updated: 2}, batch)
+	imageRepo.AssertExpectations(t)
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 登録・更新時のブランドの表記の統一方法（"rolex" と "ROLEX" が別のブランドとして集計されないようにする）
+type BrandCase string
+
+const (
+	BrandCasePreserve BrandCase = "preserve" // 前後の空白を除くのみ（既定）
+	BrandCaseUpper    BrandCase = "upper"    // 大文字（ROLEX）
+	BrandCaseLower    BrandCase = "lower"    // 小文字（rolex）
+	BrandCaseTitle    BrandCase = "title"    // 単語の先頭のみ大文字（Louis Vuitton）
+)
+
+// BRAND_CASE の値を検証する（空の場合はpreserve）
+func ParseBrandCase(value string) (BrandCase, error) {
+	brandCase := BrandCase(strings.ToLower(strings.TrimSpace(value)))
+	switch brandCase {
+	case "":
+		return BrandCasePreserve, nil
+	case BrandCasePreserve, BrandCaseUpper, BrandCaseLower, BrandCaseTitle:
+		return brandCase, nil
+	}
+	return "", fmt.Errorf("%s (must be one of: preserve, upper, lower, title)", value)
+}
+
+// 表記を統一したブランドを返す
+// preserve以外では、単語の間の連続した空白も1つにする
+func (c BrandCase) Apply(brand string) string {
+	brand = strings.TrimSpace(brand)
+	if c == "" || c == BrandCasePreserve {
+		return brand
+	}
+	brand = strings.Join(strings.Fields(brand), " ")
+	switch c {
+	case BrandCaseUpper:
+		return strings.ToUpper(brand)
+	case BrandCaseLower:
+		return strings.ToLower(brand)
+	case BrandCaseTitle:
+		return titleCase(brand)
+	}
+	return brand
+}
+
+// 英字・数字に続く文字は小文字に、それ以外（先頭や空白・記号の後）は大文字にする（Jaeger-LeCoultre は Jaeger-Lecoultre）
+func titleCase(text string) string {
+	var builder strings.Builder
+	builder.Grow(len(text))
+	prev := ' '
+	for _, r := range text {
+		if unicode.IsLetter(prev) || unicode.IsDigit(prev) {
+			builder.WriteRune(unicode.ToLower(r))
+		} else {
+			builder.WriteRune(unicode.ToTitle(r))
+		}
+		prev = r
+	}
+	return builder.String()
+}
+
+// 登録・更新時のブランドの表記の統一方法を設定する（既存の行は POST /admin/backfill?field=brand で統一する）
+func WithBrandCase(brandCase BrandCase) Option {
+	return func(u *itemUsecase) {
+		u.brandCase = brandCase
+	}
+}
+
+// 既存のアイテムのブランドを現在の設定の表記に書き換える
+type brandField struct {
+	itemRepo  ItemRepository
+	brandCase BrandCase
+}
+
+// 書き換えた行はupdated_atを更新し、変更履歴に記録する（差分同期のクライアントにも反映される）
+// 元の表記は残らないため、必要な場合は実行前にバックアップを取る
+func NewBrandField(itemRepo ItemRepository, brandCase BrandCase) DerivedField {
+	return &brandField{itemRepo: itemRepo, brandCase: brandCase}
+}
+
+func (f *brandField) RecomputeBatch(ctx context.Context, afterID int64, limit int) (BackfillBatch, error) {
+	items, err := f.itemRepo.FindBatchAfter(ctx, afterID, limit)
+	if err != nil {
+		return BackfillBatch{}, err
+	}
+
+	batch := BackfillBatch{Scanned: len(items)}
+	for _, item := range items {
+		batch.LastID = item.ID
+		brand := f.brandCase.Apply(item.Brand)
+		if brand == item.Brand {
+			continue
+		}
+		if _, err := f.itemRepo.Update(ctx, item.ID, ItemChanges{Brand: &brand}); err != nil {
+			// 読み込んだ後に削除された行は書き換えない
+			if domainErrors.IsNotFoundError(err) {
+				continue
+			}
+			return BackfillBatch{}, err
+		}
+		batch.Updated++
+	}
+	return batch, nil
+}
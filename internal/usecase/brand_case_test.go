@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestParseBrandCase(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected BrandCase
+		wantErr  bool
+	}{
+		{name: "正常系: 空の場合はpreserve", value: "", expected: BrandCasePreserve},
+		{name: "正常系: 大文字・小文字と空白を区別しない", value: " Upper ", expected: BrandCaseUpper},
+		{name: "正常系: title", value: "title", expected: BrandCaseTitle},
+		{name: "異常系: 不明な値", value: "camel", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			brandCase, err := ParseBrandCase(tt.value)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, brandCase)
+		})
+	}
+}
+
+func TestBrandCase_Apply(t *testing.T) {
+	tests := []struct {
+		name      string
+		brandCase BrandCase
+		brand     string
+		expected  string
+	}{
+		{name: "正常系: preserveは前後の空白のみ除く", brandCase: BrandCasePreserve, brand: " Louis  Vuitton ", expected: "Louis  Vuitton"},
+		{name: "正常系: 未設定はpreserveと同じ", brandCase: "", brand: "Rolex ", expected: "Rolex"},
+		{name: "正常系: upper", brandCase: BrandCaseUpper, brand: " Hermès ", expected: "HERMÈS"},
+		{name: "正常系: lower", brandCase: BrandCaseLower, brand: "ROLEX", expected: "rolex"},
+		{name: "正常系: titleは単語の間の空白も1つにする", brandCase: BrandCaseTitle, brand: "LOUIS   vuitton", expected: "Louis Vuitton"},
+		{name: "正常系: titleは記号の後も大文字", brandCase: BrandCaseTitle, brand: "jaeger-leCOULTRE", expected: "Jaeger-Lecoultre"},
+		{name: "正常系: 日本語はそのまま", brandCase: BrandCaseUpper, brand: "ロレックス", expected: "ロレックス"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.brandCase.Apply(tt.brand))
+		})
+	}
+}
+
+func TestItemUsecase_CreateItem_BrandCase(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+		return item.Brand == "ROLEX"
+	})).Return(&entity.Item{ID: 1, Brand: "ROLEX"}, nil)
+	usecase := NewItemUsecase(mockRepo, WithBrandCase(BrandCaseUpper))
+
+	item, err := usecase.CreateItem(context.Background(), CreateItemInput{
+		Name:          "サブマリーナ",
+		Category:      entity.CategoryWatch,
+		Brand:         " rolex ",
+		PurchasePrice: 1000000,
+		PurchaseDate:  "2023-01-01",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "ROLEX", item.Brand)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestItemUsecase_UpdateItem_BrandCase(t *testing.T) {
+	existing, _ := entity.NewItem("サブマリーナ", entity.CategoryWatch, "ROLEX", 1000000, "2023-01-01")
+	existing.ID = 1
+
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing, nil)
+	usecase := NewItemUsecase(mockRepo, WithBrandCase(BrandCaseUpper))
+
+	// 表記を統一すると現在の値と同じになるため書き込まない
+	brand := "Rolex"
+	item, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{Brand: &brand})
+
+	require.NoError(t, err)
+	assert.Equal(t, "ROLEX", item.Brand)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBrandField_RecomputeBatch(t *testing.T) {
+	itemRepo := new(MockItemRepository)
+	itemRepo.On("FindBatchAfter", mock.Anything, int64(0), 10).Return([]*entity.Item{
+		{ID: 1, Brand: "rolex"},  // 書き換える
+		{ID: 2, Brand: "ROLEX"},  // 統一済み
+		{ID: 3, Brand: "Hermès"}, // 読み込んだ後に削除された
+		{ID: 4, Brand: "Cartier"},
+	}, nil)
+	itemRepo.On("Update", mock.Anything, int64(1), ItemChanges{Brand: stringPtr("ROLEX")}).Return(&entity.Item{ID: 1}, nil)
+	itemRepo.On("Update", mock.Anything, int64(3), ItemChanges{Brand: stringPtr("HERMÈS")}).Return(nil, domainErrors.ErrItemNotFound)
+	itemRepo.On("Update", mock.Anything, int64(4), ItemChanges{Brand: stringPtr("CARTIER")}).Return(&entity.Item{ID: 4}, nil)
+	field := NewBrandField(itemRepo, BrandCaseUpper)
+
+	batch, err := field.RecomputeBatch(context.Background(), 0, 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, BackfillBatch{LastID: 4, Scanned: 4, Updated: 2}, batch)
+	itemRepo.AssertExpectations(t)
+}
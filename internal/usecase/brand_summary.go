@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// ブランドごとのアイテム数と購入価格の合計・平均
+type BrandSummaryEntry struct {
+	Brand                string       `json:"brand"`
+	Count                int          `json:"count"`
+	TotalPurchasePrice   entity.Money `json:"total_purchase_price"`
+	AveragePurchasePrice float64      `json:"average_purchase_price"` // WithAverageDecimalsの桁数に丸めた平均
+}
+
+// 絞り込み条件（category など）に一致するアイテムをブランドごとに集計し、件数の多い順に返す
+func (u *itemUsecase) GetBrandSummary(ctx context.Context, filter ItemFilter) ([]BrandSummaryEntry, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	entries, err := u.itemRepo.GetSummaryByBrand(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get brand summary: %w", err)
+	}
+	if entries == nil {
+		entries = []BrandSummaryEntry{}
+	}
+	for i := range entries {
+		entries[i].AveragePurchasePrice = averagePrice(entries[i].TotalPurchasePrice, entries[i].Count, u.averageDecimals)
+	}
+	return entries, nil
+}
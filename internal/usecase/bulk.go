@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 複数のアイテムを1トランザクションで登録する（1件でも失敗した場合は何も登録しない）
+// エラーメッセージには何件目（0始まり）の入力かを含める
+func (u *itemUsecase) BulkCreateItems(ctx context.Context, inputs []CreateItemInput) ([]*entity.Item, error) {
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("%w: items must not be empty", domainErrors.ErrInvalidInput)
+	}
+
+	// 先に全件を検証する
+	items := make([]*entity.Item, len(inputs))
+	serialIndexes := make(map[string]int)
+	for index, input := range inputs {
+		item, err := u.newItemFromInput(input)
+		if err != nil {
+			return nil, fmt.Errorf("items[%d]: %w", index, err)
+		}
+		if item.SerialNumber != "" {
+			if first, exists := serialIndexes[item.SerialNumber]; exists {
+				return nil, fmt.Errorf("%w: items[%d]: serial_number is duplicated with items[%d]", domainErrors.ErrInvalidInput, index, first)
+			}
+			serialIndexes[item.SerialNumber] = index
+		}
+		items[index] = item
+	}
+
+	created := make([]*entity.Item, 0, len(items))
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		for index, item := range items {
+			createdItem, err := u.itemRepo.Create(ctx, item)
+			if err != nil {
+				if domainErrors.IsDuplicateEntryError(err) {
+					return bulkDuplicateEntryError(index, err)
+				}
+				return err
+			}
+			if err := u.publisher.Publish(ctx, newItemEvent(EventItemCreated, createdItem)); err != nil {
+				return err
+			}
+			created = append(created, createdItem)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create items: %w", err)
+	}
+
+	return created, nil
+}
+
+// 一括登録の1件ごとの結果（Errがnilの場合はItemに登録したアイテム）
+type BulkItemResult struct {
+	Item *entity.Item
+	Err  error
+}
+
+// 有効な入力のみを登録し、1件ごとの結果を入力と同じ順序で返す
+// 1件ずつ別のトランザクションで登録するため、失敗した入力があっても他の入力の登録は取り消さない
+func (u *itemUsecase) BulkCreateItemsBestEffort(ctx context.Context, inputs []CreateItemInput) ([]BulkItemResult, error) {
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("%w: items must not be empty", domainErrors.ErrInvalidInput)
+	}
+
+	results := make([]BulkItemResult, len(inputs))
+	for index, input := range inputs {
+		item, err := u.newItemFromInput(input)
+		if err != nil {
+			results[index].Err = err
+			continue
+		}
+
+		err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			createdItem, err := u.itemRepo.Create(ctx, item)
+			if err != nil {
+				return err
+			}
+			if err := u.publisher.Publish(ctx, newItemEvent(EventItemCreated, createdItem)); err != nil {
+				return err
+			}
+			results[index].Item = createdItem
+			return nil
+		})
+		if err != nil {
+			results[index] = BulkItemResult{Err: err}
+		}
+	}
+
+	return results, nil
+}
+
+// 一意制約違反のメッセージに何件目の入力かを含める（ErrDuplicateEntryとしての判定は維持する）
+func bulkDuplicateEntryError(index int, err error) error {
+	var dupErr *domainErrors.DuplicateEntryError
+	if !errors.As(err, &dupErr) {
+		return fmt.Errorf("items[%d]: %w", index, err)
+	}
+	return &domainErrors.DuplicateEntryError{
+		Field:   dupErr.Field,
+		Message: fmt.Sprintf("items[%d]: %s", index, dupErr.Message),
+	}
+}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_BulkCreateItems(t *testing.T) {
+	validInput := func(serial string) CreateItemInput {
+		return CreateItemInput{Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15", SerialNumber: serial}
+	}
+	created := &entity.Item{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15"}
+
+	tests := []struct {
+		name          string
+		inputs        []CreateItemInput
+		setupMock     func(*MockItemRepository)
+		expectedCount int
+		expectedError string
+		isValidation  bool
+		isDuplicate   bool
+	}{
+		{
+			name:   "正常系: 全件登録",
+			inputs: []CreateItemInput{validInput("SN-001"), validInput("SN-002")},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(created, nil).Times(2)
+			},
+			expectedCount: 2,
+		},
+		{
+			name:          "異常系: 空の入力",
+			inputs:        nil,
+			setupMock:     func(mockRepo *MockItemRepository) {},
+			expectedError: "items must not be empty",
+			isValidation:  true,
+		},
+		{
+			name:          "異常系: 不正な入力がある場合は何も登録しない",
+			inputs:        []CreateItemInput{validInput(""), {Name: "ロレックス デイトナ", Category: "衣服", Brand: "ROLEX", PurchaseDate: "2023-01-15"}},
+			setupMock:     func(mockRepo *MockItemRepository) {},
+			expectedError: "items[1]: ",
+			isValidation:  true,
+		},
+		{
+			name:          "異常系: リクエスト内でシリアル番号が重複",
+			inputs:        []CreateItemInput{validInput("SN-001"), validInput("SN-001")},
+			setupMock:     func(mockRepo *MockItemRepository) {},
+			expectedError: "items[1]: serial_number is duplicated with items[0]",
+			isValidation:  true,
+		},
+		{
+			name:   "異常系: 既存のアイテムとシリアル番号が重複",
+			inputs: []CreateItemInput{validInput("SN-001"), validInput("SN-002")},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(created, nil).Once()
+				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).
+					Return(nil, &domainErrors.DuplicateEntryError{Field: "serial_number", Message: "An item with this serial number already exists."}).Once()
+			},
+			expectedError: "items[1]: An item with this serial number already exists.",
+			isDuplicate:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			items, err := usecase.BulkCreateItems(context.Background(), tt.inputs)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Equal(t, tt.isValidation, domainErrors.IsValidationError(err))
+				assert.Equal(t, tt.isDuplicate, domainErrors.IsDuplicateEntryError(err))
+				assert.Nil(t, items)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, items, tt.expectedCount)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_BulkCreateItemsBestEffort(t *testing.T) {
+	validInput := func(serial string) CreateItemInput {
+		return CreateItemInput{Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15", SerialNumber: serial}
+	}
+	created := &entity.Item{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15"}
+
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.SerialNumber == "SN-001" })).Return(created, nil).Once()
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.SerialNumber == "SN-002" })).
+		Return(nil, &domainErrors.DuplicateEntryError{Field: "serial_number", Message: "An item with this serial number already exists."}).Once()
+	usecase := NewItemUsecase(mockRepo)
+
+	results, err := usecase.BulkCreateItemsBestEffort(context.Background(), []CreateItemInput{
+		validInput("SN-001"),
+		{Name: "ロレックス デイトナ", Category: "衣服", Brand: "ROLEX", PurchaseDate: "2023-01-15"},
+		validInput("SN-002"),
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, created, results[0].Item)
+	assert.NoError(t, results[0].Err)
+	assert.True(t, domainErrors.IsValidationError(results[1].Err))
+	assert.Nil(t, results[1].Item)
+	assert.True(t, domainErrors.IsDuplicateEntryError(results[2].Err))
+	assert.Nil(t, results[2].Item)
+	mockRepo.AssertExpectations(t)
+
+	_, err = usecase.BulkCreateItemsBestEffort(context.Background(), nil)
+	assert.True(t, domainErrors.IsValidationError(err))
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// ブランドからカテゴリーを補う対応表を設定する（キーのブランドは大文字・小文字を区別しない）
+// カテゴリーを指定した入力はそのまま使う
+func WithBrandCategories(mapping map[string]entity.Category) Option {
+	return func(u *itemUsecase) {
+		u.brandCategories = make(map[string]entity.Category, len(mapping))
+		for brand, category := range mapping {
+			u.brandCategories[normalizeBrand(brand)] = category
+		}
+	}
+}
+
+// "ブランド=カテゴリー" 形式の値から対応表を作る（例: ROLEX=時計）
+func ParseBrandCategories(values []string) (map[string]entity.Category, error) {
+	mapping := make(map[string]entity.Category, len(values))
+	for _, value := range values {
+		brand, rawCategory, ok := strings.Cut(value, "=")
+		brand = strings.TrimSpace(brand)
+		if !ok || brand == "" {
+			return nil, fmt.Errorf("%s (must be in the form brand=category)", value)
+		}
+		category, err := entity.NewCategory(rawCategory)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", value, err)
+		}
+		if existing, exists := mapping[normalizeBrand(brand)]; exists && existing != category {
+			return nil, fmt.Errorf("%s: brand is mapped to multiple categories", value)
+		}
+		mapping[normalizeBrand(brand)] = category
+	}
+	return mapping, nil
+}
+
+func normalizeBrand(brand string) string {
+	return strings.ToLower(strings.TrimSpace(brand))
+}
+
+// カテゴリーが空で、ブランドが対応表にある場合にカテゴリーを返す
+func (u *itemUsecase) inferCategory(brand string, category entity.Category) (entity.Category, bool) {
+	if strings.TrimSpace(category.String()) != "" || len(u.brandCategories) == 0 {
+		return category, false
+	}
+	inferred, ok := u.brandCategories[normalizeBrand(brand)]
+	if !ok {
+		return category, false
+	}
+	return inferred, true
+}
+
+// 入力を検証してエンティティを作成する（文字列を整えてブランドの表記を統一し、カテゴリーが空の場合はブランドから補う）
+func (u *itemUsecase) newItemFromInput(input CreateItemInput) (*entity.Item, error) {
+	input.Name = u.sanitize(input.Name)
+	input.Brand = u.brandCase.Apply(u.sanitize(input.Brand))
+	input.SerialNumber = u.sanitize(input.SerialNumber)
+	if category, inferred := u.inferCategory(input.Brand, input.Category); inferred {
+		slog.Info("inferred category from brand", "brand", input.Brand, "category", category.String())
+		input.Category = category
+	}
+	item, err := newItemFromInput(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.checkRequiredFields(item, nil); err != nil {
+		return nil, err
+	}
+	u.assignItemUUID(item)
+	return item, nil
+}
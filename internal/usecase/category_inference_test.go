@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestParseBrandCategories(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    map[string]entity.Category
+		wantErr bool
+	}{
+		{
+			name:   "正常系: ブランドは小文字にする",
+			values: []string{"ROLEX=時計", " Hermès = バッグ "},
+			want:   map[string]entity.Category{"rolex": entity.CategoryWatch, "hermès": entity.CategoryBag},
+		},
+		{name: "正常系: 未設定", values: nil, want: map[string]entity.Category{}},
+		{name: "異常系: 区切りが無い", values: []string{"ROLEX"}, wantErr: true},
+		{name: "異常系: ブランドが空", values: []string{"=時計"}, wantErr: true},
+		{name: "異常系: 無効なカテゴリー", values: []string{"ROLEX=家電"}, wantErr: true},
+		{name: "異常系: 同じブランドに異なるカテゴリー", values: []string{"ROLEX=時計", "rolex=ジュエリー"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBrandCategories(tt.values)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestItemUsecase_CreateItem_BrandCategories(t *testing.T) {
+	brandCategories := map[string]entity.Category{"ROLEX": entity.CategoryWatch}
+	input := CreateItemInput{Name: "デイトナ", Brand: "rolex", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"}
+
+	t.Run("正常系: カテゴリーが空ならブランドから補う", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.Category == entity.CategoryWatch })).
+			Return(&entity.Item{ID: 1, Category: entity.CategoryWatch}, nil)
+		usecase := NewItemUsecase(mockRepo, WithBrandCategories(brandCategories))
+
+		item, err := usecase.CreateItem(context.Background(), input)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.CategoryWatch, item.Category)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 指定したカテゴリーを優先する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.Category == entity.CategoryJewelry })).
+			Return(&entity.Item{ID: 1, Category: entity.CategoryJewelry}, nil)
+		usecase := NewItemUsecase(mockRepo, WithBrandCategories(brandCategories))
+
+		withCategory := input
+		withCategory.Category = entity.CategoryJewelry
+		_, err := usecase.CreateItem(context.Background(), withCategory)
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 対応表に無いブランドはカテゴリーが必須", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo, WithBrandCategories(brandCategories))
+
+		unknown := input
+		unknown.Brand = "OMEGA"
+		_, err := usecase.CreateItem(context.Background(), unknown)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_ImportItems_BrandCategories(t *testing.T) {
+	rows := []ImportRow{
+		{Line: 2, Name: "デイトナ", Brand: "ROLEX", PurchasePrice: "1500000", PurchaseDate: "2023-01-15"},
+		{Line: 3, Name: "スピードマスター", Brand: "OMEGA", PurchasePrice: "800000", PurchaseDate: "2023-01-15"},
+	}
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.Category == entity.CategoryWatch })).
+		Return(&entity.Item{ID: 1}, nil).Once()
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.Category == entity.CategoryOther })).
+		Return(&entity.Item{ID: 2}, nil).Once()
+	usecase := NewItemUsecase(mockRepo,
+		WithBrandCategories(map[string]entity.Category{"rolex": entity.CategoryWatch}),
+		WithImportDefaultCategory(entity.CategoryOther),
+	)
+
+	result, err := usecase.ImportItems(context.Background(), rows, ImportModeStrict)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, 1, result.InferredCategoryRows)
+	assert.Equal(t, 1, result.DefaultCategoryRows)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// アイテム名から推定したカテゴリー（登録時の入力補助で、確定はクライアントが行う）
+type CategorySuggestion struct {
+	Category        entity.Category `json:"category"`
+	Confidence      float64         `json:"confidence"`       // 0〜1（小数点以下2桁）
+	MatchedKeywords []string        `json:"matched_keywords"` // 推定の根拠になったキーワード
+}
+
+// アイテム名からカテゴリーを推定する（推定できない場合はnil）
+// キーワードの対応表に代えて、外部のサービスなどを使う実装に差し替えられる
+type CategorySuggester interface {
+	SuggestCategory(ctx context.Context, name string) (*CategorySuggestion, error)
+}
+
+// カテゴリーの推定に使うキーワードと重み
+type CategoryKeyword struct {
+	Keyword  string
+	Category entity.Category
+	Weight   float64
+}
+
+// キーワードの種類ごとの重み（ブランドは複数のカテゴリーを扱うことがあるため低くする）
+const (
+	keywordWeightModel = 0.9 // モデル名（Submariner, バーキンなど）
+	keywordWeightNoun  = 0.8 // 品目（腕時計, ネックレスなど）
+	keywordWeightBrand = 0.5 // ブランド名
+)
+
+func categoryKeywords(category entity.Category, weight float64, keywords ...string) []CategoryKeyword {
+	entries := make([]CategoryKeyword, len(keywords))
+	for i, keyword := range keywords {
+		entries[i] = CategoryKeyword{Keyword: keyword, Category: category, Weight: weight}
+	}
+	return entries
+}
+
+// 既定のキーワードの対応表
+func DefaultCategoryKeywords() []CategoryKeyword {
+	var keywords []CategoryKeyword
+	for _, group := range [][]CategoryKeyword{
+		categoryKeywords(entity.CategoryWatch, keywordWeightModel,
+			"submariner", "daytona", "datejust", "gmt master", "explorer", "speedmaster", "seamaster",
+			"royal oak", "nautilus", "aquanaut", "santos", "tank", "carrera", "navitimer", "reverso",
+			"サブマリーナ", "デイトナ", "デイトジャスト", "スピードマスター", "シーマスター", "ロイヤルオーク", "ノーチラス"),
+		categoryKeywords(entity.CategoryWatch, keywordWeightNoun,
+			"watch", "chronograph", "時計", "ウォッチ", "クロノグラフ"),
+		categoryKeywords(entity.CategoryWatch, keywordWeightBrand,
+			"rolex", "omega", "patek philippe", "audemars piguet", "tag heuer", "breitling", "iwc", "seiko", "grand seiko",
+			"ロレックス", "オメガ", "パテックフィリップ", "グランドセイコー"),
+		categoryKeywords(entity.CategoryBag, keywordWeightModel,
+			"birkin", "kelly", "speedy", "neverfull", "lady dior", "バーキン", "ケリー", "スピーディ", "ネヴァーフル"),
+		categoryKeywords(entity.CategoryBag, keywordWeightNoun,
+			"bag", "handbag", "tote", "clutch", "backpack", "バッグ", "トート", "クラッチ", "リュック", "ショルダー"),
+		categoryKeywords(entity.CategoryBag, keywordWeightBrand,
+			"hermes", "hermès", "louis vuitton", "エルメス", "ルイヴィトン"),
+		categoryKeywords(entity.CategoryJewelry, keywordWeightModel,
+			"alhambra", "juste un clou", "love bracelet", "アルハンブラ"),
+		categoryKeywords(entity.CategoryJewelry, keywordWeightNoun,
+			"necklace", "ring", "bracelet", "earrings", "pendant", "brooch",
+			"ネックレス", "リング", "指輪", "ブレスレット", "ピアス", "イヤリング", "ペンダント", "ブローチ"),
+		categoryKeywords(entity.CategoryJewelry, keywordWeightBrand,
+			"tiffany", "cartier", "van cleef", "bulgari", "bvlgari", "ティファニー", "カルティエ", "ブルガリ"),
+		categoryKeywords(entity.CategoryShoes, keywordWeightModel,
+			"air jordan", "air force", "so kate", "エアジョーダン"),
+		categoryKeywords(entity.CategoryShoes, keywordWeightNoun,
+			"shoes", "sneakers", "pumps", "boots", "loafers", "sandals",
+			"靴", "スニーカー", "パンプス", "ブーツ", "ローファー", "サンダル"),
+		categoryKeywords(entity.CategoryShoes, keywordWeightBrand,
+			"christian louboutin", "louboutin", "jimmy choo", "ルブタン", "ジミーチュウ"),
+	} {
+		keywords = append(keywords, group...)
+	}
+	return keywords
+}
+
+// BRAND_CATEGORIES の対応表をブランド名のキーワードにする
+func BrandCategoryKeywords(mapping map[string]entity.Category) []CategoryKeyword {
+	keywords := make([]CategoryKeyword, 0, len(mapping))
+	for brand, category := range mapping {
+		keywords = append(keywords, CategoryKeyword{Keyword: brand, Category: category, Weight: keywordWeightBrand})
+	}
+	return keywords
+}
+
+// キーワードの対応表による推定
+// 名前に含まれるキーワードの重みをカテゴリーごとに合計し、最も大きいカテゴリーを返す
+// 確信度は、そのカテゴリーが重みの合計に占める割合に、重み（1で頭打ち）を掛けたもの
+type keywordCategorySuggester struct {
+	keywords []CategoryKeyword
+}
+
+// 同じカテゴリーの同じキーワードは最初のものだけを使う
+func NewKeywordCategorySuggester(keywords []CategoryKeyword) CategorySuggester {
+	normalized := make([]CategoryKeyword, 0, len(keywords))
+	seen := make(map[CategoryKeyword]bool)
+	for _, keyword := range keywords {
+		keyword.Keyword = normalizeSuggestionText(keyword.Keyword)
+		key := CategoryKeyword{Keyword: keyword.Keyword, Category: keyword.Category}
+		if keyword.Keyword == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, keyword)
+	}
+	return &keywordCategorySuggester{keywords: normalized}
+}
+
+func (s *keywordCategorySuggester) SuggestCategory(_ context.Context, name string) (*CategorySuggestion, error) {
+	// 前後に空白を付けて、英字のキーワードは単語の単位で照合する（"ring" が "earring" に一致しないように）
+	text := " " + normalizeSuggestionText(name) + " "
+
+	scores := make(map[entity.Category]float64)
+	matched := make(map[entity.Category][]string)
+	var total float64
+	for _, keyword := range s.keywords {
+		if !strings.Contains(text, suggestionPattern(keyword.Keyword)) {
+			continue
+		}
+		scores[keyword.Category] += keyword.Weight
+		matched[keyword.Category] = append(matched[keyword.Category], keyword.Keyword)
+		total += keyword.Weight
+	}
+	if total == 0 {
+		return nil, nil
+	}
+
+	// 同点の場合は許可リストの順で先のカテゴリーにする
+	var best entity.Category
+	for _, category := range entity.GetValidCategories() {
+		if scores[category] > scores[best] {
+			best = category
+		}
+	}
+	if best == "" {
+		return nil, nil
+	}
+
+	confidence := scores[best] / total * math.Min(scores[best], 1)
+	return &CategorySuggestion{
+		Category:        best,
+		Confidence:      math.Round(confidence*100) / 100,
+		MatchedKeywords: matched[best],
+	}, nil
+}
+
+// 小文字にして、英数字以外の記号を空白にする（日本語の文字はそのまま残す）
+func normalizeSuggestionText(text string) string {
+	mapped := strings.Map(func(r rune) rune {
+		if r <= unicode.MaxASCII && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return ' '
+		}
+		return unicode.ToLower(r)
+	}, text)
+	return strings.Join(strings.Fields(mapped), " ")
+}
+
+// ASCIIのキーワードは単語の境界で照合し、日本語を含むキーワードは部分一致で照合する
+func suggestionPattern(keyword string) string {
+	for _, r := range keyword {
+		if r > unicode.MaxASCII {
+			return keyword
+		}
+	}
+	return " " + keyword + " "
+}
+
+// カテゴリーの推定に使う実装を設定する（既定はキーワードの対応表）
+func WithCategorySuggester(suggester CategorySuggester) Option {
+	return func(u *itemUsecase) {
+		u.categorySuggester = suggester
+	}
+}
+
+// アイテム名からカテゴリーを推定する（推定できない場合はnil）
+func (u *itemUsecase) SuggestCategory(ctx context.Context, name string) (*CategorySuggestion, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", domainErrors.ErrInvalidInput)
+	}
+
+	suggester := u.categorySuggester
+	if suggester == nil {
+		suggester = defaultCategorySuggester
+	}
+	suggestion, err := suggester.SuggestCategory(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest category: %w", err)
+	}
+	return suggestion, nil
+}
+
+var defaultCategorySuggester = NewKeywordCategorySuggester(DefaultCategoryKeywords())
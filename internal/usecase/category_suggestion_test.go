@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestKeywordCategorySuggester_SuggestCategory(t *testing.T) {
+	suggester := NewKeywordCategorySuggester(DefaultCategoryKeywords())
+
+	tests := []struct {
+		name               string
+		itemName           string
+		expectedCategory   entity.Category
+		expectedConfidence float64
+		expectedKeywords   []string
+	}{
+		{"モデル名", "Submariner", entity.CategoryWatch, 0.9, []string{"submariner"}},
+		{"ブランドとモデル名", "ROLEX GMT-Master II", entity.CategoryWatch, 1, []string{"gmt master", "rolex"}},
+		{"日本語の品目", "ティファニー オープンハート ネックレス", entity.CategoryJewelry, 1, []string{"ネックレス", "ティファニー"}},
+		{"ブランドのみ", "HERMÈS", entity.CategoryBag, 0.5, []string{"hermès"}},
+		{"複数のカテゴリーに一致", "Cartier Santos", entity.CategoryWatch, 0.58, []string{"santos"}},
+		{"英単語の一部には一致しない", "Diamond Earrings", entity.CategoryJewelry, 0.8, []string{"earrings"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestion, err := suggester.SuggestCategory(context.Background(), tt.itemName)
+			require.NoError(t, err)
+			require.NotNil(t, suggestion)
+			assert.Equal(t, tt.expectedCategory, suggestion.Category)
+			assert.Equal(t, tt.expectedConfidence, suggestion.Confidence)
+			assert.Equal(t, tt.expectedKeywords, suggestion.MatchedKeywords)
+		})
+	}
+
+	t.Run("一致するキーワードが無い", func(t *testing.T) {
+		suggestion, err := suggester.SuggestCategory(context.Background(), "Paperweight")
+		require.NoError(t, err)
+		assert.Nil(t, suggestion)
+	})
+}
+
+type stubCategorySuggester struct {
+	suggestion *CategorySuggestion
+	err        error
+}
+
+func (s stubCategorySuggester) SuggestCategory(context.Context, string) (*CategorySuggestion, error) {
+	return s.suggestion, s.err
+}
+
+func TestItemUsecase_SuggestCategory(t *testing.T) {
+	t.Run("正常系: 既定のキーワードで推定", func(t *testing.T) {
+		usecase := NewItemUsecase(new(MockItemRepository))
+
+		suggestion, err := usecase.SuggestCategory(context.Background(), " Birkin 30 ")
+		require.NoError(t, err)
+		require.NotNil(t, suggestion)
+		assert.Equal(t, entity.CategoryBag, suggestion.Category)
+	})
+
+	t.Run("正常系: 差し替えた実装を使う", func(t *testing.T) {
+		expected := &CategorySuggestion{Category: entity.CategoryOther, Confidence: 0.3}
+		usecase := NewItemUsecase(new(MockItemRepository), WithCategorySuggester(stubCategorySuggester{suggestion: expected}))
+
+		suggestion, err := usecase.SuggestCategory(context.Background(), "Submariner")
+		require.NoError(t, err)
+		assert.Equal(t, expected, suggestion)
+	})
+
+	t.Run("異常系: 名前が空", func(t *testing.T) {
+		usecase := NewItemUsecase(new(MockItemRepository))
+
+		_, err := usecase.SuggestCategory(context.Background(), "  ")
+		assert.True(t, domainErrors.IsValidationError(err))
+	})
+
+	t.Run("異常系: 推定に失敗", func(t *testing.T) {
+		usecase := NewItemUsecase(new(MockItemRepository), WithCategorySuggester(stubCategorySuggester{err: errors.New("timeout")}))
+
+		_, err := usecase.SuggestCategory(context.Background(), "Submariner")
+		assert.EqualError(t, err, "failed to suggest category: timeout")
+	})
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 変更履歴の操作の種類（itemsのトリガーが記録する）
+type ChangeOperation string
+
+const (
+	ChangeCreated   ChangeOperation = "created"   // 登録
+	ChangeUpdated   ChangeOperation = "updated"   // 更新
+	ChangeDeleted   ChangeOperation = "deleted"   // 論理削除
+	ChangeUndeleted ChangeOperation = "undeleted" // 論理削除の取り消し（バックアップからの復元など）
+	ChangePurged    ChangeOperation = "purged"    // 物理削除
+)
+
+// 変更履歴（item_changes）の1件
+type ChangeLogEntry struct {
+	Seq       int64           `json:"seq"`
+	ItemID    int64           `json:"item_id"`
+	Operation ChangeOperation `json:"operation"`
+	ChangedAt time.Time       `json:"changed_at"`
+}
+
+// 変更履歴に残っている最も古いエントリーと最新のエントリーのシーケンス番号（空の場合はどちらも0）
+type ChangeLogRange struct {
+	Oldest int64
+	Latest int64
+	// これ以下のシーケンス番号の変更はすべてコミット済みとみなせる位置（記録からChangeLogSettleWindowが経過した最新のエントリー）
+	Settled int64
+}
+
+// 変更履歴のエントリーがコミット済みとみなせるまでの時間
+// シーケンス番号はトランザクションの途中で採番されるため、先に採番された書き込みが後からコミットされ、
+// 最新のシーケンス番号より前に現れることがある。最新の位置を返すと、その書き込みを次回の取得で取りこぼす
+const ChangeLogSettleWindow = 30 * time.Second
+
+// 変更履歴の1ページ分
+type ChangeLogPage struct {
+	Entries   []ChangeLogEntry `json:"entries"`
+	LatestSeq int64            `json:"latest_seq"` // 取得時点でコミット済みとみなせる最新のシーケンス番号
+	HasMore   bool             `json:"has_more"`   // 続きがある場合は最後のエントリーのseqを次回のsinceに指定する
+}
+
+// シーケンス番号sinceより後の変更履歴を最大limit件返す
+// 保持期間を過ぎて削除された変更履歴より前のsinceは、取りこぼしがあるためErrChangeLogExpiredにする
+// 最後のエントリーを次回のsinceにしても取りこぼさないよう、コミット済みとみなせる位置（Settled）より後のエントリーは返さない
+func (u *itemUsecase) GetChangeLog(ctx context.Context, since int64, limit int) (*ChangeLogPage, error) {
+	if since < 0 {
+		return nil, fmt.Errorf("%w: since must be 0 or greater", domainErrors.ErrInvalidInput)
+	}
+	if limit < 1 || limit > MaxPageLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", domainErrors.ErrInvalidInput, MaxPageLimit)
+	}
+
+	page := &ChangeLogPage{}
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		changeLog, err := u.itemRepo.GetChangeLogRange(ctx)
+		if err != nil {
+			return err
+		}
+		if since < changeLog.Oldest-1 {
+			return fmt.Errorf("%w: entries up to %d have been pruned", domainErrors.ErrChangeLogExpired, changeLog.Oldest-1)
+		}
+		if since > changeLog.Latest {
+			return fmt.Errorf("%w: since is newer than the latest change (%d)", domainErrors.ErrInvalidInput, changeLog.Latest)
+		}
+		page.LatestSeq = changeLog.Settled
+
+		// 1件多く取得して続きがあるかを判定する
+		entries, err := u.itemRepo.FindChangeLog(ctx, since, limit+1)
+		if err != nil {
+			return err
+		}
+		for i, entry := range entries {
+			if entry.Seq > changeLog.Settled {
+				entries = entries[:i]
+				break
+			}
+		}
+		if len(entries) > limit {
+			entries = entries[:limit]
+			page.HasMore = true
+		}
+		page.Entries = entries
+		return nil
+	})
+	if err != nil {
+		if domainErrors.IsValidationError(err) || domainErrors.IsChangeLogExpiredError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to retrieve change log: %w", err)
+	}
+
+	if page.Entries == nil {
+		page.Entries = []ChangeLogEntry{}
+	}
+	return page, nil
+}
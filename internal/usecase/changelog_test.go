@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_GetChangeLog(t *testing.T) {
+	changedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	entries := []ChangeLogEntry{
+		{Seq: 11, ItemID: 1, Operation: ChangeUpdated, ChangedAt: changedAt},
+		{Seq: 12, ItemID: 2, Operation: ChangeDeleted, ChangedAt: changedAt},
+		{Seq: 13, ItemID: 3, Operation: ChangeCreated, ChangedAt: changedAt},
+	}
+	changeLog := ChangeLogRange{Oldest: 5, Latest: 13, Settled: 13}
+
+	tests := []struct {
+		name            string
+		since           int64
+		limit           int
+		setupMock       func(*MockItemRepository)
+		expectedEntries int
+		expectedHasMore bool
+		expectedErr     error
+	}{
+		{
+			name:  "正常系: sinceより後の変更履歴",
+			since: 10,
+			limit: 10,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetChangeLogRange", mock.Anything).Return(changeLog, nil)
+				mockRepo.On("FindChangeLog", mock.Anything, int64(10), 11).Return(entries, nil)
+			},
+			expectedEntries: 3,
+		},
+		{
+			name:  "正常系: 続きがある",
+			since: 10,
+			limit: 2,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetChangeLogRange", mock.Anything).Return(changeLog, nil)
+				mockRepo.On("FindChangeLog", mock.Anything, int64(10), 3).Return(entries, nil)
+			},
+			expectedEntries: 2,
+			expectedHasMore: true,
+		},
+		{
+			name:  "正常系: 最新のsequenceの場合は空配列",
+			since: 13,
+			limit: 10,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetChangeLogRange", mock.Anything).Return(changeLog, nil)
+				mockRepo.On("FindChangeLog", mock.Anything, int64(13), 11).Return(([]ChangeLogEntry)(nil), nil)
+			},
+			expectedEntries: 0,
+		},
+		{
+			name:  "異常系: 保持期間を過ぎて削除された変更履歴",
+			since: 3,
+			limit: 10,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetChangeLogRange", mock.Anything).Return(changeLog, nil)
+			},
+			expectedErr: domainErrors.ErrChangeLogExpired,
+		},
+		{
+			name:  "異常系: 最新より新しいsince",
+			since: 14,
+			limit: 10,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetChangeLogRange", mock.Anything).Return(changeLog, nil)
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 範囲外のlimit",
+			since:       10,
+			limit:       MaxPageLimit + 1,
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: データベースエラー",
+			since: 10,
+			limit: 10,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetChangeLogRange", mock.Anything).Return(changeLog, nil)
+				mockRepo.On("FindChangeLog", mock.Anything, int64(10), 11).Return(([]ChangeLogEntry)(nil), domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			page, err := usecase.GetChangeLog(context.Background(), tt.since, tt.limit)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, page)
+				mockRepo.AssertExpectations(t)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, page.Entries, tt.expectedEntries)
+			assert.NotNil(t, page.Entries)
+			assert.Equal(t, tt.expectedHasMore, page.HasMore)
+			assert.Equal(t, int64(13), page.LatestSeq)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_GetChangeLog_UnsettledEntries(t *testing.T) {
+	changedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	entries := []ChangeLogEntry{
+		{Seq: 11, ItemID: 1, Operation: ChangeUpdated, ChangedAt: changedAt},
+		{Seq: 13, ItemID: 3, Operation: ChangeCreated, ChangedAt: changedAt},
+	}
+	// 13は記録されたばかりで、先に採番された12がまだコミットされていない可能性がある
+	changeLog := ChangeLogRange{Oldest: 5, Latest: 13, Settled: 11}
+
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("GetChangeLogRange", mock.Anything).Return(changeLog, nil)
+	mockRepo.On("FindChangeLog", mock.Anything, int64(10), 2).Return(entries, nil)
+	usecase := NewItemUsecase(mockRepo)
+
+	page, err := usecase.GetChangeLog(context.Background(), 10, 1)
+
+	require.NoError(t, err)
+	require.Len(t, page.Entries, 1)
+	assert.Equal(t, int64(11), page.Entries[0].Seq)
+	assert.False(t, page.HasMore)
+	assert.Equal(t, int64(11), page.LatestSeq)
+	mockRepo.AssertExpectations(t)
+}
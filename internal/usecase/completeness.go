@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 見直しが必要なアイテムと、欠けているフィールド
+type IncompleteItem struct {
+	Item          *entity.Item
+	MissingFields []entity.MissingField
+}
+
+// 見直しが必要なアイテムの判定で、画像が無いアイテムも対象にする（nilの場合は画像を判定しない）
+func WithCompletenessImages(imageRepo ItemImageRepository) Option {
+	return func(u *itemUsecase) {
+		u.completenessImages = imageRepo
+	}
+}
+
+// 完全性の基準（entity.Item.MissingFields）を満たさないアイテムを返す
+// 基準を1か所で管理するため、SQLでは絞り込まずに全件を判定する
+func (u *itemUsecase) GetIncompleteItems(ctx context.Context) ([]IncompleteItem, error) {
+	items, err := u.itemRepo.FindAll(ctx, ItemFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+	withImages, err := u.itemsWithImages(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+
+	incomplete := []IncompleteItem{}
+	for _, item := range items {
+		facts := entity.CompletenessFacts{CheckImages: u.completenessImages != nil, HasImage: withImages[item.ID]}
+		if missing := item.MissingFields(facts); len(missing) > 0 {
+			incomplete = append(incomplete, IncompleteItem{Item: item, MissingFields: missing})
+		}
+	}
+	return incomplete, nil
+}
+
+// 画像が1枚以上登録されているアイテムのIDを返す（画像を判定しない場合はnil）
+// アイテムごとに問い合わせないよう、先頭の画像をまとめて取得する
+func (u *itemUsecase) itemsWithImages(ctx context.Context, items []*entity.Item) (map[int64]bool, error) {
+	if u.completenessImages == nil {
+		return nil, nil
+	}
+	itemIDs := make([]int64, len(items))
+	for i, item := range items {
+		itemIDs[i] = item.ID
+	}
+
+	withImages := make(map[int64]bool, len(itemIDs))
+	for start := 0; start < len(itemIDs); start += coverImageBatchSize {
+		end := min(start+coverImageBatchSize, len(itemIDs))
+		images, err := u.completenessImages.FindCoversByItemIDs(ctx, itemIDs[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve images: %w", err)
+		}
+		for _, image := range images {
+			withImages[image.ItemID] = true
+		}
+	}
+	return withImages, nil
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// ダッシュボードの項目
+type DashboardSection string
+
+const (
+	DashboardSummary DashboardSection = "summary" // カテゴリー別集計（GET /items/summary と同じ）
+	DashboardStats   DashboardSection = "stats"   // 購入価格の集中度（GET /items/diversity と同じ）
+	DashboardRecent  DashboardSection = "recent"  // 最近登録したアイテム
+	DashboardTop     DashboardSection = "top"     // 購入価格の高いアイテム
+)
+
+// 指定できる項目（?include= を省略した場合はすべて）
+var DashboardSections = []DashboardSection{DashboardSummary, DashboardStats, DashboardRecent, DashboardTop}
+
+// recent・topのアイテム数の既定値と上限
+const (
+	DefaultDashboardItems = 5
+	MaxDashboardItems     = 20
+)
+
+// ダッシュボードの集計（指定しなかった項目はnil）
+type Dashboard struct {
+	Summary *CategorySummary
+	Stats   *Diversity
+	Recent  []*entity.Item
+	Top     []*entity.Item
+}
+
+// ?include= の値を項目の一覧にする（空の要素と重複は無視する、空の場合はすべての項目）
+func ParseDashboardSections(values []string) ([]DashboardSection, error) {
+	var sections []DashboardSection
+	seen := make(map[DashboardSection]bool)
+	for _, value := range values {
+		section := DashboardSection(strings.ToLower(strings.TrimSpace(value)))
+		if section == "" || seen[section] {
+			continue
+		}
+		if !section.IsValid() {
+			return nil, fmt.Errorf("%w: unknown dashboard section: %s (must be one of: summary, stats, recent, top)", domainErrors.ErrInvalidInput, section)
+		}
+		seen[section] = true
+		sections = append(sections, section)
+	}
+	if len(sections) == 0 {
+		return DashboardSections, nil
+	}
+	return sections, nil
+}
+
+func (s DashboardSection) IsValid() bool {
+	for _, section := range DashboardSections {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
+
+// 指定した項目を同じトランザクション（スナップショット）で集計し、項目の間で件数などが食い違わないようにする
+// 各項目は既存の集計と同じクエリを使い、アイテムを全件は読み込まない
+func (u *itemUsecase) GetDashboard(ctx context.Context, sections []DashboardSection, limit int) (*Dashboard, error) {
+	if limit < 1 || limit > MaxDashboardItems {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", domainErrors.ErrInvalidInput, MaxDashboardItems)
+	}
+	for _, section := range sections {
+		if !section.IsValid() {
+			return nil, fmt.Errorf("%w: unknown dashboard section: %s", domainErrors.ErrInvalidInput, section)
+		}
+	}
+
+	dashboard := &Dashboard{}
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		for _, section := range sections {
+			switch section {
+			case DashboardSummary:
+				dashboard.Summary, err = u.GetCategorySummary(ctx)
+			case DashboardStats:
+				dashboard.Stats, err = u.GetDiversity(ctx, ItemFilter{})
+			case DashboardRecent:
+				dashboard.Recent, err = u.itemRepo.FindPage(ctx, ItemFilter{Sort: SortCreatedAtDesc}, Page{Limit: limit})
+			case DashboardTop:
+				dashboard.Top, err = u.itemRepo.FindPage(ctx, ItemFilter{Sort: SortPurchasePriceDesc}, Page{Limit: limit})
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dashboard: %w", err)
+	}
+	return dashboard, nil
+}
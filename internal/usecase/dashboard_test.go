@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestParseDashboardSections(t *testing.T) {
+	tests := []struct {
+		name        string
+		values      []string
+		expected    []DashboardSection
+		expectedErr error
+	}{
+		{
+			name:     "正常系: 省略時はすべての項目",
+			values:   []string{""},
+			expected: DashboardSections,
+		},
+		{
+			name:     "正常系: 指定順で空白・大文字小文字・重複を無視",
+			values:   []string{"top", " Summary ", "", "top"},
+			expected: []DashboardSection{DashboardTop, DashboardSummary},
+		},
+		{
+			name:        "異常系: 不明な項目",
+			values:      []string{"summary", "users"},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sections, err := ParseDashboardSections(tt.values)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, sections)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, sections)
+		})
+	}
+}
+
+func TestItemUsecase_GetDashboard(t *testing.T) {
+	watch, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+	bag, _ := entity.NewItem("バッグ1", "バッグ", "HERMÈS", 500000, "2023-01-02")
+	totals := map[string]CategoryTotal{
+		"時計":  {Count: 1, PurchasePrice: entity.JPY(1000000)},
+		"バッグ": {Count: 1, PurchasePrice: entity.JPY(500000)},
+	}
+
+	tests := []struct {
+		name        string
+		sections    []DashboardSection
+		limit       int
+		setupMock   func(*MockItemRepository)
+		expectedErr error
+		check       func(*testing.T, *Dashboard)
+	}{
+		{
+			name:     "正常系: 集計と最近・高額のアイテム",
+			sections: []DashboardSection{DashboardSummary, DashboardRecent, DashboardTop},
+			limit:    5,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetSummaryByCategory", mock.Anything).Return(totals, nil)
+				mockRepo.On("FindPage", mock.Anything, ItemFilter{Sort: SortCreatedAtDesc}, Page{Limit: 5}).Return([]*entity.Item{bag, watch}, nil)
+				mockRepo.On("FindPage", mock.Anything, ItemFilter{Sort: SortPurchasePriceDesc}, Page{Limit: 5}).Return([]*entity.Item{watch, bag}, nil)
+			},
+			check: func(t *testing.T, dashboard *Dashboard) {
+				require.NotNil(t, dashboard.Summary)
+				assert.Equal(t, 2, dashboard.Summary.Total)
+				assert.Nil(t, dashboard.Stats)
+				assert.Equal(t, []*entity.Item{bag, watch}, dashboard.Recent)
+				assert.Equal(t, []*entity.Item{watch, bag}, dashboard.Top)
+			},
+		},
+		{
+			name:     "正常系: 指定した項目のみ",
+			sections: []DashboardSection{DashboardTop},
+			limit:    1,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindPage", mock.Anything, ItemFilter{Sort: SortPurchasePriceDesc}, Page{Limit: 1}).Return([]*entity.Item{watch}, nil)
+			},
+			check: func(t *testing.T, dashboard *Dashboard) {
+				assert.Nil(t, dashboard.Summary)
+				assert.Nil(t, dashboard.Recent)
+				assert.Equal(t, []*entity.Item{watch}, dashboard.Top)
+			},
+		},
+		{
+			name:        "異常系: 範囲外のlimit",
+			sections:    DashboardSections,
+			limit:       MaxDashboardItems + 1,
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 不明な項目",
+			sections:    []DashboardSection{"users"},
+			limit:       5,
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:     "異常系: データベースエラー",
+			sections: []DashboardSection{DashboardSummary, DashboardTop},
+			limit:    5,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetSummaryByCategory", mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			dashboard, err := usecase.GetDashboard(context.Background(), tt.sections, tt.limit)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, dashboard)
+				mockRepo.AssertExpectations(t)
+				return
+			}
+
+			require.NoError(t, err)
+			tt.check(t, dashboard)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 条件に一致するアイテムの一括削除の指定
+type DeleteByFilterInput struct {
+	// 誤操作を防ぐため、trueを指定した場合のみ削除する
+	Confirm bool
+	// 条件が空（すべてのアクティブなアイテムに一致する）の場合も削除する
+	DeleteAll bool
+	// 削除の理由（すべてのアイテムに同じ理由を記録する）
+	Reason string
+}
+
+// 絞り込む条件が無いか（並び順は絞り込まないため問わない）
+func (f ItemFilter) isEmpty() bool {
+	return len(f.Categories) == 0 && len(f.ExcludeCategories) == 0 &&
+		f.Brand == "" && f.CreatedSince == nil && f.Query == "" && f.SerialPrefix == "" &&
+		f.Status == "" && !f.AnyStatus && !f.Deleted && f.IDFrom == 0 && f.IDTo == 0 &&
+		f.MinPurchasePrice == nil && f.MaxPurchasePrice == nil &&
+		f.PurchasedFrom == "" && f.PurchasedTo == "" && f.PurchaseMonth == 0 && len(f.PurchaseWeekdays) == 0
+}
+
+// 条件に一致するアイテムを1トランザクションで論理削除し、削除した件数を返す
+// 条件の意味は一覧・検索と同じ（状態を指定しない場合はactiveのアイテムのみ）
+func (u *itemUsecase) DeleteItemsByFilter(ctx context.Context, filter ItemFilter, input DeleteByFilterInput) (int, error) {
+	if !input.Confirm {
+		return 0, fmt.Errorf("%w: confirm must be true to delete items by filter", domainErrors.ErrInvalidInput)
+	}
+	if filter.Deleted {
+		return 0, fmt.Errorf("%w: deleted items cannot be deleted again", domainErrors.ErrInvalidInput)
+	}
+	if filter.Fuzzy {
+		return 0, fmt.Errorf("%w: fuzzy search cannot be used to delete items", domainErrors.ErrInvalidInput)
+	}
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+	if filter.isEmpty() && !input.DeleteAll {
+		return 0, fmt.Errorf("%w: filter must not be empty (set delete_all to true to delete all items)", domainErrors.ErrInvalidInput)
+	}
+	if err := u.validateDeleteReason(input.Reason); err != nil {
+		return 0, err
+	}
+	// 行をロックする順序を揃えるため、IDの順に削除する
+	filter.Sort = SortIDAsc
+
+	var deleted []int64
+	defer func() {
+		for _, id := range deleted {
+			u.invalidateItem(id)
+		}
+	}()
+
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		deleted = deleted[:0]
+		items, err := u.itemRepo.FindAll(ctx, filter)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := u.itemRepo.Delete(ctx, item.ID, input.Reason); err != nil {
+				// 検索した後に他のリクエストが削除したアイテムは数えない
+				if domainErrors.IsNotFoundError(err) {
+					continue
+				}
+				return err
+			}
+			item.DeleteReason = input.Reason
+			if err := u.publisher.Publish(ctx, newItemEvent(EventItemDeleted, item)); err != nil {
+				return err
+			}
+			deleted = append(deleted, item.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete items: %w", err)
+	}
+
+	return len(deleted), nil
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_DeleteItemsByFilter(t *testing.T) {
+	rolexFilter := ItemFilter{Brand: "ROLEX", Sort: SortIDAsc}
+
+	tests := []struct {
+		name          string
+		filter        ItemFilter
+		input         DeleteByFilterInput
+		setupMock     func(*MockItemRepository)
+		expectedCount int
+		expectedErr   error
+	}{
+		{
+			name:   "正常系: 一致したアイテムをすべて削除",
+			filter: ItemFilter{Brand: "ROLEX"},
+			input:  DeleteByFilterInput{Confirm: true, Reason: "売却"},
+			setupMock: func(m *MockItemRepository) {
+				m.On("FindAll", mock.Anything, rolexFilter).
+					Return([]*entity.Item{cacheTestItem(1, "デイトナ"), cacheTestItem(2, "サブマリーナー")}, nil)
+				m.On("Delete", mock.Anything, int64(1), "売却").Return(nil)
+				m.On("Delete", mock.Anything, int64(2), "売却").Return(nil)
+			},
+			expectedCount: 2,
+		},
+		{
+			name:   "正常系: 検索した後に削除されたアイテムは数えない",
+			filter: ItemFilter{Brand: "ROLEX"},
+			input:  DeleteByFilterInput{Confirm: true},
+			setupMock: func(m *MockItemRepository) {
+				m.On("FindAll", mock.Anything, rolexFilter).
+					Return([]*entity.Item{cacheTestItem(1, "デイトナ"), cacheTestItem(2, "サブマリーナー")}, nil)
+				m.On("Delete", mock.Anything, int64(1), "").Return(domainErrors.ErrItemNotFound)
+				m.On("Delete", mock.Anything, int64(2), "").Return(nil)
+			},
+			expectedCount: 1,
+		},
+		{
+			name:   "正常系: delete_allを指定した場合は条件が空でも削除",
+			filter: ItemFilter{},
+			input:  DeleteByFilterInput{Confirm: true, DeleteAll: true},
+			setupMock: func(m *MockItemRepository) {
+				m.On("FindAll", mock.Anything, ItemFilter{Sort: SortIDAsc}).Return([]*entity.Item{cacheTestItem(1, "デイトナ")}, nil)
+				m.On("Delete", mock.Anything, int64(1), "").Return(nil)
+			},
+			expectedCount: 1,
+		},
+		{
+			name:        "異常系: confirmが無い",
+			filter:      ItemFilter{Brand: "ROLEX"},
+			input:       DeleteByFilterInput{},
+			setupMock:   func(m *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 条件が空でdelete_allが無い",
+			filter:      ItemFilter{Sort: SortPurchasePriceDesc},
+			input:       DeleteByFilterInput{Confirm: true},
+			setupMock:   func(m *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: ゴミ箱のアイテム",
+			filter:      ItemFilter{Deleted: true},
+			input:       DeleteByFilterInput{Confirm: true, DeleteAll: true},
+			setupMock:   func(m *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 無効な条件",
+			filter:      ItemFilter{Categories: []entity.Category{"家電"}},
+			input:       DeleteByFilterInput{Confirm: true},
+			setupMock:   func(m *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:   "異常系: 削除に失敗した場合はエラーを返す",
+			filter: ItemFilter{Brand: "ROLEX"},
+			input:  DeleteByFilterInput{Confirm: true},
+			setupMock: func(m *MockItemRepository) {
+				m.On("FindAll", mock.Anything, rolexFilter).
+					Return([]*entity.Item{cacheTestItem(1, "デイトナ"), cacheTestItem(2, "サブマリーナー")}, nil)
+				m.On("Delete", mock.Anything, int64(1), "").Return(nil)
+				m.On("Delete", mock.Anything, int64(2), "").Return(errors.New("connection refused"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			count, err := usecase.DeleteItemsByFilter(context.Background(), tt.filter, tt.input)
+
+			switch {
+			case tt.expectedErr != nil:
+				assert.ErrorIs(t, err, tt.expectedErr)
+				mockRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything)
+			case tt.expectedCount == 0:
+				assert.Error(t, err)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedCount, count)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
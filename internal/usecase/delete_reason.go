@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 削除の理由として指定できる値を制限する（空の場合はMaxDeleteReasonLength以内の自由記述）
+func WithDeleteReasons(reasons []string) Option {
+	return func(u *itemUsecase) {
+		u.deleteReasons = reasons
+	}
+}
+
+// 削除の理由を検証する（空の場合は理由なし）
+func (u *itemUsecase) validateDeleteReason(reason string) error {
+	if reason == "" {
+		return nil
+	}
+	if len(u.deleteReasons) > 0 {
+		if !slices.Contains(u.deleteReasons, reason) {
+			return fmt.Errorf("%w: reason must be one of: %s", domainErrors.ErrInvalidInput, strings.Join(u.deleteReasons, ", "))
+		}
+		return nil
+	}
+	if utf8.RuneCountInString(reason) > entity.MaxDeleteReasonLength {
+		return fmt.Errorf("%w: reason must be %d characters or less", domainErrors.ErrInvalidInput, entity.MaxDeleteReasonLength)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 定額法による減価償却の見込み
+type DepreciationSchedule struct {
+	ItemID             int64                      `json:"item_id"`
+	PurchasePrice      entity.Money               `json:"purchase_price"`
+	PurchaseDate       string                     `json:"purchase_date"`
+	SalvageValue       entity.Money               `json:"salvage_value"`
+	Years              int                        `json:"years"`
+	AnnualDepreciation entity.Money               `json:"annual_depreciation"` // 最終年度以外の毎年の償却額
+	Schedule           []entity.DepreciationEntry `json:"schedule"`
+}
+
+func (u *itemUsecase) GetDepreciationSchedule(ctx context.Context, id int64, years int, salvage int64) (*DepreciationSchedule, error) {
+	item, err := u.cachedItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	salvageValue := entity.NewMoney(salvage, item.PurchasePrice.Currency)
+	schedule, err := item.StraightLineDepreciation(years, salvageValue)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	return &DepreciationSchedule{
+		ItemID:             item.ID,
+		PurchasePrice:      item.PurchasePrice,
+		PurchaseDate:       item.PurchaseDate,
+		SalvageValue:       salvageValue,
+		Years:              years,
+		AnnualDepreciation: schedule[0].Depreciation,
+		Schedule:           schedule,
+	}, nil
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// EXPLAIN対象のクエリ名
+const (
+	ExplainQueryList    = "list"
+	ExplainQueryItem    = "item"
+	ExplainQuerySummary = "summary"
+)
+
+// 開発用のクエリ診断ユースケース
+type DiagnosticsUsecase interface {
+	ExplainQuery(ctx context.Context, input ExplainInput) (json.RawMessage, error)
+}
+
+type ExplainInput struct {
+	Query  string     // list / item / summary
+	Filter ItemFilter // listの場合の絞り込み条件
+	ID     int64      // itemの場合のID
+}
+
+type diagnosticsUsecase struct {
+	explainer QueryExplainer
+}
+
+func NewDiagnosticsUsecase(explainer QueryExplainer) DiagnosticsUsecase {
+	return &diagnosticsUsecase{
+		explainer: explainer,
+	}
+}
+
+func (u *diagnosticsUsecase) ExplainQuery(ctx context.Context, input ExplainInput) (json.RawMessage, error) {
+	var (
+		plan json.RawMessage
+		err  error
+	)
+
+	switch input.Query {
+	case ExplainQueryList:
+		if err := input.Filter.Validate(); err != nil {
+			return nil, err
+		}
+		plan, err = u.explainer.ExplainFindAll(ctx, input.Filter)
+	case ExplainQueryItem:
+		if input.ID <= 0 {
+			return nil, fmt.Errorf("%w: id is required for item query", domainErrors.ErrInvalidInput)
+		}
+		plan, err = u.explainer.ExplainFindByID(ctx, input.ID)
+	case ExplainQuerySummary:
+		plan, err = u.explainer.ExplainSummaryByCategory(ctx)
+	default:
+		return nil, fmt.Errorf("%w: q must be one of: list, item, summary", domainErrors.ErrInvalidInput)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to explain query: %w", err)
+	}
+
+	return plan, nil
+}
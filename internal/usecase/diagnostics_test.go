@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 呼び出されたクエリ名を記録し、固定の実行計画を返すQueryExplainer
+type fakeQueryExplainer struct {
+	called []string
+}
+
+func (f *fakeQueryExplainer) ExplainFindAll(ctx context.Context, filter ItemFilter) (json.RawMessage, error) {
+	f.called = append(f.called, ExplainQueryList)
+	return json.RawMessage(`{"query_block":{"select_id":1}}`), nil
+}
+
+func (f *fakeQueryExplainer) ExplainFindByID(ctx context.Context, id int64) (json.RawMessage, error) {
+	f.called = append(f.called, ExplainQueryItem)
+	return json.RawMessage(`{"query_block":{"select_id":1}}`), nil
+}
+
+func (f *fakeQueryExplainer) ExplainSummaryByCategory(ctx context.Context) (json.RawMessage, error) {
+	f.called = append(f.called, ExplainQuerySummary)
+	return json.RawMessage(`{"query_block":{"select_id":1}}`), nil
+}
+
+func TestDiagnosticsUsecase_ExplainQuery(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          ExplainInput
+		expectedCalled []string
+		wantErr        bool
+	}{
+		{name: "正常系: 一覧", input: ExplainInput{Query: ExplainQueryList, Filter: ItemFilter{Brand: "ROLEX"}}, expectedCalled: []string{ExplainQueryList}},
+		{name: "正常系: 1件の取得", input: ExplainInput{Query: ExplainQueryItem, ID: 1}, expectedCalled: []string{ExplainQueryItem}},
+		{name: "正常系: カテゴリー別集計", input: ExplainInput{Query: ExplainQuerySummary}, expectedCalled: []string{ExplainQuerySummary}},
+		{name: "異常系: 不明なクエリ名", input: ExplainInput{Query: "delete"}, wantErr: true},
+		{name: "異常系: クエリ名が無い", input: ExplainInput{}, wantErr: true},
+		{name: "異常系: 1件の取得でIDが無い", input: ExplainInput{Query: ExplainQueryItem}, wantErr: true},
+		{name: "異常系: 一覧の絞り込み条件が不正", input: ExplainInput{Query: ExplainQueryList, Filter: ItemFilter{Fuzzy: true}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			explainer := &fakeQueryExplainer{}
+			usecase := NewDiagnosticsUsecase(explainer)
+
+			plan, err := usecase.ExplainQuery(context.Background(), tt.input)
+
+			if tt.wantErr {
+				assert.True(t, domainErrors.IsValidationError(err), "unexpected error: %v", err)
+				assert.Empty(t, explainer.called)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"query_block":{"select_id":1}}`, string(plan))
+			assert.Equal(t, tt.expectedCalled, explainer.called)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// コレクションの多様性（購入価格の合計がどれだけ偏っているか）
+type Diversity struct {
+	Count              int                    `json:"count"`
+	TotalPurchasePrice entity.Money           `json:"total_purchase_price"`
+	TopItem            *DiversityTopItem      `json:"top_item"` // アイテムが無い場合はnull
+	Brands             DiversityConcentration `json:"brands"`
+	Categories         DiversityConcentration `json:"categories"`
+}
+
+// 購入価格が最も高いアイテムと、合計に占める割合
+type DiversityTopItem struct {
+	ID    int64   `json:"id"`
+	Name  string  `json:"name"`
+	Share float64 `json:"share"`
+}
+
+// ブランド・カテゴリーなどの値ごとの購入価格の集中度
+// 割合はいずれも0〜1（合計が0の場合は0）で、小数点以下4桁に丸める
+type DiversityConcentration struct {
+	Distinct int     `json:"distinct"`  // 値の種類の数
+	Top      string  `json:"top"`       // 購入価格の合計が最も大きい値（同額の場合は件数の多い値）
+	TopShare float64 `json:"top_share"` // Topの合計が全体に占める割合
+	HHI      float64 `json:"hhi"`       // ハーフィンダール・ハーシュマン指数（1に近いほど一つの値に集中）
+	Gini     float64 `json:"gini"`      // 値ごとの合計のジニ係数（0は均等、1に近いほど偏っている）
+}
+
+// 多様性の割合・指数を丸める小数点以下の桁数
+const diversityDecimals = 4
+
+// 絞り込み条件に一致するアイテムの多様性を、ブランド別・カテゴリー別の集計から計算する
+// アイテムを全件読み込まず、集計2回と最も高いアイテム1件の取得で求める
+func (u *itemUsecase) GetDiversity(ctx context.Context, filter ItemFilter) (*Diversity, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	brands, err := u.itemRepo.GetSummaryByGroup(ctx, filter, GroupByBrand)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get brand summary: %w", err)
+	}
+	categories, err := u.itemRepo.GetSummaryByGroup(ctx, filter, GroupByCategory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category summary: %w", err)
+	}
+
+	diversity := &Diversity{TotalPurchasePrice: entity.JPY(0)}
+	for _, entry := range brands {
+		diversity.Count += entry.Count
+		diversity.TotalPurchasePrice.Amount += entry.TotalPurchasePrice.Amount
+	}
+	diversity.Brands = concentrationOf(brands, diversity.TotalPurchasePrice.Amount)
+	diversity.Categories = concentrationOf(categories, diversity.TotalPurchasePrice.Amount)
+
+	if diversity.Count > 0 {
+		filter.Sort = SortPurchasePriceDesc
+		items, err := u.itemRepo.FindPage(ctx, filter, Page{Limit: 1})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get top item: %w", err)
+		}
+		if len(items) > 0 {
+			diversity.TopItem = &DiversityTopItem{
+				ID:    items[0].ID,
+				Name:  items[0].Name,
+				Share: shareOf(items[0].PurchasePrice.Amount, diversity.TotalPurchasePrice.Amount),
+			}
+		}
+	}
+	return diversity, nil
+}
+
+// 値ごとの集計から集中度を計算する
+func concentrationOf(entries []GroupSummaryEntry, sum int64) DiversityConcentration {
+	concentration := DiversityConcentration{Distinct: len(entries)}
+	totals := make([]int64, len(entries))
+	// 集計は件数の多い順のため、同額の場合は先の（件数の多い）値をTopにする
+	var top int64 = -1
+	for i, entry := range entries {
+		totals[i] = entry.TotalPurchasePrice.Amount
+		if totals[i] > top {
+			top = totals[i]
+			concentration.Top = entry.Value
+		}
+	}
+	concentration.TopShare = shareOf(top, sum)
+	concentration.HHI = herfindahlIndex(totals)
+	concentration.Gini = giniCoefficient(totals)
+	return concentration
+}
+
+// partがtotalに占める割合（totalが0以下の場合は0）
+func shareOf(part, total int64) float64 {
+	if total <= 0 || part <= 0 {
+		return 0
+	}
+	return roundDiversity(float64(part) / float64(total))
+}
+
+// ハーフィンダール・ハーシュマン指数: 各値の割合 s_i の二乗の和 Σ s_i²
+// 1つの値に集中すると1、n個の値に均等に分かれると1/nになる（1/HHIが実質的な値の種類の数）
+func herfindahlIndex(totals []int64) float64 {
+	sum := sumAmounts(totals)
+	if sum <= 0 {
+		return 0
+	}
+	hhi := 0.0
+	for _, total := range totals {
+		s := float64(total) / float64(sum)
+		hhi += s * s
+	}
+	return roundDiversity(hhi)
+}
+
+// ジニ係数: 値 x_i を昇順に並べたとき G = Σ (2i - n - 1) x_i / (n Σ x_i)（iは1始まり）
+// 全て同額なら0、1つの値に集中するほど (n-1)/n に近づく（値が1種類の場合は0）
+func giniCoefficient(totals []int64) float64 {
+	n := len(totals)
+	sum := sumAmounts(totals)
+	if n == 0 || sum <= 0 {
+		return 0
+	}
+	sorted := append([]int64(nil), totals...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	weighted := 0.0
+	for i, total := range sorted {
+		weighted += float64(2*(i+1)-n-1) * float64(total)
+	}
+	return roundDiversity(weighted / (float64(n) * float64(sum)))
+}
+
+func sumAmounts(totals []int64) int64 {
+	var sum int64
+	for _, total := range totals {
+		sum += total
+	}
+	return sum
+}
+
+func roundDiversity(value float64) float64 {
+	scale := math.Pow10(diversityDecimals)
+	return math.Round(value*scale) / scale
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// 購読者の数が上限に達している
+var ErrTooManySubscribers = errors.New("too many subscribers")
+
+// 購読者ごとに溜めておけるイベント数（受信が追いつかない場合は購読を終了する）
+const subscriberBufferSize = 64
+
+// アイテムの変更イベントを購読するユースケース
+type ItemEventSubscriber interface {
+	// 購読を開始し、イベントを受け取るchannelと購読を終了する関数を返す
+	// channelは購読の終了時（終了の関数の呼び出し、受信の遅れ、停止時）に閉じる
+	Subscribe() (<-chan ItemEvent, func(), error)
+}
+
+// outboxから配信されたイベントを、同じプロセスの購読者（GET /items/stream）へ配る
+// EventSenderとしてEventRelayに渡すため、イベントの内容と順序はWebhookと同じになる
+type EventBroadcaster struct {
+	mu             sync.Mutex
+	subscribers    map[chan ItemEvent]struct{}
+	maxSubscribers int // 0の場合は無制限
+	closed         bool
+}
+
+func NewEventBroadcaster(maxSubscribers int) *EventBroadcaster {
+	return &EventBroadcaster{
+		subscribers:    make(map[chan ItemEvent]struct{}),
+		maxSubscribers: maxSubscribers,
+	}
+}
+
+func (b *EventBroadcaster) Subscribe() (<-chan ItemEvent, func(), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, nil, errors.New("event broadcaster is closed")
+	}
+	if b.maxSubscribers > 0 && len(b.subscribers) >= b.maxSubscribers {
+		return nil, nil, ErrTooManySubscribers
+	}
+
+	ch := make(chan ItemEvent, subscriberBufferSize)
+	b.subscribers[ch] = struct{}{}
+	return ch, func() { b.unsubscribe(ch) }, nil
+}
+
+// 購読者へ配る（待たずに返すため、失敗することはない）
+// 受信が追いついていない購読者は、イベントを取りこぼしたまま続けないよう購読を終了する
+func (b *EventBroadcaster) Send(ctx context.Context, event ItemEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+	return nil
+}
+
+// すべての購読を終了し、以降の購読を受け付けない（サーバーの停止時に接続を閉じるため）
+func (b *EventBroadcaster) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for ch := range b.subscribers {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
+
+func (b *EventBroadcaster) unsubscribe(ch chan ItemEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[ch]; ok {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventBroadcaster_Send(t *testing.T) {
+	broadcaster := NewEventBroadcaster(0)
+	first, unsubscribeFirst, err := broadcaster.Subscribe()
+	require.NoError(t, err)
+	defer unsubscribeFirst()
+	second, unsubscribeSecond, err := broadcaster.Subscribe()
+	require.NoError(t, err)
+
+	event := ItemEvent{Type: EventItemUpdated, ItemID: 1}
+	require.NoError(t, broadcaster.Send(context.Background(), event))
+
+	assert.Equal(t, event, <-first)
+	assert.Equal(t, event, <-second)
+
+	// 購読を終了したchannelは閉じられ、以降は配信されない
+	unsubscribeSecond()
+	_, ok := <-second
+	assert.False(t, ok)
+	unsubscribeSecond()
+	require.NoError(t, broadcaster.Send(context.Background(), event))
+	assert.Equal(t, event, <-first)
+}
+
+func TestEventBroadcaster_SlowSubscriber(t *testing.T) {
+	broadcaster := NewEventBroadcaster(0)
+	events, unsubscribe, err := broadcaster.Subscribe()
+	require.NoError(t, err)
+	defer unsubscribe()
+
+	for i := 0; i <= subscriberBufferSize; i++ {
+		require.NoError(t, broadcaster.Send(context.Background(), ItemEvent{Type: EventItemCreated, ItemID: int64(i)}))
+	}
+
+	// 溜められる分を受信した後、channelが閉じられる
+	received := 0
+	for range events {
+		received++
+	}
+	assert.Equal(t, subscriberBufferSize, received)
+}
+
+func TestEventBroadcaster_MaxSubscribers(t *testing.T) {
+	broadcaster := NewEventBroadcaster(1)
+	_, unsubscribe, err := broadcaster.Subscribe()
+	require.NoError(t, err)
+
+	_, _, err = broadcaster.Subscribe()
+	assert.ErrorIs(t, err, ErrTooManySubscribers)
+
+	unsubscribe()
+	_, _, err = broadcaster.Subscribe()
+	assert.NoError(t, err)
+}
+
+func TestEventBroadcaster_Close(t *testing.T) {
+	broadcaster := NewEventBroadcaster(0)
+	events, unsubscribe, err := broadcaster.Subscribe()
+	require.NoError(t, err)
+
+	broadcaster.Close()
+
+	_, ok := <-events
+	assert.False(t, ok)
+	unsubscribe()
+	_, _, err = broadcaster.Subscribe()
+	assert.Error(t, err)
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// アイテムのライフサイクルイベントの種類
+type EventType string
+
+const (
+	EventItemCreated EventType = "item.created"
+	EventItemUpdated EventType = "item.updated"
+	EventItemDeleted EventType = "item.deleted"
+)
+
+// アイテムの変更を外部システムへ通知するイベント
+type ItemEvent struct {
+	Type       EventType    `json:"event"`
+	ItemID     int64        `json:"item_id"`
+	Item       *entity.Item `json:"item,omitempty"` // 削除イベントでは削除前のアイテム
+	OccurredAt time.Time    `json:"occurred_at"`
+}
+
+// EventPublisher records item events. It is called inside the same transaction as the mutation,
+// so returning an error rolls the mutation back.
+type EventPublisher interface {
+	Publish(ctx context.Context, event ItemEvent) error
+}
+
+// Transactor runs fn in a transaction. Repository calls made with the ctx passed to fn join it.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// 通知先が設定されていない場合に使う何もしないPublisher
+type noopEventPublisher struct{}
+
+func (noopEventPublisher) Publish(context.Context, ItemEvent) error { return nil }
+
+// トランザクションを使わない場合のTransactor
+type noopTransactor struct{}
+
+func (noopTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// ItemUsecaseの生成オプション
+type Option func(*itemUsecase)
+
+// アイテムの変更イベントの記録先を設定する
+func WithEventPublisher(publisher EventPublisher) Option {
+	return func(u *itemUsecase) {
+		u.publisher = publisher
+	}
+}
+
+// 変更とイベントの記録を同一トランザクションで行うためのTransactorを設定する
+func WithTransactor(transactor Transactor) Option {
+	return func(u *itemUsecase) {
+		u.transactor = transactor
+	}
+}
+
+func newItemEvent(eventType EventType, item *entity.Item) ItemEvent {
+	return ItemEvent{
+		Type:       eventType,
+		ItemID:     item.ID,
+		Item:       item,
+		OccurredAt: entity.Now().UTC(),
+	}
+}
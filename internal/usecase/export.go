@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// アイテムをJSONとして書き出すユースケース（自動バックアップで使う）
+type ItemExporter interface {
+	// 論理削除されていないすべてのアイテム（状態を問わない）をID順のJSONの配列として書き出し、件数を返す
+	// 出力は POST /items/import?format=json でそのまま読み込める
+	ExportItems(ctx context.Context, w io.Writer) (int, error)
+}
+
+type itemExporter struct {
+	itemRepo ItemRepository
+}
+
+func NewItemExporter(itemRepo ItemRepository) ItemExporter {
+	return &itemExporter{itemRepo: itemRepo}
+}
+
+func (e *itemExporter) ExportItems(ctx context.Context, w io.Writer) (int, error) {
+	// 状態を指定しない一覧はactiveのみのため、状態ごとに取得する
+	var items []*entity.Item
+	for _, status := range entity.ValidStatuses {
+		found, err := e.itemRepo.FindAll(ctx, ItemFilter{Status: status})
+		if err != nil {
+			return 0, fmt.Errorf("failed to retrieve %s items: %w", status, err)
+		}
+		items = append(items, found...)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+
+	if items == nil {
+		items = []*entity.Item{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(items); err != nil {
+		return 0, fmt.Errorf("failed to write items: %w", err)
+	}
+	return len(items), nil
+}
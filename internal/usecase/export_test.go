@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemExporter_ExportItems(t *testing.T) {
+	draft := &entity.Item{ID: 3, Name: "下書き", Status: entity.StatusDraft, PurchasePrice: entity.JPY(100)}
+	active := &entity.Item{ID: 1, Name: "デイトナ", Status: entity.StatusActive, PurchasePrice: entity.JPY(1500000)}
+	archived := &entity.Item{ID: 2, Name: "バーキン", Status: entity.StatusArchived, PurchasePrice: entity.JPY(2000000)}
+
+	t.Run("正常系: すべての状態のアイテムをID順に書き出す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, ItemFilter{Status: entity.StatusDraft}).Return([]*entity.Item{draft}, nil)
+		mockRepo.On("FindAll", mock.Anything, ItemFilter{Status: entity.StatusActive}).Return([]*entity.Item{active}, nil)
+		mockRepo.On("FindAll", mock.Anything, ItemFilter{Status: entity.StatusArchived}).Return([]*entity.Item{archived}, nil)
+
+		var buf bytes.Buffer
+		count, err := NewItemExporter(mockRepo).ExportItems(context.Background(), &buf)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, count)
+		var exported []entity.Item
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &exported))
+		assert.Len(t, exported, 3)
+		assert.Equal(t, []int64{1, 2, 3}, []int64{exported[0].ID, exported[1].ID, exported[2].ID})
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: アイテムが無い場合は空の配列", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{}, nil)
+
+		var buf bytes.Buffer
+		count, err := NewItemExporter(mockRepo).ExportItems(context.Background(), &buf)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count)
+		assert.Equal(t, "[]\n", buf.String())
+	})
+
+	t.Run("異常系: データベースエラー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, mock.Anything).Return(([]*entity.Item)(nil), domainErrors.ErrDatabaseError)
+
+		var buf bytes.Buffer
+		_, err := NewItemExporter(mockRepo).ExportItems(context.Background(), &buf)
+
+		assert.Error(t, err)
+		assert.True(t, domainErrors.IsDatabaseError(err))
+		assert.Empty(t, buf.String())
+	})
+}
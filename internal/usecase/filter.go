@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// ItemFilter はアイテム一覧の絞り込み条件（ゼロ値は条件なし）
+type ItemFilter struct {
+	Categories        []entity.Category // いずれかのカテゴリーに一致するアイテム
+	ExcludeCategories []entity.Category // いずれのカテゴリーにも一致しないアイテム
+	Brand             string            // 完全一致（大文字・小文字は区別しない）
+	CreatedSince      *time.Time        // created_atがこの日時以降のアイテム
+	Query             string            // 名前またはブランドの部分一致（大文字・小文字は区別しない）
+	SerialPrefix      string            // シリアル番号の前方一致（大文字・小文字は区別しない）
+	Fuzzy             bool              // Queryを表記ゆれ・入力ミスを許容して検索し、類似度順に並べる
+	Status            entity.Status     // 空の場合はactiveのアイテムのみ（Deletedの場合はすべての状態）
+	AnyStatus         bool              // Statusが空の場合もすべての状態のアイテムを対象にする（状態ごとの集計など）
+	Deleted           bool              // 論理削除済みのアイテムのみ（ゴミ箱）
+	IDFrom            int64             // IDがこの値以上のアイテム（0の場合は絞り込まない、IDToと同時に指定する）
+	IDTo              int64             // IDがこの値以下のアイテム（0の場合は絞り込まない）
+	MinPurchasePrice  *int64            // 購入価格がこの値以上のアイテム
+	MaxPurchasePrice  *int64            // 購入価格がこの値以下のアイテム
+	PurchasedFrom     string            // 購入日がこの日以降のアイテム（YYYY-MM-DD）
+	PurchasedTo       string            // 購入日がこの日以前のアイテム（YYYY-MM-DD）
+	PurchaseMonth     int               // 購入日の月（1〜12、年は問わない、0の場合は絞り込まない）
+	PurchaseWeekdays  []time.Weekday    // 購入日がいずれかの曜日のアイテム
+	Sort              SortOrder         // 並び順（空の場合はWithDefaultSortで指定した順、未指定の場合は登録の新しい順）
+}
+
+// 検索語の最大文字数
+const MaxQueryLength = 100
+
+// IDの範囲（id_from〜id_to）に指定できる件数の上限
+const MaxIDRangeSpan = 10000
+
+// シリアル番号の前方一致に指定できる文字数（短すぎる値でほぼ全件を読み込まないよう下限を設ける）
+const (
+	MinSerialPrefixLength = 3
+	MaxSerialPrefixLength = 100
+)
+
+// 一覧の並び順
+type SortOrder string
+
+const (
+	SortIDAsc             SortOrder = "id"              // IDの小さい順
+	SortIDDesc            SortOrder = "-id"             // IDの大きい順
+	SortCreatedAtAsc      SortOrder = "created_at"      // 登録の古い順
+	SortCreatedAtDesc     SortOrder = "-created_at"     // 登録の新しい順
+	SortPurchaseDateAsc   SortOrder = "purchase_date"   // 購入日の古い順
+	SortPurchaseDateDesc  SortOrder = "-purchase_date"  // 購入日の新しい順
+	SortPurchasePriceAsc  SortOrder = "purchase_price"  // 購入価格の安い順
+	SortPurchasePriceDesc SortOrder = "-purchase_price" // 購入価格の高い順
+	SortSerialNumberAsc   SortOrder = "serial_number"   // シリアル番号の昇順
+	SortSerialNumberDesc  SortOrder = "-serial_number"  // シリアル番号の降順
+	SortDeletedAtAsc      SortOrder = "deleted_at"      // 削除の古い順
+	SortDeletedAtDesc     SortOrder = "-deleted_at"     // 削除の新しい順
+)
+
+// 一覧の既定の並び順として指定できるか
+// deleted_atはゴミ箱のみ、serial_numberは未設定のアイテムが多いため指定できない
+func (s SortOrder) IsValidDefault() bool {
+	switch s {
+	case SortIDAsc, SortIDDesc, SortCreatedAtAsc, SortCreatedAtDesc, SortPurchaseDateAsc, SortPurchaseDateDesc:
+		return true
+	}
+	return false
+}
+
+// ページ単位の一覧で指定できるoffsetの上限を設定する（0の場合は無制限）
+// offsetが大きいとMySQLは読み飛ばす行もすべて読み込むため、深いページの取得を拒否してDBを保護する
+func WithMaxPageOffset(max int) Option {
+	return func(u *itemUsecase) {
+		u.maxPageOffset = max
+	}
+}
+
+// offsetが上限を超えていないか
+func (u *itemUsecase) validatePageDepth(page Page) error {
+	if u.maxPageOffset > 0 && page.Offset > u.maxPageOffset {
+		return fmt.Errorf("%w: offset must be %d or less; narrow the results with filters or use GET /items/changes to sync all items", domainErrors.ErrInvalidInput, u.maxPageOffset)
+	}
+	return nil
+}
+
+// 検索語（q）で絞り込んだ一覧で返す最大件数を設定する（0の場合は無制限）
+// よくある語では大量のアイテムに一致するため、上限より後は返さずに条件の絞り込みを促す
+func WithMaxSearchResults(max int) Option {
+	return func(u *itemUsecase) {
+		u.maxSearchResults = max
+	}
+}
+
+// 一覧に適用する検索の上限（検索語が無い場合と、件数が元々少ないあいまい検索は0）
+func (u *itemUsecase) searchWindow(filter ItemFilter) int {
+	if filter.Query == "" || filter.Fuzzy {
+		return 0
+	}
+	return u.maxSearchResults
+}
+
+// 上限（0の場合は無制限）までに収まるよう切り詰めたページ（offsetが上限以上の場合は取得しない）
+func (p Page) within(window int) (Page, bool) {
+	if window <= 0 {
+		return p, true
+	}
+	if p.Offset >= window {
+		return p, false
+	}
+	p.Limit = min(p.Limit, window-p.Offset)
+	return p, true
+}
+
+// ?sort= を指定しない一覧の並び順を設定する
+func WithDefaultSort(sort SortOrder) Option {
+	return func(u *itemUsecase) {
+		u.defaultSort = sort
+	}
+}
+
+// 並び順が指定されていない場合は既定の並び順にする（シリアル番号で絞り込む場合はシリアル番号順）
+func (u *itemUsecase) withDefaultSort(filter ItemFilter) ItemFilter {
+	if filter.Sort == "" && filter.SerialPrefix != "" {
+		filter.Sort = SortSerialNumberAsc
+	}
+	if filter.Sort == "" {
+		filter.Sort = u.defaultSort
+	}
+	return filter
+}
+
+// 絞り込み条件のバリデーション
+func (f ItemFilter) Validate() error {
+	for _, categories := range [][]entity.Category{f.Categories, f.ExcludeCategories} {
+		for _, category := range categories {
+			if !category.IsValid() {
+				return fmt.Errorf("%w: unknown category: %s", domainErrors.ErrInvalidInput, category)
+			}
+		}
+	}
+	if f.Status != "" && !f.Status.IsValid() {
+		return fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, entity.ErrInvalidStatus.Error())
+	}
+	if utf8.RuneCountInString(f.Query) > MaxQueryLength {
+		return fmt.Errorf("%w: q must be %d characters or less", domainErrors.ErrInvalidInput, MaxQueryLength)
+	}
+	if f.Fuzzy && f.Query == "" {
+		return fmt.Errorf("%w: fuzzy requires q", domainErrors.ErrInvalidInput)
+	}
+	if f.SerialPrefix != "" {
+		if length := utf8.RuneCountInString(f.SerialPrefix); length < MinSerialPrefixLength || length > MaxSerialPrefixLength {
+			return fmt.Errorf("%w: serial_prefix must be between %d and %d characters", domainErrors.ErrInvalidInput, MinSerialPrefixLength, MaxSerialPrefixLength)
+		}
+	}
+	if f.IDFrom != 0 || f.IDTo != 0 {
+		if f.IDFrom <= 0 || f.IDTo <= 0 {
+			return fmt.Errorf("%w: id_from and id_to must be specified together as positive integers", domainErrors.ErrInvalidInput)
+		}
+		if f.IDFrom > f.IDTo {
+			return fmt.Errorf("%w: id_from must be less than or equal to id_to", domainErrors.ErrInvalidInput)
+		}
+		if f.IDTo-f.IDFrom >= MaxIDRangeSpan {
+			return fmt.Errorf("%w: id range must span %d ids or fewer", domainErrors.ErrInvalidInput, MaxIDRangeSpan)
+		}
+	}
+	if (f.MinPurchasePrice != nil && *f.MinPurchasePrice < 0) || (f.MaxPurchasePrice != nil && *f.MaxPurchasePrice < 0) {
+		return fmt.Errorf("%w: purchase price range must not be negative", domainErrors.ErrInvalidInput)
+	}
+	if f.MinPurchasePrice != nil && f.MaxPurchasePrice != nil && *f.MinPurchasePrice > *f.MaxPurchasePrice {
+		return fmt.Errorf("%w: min_purchase_price must be less than or equal to max_purchase_price", domainErrors.ErrInvalidInput)
+	}
+	for _, date := range []string{f.PurchasedFrom, f.PurchasedTo} {
+		if _, err := time.Parse("2006-01-02", date); date != "" && err != nil {
+			return fmt.Errorf("%w: purchase date range must be in YYYY-MM-DD format", domainErrors.ErrInvalidInput)
+		}
+	}
+	// YYYY-MM-DDのため文字列の順序が日付の順序になる
+	if f.PurchasedFrom != "" && f.PurchasedTo != "" && f.PurchasedFrom > f.PurchasedTo {
+		return fmt.Errorf("%w: purchase_date_from must be on or before purchase_date_to", domainErrors.ErrInvalidInput)
+	}
+	if f.PurchaseMonth < 0 || f.PurchaseMonth > 12 {
+		return fmt.Errorf("%w: month must be between 1 and 12", domainErrors.ErrInvalidInput)
+	}
+	for _, weekday := range f.PurchaseWeekdays {
+		if weekday < time.Sunday || weekday > time.Saturday {
+			return fmt.Errorf("%w: unknown weekday: %d", domainErrors.ErrInvalidInput, weekday)
+		}
+	}
+	switch f.Sort {
+	case "", SortIDAsc, SortIDDesc, SortCreatedAtAsc, SortCreatedAtDesc, SortPurchaseDateAsc, SortPurchaseDateDesc,
+		SortPurchasePriceAsc, SortPurchasePriceDesc, SortSerialNumberAsc, SortSerialNumberDesc:
+	case SortDeletedAtAsc, SortDeletedAtDesc:
+		if !f.Deleted {
+			return fmt.Errorf("%w: sort by deleted_at is only available for deleted items", domainErrors.ErrInvalidInput)
+		}
+	default:
+		return fmt.Errorf("%w: unknown sort: %s", domainErrors.ErrInvalidInput, f.Sort)
+	}
+	return nil
+}
+
+// 1ページあたりの件数のデフォルト値と上限
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// 一覧のページ指定
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+// ページ指定のバリデーション
+func (p Page) Validate() error {
+	if p.Limit < 1 || p.Limit > MaxPageLimit {
+		return fmt.Errorf("%w: limit must be between 1 and %d", domainErrors.ErrInvalidInput, MaxPageLimit)
+	}
+	if p.Offset < 0 {
+		return fmt.Errorf("%w: offset must be 0 or greater", domainErrors.ErrInvalidInput)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 集計に使うカラム（SQLに埋め込むため、この許可リストに無い値は受け付けない）
+type GroupBy string
+
+const (
+	GroupByCategory GroupBy = "category"
+	GroupByBrand    GroupBy = "brand"
+	GroupByStatus   GroupBy = "status"
+)
+
+// 集計に使えるカラム
+var ValidGroupBys = []GroupBy{GroupByCategory, GroupByBrand, GroupByStatus}
+
+// 許可リストに含まれるかどうか
+func (g GroupBy) IsValid() bool {
+	for _, valid := range ValidGroupBys {
+		if g == valid {
+			return true
+		}
+	}
+	return false
+}
+
+func invalidGroupByError(value string) error {
+	names := make([]string, len(ValidGroupBys))
+	for i, groupBy := range ValidGroupBys {
+		names[i] = string(groupBy)
+	}
+	return fmt.Errorf("%w: unknown by: %s (must be one of: %s)", domainErrors.ErrInvalidInput, value, strings.Join(names, ", "))
+}
+
+// カラムの値ごとのアイテム数と購入価格の合計・平均
+type GroupSummaryEntry struct {
+	Value                string       `json:"value"`
+	Count                int          `json:"count"`
+	TotalPurchasePrice   entity.Money `json:"total_purchase_price"`
+	AveragePurchasePrice float64      `json:"average_purchase_price"` // WithAverageDecimalsの桁数に丸めた平均
+}
+
+// 絞り込み条件に一致するアイテムを指定したカラムの値ごとに集計し、件数の多い順に返す
+// 状態で集計する場合、statusを指定しなければ下書き・アーカイブを含むすべての状態を対象にする
+func (u *itemUsecase) GetGroupSummary(ctx context.Context, filter ItemFilter, by GroupBy) ([]GroupSummaryEntry, error) {
+	if !by.IsValid() {
+		return nil, invalidGroupByError(string(by))
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	if by == GroupByStatus && filter.Status == "" {
+		filter.AnyStatus = true
+	}
+
+	entries, err := u.itemRepo.GetSummaryByGroup(ctx, filter, by)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s summary: %w", by, err)
+	}
+	if entries == nil {
+		entries = []GroupSummaryEntry{}
+	}
+	for i := range entries {
+		entries[i].AveragePurchasePrice = averagePrice(entries[i].TotalPurchasePrice, entries[i].Count, u.averageDecimals)
+	}
+	return entries, nil
+}
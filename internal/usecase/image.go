@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"log/slog"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// アイテムの画像を管理するユースケース
+type ItemImageUsecase interface {
+	// 画像を末尾に追加する
+	AddImage(ctx context.Context, itemID int64, data []byte) (*entity.ItemImage, error)
+	// 画像を表示順に返す（画像の本体は含まない）
+	ListImages(ctx context.Context, itemID int64) ([]*entity.ItemImage, error)
+	// 複数のアイテムの最初の画像をまとめて返す（アイテムID → 画像、画像の無いアイテムは含まない）
+	ListCoverImages(ctx context.Context, itemIDs []int64) (map[int64]*entity.ItemImage, error)
+	// 画像の本体を含めて返す
+	GetImage(ctx context.Context, itemID, imageID int64) (*entity.ItemImage, error)
+	DeleteImage(ctx context.Context, itemID, imageID int64) error
+}
+
+// 画像の登録に関する設定
+type ItemImageSettings struct {
+	// 1つのアイテムに登録できる画像の数（0以下の場合は無制限）
+	MaxImagesPerItem int
+	// 縮小画像の長辺の最大ピクセル数（0以下の場合は縮小画像を作らない）
+	ThumbnailMaxDimension int
+	// 縮小画像の作成にかける最大時間（超えた場合は縮小画像なしで登録する、0以下の場合は無制限）
+	ThumbnailTimeout time.Duration
+}
+
+type itemImageUsecase struct {
+	itemRepo   ItemRepository
+	imageRepo  ItemImageRepository
+	transactor Transactor
+	settings   ItemImageSettings
+}
+
+func NewItemImageUsecase(itemRepo ItemRepository, imageRepo ItemImageRepository, transactor Transactor, settings ItemImageSettings) ItemImageUsecase {
+	if transactor == nil {
+		transactor = noopTransactor{}
+	}
+	return &itemImageUsecase{
+		itemRepo:   itemRepo,
+		imageRepo:  imageRepo,
+		transactor: transactor,
+		settings:   settings,
+	}
+}
+
+func (u *itemImageUsecase) AddImage(ctx context.Context, itemID int64, data []byte) (*entity.ItemImage, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	image, err := entity.NewItemImage(itemID, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+	// 壊れた画像は保存する前に拒否する
+	decoded, err := decodeImage(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+	image.Thumbnail = createThumbnail(ctx, decoded, u.settings)
+
+	// 上限の確認と表示順の決定を、登録と同じトランザクションで行う
+	var created *entity.ItemImage
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.ensureItemExists(ctx, itemID); err != nil {
+			return err
+		}
+
+		existing, err := u.imageRepo.FindByItemID(ctx, itemID)
+		if err != nil {
+			return err
+		}
+		if u.settings.MaxImagesPerItem > 0 && len(existing) >= u.settings.MaxImagesPerItem {
+			return fmt.Errorf("%w: item can have at most %d images", domainErrors.ErrTooManyImages, u.settings.MaxImagesPerItem)
+		}
+
+		image.Position = 1
+		if len(existing) > 0 {
+			image.Position = existing[len(existing)-1].Position + 1
+		}
+
+		created, err = u.imageRepo.Create(ctx, image)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add image: %w", err)
+	}
+
+	return created, nil
+}
+
+func (u *itemImageUsecase) ListImages(ctx context.Context, itemID int64) ([]*entity.ItemImage, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+	if err := u.ensureItemExists(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	images, err := u.imageRepo.FindByItemID(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve images: %w", err)
+	}
+	if images == nil {
+		images = []*entity.ItemImage{}
+	}
+	return images, nil
+}
+
+// 1回のクエリで指定するアイテムIDの上限（プレースホルダーの数を抑える）
+const coverImageBatchSize = 1000
+
+func (u *itemImageUsecase) ListCoverImages(ctx context.Context, itemIDs []int64) (map[int64]*entity.ItemImage, error) {
+	covers := make(map[int64]*entity.ItemImage, len(itemIDs))
+	for start := 0; start < len(itemIDs); start += coverImageBatchSize {
+		end := min(start+coverImageBatchSize, len(itemIDs))
+		images, err := u.imageRepo.FindCoversByItemIDs(ctx, itemIDs[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve images: %w", err)
+		}
+		for _, image := range images {
+			covers[image.ItemID] = image
+		}
+	}
+	return covers, nil
+}
+
+func (u *itemImageUsecase) GetImage(ctx context.Context, itemID, imageID int64) (*entity.ItemImage, error) {
+	if itemID <= 0 || imageID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+	if err := u.ensureItemExists(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	image, err := u.imageRepo.FindByID(ctx, itemID, imageID)
+	if err != nil {
+		if domainErrors.IsImageNotFoundError(err) {
+			return nil, domainErrors.ErrImageNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve image: %w", err)
+	}
+	return image, nil
+}
+
+func (u *itemImageUsecase) DeleteImage(ctx context.Context, itemID, imageID int64) error {
+	if itemID <= 0 || imageID <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+	if err := u.ensureItemExists(ctx, itemID); err != nil {
+		return err
+	}
+
+	if err := u.imageRepo.Delete(ctx, itemID, imageID); err != nil {
+		if domainErrors.IsImageNotFoundError(err) {
+			return domainErrors.ErrImageNotFound
+		}
+		return fmt.Errorf("failed to delete image: %w", err)
+	}
+	return nil
+}
+
+// 縮小画像を作成する（失敗・タイムアウトした場合はnilとし、取得時は元の画像を返す）
+func createThumbnail(ctx context.Context, decoded image.Image, settings ItemImageSettings) []byte {
+	if settings.ThumbnailTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, settings.ThumbnailTimeout)
+		defer cancel()
+	}
+
+	thumbnail, err := generateThumbnail(ctx, decoded, settings.ThumbnailMaxDimension)
+	if err != nil {
+		slog.Warn("failed to generate thumbnail", "error", err)
+		return nil
+	}
+	return thumbnail
+}
+
+// 削除済みのアイテムの画像は扱わない
+func (u *itemImageUsecase) ensureItemExists(ctx context.Context, itemID int64) error {
+	if _, err := u.itemRepo.FindByID(ctx, itemID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrItemNotFound
+		}
+		return fmt.Errorf("failed to retrieve item: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,328 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockItemImageRepository はtestify/mockを使用した画像のモックリポジトリ
+type MockItemImageRepository struct {
+	mock.Mock
+}
+
+func (m *MockItemImageRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemImage, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageRepository) FindCoversByItemIDs(ctx context.Context, itemIDs []int64) ([]*entity.ItemImage, error) {
+	args := m.Called(ctx, itemIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageRepository) FindByID(ctx context.Context, itemID, imageID int64) (*entity.ItemImage, error) {
+	args := m.Called(ctx, itemID, imageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageRepository) Create(ctx context.Context, image *entity.ItemImage) (*entity.ItemImage, error) {
+	args := m.Called(ctx, image)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageRepository) FindBatchAfter(ctx context.Context, afterID int64, limit int) ([]*entity.ItemImage, error) {
+	args := m.Called(ctx, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageRepository) UpdateThumbnail(ctx context.Context, imageID int64, thumbnail []byte) error {
+	args := m.Called(ctx, imageID, thumbnail)
+	return args.Error(0)
+}
+
+func (m *MockItemImageRepository) Delete(ctx context.Context, itemID, imageID int64) error {
+	args := m.Called(ctx, itemID, imageID)
+	return args.Error(0)
+}
+
+// テスト用の単色のPNG
+func encodeTestPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.RGBA{R: 200, G: 30, B: 30, A: 255}), image.Point{}, draw.Src)
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestItemImageUsecase_AddImage(t *testing.T) {
+	item := &entity.Item{ID: 1, Name: "ロレックス デイトナ"}
+	testPNG := encodeTestPNG(t, 8, 8)
+	corruptPNG := testPNG[:len(testPNG)/2]
+
+	tests := []struct {
+		name             string
+		data             []byte
+		maxImages        int
+		setupMock        func(*MockItemRepository, *MockItemImageRepository)
+		expectedPosition int
+		expectedError    error
+	}{
+		{
+			name:      "正常系: 最初の画像",
+			data:      testPNG,
+			maxImages: 2,
+			setupMock: func(itemRepo *MockItemRepository, imageRepo *MockItemImageRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				imageRepo.On("FindByItemID", mock.Anything, int64(1)).Return(([]*entity.ItemImage)(nil), nil)
+				imageRepo.On("Create", mock.Anything, mock.MatchedBy(func(image *entity.ItemImage) bool {
+					return image.Position == 1 && image.ContentType == "image/png" && image.Size == len(testPNG)
+				})).Return(&entity.ItemImage{ID: 10, ItemID: 1, Position: 1}, nil)
+			},
+			expectedPosition: 1,
+		},
+		{
+			name:      "正常系: 末尾に追加",
+			data:      testPNG,
+			maxImages: 2,
+			setupMock: func(itemRepo *MockItemRepository, imageRepo *MockItemImageRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				imageRepo.On("FindByItemID", mock.Anything, int64(1)).Return([]*entity.ItemImage{{ID: 10, Position: 3}}, nil)
+				imageRepo.On("Create", mock.Anything, mock.MatchedBy(func(image *entity.ItemImage) bool {
+					return image.Position == 4
+				})).Return(&entity.ItemImage{ID: 11, ItemID: 1, Position: 4}, nil)
+			},
+			expectedPosition: 4,
+		},
+		{
+			name:      "異常系: 枚数の上限",
+			data:      testPNG,
+			maxImages: 2,
+			setupMock: func(itemRepo *MockItemRepository, imageRepo *MockItemImageRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				imageRepo.On("FindByItemID", mock.Anything, int64(1)).Return([]*entity.ItemImage{{ID: 10, Position: 1}, {ID: 11, Position: 2}}, nil)
+			},
+			expectedError: domainErrors.ErrTooManyImages,
+		},
+		{
+			name:          "異常系: 画像ではないデータ",
+			data:          []byte("not an image"),
+			maxImages:     2,
+			setupMock:     func(itemRepo *MockItemRepository, imageRepo *MockItemImageRepository) {},
+			expectedError: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:          "異常系: 壊れた画像は保存しない",
+			data:          corruptPNG,
+			maxImages:     2,
+			setupMock:     func(itemRepo *MockItemRepository, imageRepo *MockItemImageRepository) {},
+			expectedError: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:      "異常系: 存在しないアイテム",
+			data:      testPNG,
+			maxImages: 2,
+			setupMock: func(itemRepo *MockItemRepository, imageRepo *MockItemImageRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedError: domainErrors.ErrItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			imageRepo := new(MockItemImageRepository)
+			tt.setupMock(itemRepo, imageRepo)
+			usecase := NewItemImageUsecase(itemRepo, imageRepo, nil, ItemImageSettings{MaxImagesPerItem: tt.maxImages})
+
+			image, err := usecase.AddImage(context.Background(), 1, tt.data)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, image)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedPosition, image.Position)
+			}
+			itemRepo.AssertExpectations(t)
+			imageRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemImageUsecase_DeleteImage(t *testing.T) {
+	itemRepo := new(MockItemRepository)
+	imageRepo := new(MockItemImageRepository)
+	itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+	imageRepo.On("Delete", mock.Anything, int64(1), int64(99)).Return(domainErrors.ErrImageNotFound)
+	usecase := NewItemImageUsecase(itemRepo, imageRepo, nil, ItemImageSettings{})
+
+	err := usecase.DeleteImage(context.Background(), 1, 99)
+
+	assert.ErrorIs(t, err, domainErrors.ErrImageNotFound)
+	itemRepo.AssertExpectations(t)
+	imageRepo.AssertExpectations(t)
+}
+
+func TestItemImageUsecase_AddImage_Thumbnail(t *testing.T) {
+	tests := []struct {
+		name           string
+		width, height  int
+		settings       ItemImageSettings
+		expectedWidth  int
+		expectedHeight int
+	}{
+		{
+			name:           "正常系: 横長の画像は幅を上限に縮小",
+			width:          400,
+			height:         200,
+			settings:       ItemImageSettings{ThumbnailMaxDimension: 100},
+			expectedWidth:  100,
+			expectedHeight: 50,
+		},
+		{
+			name:           "正常系: 縦長の画像は高さを上限に縮小",
+			width:          150,
+			height:         300,
+			settings:       ItemImageSettings{ThumbnailMaxDimension: 100, ThumbnailTimeout: time.Minute},
+			expectedWidth:  50,
+			expectedHeight: 100,
+		},
+		{
+			name:     "正常系: 上限より小さい画像は縮小画像を作らない",
+			width:    80,
+			height:   60,
+			settings: ItemImageSettings{ThumbnailMaxDimension: 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			imageRepo := new(MockItemImageRepository)
+			itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+			imageRepo.On("FindByItemID", mock.Anything, int64(1)).Return(([]*entity.ItemImage)(nil), nil)
+			var stored *entity.ItemImage
+			imageRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.ItemImage")).
+				Run(func(args mock.Arguments) { stored = args.Get(1).(*entity.ItemImage) }).
+				Return(&entity.ItemImage{ID: 10, ItemID: 1, Position: 1}, nil)
+			usecase := NewItemImageUsecase(itemRepo, imageRepo, nil, tt.settings)
+
+			_, err := usecase.AddImage(context.Background(), 1, encodeTestPNG(t, tt.width, tt.height))
+
+			require.NoError(t, err)
+			require.NotNil(t, stored)
+			if tt.expectedWidth == 0 {
+				assert.Nil(t, stored.Thumbnail)
+				return
+			}
+			thumbnail, err := jpeg.Decode(bytes.NewReader(stored.Thumbnail))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedWidth, thumbnail.Bounds().Dx())
+			assert.Equal(t, tt.expectedHeight, thumbnail.Bounds().Dy())
+		})
+	}
+}
+
+func TestGenerateThumbnail_Timeout(t *testing.T) {
+	img, err := decodeImage(encodeTestPNG(t, 400, 400))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	thumbnail, err := generateThumbnail(ctx, img, 100)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, thumbnail)
+}
+
+func TestItemImageUsecase_ListCoverImages(t *testing.T) {
+	t.Run("正常系: アイテムごとの最初の画像", func(t *testing.T) {
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindCoversByItemIDs", mock.Anything, []int64{1, 2, 3}).
+			Return([]*entity.ItemImage{{ID: 7, ItemID: 1}, {ID: 9, ItemID: 3}}, nil)
+		usecase := NewItemImageUsecase(new(MockItemRepository), imageRepo, nil, ItemImageSettings{})
+
+		covers, err := usecase.ListCoverImages(context.Background(), []int64{1, 2, 3})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[int64]*entity.ItemImage{1: {ID: 7, ItemID: 1}, 3: {ID: 9, ItemID: 3}}, covers)
+		imageRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 上限を超えるアイテムは分けて取得する", func(t *testing.T) {
+		ids := make([]int64, coverImageBatchSize+1)
+		for i := range ids {
+			ids[i] = int64(i + 1)
+		}
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindCoversByItemIDs", mock.Anything, ids[:coverImageBatchSize]).Return([]*entity.ItemImage{}, nil).Once()
+		imageRepo.On("FindCoversByItemIDs", mock.Anything, ids[coverImageBatchSize:]).Return([]*entity.ItemImage{{ID: 1, ItemID: ids[coverImageBatchSize]}}, nil).Once()
+		usecase := NewItemImageUsecase(new(MockItemRepository), imageRepo, nil, ItemImageSettings{})
+
+		covers, err := usecase.ListCoverImages(context.Background(), ids)
+
+		require.NoError(t, err)
+		assert.Len(t, covers, 1)
+		imageRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: データベースエラー", func(t *testing.T) {
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindCoversByItemIDs", mock.Anything, []int64{1}).Return(nil, domainErrors.ErrDatabaseError)
+		usecase := NewItemImageUsecase(new(MockItemRepository), imageRepo, nil, ItemImageSettings{})
+
+		_, err := usecase.ListCoverImages(context.Background(), []int64{1})
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+	})
+}
+
+func TestGenerateThumbnail_TransparentOnWhite(t *testing.T) {
+	// 左半分は透明、右半分は不透明の赤
+	img := image.NewNRGBA(image.Rect(0, 0, 400, 200))
+	draw.Draw(img, image.Rect(200, 0, 400, 200), image.NewUniform(color.NRGBA{R: 200, G: 30, B: 30, A: 255}), image.Point{}, draw.Src)
+
+	data, err := generateThumbnail(context.Background(), img, 100)
+	require.NoError(t, err)
+	thumbnail, err := jpeg.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	r, g, b, _ := thumbnail.At(10, 25).RGBA()
+	assert.Greater(t, r>>8, uint32(240))
+	assert.Greater(t, g>>8, uint32(240))
+	assert.Greater(t, b>>8, uint32(240))
+	r, g, _, _ = thumbnail.At(90, 25).RGBA()
+	assert.Greater(t, r>>8, uint32(180))
+	assert.Less(t, g>>8, uint32(60))
+}
@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 一括登録のモード
+type ImportMode string
+
+const (
+	// 全行が有効な場合のみ登録する（1行でもエラーがあれば何も登録しない）
+	ImportModeStrict ImportMode = "strict"
+	// 検証のみ行い、登録はしない
+	ImportModeDryRun ImportMode = "dry_run"
+	// 有効な行のみ登録し、エラーのある行はスキップする
+	ImportModeLenient ImportMode = "lenient"
+)
+
+// 有効なモードかどうか
+func (m ImportMode) IsValid() bool {
+	switch m {
+	case ImportModeStrict, ImportModeDryRun, ImportModeLenient:
+		return true
+	}
+	return false
+}
+
+// 一括登録の1行分の入力（値はCSVなどから読み込んだ文字列のまま）
+type ImportRow struct {
+	Line          int
+	Name          string
+	Category      string
+	Brand         string
+	PurchasePrice string
+	PurchaseDate  string
+	SerialNumber  string
+}
+
+// 行ごとのエラー
+type ImportError struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// 一括登録の結果
+type ImportResult struct {
+	Mode      ImportMode `json:"mode"`
+	TotalRows int        `json:"total_rows"`
+	Imported  int        `json:"imported"`
+	Skipped   int        `json:"skipped"`
+	Committed bool       `json:"committed"`
+	// カテゴリーが空のためブランドから補った行数と、デフォルトのカテゴリーを使った行数
+	InferredCategoryRows int           `json:"inferred_category_rows"`
+	DefaultCategoryRows  int           `json:"default_category_rows"`
+	Errors               []ImportError `json:"errors"`
+	// 仮の値と思われる購入価格の行（IMPORT_SUSPICIOUS_PRICE_ACTION がrejectの場合はerrorsにも含める）
+	FlaggedRows []ImportError `json:"flagged_rows"`
+}
+
+// 一括登録でカテゴリーが空の行に使うカテゴリーを設定する（通常の登録APIでは引き続き必須）
+func WithImportDefaultCategory(category entity.Category) Option {
+	return func(u *itemUsecase) {
+		u.importDefaultCategory = category
+	}
+}
+
+// 全行を検証してエラーをまとめて返し、モードに応じて登録する
+func (u *itemUsecase) ImportItems(ctx context.Context, rows []ImportRow, mode ImportMode) (*ImportResult, error) {
+	if !mode.IsValid() {
+		return nil, fmt.Errorf("%w: mode must be one of: strict, dry_run, lenient", domainErrors.ErrInvalidInput)
+	}
+
+	result := &ImportResult{
+		Mode:        mode,
+		TotalRows:   len(rows),
+		Errors:      []ImportError{},
+		FlaggedRows: []ImportError{},
+	}
+
+	// 先に全行を検証する
+	items := make([]*entity.Item, len(rows))
+	invalidRows := 0
+	serialLines := make(map[string]int)
+	for index, row := range rows {
+		row.Name = u.sanitize(row.Name)
+		row.Brand = u.brandCase.Apply(u.sanitize(row.Brand))
+		row.SerialNumber = u.sanitize(row.SerialNumber)
+		if category, inferred := u.inferCategory(row.Brand, entity.Category(row.Category)); inferred {
+			row.Category = category.String()
+			result.InferredCategoryRows++
+		}
+		if strings.TrimSpace(row.Category) == "" && u.importDefaultCategory != "" {
+			row.Category = u.importDefaultCategory.String()
+			result.DefaultCategoryRows++
+		}
+
+		item, errs := buildImportItem(row)
+		if len(errs) == 0 {
+			if message, flagged := u.importPriceCheck.flag(item.PurchasePrice.Amount); flagged {
+				flag := ImportError{Line: row.Line, Field: "purchase_price", Message: message}
+				result.FlaggedRows = append(result.FlaggedRows, flag)
+				if u.importPriceCheck.action == SuspiciousPriceReject {
+					errs = append(errs, flag)
+				} else {
+					item.Status = entity.StatusDraft
+				}
+			}
+		}
+		if item != nil && item.SerialNumber != "" {
+			if firstLine, exists := serialLines[item.SerialNumber]; exists {
+				errs = append(errs, ImportError{
+					Line:    row.Line,
+					Field:   "serial_number",
+					Message: fmt.Sprintf("serial_number is duplicated with line %d", firstLine),
+				})
+			} else {
+				serialLines[item.SerialNumber] = row.Line
+			}
+		}
+
+		if len(errs) > 0 {
+			invalidRows++
+			result.Errors = append(result.Errors, errs...)
+			continue
+		}
+		u.assignItemUUID(item)
+		items[index] = item
+	}
+
+	if result.InferredCategoryRows > 0 {
+		slog.Info("import rows used the category inferred from brand",
+			"count", result.InferredCategoryRows,
+			"mode", string(mode),
+		)
+	}
+	if result.DefaultCategoryRows > 0 {
+		slog.Info("import rows used the default category",
+			"count", result.DefaultCategoryRows,
+			"category", u.importDefaultCategory.String(),
+			"mode", string(mode),
+		)
+	}
+
+	if len(result.FlaggedRows) > 0 {
+		slog.Info("import rows had a suspicious purchase price",
+			"count", len(result.FlaggedRows),
+			"action", string(u.importPriceCheck.action),
+			"mode", string(mode),
+		)
+	}
+
+	// dry_runではエラーのある行数のみを返す
+	if mode == ImportModeDryRun {
+		result.Skipped = invalidRows
+		return result, nil
+	}
+	if mode == ImportModeStrict && invalidRows > 0 {
+		result.Skipped = len(rows)
+		return result, nil
+	}
+
+	// 検証済みの行を1トランザクションで登録する
+	imported := 0
+	var dbErrors []ImportError
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		for index, item := range items {
+			if item == nil {
+				continue
+			}
+
+			created, err := u.itemRepo.Create(ctx, item)
+			if err != nil {
+				if !domainErrors.IsDuplicateEntryError(err) {
+					return err
+				}
+				importErr := ImportError{Line: rows[index].Line, Field: "serial_number", Message: "serial_number already exists"}
+				// 違反した制約が分かる場合は、そのフィールドとメッセージを返す
+				var dupErr *domainErrors.DuplicateEntryError
+				if errors.As(err, &dupErr) {
+					importErr.Field, importErr.Message = dupErr.Field, dupErr.Message
+				}
+				dbErrors = append(dbErrors, importErr)
+				// strictでは1件でも登録できなければ全体をロールバックする
+				if mode == ImportModeStrict {
+					return errImportRejected
+				}
+				continue
+			}
+
+			if err := u.publisher.Publish(ctx, newItemEvent(EventItemCreated, created)); err != nil {
+				return err
+			}
+			imported++
+		}
+		return nil
+	})
+	result.Errors = append(result.Errors, dbErrors...)
+
+	if errors.Is(err, errImportRejected) {
+		result.Skipped = len(rows)
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to import items: %w", err)
+	}
+
+	result.Imported = imported
+	result.Skipped = len(rows) - imported
+	result.Committed = true
+	return result, nil
+}
+
+// strictモードで登録中にエラーがあった場合にロールバックさせるためのエラー
+var errImportRejected = errors.New("import rejected")
+
+// 1行分の入力からエンティティを組み立て、フィールドごとのエラーを返す
+func buildImportItem(row ImportRow) (*entity.Item, []ImportError) {
+	var errs []ImportError
+
+	var price int64
+	priceStr := strings.TrimSpace(row.PurchasePrice)
+	if priceStr == "" {
+		errs = append(errs, ImportError{Line: row.Line, Field: "purchase_price", Message: "purchase_price is required"})
+	} else if parsed, err := entity.ParsePrice(priceStr); err != nil {
+		// 表計算ソフトから出力した "¥1,000,000" のような値も受け付ける
+		errs = append(errs, ImportError{Line: row.Line, Field: "purchase_price", Message: fmt.Sprintf("purchase_price must be an integer: %q", priceStr)})
+	} else {
+		price = parsed
+	}
+
+	// 表計算ソフトで書式が変わった "2023/1/15" のような購入日も受け付ける
+	// 解釈できない場合はそのまま渡し、形式のエラーとして返す
+	purchaseDate := strings.TrimSpace(row.PurchaseDate)
+	if normalized, err := entity.ParsePurchaseDate(purchaseDate); err == nil {
+		purchaseDate = normalized
+	}
+
+	item := &entity.Item{
+		Name:          strings.TrimSpace(row.Name),
+		Category:      entity.Category(row.Category).Normalize(),
+		Brand:         strings.TrimSpace(row.Brand),
+		PurchasePrice: entity.JPY(price),
+		PurchaseDate:  purchaseDate,
+		SerialNumber:  strings.TrimSpace(row.SerialNumber),
+	}
+	for _, fieldErr := range item.FieldErrors() {
+		errs = append(errs, ImportError{Line: row.Line, Field: fieldErr.Field, Message: fieldErr.Message})
+	}
+
+	return item, errs
+}
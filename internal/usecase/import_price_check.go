@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 一括登録で、仮の値と思われる購入価格（0や下限未満）の行の扱い
+type SuspiciousPriceAction string
+
+const (
+	// 確認しない（デフォルト）
+	SuspiciousPriceIgnore SuspiciousPriceAction = ""
+	// 行のエラーとして扱う（strictでは何も登録せず、lenientではその行をスキップする）
+	SuspiciousPriceReject SuspiciousPriceAction = "reject"
+	// draftの状態で登録し、後で見直せるようにする
+	SuspiciousPriceDraft SuspiciousPriceAction = "draft"
+)
+
+// IMPORT_SUSPICIOUS_PRICE_ACTION の値を検証する（空の場合は確認しない）
+func ParseSuspiciousPriceAction(value string) (SuspiciousPriceAction, error) {
+	action := SuspiciousPriceAction(strings.ToLower(strings.TrimSpace(value)))
+	switch action {
+	case SuspiciousPriceIgnore, SuspiciousPriceReject, SuspiciousPriceDraft:
+		return action, nil
+	}
+	return "", fmt.Errorf("%s (must be one of: reject, draft)", value)
+}
+
+// 一括登録の購入価格の確認
+type importPriceCheck struct {
+	action SuspiciousPriceAction
+	// これより安い購入価格を仮の値とみなす（0ちょうどは下限によらず仮の値とみなす）
+	floor int64
+}
+
+// 一括登録で、0や下限未満の購入価格の行をactionに応じて扱う（通常の登録APIには適用しない）
+func WithImportPriceCheck(action SuspiciousPriceAction, floor int64) Option {
+	return func(u *itemUsecase) {
+		u.importPriceCheck = importPriceCheck{action: action, floor: floor}
+	}
+}
+
+// 仮の値と思われる購入価格の場合に、行に付けるメッセージを返す
+func (c importPriceCheck) flag(price int64) (string, bool) {
+	if c.action == SuspiciousPriceIgnore || (price != 0 && price >= c.floor) {
+		return "", false
+	}
+	if c.floor > 1 {
+		return fmt.Sprintf("purchase_price looks like a placeholder: %d (must be at least %d)", price, c.floor), true
+	}
+	return fmt.Sprintf("purchase_price looks like a placeholder: %d", price), true
+}
@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_ImportItems(t *testing.T) {
+	validRow := func(line int, serial string) ImportRow {
+		return ImportRow{Line: line, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: "1500000", PurchaseDate: "2023-01-15", SerialNumber: serial}
+	}
+	invalidRow := ImportRow{Line: 3, Name: "", Category: "衣服", Brand: "ROLEX", PurchasePrice: "abc", PurchaseDate: "2023-01-15"}
+	created := &entity.Item{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15"}
+
+	tests := []struct {
+		name              string
+		rows              []ImportRow
+		mode              ImportMode
+		setupMock         func(*MockItemRepository)
+		expectedImported  int
+		expectedSkipped   int
+		expectedCommitted bool
+		expectedErrors    []ImportError
+	}{
+		{
+			name: "正常系: strictで全行有効なら全件登録",
+			rows: []ImportRow{validRow(2, ""), validRow(3, "")},
+			mode: ImportModeStrict,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(created, nil).Times(2)
+			},
+			expectedImported:  2,
+			expectedCommitted: true,
+			expectedErrors:    []ImportError{},
+		},
+		{
+			name: "正常系: 3桁区切りや通貨記号付きの金額",
+			rows: []ImportRow{
+				{Line: 2, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: "¥1,500,000", PurchaseDate: "2023-01-15"},
+			},
+			mode: ImportModeStrict,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.PurchasePrice == entity.JPY(1500000) })).Return(created, nil)
+			},
+			expectedImported:  1,
+			expectedCommitted: true,
+			expectedErrors:    []ImportError{},
+		},
+		{
+			name:            "異常系: strictで1行でもエラーがあれば登録しない",
+			rows:            []ImportRow{validRow(2, ""), invalidRow},
+			mode:            ImportModeStrict,
+			setupMock:       func(mockRepo *MockItemRepository) {},
+			expectedSkipped: 2,
+			expectedErrors: []ImportError{
+				{Line: 3, Field: "purchase_price", Message: `purchase_price must be an integer: "abc"`},
+				{Line: 3, Field: "name", Message: "name is required"},
+				{Line: 3, Field: "category", Message: "category must be one of: 時計, バッグ, ジュエリー, 靴, その他"},
+			},
+		},
+		{
+			name:            "正常系: dry_runは検証のみで登録しない",
+			rows:            []ImportRow{validRow(2, ""), invalidRow},
+			mode:            ImportModeDryRun,
+			setupMock:       func(mockRepo *MockItemRepository) {},
+			expectedSkipped: 1,
+			expectedErrors: []ImportError{
+				{Line: 3, Field: "purchase_price", Message: `purchase_price must be an integer: "abc"`},
+				{Line: 3, Field: "name", Message: "name is required"},
+				{Line: 3, Field: "category", Message: "category must be one of: 時計, バッグ, ジュエリー, 靴, その他"},
+			},
+		},
+		{
+			name: "正常系: lenientは有効な行のみ登録",
+			rows: []ImportRow{validRow(2, ""), invalidRow, validRow(4, "")},
+			mode: ImportModeLenient,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(created, nil).Times(2)
+			},
+			expectedImported:  2,
+			expectedSkipped:   1,
+			expectedCommitted: true,
+			expectedErrors: []ImportError{
+				{Line: 3, Field: "purchase_price", Message: `purchase_price must be an integer: "abc"`},
+				{Line: 3, Field: "name", Message: "name is required"},
+				{Line: 3, Field: "category", Message: "category must be one of: 時計, バッグ, ジュエリー, 靴, その他"},
+			},
+		},
+		{
+			name:            "異常系: ファイル内のシリアル番号の重複",
+			rows:            []ImportRow{validRow(2, "SN-001"), validRow(3, "SN-001")},
+			mode:            ImportModeStrict,
+			setupMock:       func(mockRepo *MockItemRepository) {},
+			expectedSkipped: 2,
+			expectedErrors: []ImportError{
+				{Line: 3, Field: "serial_number", Message: "serial_number is duplicated with line 2"},
+			},
+		},
+		{
+			name: "正常系: lenientで登録済みのシリアル番号はスキップ",
+			rows: []ImportRow{validRow(2, "SN-001"), validRow(3, "SN-002")},
+			mode: ImportModeLenient,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.SerialNumber == "SN-001" })).
+					Return(nil, domainErrors.ErrDuplicateEntry)
+				mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.SerialNumber == "SN-002" })).
+					Return(created, nil)
+			},
+			expectedImported:  1,
+			expectedSkipped:   1,
+			expectedCommitted: true,
+			expectedErrors: []ImportError{
+				{Line: 2, Field: "serial_number", Message: "serial_number already exists"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			result, err := usecase.ImportItems(context.Background(), tt.rows, tt.mode)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.mode, result.Mode)
+			assert.Equal(t, len(tt.rows), result.TotalRows)
+			assert.Equal(t, tt.expectedImported, result.Imported)
+			assert.Equal(t, tt.expectedSkipped, result.Skipped)
+			assert.Equal(t, tt.expectedCommitted, result.Committed)
+			assert.Equal(t, tt.expectedErrors, result.Errors)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_ImportItems_InvalidMode(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	usecase := NewItemUsecase(mockRepo)
+
+	result, err := usecase.ImportItems(context.Background(), nil, ImportMode("unknown"))
+
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	assert.Nil(t, result)
+}
+
+func TestItemUsecase_ImportItems_DefaultCategory(t *testing.T) {
+	rows := []ImportRow{
+		{Line: 2, Name: "古い指輪", Category: "", Brand: "不明", PurchasePrice: "30000", PurchaseDate: "2001-05-01"},
+		{Line: 3, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: "1500000", PurchaseDate: "2023-01-15"},
+	}
+
+	t.Run("正常系: カテゴリーが空の行にデフォルトを使う", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.Category == entity.CategoryOther })).
+			Return(&entity.Item{ID: 1}, nil).Once()
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.Category == entity.CategoryWatch })).
+			Return(&entity.Item{ID: 2}, nil).Once()
+		usecase := NewItemUsecase(mockRepo, WithImportDefaultCategory(entity.CategoryOther))
+
+		result, err := usecase.ImportItems(context.Background(), rows, ImportModeStrict)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Imported)
+		assert.Equal(t, 1, result.DefaultCategoryRows)
+		assert.Empty(t, result.Errors)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: デフォルト未設定ならカテゴリーは必須", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		result, err := usecase.ImportItems(context.Background(), rows, ImportModeStrict)
+
+		require.NoError(t, err)
+		assert.False(t, result.Committed)
+		assert.Equal(t, 0, result.DefaultCategoryRows)
+		assert.Equal(t, []ImportError{{Line: 2, Field: "category", Message: "category is required"}}, result.Errors)
+	})
+}
+
+func TestItemUsecase_ImportItems_SuspiciousPrice(t *testing.T) {
+	rows := []ImportRow{
+		{Line: 2, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: "1500000", PurchaseDate: "2023-01-15"},
+		{Line: 3, Name: "古い指輪", Category: "ジュエリー", Brand: "不明", PurchasePrice: "0", PurchaseDate: "2001-05-01"},
+		{Line: 4, Name: "財布", Category: "その他", Brand: "不明", PurchasePrice: "1", PurchaseDate: "2010-03-01"},
+	}
+	flagged := []ImportError{
+		{Line: 3, Field: "purchase_price", Message: "purchase_price looks like a placeholder: 0 (must be at least 100)"},
+		{Line: 4, Field: "purchase_price", Message: "purchase_price looks like a placeholder: 1 (must be at least 100)"},
+	}
+
+	t.Run("正常系: draftの場合は見直し用にdraftで登録する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.Status == "" })).
+			Return(&entity.Item{ID: 1}, nil).Once()
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.Status == entity.StatusDraft })).
+			Return(&entity.Item{ID: 2}, nil).Twice()
+		usecase := NewItemUsecase(mockRepo, WithImportPriceCheck(SuspiciousPriceDraft, 100))
+
+		result, err := usecase.ImportItems(context.Background(), rows, ImportModeStrict)
+
+		require.NoError(t, err)
+		assert.True(t, result.Committed)
+		assert.Equal(t, 3, result.Imported)
+		assert.Empty(t, result.Errors)
+		assert.Equal(t, flagged, result.FlaggedRows)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: rejectの場合は行のエラーにする", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1}, nil).Once()
+		usecase := NewItemUsecase(mockRepo, WithImportPriceCheck(SuspiciousPriceReject, 100))
+
+		result, err := usecase.ImportItems(context.Background(), rows, ImportModeLenient)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Imported)
+		assert.Equal(t, 2, result.Skipped)
+		assert.Equal(t, flagged, result.Errors)
+		assert.Equal(t, flagged, result.FlaggedRows)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 下限が無い場合は0ちょうどのみ", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo, WithImportPriceCheck(SuspiciousPriceReject, 0))
+
+		result, err := usecase.ImportItems(context.Background(), rows, ImportModeDryRun)
+
+		require.NoError(t, err)
+		assert.Equal(t, []ImportError{{Line: 3, Field: "purchase_price", Message: "purchase_price looks like a placeholder: 0"}}, result.FlaggedRows)
+	})
+
+	t.Run("正常系: 設定しない場合は確認しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		result, err := usecase.ImportItems(context.Background(), rows, ImportModeDryRun)
+
+		require.NoError(t, err)
+		assert.Empty(t, result.FlaggedRows)
+		assert.Empty(t, result.Errors)
+	})
+}
+
+func TestBuildImportItem_PurchaseDate(t *testing.T) {
+	row := ImportRow{Line: 2, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: "1500000"}
+
+	row.PurchaseDate = "2023/1/15"
+	item, errs := buildImportItem(row)
+	assert.Empty(t, errs)
+	assert.Equal(t, "2023-01-15", item.PurchaseDate)
+
+	row.PurchaseDate = "15/01/2023"
+	_, errs = buildImportItem(row)
+	assert.Equal(t, []ImportError{{Line: 2, Field: "purchase_date", Message: "purchase_date must be in YYYY-MM-DD format"}}, errs)
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// データの整合性の確認項目（検証のルールを追加する前に登録した行や、DBに直接書き込んだ行を見つける）
+type IntegrityCheck string
+
+const (
+	IntegrityInvalidCategory     IntegrityCheck = "invalid_category"      // 許可リストに無いカテゴリー（前後の空白を含むものも）
+	IntegrityInvalidStatus       IntegrityCheck = "invalid_status"        // draft, active, archived 以外の状態
+	IntegrityNegativePrice       IntegrityCheck = "negative_price"        // 負の購入価格
+	IntegrityInvalidPurchaseDate IntegrityCheck = "invalid_purchase_date" // 0000-00-00 などの存在しない日付や未来の日付
+	IntegrityOrphanedImage       IntegrityCheck = "orphaned_image"        // アイテムの行が存在しない画像（IDは画像のID）
+)
+
+// 確認する項目（レポートはこの順）
+var IntegrityChecks = []IntegrityCheck{
+	IntegrityInvalidCategory,
+	IntegrityInvalidStatus,
+	IntegrityNegativePrice,
+	IntegrityInvalidPurchaseDate,
+	IntegrityOrphanedImage,
+}
+
+// 自動で修正できる項目（価格や購入日は正しい値が分からないため、確認して個別に修正する）
+//   - invalid_category: 前後の空白を除くと有効なカテゴリーはそのカテゴリーに、それ以外は「その他」にする
+//   - invalid_status: active にする
+//   - orphaned_image: 画像を削除する
+var fixableIntegrityChecks = map[IntegrityCheck]bool{
+	IntegrityInvalidCategory: true,
+	IntegrityInvalidStatus:   true,
+	IntegrityOrphanedImage:   true,
+}
+
+func (c IntegrityCheck) IsValid() bool {
+	for _, check := range IntegrityChecks {
+		if c == check {
+			return true
+		}
+	}
+	return false
+}
+
+func (c IntegrityCheck) Fixable() bool {
+	return fixableIntegrityChecks[c]
+}
+
+// レポートに含めるIDの件数の既定値と上限（件数はすべての行を数える）
+const (
+	DefaultIntegrityLimit = 100
+	MaxIntegrityLimit     = 1000
+)
+
+// 1つの確認項目の結果
+type IntegrityIssue struct {
+	Check   IntegrityCheck `json:"check"`
+	Count   int            `json:"count"`
+	IDs     []int64        `json:"ids"` // ID順に最大limit件
+	Fixable bool           `json:"fixable"`
+}
+
+// 整合性の確認の結果（問題の無い項目も件数0で含める）
+type IntegrityReport struct {
+	OK     bool             `json:"ok"`
+	Issues []IntegrityIssue `json:"issues"`
+}
+
+type IntegrityFixResult struct {
+	Check IntegrityCheck `json:"check"`
+	Fixed int64          `json:"fixed"` // 修正した行数
+}
+
+// データの整合性を確認・修正するユースケース（管理者用）
+type IntegrityChecker interface {
+	// すべての項目を確認する（読み取りのみ、削除済みのアイテムも対象）
+	CheckIntegrity(ctx context.Context, limit int) (*IntegrityReport, error)
+	// 自動で修正できる項目の、違反しているすべての行を修正する
+	FixIntegrity(ctx context.Context, check IntegrityCheck) (*IntegrityFixResult, error)
+}
+
+type integrityChecker struct {
+	repo IntegrityRepository
+	// 修正した後に破棄するGetItemByIDのキャッシュ（nilの場合は使わない）
+	cache *ItemCache
+}
+
+type IntegrityOption func(*integrityChecker)
+
+// 行を修正した後に、cacheが保持しているアイテムをすべて破棄する
+// 修正ではアイテムごとのイベント（item.updated）は記録しない
+func WithIntegrityItemCache(cache *ItemCache) IntegrityOption {
+	return func(c *integrityChecker) {
+		c.cache = cache
+	}
+}
+
+func NewIntegrityChecker(repo IntegrityRepository, opts ...IntegrityOption) IntegrityChecker {
+	c := &integrityChecker{repo: repo}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *integrityChecker) CheckIntegrity(ctx context.Context, limit int) (*IntegrityReport, error) {
+	if limit < 1 || limit > MaxIntegrityLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", domainErrors.ErrInvalidInput, MaxIntegrityLimit)
+	}
+
+	report := &IntegrityReport{OK: true, Issues: make([]IntegrityIssue, 0, len(IntegrityChecks))}
+	for _, check := range IntegrityChecks {
+		ids, count, err := c.repo.FindViolations(ctx, check, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s: %w", check, err)
+		}
+		if ids == nil {
+			ids = []int64{}
+		}
+		report.Issues = append(report.Issues, IntegrityIssue{Check: check, Count: count, IDs: ids, Fixable: check.Fixable()})
+		if count > 0 {
+			report.OK = false
+		}
+	}
+	return report, nil
+}
+
+func (c *integrityChecker) FixIntegrity(ctx context.Context, check IntegrityCheck) (*IntegrityFixResult, error) {
+	if !check.IsValid() {
+		return nil, fmt.Errorf("%w: unknown integrity check: %s", domainErrors.ErrInvalidInput, check)
+	}
+	if !check.Fixable() {
+		return nil, fmt.Errorf("%w: %s cannot be fixed automatically (fix the reported items individually)", domainErrors.ErrInvalidInput, check)
+	}
+
+	fixed, err := c.repo.FixViolations(ctx, check)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fix %s: %w", check, err)
+	}
+	if fixed > 0 {
+		c.cache.InvalidateAll()
+	}
+	return &IntegrityFixResult{Check: check, Fixed: fixed}, nil
+}
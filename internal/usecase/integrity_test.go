@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockIntegrityRepository はtestify/mockを使用した整合性の確認のモックリポジトリ
+type MockIntegrityRepository struct {
+	mock.Mock
+}
+
+func (m *MockIntegrityRepository) FindViolations(ctx context.Context, check IntegrityCheck, limit int) ([]int64, int, error) {
+	args := m.Called(ctx, check, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]int64), args.Int(1), args.Error(2)
+}
+
+func (m *MockIntegrityRepository) FixViolations(ctx context.Context, check IntegrityCheck) (int64, error) {
+	args := m.Called(ctx, check)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestIntegrityChecker_CheckIntegrity(t *testing.T) {
+	t.Run("正常系: すべての項目を順に確認する", func(t *testing.T) {
+		mockRepo := new(MockIntegrityRepository)
+		mockRepo.On("FindViolations", mock.Anything, IntegrityInvalidCategory, 2).Return([]int64{3, 5}, 4, nil)
+		mockRepo.On("FindViolations", mock.Anything, IntegrityNegativePrice, 2).Return([]int64{7}, 1, nil)
+		mockRepo.On("FindViolations", mock.Anything, mock.Anything, 2).Return(nil, 0, nil)
+
+		report, err := NewIntegrityChecker(mockRepo).CheckIntegrity(context.Background(), 2)
+
+		require.NoError(t, err)
+		assert.False(t, report.OK)
+		require.Len(t, report.Issues, len(IntegrityChecks))
+		assert.Equal(t, IntegrityIssue{Check: IntegrityInvalidCategory, Count: 4, IDs: []int64{3, 5}, Fixable: true}, report.Issues[0])
+		assert.Equal(t, IntegrityIssue{Check: IntegrityInvalidStatus, Count: 0, IDs: []int64{}, Fixable: true}, report.Issues[1])
+		assert.Equal(t, IntegrityIssue{Check: IntegrityNegativePrice, Count: 1, IDs: []int64{7}, Fixable: false}, report.Issues[2])
+	})
+
+	t.Run("正常系: 問題が無い場合はOK", func(t *testing.T) {
+		mockRepo := new(MockIntegrityRepository)
+		mockRepo.On("FindViolations", mock.Anything, mock.Anything, DefaultIntegrityLimit).Return(nil, 0, nil)
+
+		report, err := NewIntegrityChecker(mockRepo).CheckIntegrity(context.Background(), DefaultIntegrityLimit)
+
+		require.NoError(t, err)
+		assert.True(t, report.OK)
+	})
+
+	t.Run("異常系: limitが範囲外", func(t *testing.T) {
+		_, err := NewIntegrityChecker(new(MockIntegrityRepository)).CheckIntegrity(context.Background(), MaxIntegrityLimit+1)
+		assert.True(t, domainErrors.IsValidationError(err))
+	})
+
+	t.Run("異常系: 確認に失敗", func(t *testing.T) {
+		mockRepo := new(MockIntegrityRepository)
+		mockRepo.On("FindViolations", mock.Anything, IntegrityInvalidCategory, 1).Return(nil, 0, errors.New("connection refused"))
+
+		_, err := NewIntegrityChecker(mockRepo).CheckIntegrity(context.Background(), 1)
+
+		assert.ErrorContains(t, err, "failed to check invalid_category")
+	})
+}
+
+func TestIntegrityChecker_FixIntegrity(t *testing.T) {
+	tests := []struct {
+		name            string
+		check           IntegrityCheck
+		setupMock       func(*MockIntegrityRepository)
+		expectedFixed   int64
+		expectedInvalid bool
+	}{
+		{
+			name:  "正常系: 自動で修正できる項目",
+			check: IntegrityOrphanedImage,
+			setupMock: func(m *MockIntegrityRepository) {
+				m.On("FixViolations", mock.Anything, IntegrityOrphanedImage).Return(int64(3), nil)
+			},
+			expectedFixed: 3,
+		},
+		{name: "異常系: 自動で修正できない項目", check: IntegrityNegativePrice, expectedInvalid: true},
+		{name: "異常系: 存在しない項目", check: "orphaned_tag", expectedInvalid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockIntegrityRepository)
+			if tt.setupMock != nil {
+				tt.setupMock(mockRepo)
+			}
+
+			cache := NewItemCache(10, time.Minute)
+			cache.put(cacheTestItem(1, "修正前"), cache.currentGeneration())
+
+			result, err := NewIntegrityChecker(mockRepo, WithIntegrityItemCache(cache)).FixIntegrity(context.Background(), tt.check)
+
+			_, cached := cache.get(1)
+			if tt.expectedInvalid {
+				assert.True(t, domainErrors.IsValidationError(err))
+				mockRepo.AssertNotCalled(t, "FixViolations", mock.Anything, mock.Anything)
+				assert.True(t, cached)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &IntegrityFixResult{Check: tt.check, Fixed: tt.expectedFixed}, result)
+			// 修正した行の修正前の値を返し続けないよう破棄する
+			assert.False(t, cached)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// IDでアイテムを取得した結果をメモリに保持する（GetItemByIDのみ、最近使われていないものから破棄する）
+// このユースケースを経由した更新・削除では該当するIDを破棄する
+// 復元・派生カラムの再計算・整合性の修正は、書き込んだ後に InvalidateAll ですべて破棄する
+// 他のプロセスからの書き込みは、保持期間（ttl）が過ぎるまで反映されない
+type ItemCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	order   *list.List // 先頭ほど最近使われた
+	entries map[int64]*list.Element
+	// 破棄するたびに増やす。読み込み中に破棄された場合は、読み込んだ古い値を保持しない
+	generation uint64
+	now        func() time.Time
+}
+
+type itemCacheEntry struct {
+	id        int64
+	item      entity.Item
+	expiresAt time.Time
+}
+
+// sizeが0以下の場合は保持しない（nilを返す）、ttlが0以下の場合は期限なし
+func NewItemCache(size int, ttl time.Duration) *ItemCache {
+	if size <= 0 {
+		return nil
+	}
+	return &ItemCache{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[int64]*list.Element),
+		now:     time.Now,
+	}
+}
+
+// GetItemByIDの結果をcacheに保持する（nilの場合は保持しない）
+func WithItemCache(cache *ItemCache) Option {
+	return func(u *itemUsecase) {
+		u.cache = cache
+	}
+}
+
+// 保持しているアイテムのコピーを返す（期限切れの場合は破棄する）
+func (c *ItemCache) get(id int64) (*entity.Item, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[id]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*itemCacheEntry)
+	if c.ttl > 0 && !c.now().Before(entry.expiresAt) {
+		c.removeElement(element)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return cloneItem(&entry.item), true
+}
+
+// 読み込みを開始した時点の世代（putに渡す）
+func (c *ItemCache) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// DBから読み込んだアイテムを保持する
+// 読み込みを開始してから破棄が行われた場合は、更新前の値の可能性があるため保持しない
+func (c *ItemCache) put(item *entity.Item, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	entry := &itemCacheEntry{id: item.ID, item: *cloneItem(item), expiresAt: c.now().Add(c.ttl)}
+	if element, ok := c.entries[item.ID]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[item.ID] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		c.removeElement(c.order.Back())
+	}
+}
+
+// 更新・削除したアイテムを破棄する
+func (c *ItemCache) invalidate(id int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	if element, ok := c.entries[id]; ok {
+		c.removeElement(element)
+	}
+}
+
+// 保持しているすべてのアイテムを破棄する（nilの場合は何もしない）
+// このユースケースを経由せずに複数のアイテムを書き換えた後、コミットしてから呼ぶ
+func (c *ItemCache) InvalidateAll() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.order.Init()
+	c.entries = make(map[int64]*list.Element)
+}
+
+func (c *ItemCache) removeElement(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*itemCacheEntry).id)
+}
+
+// 呼び出し元が変更しても保持している値に影響しないようコピーする
+func cloneItem(item *entity.Item) *entity.Item {
+	cloned := *item
+	if item.DeletedAt != nil {
+		deletedAt := *item.DeletedAt
+		cloned.DeletedAt = &deletedAt
+	}
+	return &cloned
+}
+
+// キャッシュを使う場合は、書き込んだアイテムを破棄する
+func (u *itemUsecase) invalidateItem(id int64) {
+	if u.cache != nil {
+		u.cache.invalidate(id)
+	}
+}
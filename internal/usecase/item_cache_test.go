@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func cacheTestItem(id int64, name string) *entity.Item {
+	return &entity.Item{
+		ID: id, Name: name, Category: entity.CategoryWatch, Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15",
+		Status: entity.StatusActive,
+	}
+}
+
+func TestItemUsecase_GetItemByID_Cache(t *testing.T) {
+	t.Run("正常系: 2回目以降はDBから読み込まない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(cacheTestItem(1, "デイトナ"), nil).Once()
+		usecase := NewItemUsecase(mockRepo, WithItemCache(NewItemCache(10, time.Minute)))
+
+		for i := 0; i < 3; i++ {
+			item, err := usecase.GetItemByID(context.Background(), 1)
+			require.NoError(t, err)
+			assert.Equal(t, "デイトナ", item.Name)
+		}
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 更新したアイテムは古い値を返さない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(cacheTestItem(1, "デイトナ"), nil).Once()
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(cacheTestItem(1, "デイトナ"), nil).Once()
+		mockRepo.On("Update", mock.Anything, int64(1), ItemChanges{Name: stringPtr("サブマリーナー")}).Return(cacheTestItem(1, "サブマリーナー"), nil)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(cacheTestItem(1, "サブマリーナー"), nil).Once()
+		usecase := NewItemUsecase(mockRepo, WithItemCache(NewItemCache(10, time.Minute)))
+		ctx := context.Background()
+
+		item, err := usecase.GetItemByID(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "デイトナ", item.Name)
+
+		_, err = usecase.UpdateItem(ctx, 1, UpdateItemInput{Name: stringPtr("サブマリーナー")})
+		require.NoError(t, err)
+
+		item, err = usecase.GetItemByID(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "サブマリーナー", item.Name)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 削除したアイテムは見つからない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(cacheTestItem(1, "デイトナ"), nil).Twice()
+		mockRepo.On("Delete", mock.Anything, int64(1), "").Return(nil)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound).Once()
+		mockRepo.On("WasDeleted", mock.Anything, int64(1)).Return(true, nil)
+		usecase := NewItemUsecase(mockRepo, WithItemCache(NewItemCache(10, time.Minute)))
+		ctx := context.Background()
+
+		_, err := usecase.GetItemByID(ctx, 1)
+		require.NoError(t, err)
+		require.NoError(t, usecase.DeleteItem(ctx, 1, DeleteItemInput{}))
+
+		_, err = usecase.GetItemByID(ctx, 1)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		assert.ErrorIs(t, err, domainErrors.ErrItemDeleted)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 保持する値はプライマリーから読み込む", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.MatchedBy(UsePrimaryReads), int64(1)).Return(cacheTestItem(1, "デイトナ"), nil).Once()
+		usecase := NewItemUsecase(mockRepo, WithItemCache(NewItemCache(10, time.Minute)))
+
+		_, err := usecase.GetItemByID(context.Background(), 1)
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 無効の場合は毎回DBから読み込む", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(cacheTestItem(1, "デイトナ"), nil).Twice()
+		usecase := NewItemUsecase(mockRepo, WithItemCache(NewItemCache(0, time.Minute)))
+
+		for i := 0; i < 2; i++ {
+			_, err := usecase.GetItemByID(context.Background(), 1)
+			require.NoError(t, err)
+		}
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestItemCache(t *testing.T) {
+	t.Run("期限が過ぎた値は返さない", func(t *testing.T) {
+		now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+		cache := NewItemCache(10, time.Minute)
+		cache.now = func() time.Time { return now }
+		cache.put(cacheTestItem(1, "デイトナ"), cache.currentGeneration())
+
+		_, ok := cache.get(1)
+		assert.True(t, ok)
+
+		now = now.Add(time.Minute)
+		_, ok = cache.get(1)
+		assert.False(t, ok)
+	})
+
+	t.Run("上限を超えた場合は最近使われていないものから破棄する", func(t *testing.T) {
+		cache := NewItemCache(2, time.Minute)
+		cache.put(cacheTestItem(1, "1"), cache.currentGeneration())
+		cache.put(cacheTestItem(2, "2"), cache.currentGeneration())
+		cache.get(1)
+		cache.put(cacheTestItem(3, "3"), cache.currentGeneration())
+
+		_, ok := cache.get(1)
+		assert.True(t, ok)
+		_, ok = cache.get(2)
+		assert.False(t, ok)
+		_, ok = cache.get(3)
+		assert.True(t, ok)
+	})
+
+	t.Run("読み込み中に破棄された場合は保持しない", func(t *testing.T) {
+		cache := NewItemCache(10, time.Minute)
+		generation := cache.currentGeneration()
+		// 読み込みの間に別のリクエストが更新した
+		cache.invalidate(1)
+		cache.put(cacheTestItem(1, "更新前"), generation)
+
+		_, ok := cache.get(1)
+		assert.False(t, ok)
+	})
+
+	t.Run("返した値を変更しても保持している値は変わらない", func(t *testing.T) {
+		cache := NewItemCache(10, time.Minute)
+		item := cacheTestItem(1, "デイトナ")
+		cache.put(item, cache.currentGeneration())
+		item.Name = "変更"
+
+		cached, _ := cache.get(1)
+		cached.Name = "変更"
+
+		cached, _ = cache.get(1)
+		assert.Equal(t, "デイトナ", cached.Name)
+	})
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// APIのパスやレスポンスで使うアイテムのIDの種類
+type ItemIDType string
+
+const (
+	// AUTO_INCREMENTの数値のID（デフォルト）
+	ItemIDInt64 ItemIDType = "int64"
+	// 登録時に振るランダムなUUID（登録件数や他のアイテムのIDを推測できない）
+	ItemIDUUID ItemIDType = "uuid"
+)
+
+// ID_TYPE の値を検証する（空の場合はint64）
+func ParseItemIDType(value string) (ItemIDType, error) {
+	switch idType := ItemIDType(strings.ToLower(strings.TrimSpace(value))); idType {
+	case "", ItemIDInt64:
+		return ItemIDInt64, nil
+	case ItemIDUUID:
+		return idType, nil
+	}
+	return "", fmt.Errorf("%s (must be one of: int64, uuid)", value)
+}
+
+// uuidの場合は、登録するアイテムにUUIDを振る
+// 行の主キーや変更履歴・画像の参照は数値のIDのままで、UUIDはAPIで指定・返却するIDとして使う
+func WithItemIDType(idType ItemIDType) Option {
+	return func(u *itemUsecase) {
+		u.uuidIDs = idType == ItemIDUUID
+	}
+}
+
+// 登録する前のエンティティにUUIDを振る（uuidを使わない場合や、既に振られている場合は何もしない）
+func (u *itemUsecase) assignItemUUID(item *entity.Item) {
+	if u.uuidIDs && item.UUID == "" {
+		item.UUID = entity.NewUUID()
+	}
+}
+
+// UUIDからアイテムの数値のIDを返す（論理削除済みのアイテムも含む）
+func (u *itemUsecase) ResolveItemID(ctx context.Context, uuid string) (int64, error) {
+	uuid, ok := entity.ParseUUID(uuid)
+	if !ok {
+		return 0, fmt.Errorf("%w: item ID must be a UUID", domainErrors.ErrInvalidInput)
+	}
+
+	id, err := u.itemRepo.FindIDByUUID(ctx, uuid)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return 0, domainErrors.ErrItemNotFound
+		}
+		return 0, fmt.Errorf("failed to resolve item ID: %w", err)
+	}
+	return id, nil
+}
+
+// UUIDが振られていないアイテム（ID_TYPE=uuid にする前に登録したもの）にUUIDを振る
+type itemUUIDField struct {
+	itemRepo ItemRepository
+}
+
+// 振ったUUIDは変更しないため、何度実行しても振られていない行のみを書き換える
+// updated_at とバージョンは変えないが、ETagは数値のIDからUUIDを使う値に変わる
+func NewItemUUIDField(itemRepo ItemRepository) DerivedField {
+	return &itemUUIDField{itemRepo: itemRepo}
+}
+
+func (f *itemUUIDField) RecomputeBatch(ctx context.Context, afterID int64, limit int) (BackfillBatch, error) {
+	items, err := f.itemRepo.FindBatchAfter(ctx, afterID, limit)
+	if err != nil {
+		return BackfillBatch{}, err
+	}
+
+	batch := BackfillBatch{Scanned: len(items)}
+	for _, item := range items {
+		batch.LastID = item.ID
+		if item.UUID != "" {
+			continue
+		}
+		assigned, err := f.itemRepo.AssignUUID(ctx, item.ID, entity.NewUUID())
+		if err != nil {
+			return BackfillBatch{}, err
+		}
+		if assigned {
+			batch.Updated++
+		}
+	}
+	return batch, nil
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestParseItemIDType(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected ItemIDType
+		wantErr  bool
+	}{
+		{name: "正常系: 未設定はint64", value: "", expected: ItemIDInt64},
+		{name: "正常系: int64", value: "int64", expected: ItemIDInt64},
+		{name: "正常系: uuid（大文字・空白を許容）", value: " UUID ", expected: ItemIDUUID},
+		{name: "異常系: 不明な値", value: "ulid", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idType, err := ParseItemIDType(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, idType)
+		})
+	}
+}
+
+func TestItemUsecase_ResolveItemID(t *testing.T) {
+	const uuid = "5f0c7a4e-2b1d-4c3e-9a8f-1d2e3f4a5b6c"
+
+	tests := []struct {
+		name      string
+		value     string
+		setupMock func(*MockItemRepository)
+		expected  int64
+		checkErr  func(error) bool
+	}{
+		{
+			name:  "正常系: 大文字のUUIDも小文字にして引く",
+			value: "5F0C7A4E-2B1D-4C3E-9A8F-1D2E3F4A5B6C",
+			setupMock: func(repo *MockItemRepository) {
+				repo.On("FindIDByUUID", mock.Anything, uuid).Return(int64(7), nil)
+			},
+			expected: 7,
+		},
+		{
+			name:      "異常系: 数値のID",
+			value:     "7",
+			setupMock: func(repo *MockItemRepository) {},
+			checkErr:  domainErrors.IsValidationError,
+		},
+		{
+			name:  "異常系: 存在しないUUID",
+			value: uuid,
+			setupMock: func(repo *MockItemRepository) {
+				repo.On("FindIDByUUID", mock.Anything, uuid).Return(int64(0), domainErrors.ErrItemNotFound)
+			},
+			checkErr: domainErrors.IsNotFoundError,
+		},
+		{
+			name:  "異常系: データベースエラー",
+			value: uuid,
+			setupMock: func(repo *MockItemRepository) {
+				repo.On("FindIDByUUID", mock.Anything, uuid).Return(int64(0), domainErrors.ErrDatabaseError)
+			},
+			checkErr: func(err error) bool { return errors.Is(err, domainErrors.ErrDatabaseError) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo, WithItemIDType(ItemIDUUID))
+
+			id, err := usecase.ResolveItemID(context.Background(), tt.value)
+
+			if tt.checkErr != nil {
+				assert.True(t, tt.checkErr(err), "unexpected error: %v", err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, id)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_CreateItem_UUID(t *testing.T) {
+	input := CreateItemInput{
+		Name:          "サブマリーナ",
+		Category:      entity.CategoryWatch,
+		Brand:         "ROLEX",
+		PurchasePrice: 1000000,
+		PurchaseDate:  "2023-01-01",
+	}
+
+	t.Run("正常系: uuidの場合は登録時にUUIDを振る", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			_, ok := entity.ParseUUID(item.UUID)
+			return ok
+		})).Return(&entity.Item{ID: 1}, nil)
+		usecase := NewItemUsecase(mockRepo, WithItemIDType(ItemIDUUID))
+
+		_, err := usecase.CreateItem(context.Background(), input)
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: int64の場合はUUIDを振らない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.UUID == ""
+		})).Return(&entity.Item{ID: 1}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.CreateItem(context.Background(), input)
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestItemUUIDField_RecomputeBatch(t *testing.T) {
+	isUUID := mock.MatchedBy(func(uuid string) bool {
+		_, ok := entity.ParseUUID(uuid)
+		return ok
+	})
+	itemRepo := new(MockItemRepository)
+	itemRepo.On("FindBatchAfter", mock.Anything, int64(0), 10).Return([]*entity.Item{
+		{ID: 1}, // 振る
+		{ID: 2, UUID: "5f0c7a4e-2b1d-4c3e-9a8f-1d2e3f4a5b6c"}, // 振り済み
+		{ID: 3}, // 読み込んだ後に他のリクエストが振った
+	}, nil)
+	itemRepo.On("AssignUUID", mock.Anything, int64(1), isUUID).Return(true, nil)
+	itemRepo.On("AssignUUID", mock.Anything, int64(3), isUUID).Return(false, nil)
+	field := NewItemUUIDField(itemRepo)
+
+	batch, err := field.RecomputeBatch(context.Background(), 0, 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, BackfillBatch{LastID: 3, Scanned: 3, Updated: 1}, batch)
+	itemRepo.AssertExpectations(t)
+}
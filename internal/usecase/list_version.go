@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 一覧の内容が変わったかを判定するための集計値
+type ListVersion struct {
+	Count         int
+	LastUpdatedAt *time.Time // 条件に一致するアイテムの最新の更新日時（0件の場合はnil）
+	// 条件に一致するアイテムのバージョンの合計と、IDとバージョンから計算したチェックサム
+	// 更新日時は秒単位のため、同じ秒の中の書き込みや、入れ替わりで件数が変わらない場合もこちらで区別する
+	VersionSum int64
+	Checksum   uint64
+	Sort       SortOrder // 一覧に適用する並び順（?sort= を指定しない場合は既定の並び順）
+	// 検索語で絞り込んだ一覧（GetAllItems）で返す最大件数（0の場合は無制限）
+	SearchWindow int
+}
+
+// 一覧で返す件数（上限を超えた場合は上限まで）
+func (v ListVersion) ReturnedCount() int {
+	if v.SearchWindow > 0 && v.Count > v.SearchWindow {
+		return v.SearchWindow
+	}
+	return v.Count
+}
+
+// 一致した件数が上限を超えたため、一覧を切り詰めた
+func (v ListVersion) RefineSuggested() bool {
+	return v.SearchWindow > 0 && v.Count > v.SearchWindow
+}
+
+// 一覧の弱いETag（絞り込み条件・件数・最新の更新日時・バージョンから生成）
+// 件数と更新日時が同じでも、条件や並び順が異なれば別の値になる
+func (v ListVersion) ETag(filter ItemFilter) string {
+	if v.Sort != "" {
+		filter.Sort = v.Sort
+	}
+	lastUpdated := "-"
+	if v.LastUpdatedAt != nil {
+		lastUpdated = strconv.FormatInt(v.LastUpdatedAt.UnixNano(), 10)
+	}
+
+	sum := sha256.Sum256([]byte(filter.cacheKey() + "|" + strconv.Itoa(v.Count) + "|" + lastUpdated +
+		"|" + strconv.FormatInt(v.VersionSum, 10) + "|" + strconv.FormatUint(v.Checksum, 10)))
+	return `W/"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// 絞り込み条件を一意に表す文字列（ETagの生成に使う）
+func (f ItemFilter) cacheKey() string {
+	categories := make([]string, len(f.Categories))
+	for i, category := range f.Categories {
+		categories[i] = category.String()
+	}
+	excluded := make([]string, len(f.ExcludeCategories))
+	for i, category := range f.ExcludeCategories {
+		excluded[i] = category.String()
+	}
+	createdSince := ""
+	if f.CreatedSince != nil {
+		createdSince = strconv.FormatInt(f.CreatedSince.UnixNano(), 10)
+	}
+	weekdays := make([]string, len(f.PurchaseWeekdays))
+	for i, weekday := range f.PurchaseWeekdays {
+		weekdays[i] = strconv.Itoa(int(weekday))
+	}
+	minPrice, maxPrice := "", ""
+	if f.MinPurchasePrice != nil {
+		minPrice = strconv.FormatInt(*f.MinPurchasePrice, 10)
+	}
+	if f.MaxPurchasePrice != nil {
+		maxPrice = strconv.FormatInt(*f.MaxPurchasePrice, 10)
+	}
+
+	return strings.Join([]string{
+		"categories=" + strings.Join(categories, ","),
+		"exclude_categories=" + strings.Join(excluded, ","),
+		"brand=" + strings.ToLower(f.Brand),
+		"created_since=" + createdSince,
+		"q=" + f.Query,
+		"serial_prefix=" + strings.ToLower(f.SerialPrefix),
+		"fuzzy=" + strconv.FormatBool(f.Fuzzy),
+		"status=" + f.Status.String(),
+		"any_status=" + strconv.FormatBool(f.AnyStatus),
+		"deleted=" + strconv.FormatBool(f.Deleted),
+		"id_from=" + strconv.FormatInt(f.IDFrom, 10),
+		"id_to=" + strconv.FormatInt(f.IDTo, 10),
+		"min_purchase_price=" + minPrice,
+		"max_purchase_price=" + maxPrice,
+		"purchase_date_from=" + f.PurchasedFrom,
+		"purchase_date_to=" + f.PurchasedTo,
+		"month=" + strconv.Itoa(f.PurchaseMonth),
+		"weekdays=" + strings.Join(weekdays, ","),
+		"sort=" + string(f.Sort),
+	}, "&")
+}
+
+// 絞り込み条件に一致するアイテムの件数と最新の更新日時を返す（一覧を取得せずに変更の有無を判定する）
+func (u *itemUsecase) GetItemListVersion(ctx context.Context, filter ItemFilter) (*ListVersion, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	filter = u.withDefaultSort(filter)
+
+	version, err := u.itemRepo.GetListVersion(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list version: %w", err)
+	}
+	version.Sort = filter.Sort
+	version.SearchWindow = u.searchWindow(filter)
+	return version, nil
+}
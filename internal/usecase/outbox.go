@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// 送信待ちのイベント
+type OutboxEntry struct {
+	ID       int64
+	Event    ItemEvent
+	Attempts int
+}
+
+// OutboxRepository defines the interface for reading and updating undelivered events
+type OutboxRepository interface {
+	// FetchPending returns undelivered events in the order they were recorded
+	FetchPending(ctx context.Context, limit int) ([]OutboxEntry, error)
+
+	// MarkDelivered marks the event as delivered so that it is not sent again
+	MarkDelivered(ctx context.Context, id int64) error
+
+	// MarkFailed records a failed delivery attempt
+	MarkFailed(ctx context.Context, id int64, reason string) error
+
+	// MarkDeadLettered records the last failed delivery attempt and excludes the event from FetchPending
+	MarkDeadLettered(ctx context.Context, id int64, reason string) error
+}
+
+// EventSender delivers an event to external systems synchronously
+type EventSender interface {
+	Send(ctx context.Context, event ItemEvent) error
+}
+
+// 複数の配信先へ順に送る（失敗した場合は以降の配信先には送らず、次回の再送で先頭から送り直す）
+// 他の配信先の失敗に影響されない配信先（EventBroadcasterなど）は、NewEventRelayのobserversに指定する
+type chainedEventSender []EventSender
+
+func ChainEventSenders(senders ...EventSender) EventSender {
+	if len(senders) == 1 {
+		return senders[0]
+	}
+	return chainedEventSender(senders)
+}
+
+func (s chainedEventSender) Send(ctx context.Context, event ItemEvent) error {
+	for _, sender := range s {
+		if err := sender.Send(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// アウトボックスに記録されたイベントを外部へ配信するユースケース
+type EventRelay interface {
+	// 送信待ちのイベントを配信し、配信できた件数を返す
+	RelayPending(ctx context.Context) (int, error)
+}
+
+type eventRelay struct {
+	outbox      OutboxRepository
+	sender      EventSender
+	observers   []EventSender
+	batchSize   int
+	maxAttempts int // この回数失敗したイベントは配信を諦める（0以下の場合は無制限）
+
+	// observersへ送ったが、まだ送信待ちのイベント（同じアイテムの前のイベントの再送待ち）
+	// RelayPendingは1つのワーカーから順に呼ばれるため、ロックしない
+	observed map[int64]bool
+}
+
+// observersには、senderの成否によらず各イベントを1回だけ送る（失敗しても再送しない、EventBroadcasterなど）
+func NewEventRelay(outbox OutboxRepository, sender EventSender, batchSize, maxAttempts int, observers ...EventSender) EventRelay {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	return &eventRelay{
+		outbox:      outbox,
+		sender:      sender,
+		observers:   observers,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		observed:    make(map[int64]bool),
+	}
+}
+
+// まだobserversへ送っていないイベントを送る
+// 1回でも配信を試みたイベントは送信済みのため、再送では送らない
+func (r *eventRelay) notifyObservers(ctx context.Context, entries []OutboxEntry) {
+	observed := make(map[int64]bool)
+	for _, entry := range entries {
+		if entry.Attempts > 0 {
+			continue
+		}
+		observed[entry.ID] = true
+		if r.observed[entry.ID] {
+			continue
+		}
+		for _, observer := range r.observers {
+			if err := observer.Send(ctx, entry.Event); err != nil {
+				slog.Warn("failed to notify event", "outbox_id", entry.ID, "event", entry.Event.Type, "error", err)
+			}
+		}
+	}
+	r.observed = observed
+}
+
+func (r *eventRelay) RelayPending(ctx context.Context) (int, error) {
+	entries, err := r.outbox.FetchPending(ctx, r.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch pending events: %w", err)
+	}
+	r.notifyObservers(ctx, entries)
+
+	delivered := 0
+	// 配信に失敗したイベントのアイテム（同じアイテムのイベントの順序を保つため、以降のイベントは次回の実行で送る）
+	blocked := make(map[int64]bool)
+	for _, entry := range entries {
+		if blocked[entry.Event.ItemID] {
+			continue
+		}
+		if err := r.sender.Send(ctx, entry.Event); err != nil {
+			attempts := entry.Attempts + 1
+			if r.maxAttempts > 0 && attempts >= r.maxAttempts {
+				// 配信を諦めたイベントはoutboxに残し、同じアイテムの以降のイベントは送る
+				slog.Error("giving up delivering event", "outbox_id", entry.ID, "event", entry.Event.Type, "item_id", entry.Event.ItemID, "attempts", attempts, "error", err)
+				if markErr := r.outbox.MarkDeadLettered(ctx, entry.ID, err.Error()); markErr != nil {
+					return delivered, fmt.Errorf("failed to record delivery failure: %w", markErr)
+				}
+				continue
+			}
+
+			slog.Warn("failed to deliver event", "outbox_id", entry.ID, "event", entry.Event.Type, "item_id", entry.Event.ItemID, "attempts", attempts, "error", err)
+			if markErr := r.outbox.MarkFailed(ctx, entry.ID, err.Error()); markErr != nil {
+				return delivered, fmt.Errorf("failed to record delivery failure: %w", markErr)
+			}
+			blocked[entry.Event.ItemID] = true
+			continue
+		}
+
+		// 配信後にマークが失敗した場合は次回再送される（at-least-once）
+		if err := r.outbox.MarkDelivered(ctx, entry.ID); err != nil {
+			return delivered, fmt.Errorf("failed to mark event as delivered: %w", err)
+		}
+		delivered++
+	}
+
+	return delivered, nil
+}
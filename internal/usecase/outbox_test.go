@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockOutboxRepository はtestify/mockを使用したモックリポジトリ
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+func (m *MockOutboxRepository) FetchPending(ctx context.Context, limit int) ([]OutboxEntry, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]OutboxEntry), args.Error(1)
+}
+
+func (m *MockOutboxRepository) MarkDelivered(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) MarkFailed(ctx context.Context, id int64, reason string) error {
+	args := m.Called(ctx, id, reason)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) MarkDeadLettered(ctx context.Context, id int64, reason string) error {
+	args := m.Called(ctx, id, reason)
+	return args.Error(0)
+}
+
+// MockEventSender はtestify/mockを使用したモック送信先
+type MockEventSender struct {
+	mock.Mock
+}
+
+func (m *MockEventSender) Send(ctx context.Context, event ItemEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func TestEventRelay_RelayPending(t *testing.T) {
+	entries := []OutboxEntry{
+		{ID: 1, Event: ItemEvent{Type: EventItemCreated, ItemID: 10}},
+		{ID: 2, Event: ItemEvent{Type: EventItemUpdated, ItemID: 10}},
+	}
+	otherItem := OutboxEntry{ID: 3, Event: ItemEvent{Type: EventItemCreated, ItemID: 20}}
+
+	tests := []struct {
+		name              string
+		setupMock         func(*MockOutboxRepository, *MockEventSender)
+		expectedDelivered int
+		expectedSends     int
+		expectError       bool
+	}{
+		{
+			name: "正常系: 全てのイベントを配信",
+			setupMock: func(outbox *MockOutboxRepository, sender *MockEventSender) {
+				outbox.On("FetchPending", mock.Anything, 100).Return(entries, nil)
+				sender.On("Send", mock.Anything, mock.Anything).Return(nil)
+				outbox.On("MarkDelivered", mock.Anything, int64(1)).Return(nil)
+				outbox.On("MarkDelivered", mock.Anything, int64(2)).Return(nil)
+			},
+			expectedDelivered: 2,
+			expectedSends:     2,
+		},
+		{
+			name: "正常系: 送信待ちが無い場合",
+			setupMock: func(outbox *MockOutboxRepository, sender *MockEventSender) {
+				outbox.On("FetchPending", mock.Anything, 100).Return([]OutboxEntry{}, nil)
+			},
+			expectedDelivered: 0,
+		},
+		{
+			name: "異常系: 配信失敗時は失敗を記録し同じアイテムの以降のイベントは配信しない",
+			setupMock: func(outbox *MockOutboxRepository, sender *MockEventSender) {
+				outbox.On("FetchPending", mock.Anything, 100).Return(append(entries, otherItem), nil)
+				sender.On("Send", mock.Anything, entries[0].Event).Return(errors.New("status 500"))
+				outbox.On("MarkFailed", mock.Anything, int64(1), "status 500").Return(nil)
+				// 他のアイテムのイベントは失敗したイベントを待たずに配信する
+				sender.On("Send", mock.Anything, otherItem.Event).Return(nil)
+				outbox.On("MarkDelivered", mock.Anything, int64(3)).Return(nil)
+			},
+			expectedDelivered: 1,
+			expectedSends:     2,
+		},
+		{
+			name: "異常系: 上限の回数失敗したイベントは配信を諦め、同じアイテムの以降のイベントを配信する",
+			setupMock: func(outbox *MockOutboxRepository, sender *MockEventSender) {
+				failing := entries[0]
+				failing.Attempts = 9
+				outbox.On("FetchPending", mock.Anything, 100).Return([]OutboxEntry{failing, entries[1]}, nil)
+				sender.On("Send", mock.Anything, entries[0].Event).Return(errors.New("status 500"))
+				outbox.On("MarkDeadLettered", mock.Anything, int64(1), "status 500").Return(nil)
+				sender.On("Send", mock.Anything, entries[1].Event).Return(nil)
+				outbox.On("MarkDelivered", mock.Anything, int64(2)).Return(nil)
+			},
+			expectedDelivered: 1,
+			expectedSends:     2,
+		},
+		{
+			name: "異常系: 取得時のデータベースエラー",
+			setupMock: func(outbox *MockOutboxRepository, sender *MockEventSender) {
+				outbox.On("FetchPending", mock.Anything, 100).Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outbox := new(MockOutboxRepository)
+			sender := new(MockEventSender)
+			tt.setupMock(outbox, sender)
+
+			delivered, err := NewEventRelay(outbox, sender, 0, 10).RelayPending(context.Background())
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedDelivered, delivered)
+			outbox.AssertExpectations(t)
+			sender.AssertExpectations(t)
+			sender.AssertNumberOfCalls(t, "Send", tt.expectedSends)
+		})
+	}
+}
+
+func TestEventRelay_RelayPending_Observers(t *testing.T) {
+	entries := []OutboxEntry{
+		{ID: 1, Event: ItemEvent{Type: EventItemCreated, ItemID: 10}},
+		{ID: 2, Event: ItemEvent{Type: EventItemUpdated, ItemID: 10}},
+	}
+	retried := []OutboxEntry{{ID: 1, Event: entries[0].Event, Attempts: 1}, entries[1]}
+
+	outbox := new(MockOutboxRepository)
+	outbox.On("FetchPending", mock.Anything, 100).Return(entries, nil).Once()
+	outbox.On("FetchPending", mock.Anything, 100).Return(retried, nil).Once()
+	outbox.On("MarkFailed", mock.Anything, int64(1), "status 500").Return(nil).Twice()
+	sender := new(MockEventSender)
+	sender.On("Send", mock.Anything, entries[0].Event).Return(errors.New("status 500"))
+	observer := new(MockEventSender)
+	observer.On("Send", mock.Anything, entries[0].Event).Return(nil).Once()
+	observer.On("Send", mock.Anything, entries[1].Event).Return(nil).Once()
+	relay := NewEventRelay(outbox, sender, 0, 10, observer)
+
+	// Webhookの配信に失敗しても、observersにはすべてのイベントを1回だけ送る
+	for i := 0; i < 2; i++ {
+		delivered, err := relay.RelayPending(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, delivered)
+	}
+	outbox.AssertExpectations(t)
+	observer.AssertExpectations(t)
+	observer.AssertNumberOfCalls(t, "Send", 2)
+}
+
+func TestChainEventSenders(t *testing.T) {
+	event := ItemEvent{Type: EventItemCreated, ItemID: 10}
+
+	t.Run("正常系: すべての配信先へ順に送る", func(t *testing.T) {
+		first, second := new(MockEventSender), new(MockEventSender)
+		first.On("Send", mock.Anything, event).Return(nil)
+		second.On("Send", mock.Anything, event).Return(nil)
+
+		err := ChainEventSenders(first, second).Send(context.Background(), event)
+
+		assert.NoError(t, err)
+		first.AssertExpectations(t)
+		second.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 失敗した場合は以降の配信先に送らない", func(t *testing.T) {
+		first, second := new(MockEventSender), new(MockEventSender)
+		first.On("Send", mock.Anything, event).Return(errors.New("status 500"))
+
+		err := ChainEventSenders(first, second).Send(context.Background(), event)
+
+		assert.Error(t, err)
+		second.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+}
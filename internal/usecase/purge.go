@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 論理削除から一定期間が経過したアイテムを物理削除するユースケース
+// 同じ保持期間を過ぎた変更履歴も削除する（それより古い同期トークンは全件の同期になる）
+type ItemPurger interface {
+	// 保持期間を過ぎたアイテムを物理削除し、削除した件数を返す
+	PurgeDeletedItems(ctx context.Context) (int64, error)
+}
+
+type itemPurger struct {
+	itemRepo  ItemRepository
+	retention time.Duration
+	now       func() time.Time
+}
+
+func NewItemPurger(itemRepo ItemRepository, retention time.Duration) ItemPurger {
+	return &itemPurger{
+		itemRepo:  itemRepo,
+		retention: retention,
+		now:       entity.Now,
+	}
+}
+
+func (p *itemPurger) PurgeDeletedItems(ctx context.Context) (int64, error) {
+	before := p.now().Add(-p.retention)
+	purged, err := p.itemRepo.PurgeDeleted(ctx, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted items: %w", err)
+	}
+	if _, err := p.itemRepo.PruneChangeLog(ctx, before); err != nil {
+		return purged, fmt.Errorf("failed to prune change log: %w", err)
+	}
+	return purged, nil
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemPurger_PurgeDeletedItems(t *testing.T) {
+	now := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+	retention := 30 * 24 * time.Hour
+	before := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		setupMock      func(*MockItemRepository)
+		expectedPurged int64
+		expectError    bool
+	}{
+		{
+			name: "正常系: 保持期間を過ぎたアイテムを削除",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("PurgeDeleted", mock.Anything, before).Return(int64(3), nil)
+				mockRepo.On("PruneChangeLog", mock.Anything, before).Return(int64(10), nil)
+			},
+			expectedPurged: 3,
+		},
+		{
+			name: "異常系: データベースエラー",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("PurgeDeleted", mock.Anything, before).Return(int64(0), domainErrors.ErrDatabaseError)
+			},
+			expectError: true,
+		},
+		{
+			name: "異常系: 変更履歴の削除に失敗",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("PurgeDeleted", mock.Anything, before).Return(int64(3), nil)
+				mockRepo.On("PruneChangeLog", mock.Anything, before).Return(int64(0), domainErrors.ErrDatabaseError)
+			},
+			expectedPurged: 3,
+			expectError:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			purger := &itemPurger{itemRepo: mockRepo, retention: retention, now: func() time.Time { return now }}
+
+			purged, err := purger.PurgeDeletedItems(context.Background())
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.True(t, domainErrors.IsDatabaseError(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedPurged, purged)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
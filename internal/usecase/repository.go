@@ -2,27 +2,198 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"Aicon-assignment/internal/domain/entity"
 )
 
 // ItemRepository defines the interface for item data access
 type ItemRepository interface {
-	// FindAll retrieves all items
-	FindAll(ctx context.Context) ([]*entity.Item, error)
+	// FindAll retrieves all items matching the filter
+	FindAll(ctx context.Context, filter ItemFilter) ([]*entity.Item, error)
+
+	// FindPage retrieves one page of items matching the filter
+	FindPage(ctx context.Context, filter ItemFilter, page Page) ([]*entity.Item, error)
+
+	// Count returns the number of items matching the filter
+	Count(ctx context.Context, filter ItemFilter) (int, error)
+
+	// GetListVersion returns the number of items matching the filter, their latest updated_at and a digest of their versions
+	GetListVersion(ctx context.Context, filter ItemFilter) (*ListVersion, error)
 
 	// FindByID retrieves an item by ID
 	FindByID(ctx context.Context, id int64) (*entity.Item, error)
 
+	// FindIDByUUID returns the ID of the item with the given UUID, including soft-deleted items
+	FindIDByUUID(ctx context.Context, uuid string) (int64, error)
+
+	// AssignUUID sets the UUID of an item that does not have one yet and reports whether it was set
+	AssignUUID(ctx context.Context, id int64, uuid string) (bool, error)
+
+	// FindBatchAfter retrieves up to limit non-deleted items with an ID greater than afterID, in ID order (for backfills)
+	FindBatchAfter(ctx context.Context, afterID int64, limit int) ([]*entity.Item, error)
+
+	// FindChangedSince retrieves active items created or updated at or after the given time
+	FindChangedSince(ctx context.Context, since time.Time) ([]*entity.Item, error)
+
+	// FindDeletedIDsSince retrieves IDs of items soft-deleted at or after the given time
+	FindDeletedIDsSince(ctx context.Context, since time.Time) ([]int64, error)
+
+	// WasDeleted reports whether an item that is not found existed and has been deleted:
+	// it is soft-deleted, or the change log still records it (purged items no longer have a row)
+	WasDeleted(ctx context.Context, id int64) (bool, error)
+
+	// GetChangeLogRange returns the sequence numbers of the oldest and latest retained entries of the item change log,
+	// and the latest entry recorded at least ChangeLogSettleWindow ago
+	GetChangeLogRange(ctx context.Context) (ChangeLogRange, error)
+
+	// FindChangedAfter retrieves active items with a change log entry after the given sequence number
+	FindChangedAfter(ctx context.Context, seq int64) ([]*entity.Item, error)
+
+	// FindDeletedIDsAfter retrieves IDs of items with a change log entry after the given sequence number
+	// that are now soft-deleted or purged
+	FindDeletedIDsAfter(ctx context.Context, seq int64) ([]int64, error)
+
+	// PruneChangeLog removes change log entries recorded before the given time, always keeping the latest entry,
+	// and returns the number of entries removed
+	PruneChangeLog(ctx context.Context, before time.Time) (int64, error)
+
+	// FindChangeLog retrieves up to limit change log entries after the given sequence number, ordered by sequence number
+	FindChangeLog(ctx context.Context, seq int64, limit int) ([]ChangeLogEntry, error)
+
 	// Create creates a new item and returns it with the generated ID
 	Create(ctx context.Context, item *entity.Item) (*entity.Item, error)
 
-	// Update updates an existing item and returns the updated item
-	Update(ctx context.Context, item *entity.Item) (*entity.Item, error)
+	// Upsert inserts the item, or updates the item with the same serial number.
+	// The returned bool reports whether a new item was created.
+	Upsert(ctx context.Context, item *entity.Item) (*entity.Item, bool, error)
+
+	// Update writes only the changed columns of an item and returns the updated item
+	Update(ctx context.Context, id int64, changes ItemChanges) (*entity.Item, error)
+
+	// Delete soft-deletes an item by ID, recording the reason (empty for none) alongside deleted_at
+	Delete(ctx context.Context, id int64, reason string) error
+
+	// DeleteVersion soft-deletes an item only while its version is still the given one
+	// Returns ErrPreconditionFailed when it has been written since, ErrItemNotFound when it no longer exists
+	DeleteVersion(ctx context.Context, id, version int64, reason string) error
+
+	// PurgeDeleted permanently removes items soft-deleted before the given time and returns the number of rows removed
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+
+	// DeleteAll permanently removes every item, including soft-deleted ones, and returns the number of rows removed
+	DeleteAll(ctx context.Context) (int64, error)
+
+	// Restore writes an item from a backup keeping its ID, status and timestamps.
+	// An item with the same ID or serial number is overwritten (and undeleted); an item without an ID gets a new one.
+	Restore(ctx context.Context, item *entity.Item) (RestoreOutcome, error)
+
+	// GetSummaryByCategory returns item counts and purchase price totals grouped by category (bonus feature)
+	GetSummaryByCategory(ctx context.Context) (map[string]CategoryTotal, error)
+
+	// GetSummaryByCategories returns the same totals restricted to the given categories in a single grouped query.
+	// Categories without items are omitted from the result.
+	GetSummaryByCategories(ctx context.Context, categories []string) (map[string]CategoryTotal, error)
+
+	// GetSummaryByCategoryAsOf returns the same totals for items that existed at the given time
+	// (created at or before it and not yet soft-deleted). Purged items are not included.
+	GetSummaryByCategoryAsOf(ctx context.Context, asOf time.Time) (map[string]CategoryTotal, error)
+
+	// GetUsedCategories returns categories that have at least one item, ordered by count descending
+	GetUsedCategories(ctx context.Context) ([]CategoryCount, error)
+
+	// GetSummaryByBrand returns item counts and purchase price totals grouped by brand for items matching the filter,
+	// ordered by count descending
+	GetSummaryByBrand(ctx context.Context, filter ItemFilter) ([]BrandSummaryEntry, error)
+
+	// GetSummaryByGroup returns item counts and purchase price totals grouped by the given allowlisted column
+	// for items matching the filter, ordered by count descending
+	GetSummaryByGroup(ctx context.Context, filter ItemFilter, by GroupBy) ([]GroupSummaryEntry, error)
 
-	// Delete deletes an item by ID
-	Delete(ctx context.Context, id int64) error
+	// GetSummaryByPurchaseYear returns item counts and purchase price totals grouped by purchase year for items
+	// matching the filter, ordered by year ascending. Items whose purchase date has no valid year are grouped
+	// under a nil year at the end.
+	GetSummaryByPurchaseYear(ctx context.Context, filter ItemFilter) ([]TimelineEntry, error)
+
+	// FindSiblings returns the items immediately before and after the given item when the items matching the filter
+	// are ordered by filter.Sort. It returns ErrItemNotFound if the item does not match the filter.
+	FindSiblings(ctx context.Context, id int64, filter ItemFilter) (*ItemSiblings, error)
+}
+
+// ItemImageRepository defines the interface for item image data access
+type ItemImageRepository interface {
+	// FindByItemID retrieves the images of an item ordered by position, without the image data
+	FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemImage, error)
+
+	// FindCoversByItemIDs retrieves the first image by position of each of the given items, without the image data.
+	// Items without images are omitted.
+	FindCoversByItemIDs(ctx context.Context, itemIDs []int64) ([]*entity.ItemImage, error)
+
+	// FindByID retrieves an image of an item including the image data
+	FindByID(ctx context.Context, itemID, imageID int64) (*entity.ItemImage, error)
+
+	// Create stores a new image and returns it with the generated ID
+	Create(ctx context.Context, image *entity.ItemImage) (*entity.ItemImage, error)
+
+	// Delete removes an image of an item
+	Delete(ctx context.Context, itemID, imageID int64) error
+
+	// FindBatchAfter retrieves up to limit images with an ID greater than afterID, ordered by ID, including the image data.
+	// Images of soft-deleted items are included.
+	FindBatchAfter(ctx context.Context, afterID int64, limit int) ([]*entity.ItemImage, error)
+
+	// UpdateThumbnail replaces the thumbnail of an image (nil clears it)
+	UpdateThumbnail(ctx context.Context, imageID int64, thumbnail []byte) error
+}
+
+// SearchPresetRepository defines the interface for named search preset data access
+type SearchPresetRepository interface {
+	// Save stores the preset, replacing the filter of an existing preset with the same name
+	Save(ctx context.Context, preset *entity.SearchPreset) (*entity.SearchPreset, error)
+
+	// FindByName retrieves a preset by name
+	FindByName(ctx context.Context, name string) (*entity.SearchPreset, error)
+
+	// FindAll retrieves all presets ordered by name
+	FindAll(ctx context.Context) ([]*entity.SearchPreset, error)
+
+	// Delete removes a preset by name
+	Delete(ctx context.Context, name string) error
+}
+
+// IntegrityRepository finds and repairs rows that violate the current validation rules
+type IntegrityRepository interface {
+	// FindViolations retrieves up to limit IDs (in ID order) of the rows violating the check, and the number of all such rows.
+	// Soft-deleted items are included.
+	FindViolations(ctx context.Context, check IntegrityCheck, limit int) ([]int64, int, error)
+
+	// FixViolations repairs all rows violating a fixable check and returns the number of rows changed
+	FixViolations(ctx context.Context, check IntegrityCheck) (int64, error)
+}
+
+// QueryExplainer returns the execution plan (EXPLAIN FORMAT=JSON) of the queries issued by ItemRepository
+type QueryExplainer interface {
+	// ExplainFindAll explains the query used by FindAll
+	ExplainFindAll(ctx context.Context, filter ItemFilter) (json.RawMessage, error)
+
+	// ExplainFindByID explains the query used by FindByID
+	ExplainFindByID(ctx context.Context, id int64) (json.RawMessage, error)
+
+	// ExplainSummaryByCategory explains the query used by GetSummaryByCategory
+	ExplainSummaryByCategory(ctx context.Context) (json.RawMessage, error)
+}
+
+// 読み取りもプライマリーで実行させるctxのキー
+type primaryReadsKey struct{}
+
+// WithPrimaryReads marks ctx so that repository reads made with it go to the primary instead of a replica.
+func WithPrimaryReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryReadsKey{}, true)
+}
 
-	// GetSummaryByCategory returns item counts grouped by category (bonus feature)
-	GetSummaryByCategory(ctx context.Context) (map[string]int, error)
+// UsePrimaryReads reports whether ctx was marked by WithPrimaryReads.
+func UsePrimaryReads(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryReadsKey{}).(bool)
+	return primary
 }
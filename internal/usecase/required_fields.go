@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// カテゴリーごとに必須にできるフィールドと、値があるかどうかの判定（登録時に常に必須のフィールドは含まない）
+var requirableFields = map[string]func(item *entity.Item) bool{
+	"serial_number":  func(item *entity.Item) bool { return item.SerialNumber != "" },
+	"purchase_price": func(item *entity.Item) bool { return item.PurchasePrice.Amount > 0 },
+}
+
+// カテゴリーごとに、登録時に常に必須のフィールドに加えて必須とするフィールドを設定する
+func WithCategoryRequiredFields(fields map[entity.Category][]string) Option {
+	return func(u *itemUsecase) {
+		u.categoryRequiredFields = fields
+	}
+}
+
+// "カテゴリー=フィールド" 形式の値から設定を作る（例: 時計=serial_number、同じカテゴリーを複数指定できる）
+func ParseCategoryRequiredFields(values []string) (map[entity.Category][]string, error) {
+	fields := make(map[entity.Category][]string)
+	for _, value := range values {
+		rawCategory, field, ok := strings.Cut(value, "=")
+		field = strings.TrimSpace(field)
+		if !ok || field == "" {
+			return nil, fmt.Errorf("%s (must be in the form category=field)", value)
+		}
+		category, err := entity.NewCategory(rawCategory)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", value, err)
+		}
+		if _, exists := requirableFields[field]; !exists {
+			return nil, fmt.Errorf("%s: field must be one of: %s", value, strings.Join(requirableFieldNames(), ", "))
+		}
+		fields[category] = append(fields[category], field)
+	}
+	return fields, nil
+}
+
+func requirableFieldNames() []string {
+	names := make([]string, 0, len(requirableFields))
+	for name := range requirableFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// アイテムのカテゴリーで必須としたフィールドに値があるかを確認する
+// checkedがnilでない場合は、checkedがtrueを返すフィールドのみを確認する
+func (u *itemUsecase) checkRequiredFields(item *entity.Item, checked func(field string) bool) error {
+	var missing []string
+	for _, field := range u.categoryRequiredFields[item.Category] {
+		if checked != nil && !checked(field) {
+			continue
+		}
+		if !requirableFields[field](item) {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return &domainErrors.MissingRequiredFieldsError{Category: item.Category.String(), Fields: missing}
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestParseCategoryRequiredFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    map[entity.Category][]string
+		wantErr bool
+	}{
+		{
+			name:   "正常系: 同じカテゴリーを複数指定",
+			values: []string{"時計=serial_number", " ジュエリー = serial_number", "ジュエリー=purchase_price"},
+			want: map[entity.Category][]string{
+				entity.CategoryWatch:   {"serial_number"},
+				entity.CategoryJewelry: {"serial_number", "purchase_price"},
+			},
+		},
+		{name: "正常系: 未設定", values: nil, want: map[entity.Category][]string{}},
+		{name: "異常系: 区切りが無い", values: []string{"時計"}, wantErr: true},
+		{name: "異常系: 無効なカテゴリー", values: []string{"家電=serial_number"}, wantErr: true},
+		{name: "異常系: 必須にできないフィールド", values: []string{"時計=color"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCategoryRequiredFields(tt.values)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestItemUsecase_CreateItem_CategoryRequiredFields(t *testing.T) {
+	requiredFields := WithCategoryRequiredFields(map[entity.Category][]string{entity.CategoryWatch: {"serial_number"}})
+
+	t.Run("異常系: 必須のシリアル番号が無い", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo, requiredFields)
+
+		_, err := usecase.CreateItem(context.Background(), CreateItemInput{Name: "デイトナ", Category: entity.CategoryWatch, Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"})
+
+		var missingErr *domainErrors.MissingRequiredFieldsError
+		require.ErrorAs(t, err, &missingErr)
+		assert.Equal(t, []string{"serial_number"}, missingErr.Fields)
+		assert.True(t, domainErrors.IsValidationError(err))
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: 設定していないカテゴリーは必須にしない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1}, nil)
+		usecase := NewItemUsecase(mockRepo, requiredFields)
+
+		_, err := usecase.CreateItem(context.Background(), CreateItemInput{Name: "ノート", Category: entity.CategoryOther, Brand: "MUJI", PurchasePrice: 100, PurchaseDate: "2023-01-15"})
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestItemUsecase_UpdateItem_CategoryRequiredFields(t *testing.T) {
+	requiredFields := WithCategoryRequiredFields(map[entity.Category][]string{entity.CategoryWatch: {"serial_number", "purchase_price"}})
+	existing := func() *entity.Item {
+		return &entity.Item{ID: 1, Name: "デイトナ", Category: entity.CategoryWatch, Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15", Status: entity.StatusActive}
+	}
+
+	t.Run("正常系: 変更しないフィールドは確認しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing(), nil)
+		mockRepo.On("Update", mock.Anything, int64(1), mock.Anything).Return(existing(), nil)
+		usecase := NewItemUsecase(mockRepo, requiredFields)
+
+		_, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{Name: stringPtr("デイトナ 116500")})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("異常系: 必須の購入価格を0にする", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing(), nil)
+		usecase := NewItemUsecase(mockRepo, requiredFields)
+
+		_, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{PurchasePrice: int64Ptr(0)})
+
+		assert.ErrorIs(t, err, domainErrors.ErrMissingRequiredFields)
+		assert.ErrorContains(t, err, "purchase_price (required for 時計)")
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
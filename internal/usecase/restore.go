@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// バックアップからの復元のモード
+type RestoreMode string
+
+const (
+	// すべてのアイテム（論理削除済みを含む）を削除してから読み込む
+	RestoreModeReplace RestoreMode = "replace"
+	// IDまたはシリアル番号が一致するアイテムを更新し、それ以外を登録する
+	RestoreModeMerge RestoreMode = "merge"
+)
+
+// 有効なモードかどうか
+func (m RestoreMode) IsValid() bool {
+	return m == RestoreModeReplace || m == RestoreModeMerge
+}
+
+// 1件のアイテムを書き込んだ結果
+type RestoreOutcome int
+
+const (
+	RestoreCreated RestoreOutcome = iota
+	RestoreUpdated
+	RestoreUnchanged
+)
+
+// 復元の結果
+type RestoreResult struct {
+	Mode      RestoreMode `json:"mode"`
+	Total     int         `json:"total"`
+	Created   int         `json:"created"`
+	Updated   int         `json:"updated"`
+	Unchanged int         `json:"unchanged"`
+	Deleted   int64       `json:"deleted"` // replaceで復元前に削除した件数
+}
+
+// バックアップファイル（GET /items などと同じ形式のJSON）からアイテムを復元するユースケース
+type ItemRestorer interface {
+	// すべてのアイテムを検証してから、1トランザクションで書き込む（1件でも失敗した場合は何も変更しない）
+	RestoreItems(ctx context.Context, items []*entity.Item, mode RestoreMode) (*RestoreResult, error)
+}
+
+type itemRestorer struct {
+	itemRepo   ItemRepository
+	transactor Transactor
+
+	// ファイルの日時として受け付ける、サーバーの時刻より未来の幅と過去の幅（0の場合は確認しない）
+	maxFutureSkew time.Duration
+	maxAge        time.Duration
+	// 復元した後に破棄するGetItemByIDのキャッシュ（nilの場合は使わない）
+	cache *ItemCache
+}
+
+type RestorerOption func(*itemRestorer)
+
+// ファイルの登録日時・更新日時・削除日時が、サーバーの時刻からmaxFutureSkewより未来、
+// またはmaxAgeより過去の場合は復元しない（0の場合はその方向を確認しない）
+func WithTimestampTolerance(maxFutureSkew, maxAge time.Duration) RestorerOption {
+	return func(r *itemRestorer) {
+		r.maxFutureSkew = maxFutureSkew
+		r.maxAge = maxAge
+	}
+}
+
+// 復元した後に、cacheが保持しているアイテムをすべて破棄する
+// 復元ではアイテムごとのイベント（item.created など）は記録しないため、Webhookの受信側は復元後に一覧を取得し直す
+func WithRestoreItemCache(cache *ItemCache) RestorerOption {
+	return func(r *itemRestorer) {
+		r.cache = cache
+	}
+}
+
+func NewItemRestorer(itemRepo ItemRepository, transactor Transactor, opts ...RestorerOption) ItemRestorer {
+	r := &itemRestorer{
+		itemRepo:   itemRepo,
+		transactor: transactor,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func (r *itemRestorer) RestoreItems(ctx context.Context, items []*entity.Item, mode RestoreMode) (*RestoreResult, error) {
+	if !mode.IsValid() {
+		return nil, fmt.Errorf("%w: mode must be one of: replace, merge", domainErrors.ErrInvalidInput)
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: items must not be empty", domainErrors.ErrInvalidInput)
+	}
+	if err := prepareRestoreItems(items); err != nil {
+		return nil, err
+	}
+	if err := r.checkTimestamps(items, entity.Now()); err != nil {
+		return nil, err
+	}
+
+	result := &RestoreResult{Mode: mode, Total: len(items)}
+	// 失敗してロールバックした場合も、途中で読み込まれた値を残さないよう破棄する
+	defer r.cache.InvalidateAll()
+	err := r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if mode == RestoreModeReplace {
+			deleted, err := r.itemRepo.DeleteAll(ctx)
+			if err != nil {
+				return err
+			}
+			result.Deleted = deleted
+		}
+
+		for index, item := range items {
+			outcome, err := r.itemRepo.Restore(ctx, item)
+			if err != nil {
+				if domainErrors.IsDuplicateEntryError(err) {
+					return bulkDuplicateEntryError(index, err)
+				}
+				return err
+			}
+			switch outcome {
+			case RestoreCreated:
+				result.Created++
+			case RestoreUpdated:
+				result.Updated++
+			default:
+				result.Unchanged++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore items: %w", err)
+	}
+
+	return result, nil
+}
+
+// 各アイテムを検証し、ファイル内でのIDとシリアル番号の重複を確認する（何件目かは0始まり）
+// 登録日時・更新日時が無い場合は現在時刻とする
+func prepareRestoreItems(items []*entity.Item) error {
+	now := entity.Now()
+	idIndexes := make(map[int64]int)
+	serialIndexes := make(map[string]int)
+	for index, item := range items {
+		if item == nil {
+			return fmt.Errorf("%w: items[%d]: item must be an object", domainErrors.ErrInvalidInput, index)
+		}
+		item.Name = strings.TrimSpace(item.Name)
+		item.Category = item.Category.Normalize()
+		item.Brand = strings.TrimSpace(item.Brand)
+		item.SerialNumber = strings.TrimSpace(item.SerialNumber)
+		if item.PurchasePrice.Currency == "" {
+			item.PurchasePrice.Currency = entity.PriceCurrency
+		}
+		if item.CreatedAt.IsZero() {
+			item.CreatedAt = now
+		}
+		if item.UpdatedAt.IsZero() {
+			item.UpdatedAt = now
+		}
+
+		if err := item.Validate(); err != nil {
+			return fmt.Errorf("%w: items[%d]: %s", domainErrors.ErrInvalidInput, index, err.Error())
+		}
+		if item.PurchasePrice.Currency != entity.PriceCurrency {
+			return fmt.Errorf("%w: items[%d]: purchase_price currency must be %s", domainErrors.ErrInvalidInput, index, entity.PriceCurrency)
+		}
+		if item.ID < 0 {
+			return fmt.Errorf("%w: items[%d]: id must be greater than 0", domainErrors.ErrInvalidInput, index)
+		}
+
+		if item.ID > 0 {
+			if first, exists := idIndexes[item.ID]; exists {
+				return fmt.Errorf("%w: items[%d]: id is duplicated with items[%d]", domainErrors.ErrInvalidInput, index, first)
+			}
+			idIndexes[item.ID] = index
+		}
+		if item.SerialNumber != "" {
+			if first, exists := serialIndexes[item.SerialNumber]; exists {
+				return fmt.Errorf("%w: items[%d]: serial_number is duplicated with items[%d]", domainErrors.ErrInvalidInput, index, first)
+			}
+			serialIndexes[item.SerialNumber] = index
+		}
+	}
+	return nil
+}
+
+// DBのTIMESTAMP型に保存できる最も古い日時（これより前は許容範囲に関わらず受け付けない）
+var minRestoreTimestamp = time.Unix(1, 0)
+
+// 各アイテムの日時がサーバーの時刻nowからの許容範囲にあるかを確認する（何件目かは0始まり）
+func (r *itemRestorer) checkTimestamps(items []*entity.Item, now time.Time) error {
+	for index, item := range items {
+		timestamps := map[string]time.Time{"created_at": item.CreatedAt, "updated_at": item.UpdatedAt}
+		if item.DeletedAt != nil {
+			timestamps["deleted_at"] = *item.DeletedAt
+		}
+
+		for _, name := range []string{"created_at", "updated_at", "deleted_at"} {
+			value, ok := timestamps[name]
+			if !ok {
+				continue
+			}
+			switch {
+			case r.maxFutureSkew > 0 && value.After(now.Add(r.maxFutureSkew)):
+				return fmt.Errorf("%w: items[%d]: %s must not be more than %s in the future", domainErrors.ErrTimestampOutOfRange, index, name, r.maxFutureSkew)
+			case r.maxAge > 0 && value.Before(now.Add(-r.maxAge)):
+				return fmt.Errorf("%w: items[%d]: %s must not be more than %s in the past", domainErrors.ErrTimestampOutOfRange, index, name, r.maxAge)
+			case value.Before(minRestoreTimestamp):
+				return fmt.Errorf("%w: items[%d]: %s must be after 1970-01-01", domainErrors.ErrTimestampOutOfRange, index, name)
+			}
+		}
+	}
+	return nil
+}
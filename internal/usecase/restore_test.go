@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemRestorer_RestoreItems(t *testing.T) {
+	createdAt := time.Date(2023, 1, 15, 9, 0, 0, 0, time.UTC)
+	newItems := func() []*entity.Item {
+		return []*entity.Item{
+			{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15", SerialNumber: "SN-001", Status: entity.StatusActive, CreatedAt: createdAt, UpdatedAt: createdAt},
+			{ID: 2, Name: "エルメス バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: entity.JPY(2000000), PurchaseDate: "2023-02-20", Status: entity.StatusArchived, CreatedAt: createdAt, UpdatedAt: createdAt},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mode           RestoreMode
+		items          func() []*entity.Item
+		setupMock      func(*MockItemRepository)
+		expectedResult *RestoreResult
+		expectedErr    string
+	}{
+		{
+			name:  "正常系: replaceは全件削除してから登録",
+			mode:  RestoreModeReplace,
+			items: newItems,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("DeleteAll", mock.Anything).Return(int64(5), nil)
+				mockRepo.On("Restore", mock.Anything, mock.Anything).Return(RestoreCreated, nil).Twice()
+			},
+			expectedResult: &RestoreResult{Mode: RestoreModeReplace, Total: 2, Created: 2, Deleted: 5},
+		},
+		{
+			name:  "正常系: mergeは既存のアイテムを更新",
+			mode:  RestoreModeMerge,
+			items: newItems,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Restore", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.ID == 1 })).Return(RestoreUpdated, nil)
+				mockRepo.On("Restore", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.ID == 2 })).Return(RestoreUnchanged, nil)
+			},
+			expectedResult: &RestoreResult{Mode: RestoreModeMerge, Total: 2, Updated: 1, Unchanged: 1},
+		},
+		{
+			name: "異常系: 不正なアイテムがある場合は何も変更しない",
+			mode: RestoreModeReplace,
+			items: func() []*entity.Item {
+				items := newItems()
+				items[1].Category = "家具"
+				return items
+			},
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: "items[1]",
+		},
+		{
+			name: "異常系: ファイル内でIDが重複",
+			mode: RestoreModeMerge,
+			items: func() []*entity.Item {
+				items := newItems()
+				items[1].ID = 1
+				return items
+			},
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: "items[1]: id is duplicated with items[0]",
+		},
+		{
+			name:        "異常系: 不正なモード",
+			mode:        "append",
+			items:       newItems,
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: "mode must be one of: replace, merge",
+		},
+		{
+			name:        "異常系: 空のファイル",
+			mode:        RestoreModeMerge,
+			items:       func() []*entity.Item { return nil },
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: "items must not be empty",
+		},
+		{
+			name:  "異常系: シリアル番号の一意制約違反",
+			mode:  RestoreModeMerge,
+			items: newItems,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Restore", mock.Anything, mock.Anything).Return(RestoreCreated, nil).Once()
+				mockRepo.On("Restore", mock.Anything, mock.Anything).Return(RestoreCreated, &domainErrors.DuplicateEntryError{Field: "serial_number", Message: "serial_number already exists"}).Once()
+			},
+			expectedErr: "items[1]: serial_number already exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			restorer := NewItemRestorer(mockRepo, noopTransactor{})
+
+			result, err := restorer.RestoreItems(context.Background(), tt.items(), tt.mode)
+
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemRestorer_RestoreItems_InvalidatesCache(t *testing.T) {
+	cache := NewItemCache(10, time.Minute)
+	cache.put(cacheTestItem(1, "復元前"), cache.currentGeneration())
+
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("Restore", mock.Anything, mock.Anything).Return(RestoreUpdated, nil)
+	restorer := NewItemRestorer(mockRepo, noopTransactor{}, WithRestoreItemCache(cache))
+
+	createdAt := time.Date(2023, 1, 15, 9, 0, 0, 0, time.UTC)
+	_, err := restorer.RestoreItems(context.Background(), []*entity.Item{
+		{ID: 1, Name: "復元後", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15", Status: entity.StatusActive, CreatedAt: createdAt, UpdatedAt: createdAt},
+	}, RestoreModeMerge)
+
+	assert.NoError(t, err)
+	// 復元前の値をGetItemByIDで返し続けないよう破棄する
+	_, ok := cache.get(1)
+	assert.False(t, ok)
+}
+
+func TestPrepareRestoreItems_Defaults(t *testing.T) {
+	item := &entity.Item{Name: " デイトナ ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.Money{Amount: 1000}, PurchaseDate: "2023-01-15"}
+
+	assert.NoError(t, prepareRestoreItems([]*entity.Item{item}))
+	assert.Equal(t, "デイトナ", item.Name)
+	assert.Equal(t, entity.PriceCurrency, item.PurchasePrice.Currency)
+	assert.False(t, item.CreatedAt.IsZero())
+	assert.False(t, item.UpdatedAt.IsZero())
+}
+
+func TestItemRestorer_CheckTimestamps(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	deletedAt := now.Add(time.Hour)
+	restorer := &itemRestorer{maxFutureSkew: 5 * time.Minute, maxAge: 10 * 365 * 24 * time.Hour}
+
+	tests := []struct {
+		name        string
+		item        *entity.Item
+		expectedErr string
+	}{
+		{name: "正常系: 許容範囲内の未来", item: &entity.Item{CreatedAt: now, UpdatedAt: now.Add(4 * time.Minute)}},
+		{name: "異常系: 未来すぎる更新日時", item: &entity.Item{CreatedAt: now, UpdatedAt: now.Add(10 * time.Minute)}, expectedErr: "items[0]: updated_at must not be more than 5m0s in the future"},
+		{name: "異常系: 古すぎる登録日時", item: &entity.Item{CreatedAt: now.AddDate(-20, 0, 0), UpdatedAt: now}, expectedErr: "items[0]: created_at must not be more than"},
+		{name: "異常系: 未来すぎる削除日時", item: &entity.Item{CreatedAt: now, UpdatedAt: now, DeletedAt: &deletedAt}, expectedErr: "items[0]: deleted_at"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := restorer.checkTimestamps([]*entity.Item{tt.item}, now)
+
+			if tt.expectedErr != "" {
+				assert.ErrorIs(t, err, domainErrors.ErrTimestampOutOfRange)
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	// 許容範囲を設定しない場合も、TIMESTAMP型に保存できない日時は受け付けない
+	unlimited := &itemRestorer{}
+	assert.NoError(t, unlimited.checkTimestamps([]*entity.Item{{CreatedAt: now.AddDate(50, 0, 0), UpdatedAt: now}}, now))
+	assert.ErrorIs(t, unlimited.checkTimestamps([]*entity.Item{{CreatedAt: time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC), UpdatedAt: now}}, now), domainErrors.ErrTimestampOutOfRange)
+}
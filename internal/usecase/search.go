@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+const (
+	// あいまい検索で一致とみなす類似度の下限（0〜1）
+	FuzzyMinSimilarity = 0.6
+	// あいまい検索で返す最大件数
+	FuzzyMaxResults = 50
+)
+
+// 入力ミスを許容してQueryに近いアイテムを検索し、類似度の高い順に返す
+// 類似度はアプリケーション側で計算するため、Query以外の条件で絞り込んだ全件を判定する
+func (u *itemUsecase) searchItemsFuzzy(ctx context.Context, filter ItemFilter) ([]*entity.Item, error) {
+	query := filter.Query
+	filter.Query = ""
+	filter.Fuzzy = false
+
+	candidates, err := u.itemRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+
+	return rankBySimilarity(candidates, query), nil
+}
+
+type scoredItem struct {
+	item  *entity.Item
+	score float64
+}
+
+// 類似度の下限を満たすアイテムを類似度の高い順に並べる（同じ類似度の場合は元の順序）
+func rankBySimilarity(items []*entity.Item, query string) []*entity.Item {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	scored := make([]scoredItem, 0, len(items))
+	for _, item := range items {
+		if score := itemSimilarity(item, query); score >= FuzzyMinSimilarity {
+			scored = append(scored, scoredItem{item: item, score: score})
+		}
+	}
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+	if len(scored) > FuzzyMaxResults {
+		scored = scored[:FuzzyMaxResults]
+	}
+
+	ranked := make([]*entity.Item, len(scored))
+	for i, s := range scored {
+		ranked[i] = s.item
+	}
+	return ranked
+}
+
+// 名前・ブランド全体と、名前の各単語のうち最もQueryに近いものの類似度
+// Queryを部分文字列として含む場合は1とする
+func itemSimilarity(item *entity.Item, query string) float64 {
+	name := strings.ToLower(item.Name)
+	brand := strings.ToLower(item.Brand)
+	if strings.Contains(name, query) || strings.Contains(brand, query) {
+		return 1
+	}
+
+	targets := append([]string{name, brand}, strings.FieldsFunc(name, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	})...)
+
+	best := 0.0
+	for _, target := range targets {
+		if score := similarity(query, target); score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+// 編集距離をもとにした類似度（1が完全一致、0が全く異なる）
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+// 文字（rune）単位のレーベンシュタイン距離
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 名前を付けた検索条件を管理するユースケース
+// 検索条件の形式はcontrollerが解釈するため、ここでは保存したJSONをそのまま扱う
+type SearchPresetUsecase interface {
+	// 同じ名前の検索条件がある場合は置き換える
+	SavePreset(ctx context.Context, name string, filter json.RawMessage) (*entity.SearchPreset, error)
+	GetPreset(ctx context.Context, name string) (*entity.SearchPreset, error)
+	// 名前の順に返す
+	ListPresets(ctx context.Context) ([]*entity.SearchPreset, error)
+	DeletePreset(ctx context.Context, name string) error
+}
+
+type searchPresetUsecase struct {
+	presetRepo SearchPresetRepository
+}
+
+func NewSearchPresetUsecase(presetRepo SearchPresetRepository) SearchPresetUsecase {
+	return &searchPresetUsecase{presetRepo: presetRepo}
+}
+
+func (u *searchPresetUsecase) SavePreset(ctx context.Context, name string, filter json.RawMessage) (*entity.SearchPreset, error) {
+	preset, err := entity.NewSearchPreset(name, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	saved, err := u.presetRepo.Save(ctx, preset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save search preset: %w", err)
+	}
+	return saved, nil
+}
+
+func (u *searchPresetUsecase) GetPreset(ctx context.Context, name string) (*entity.SearchPreset, error) {
+	preset, err := u.presetRepo.FindByName(ctx, name)
+	if err != nil {
+		if domainErrors.IsPresetNotFoundError(err) {
+			return nil, domainErrors.ErrPresetNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve search preset: %w", err)
+	}
+	return preset, nil
+}
+
+func (u *searchPresetUsecase) ListPresets(ctx context.Context) ([]*entity.SearchPreset, error) {
+	presets, err := u.presetRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve search presets: %w", err)
+	}
+	// 0件でもJSONでは空配列を返す
+	if presets == nil {
+		presets = []*entity.SearchPreset{}
+	}
+	return presets, nil
+}
+
+func (u *searchPresetUsecase) DeletePreset(ctx context.Context, name string) error {
+	if err := u.presetRepo.Delete(ctx, name); err != nil {
+		if domainErrors.IsPresetNotFoundError(err) {
+			return domainErrors.ErrPresetNotFound
+		}
+		return fmt.Errorf("failed to delete search preset: %w", err)
+	}
+	return nil
+}
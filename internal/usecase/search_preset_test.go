@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockSearchPresetRepository はtestify/mockを使用した検索条件のモックリポジトリ
+type MockSearchPresetRepository struct {
+	mock.Mock
+}
+
+func (m *MockSearchPresetRepository) Save(ctx context.Context, preset *entity.SearchPreset) (*entity.SearchPreset, error) {
+	args := m.Called(ctx, preset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.SearchPreset), args.Error(1)
+}
+
+func (m *MockSearchPresetRepository) FindByName(ctx context.Context, name string) (*entity.SearchPreset, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.SearchPreset), args.Error(1)
+}
+
+func (m *MockSearchPresetRepository) FindAll(ctx context.Context) ([]*entity.SearchPreset, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.SearchPreset), args.Error(1)
+}
+
+func (m *MockSearchPresetRepository) Delete(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+func TestSearchPresetUsecase_SavePreset(t *testing.T) {
+	filter := json.RawMessage(`{"brand":"ROLEX"}`)
+
+	t.Run("正常系: 前後の空白を除いた名前で保存", func(t *testing.T) {
+		mockRepo := new(MockSearchPresetRepository)
+		mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(p *entity.SearchPreset) bool {
+			return p.Name == "rolex" && string(p.Filter) == string(filter)
+		})).Return(&entity.SearchPreset{Name: "rolex", Filter: filter}, nil)
+		usecase := NewSearchPresetUsecase(mockRepo)
+
+		preset, err := usecase.SavePreset(context.Background(), " rolex ", filter)
+
+		require.NoError(t, err)
+		assert.Equal(t, "rolex", preset.Name)
+		mockRepo.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name       string
+		presetName string
+	}{
+		{name: "異常系: 名前が空", presetName: " "},
+		{name: "異常系: 名前が長すぎる", presetName: strings.Repeat("あ", entity.MaxSearchPresetNameLength+1)},
+		{name: "異常系: 名前にスラッシュを含む", presetName: "a/b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockSearchPresetRepository)
+			usecase := NewSearchPresetUsecase(mockRepo)
+
+			_, err := usecase.SavePreset(context.Background(), tt.presetName, filter)
+
+			assert.True(t, domainErrors.IsValidationError(err))
+			mockRepo.AssertNotCalled(t, "Save")
+		})
+	}
+}
+
+func TestSearchPresetUsecase_GetPreset(t *testing.T) {
+	t.Run("異常系: 存在しない", func(t *testing.T) {
+		mockRepo := new(MockSearchPresetRepository)
+		mockRepo.On("FindByName", mock.Anything, "missing").Return(nil, domainErrors.ErrPresetNotFound)
+		usecase := NewSearchPresetUsecase(mockRepo)
+
+		_, err := usecase.GetPreset(context.Background(), "missing")
+
+		assert.True(t, domainErrors.IsPresetNotFoundError(err))
+	})
+
+	t.Run("異常系: データベースエラー", func(t *testing.T) {
+		mockRepo := new(MockSearchPresetRepository)
+		mockRepo.On("FindByName", mock.Anything, "rolex").Return(nil, domainErrors.ErrDatabaseError)
+		usecase := NewSearchPresetUsecase(mockRepo)
+
+		_, err := usecase.GetPreset(context.Background(), "rolex")
+
+		assert.True(t, domainErrors.IsDatabaseError(err))
+	})
+}
+
+func TestSearchPresetUsecase_ListPresets(t *testing.T) {
+	mockRepo := new(MockSearchPresetRepository)
+	mockRepo.On("FindAll", mock.Anything).Return(nil, nil)
+	usecase := NewSearchPresetUsecase(mockRepo)
+
+	presets, err := usecase.ListPresets(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.SearchPreset{}, presets)
+}
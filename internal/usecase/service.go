@@ -3,52 +3,266 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"slices"
 	"strings"
+	"time"
 
 	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
 )
 
 type ItemUsecase interface {
-	GetAllItems(ctx context.Context) ([]*entity.Item, error)
+	GetAllItems(ctx context.Context, filter ItemFilter) ([]*entity.Item, error)
+	GetItemPage(ctx context.Context, filter ItemFilter, page Page) (*ItemPage, error)
+	GetItemListVersion(ctx context.Context, filter ItemFilter) (*ListVersion, error)
 	GetItemByID(ctx context.Context, id int64) (*entity.Item, error)
+	ResolveItemID(ctx context.Context, uuid string) (int64, error)
 	CreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error)
+	UpsertItem(ctx context.Context, input CreateItemInput) (*entity.Item, bool, error)
+	ImportItems(ctx context.Context, rows []ImportRow, mode ImportMode) (*ImportResult, error)
+	BulkCreateItems(ctx context.Context, inputs []CreateItemInput) ([]*entity.Item, error)
+	BulkCreateItemsBestEffort(ctx context.Context, inputs []CreateItemInput) ([]BulkItemResult, error)
 	UpdateItem(ctx context.Context, id int64, input UpdateItemInput) (*entity.Item, error)
-	DeleteItem(ctx context.Context, id int64) error
+	DeleteItem(ctx context.Context, id int64, input DeleteItemInput) error
+	DeleteItemsByFilter(ctx context.Context, filter ItemFilter, input DeleteByFilterInput) (int, error)
+	ArchiveItem(ctx context.Context, id int64) (*entity.Item, error)
+	UnarchiveItem(ctx context.Context, id int64) (*entity.Item, error)
 	GetCategorySummary(ctx context.Context) (*CategorySummary, error)
+	CompareCategorySummary(ctx context.Context, asOf time.Time) (*SummaryComparison, error)
+	GetCategorySummaries(ctx context.Context, categories []string) ([]CategorySummaryEntry, error)
+	GetUsedCategories(ctx context.Context) ([]CategoryCount, error)
+	GetBrandSummary(ctx context.Context, filter ItemFilter) ([]BrandSummaryEntry, error)
+	GetGroupSummary(ctx context.Context, filter ItemFilter, by GroupBy) ([]GroupSummaryEntry, error)
+	GetTimeline(ctx context.Context, filter ItemFilter) ([]TimelineEntry, error)
+	GetTrends(ctx context.Context, filter ItemFilter) ([]TrendEntry, error)
+	GetDiversity(ctx context.Context, filter ItemFilter) (*Diversity, error)
+	GetIncompleteItems(ctx context.Context) ([]IncompleteItem, error)
+	GetChangesSince(ctx context.Context, since time.Time) (*ItemChangeSet, error)
+	SyncItems(ctx context.Context, token string) (*ItemSyncSet, error)
+	GetChangeLog(ctx context.Context, since int64, limit int) (*ChangeLogPage, error)
+	SuggestCategory(ctx context.Context, name string) (*CategorySuggestion, error)
+	GetDashboard(ctx context.Context, sections []DashboardSection, limit int) (*Dashboard, error)
+	GetDepreciationSchedule(ctx context.Context, id int64, years int, salvage int64) (*DepreciationSchedule, error)
+	GetItemSiblings(ctx context.Context, id int64, filter ItemFilter) (*ItemSiblings, error)
 }
 
 type CreateItemInput struct {
-	Name          string `json:"name"`
-	Category      string `json:"category"`
-	Brand         string `json:"brand"`
-	PurchasePrice int    `json:"purchase_price"`
-	PurchaseDate  string `json:"purchase_date"`
+	Name          string          `json:"name"`
+	Category      entity.Category `json:"category"`
+	Brand         string          `json:"brand"`
+	PurchasePrice int64           `json:"purchase_price"`
+	PurchaseDate  string          `json:"purchase_date"`
+	SerialNumber  string          `json:"serial_number,omitempty"`
+	Status        entity.Status   `json:"status,omitempty"` // 空の場合はactive
 }
 
 type UpdateItemInput struct {
-	Name          *string `json:"name,omitempty"`
-	Brand         *string `json:"brand,omitempty"`
-	PurchasePrice *int    `json:"purchase_price,omitempty"`
+	Name          *string        `json:"name,omitempty"`
+	Brand         *string        `json:"brand,omitempty"`
+	PurchasePrice *int64         `json:"purchase_price,omitempty"`
+	Status        *entity.Status `json:"status,omitempty"`
+	// 指定した場合、アイテムの現在の値が条件に一致する場合のみ更新する
+	If *UpdateCondition `json:"if,omitempty"`
+}
+
+// JSONのキーのフィールドを指定しているかどうか
+func (input UpdateItemInput) sets(field string) bool {
+	switch field {
+	case "name":
+		return input.Name != nil
+	case "brand":
+		return input.Brand != nil
+	case "purchase_price":
+		return input.PurchasePrice != nil
+	case "status":
+		return input.Status != nil
+	}
+	return false
+}
+
+// 指定したフィールドのみをアイテムに反映する
+func (input UpdateItemInput) applyTo(item *entity.Item) {
+	if input.Name != nil {
+		item.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.Brand != nil {
+		item.Brand = strings.TrimSpace(*input.Brand)
+	}
+	if input.PurchasePrice != nil {
+		item.PurchasePrice = entity.JPY(*input.PurchasePrice)
+	}
+	if input.Status != nil {
+		item.Status = *input.Status
+	}
+}
+
+// 既存のアイテムを取得する前に、指定したフィールドのみをエンティティの規則で検証する（不正な入力ではクエリを発行しない）
+// 指定していないフィールドには規則を満たす値を入れておき、エラーにならないようにする
+func (input UpdateItemInput) validate() error {
+	probe := entity.Item{Name: "-", Category: entity.CategoryOther, Brand: "-", PurchaseDate: "2000-01-01"}
+	input.applyTo(&probe)
+	if err := probe.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+	if input.If != nil {
+		return input.If.validate()
+	}
+	return nil
+}
+
+// 更新で値が変わるフィールド（nilのフィールドは書き込まない）
+type ItemChanges struct {
+	Name          *string
+	Brand         *string
+	PurchasePrice *entity.Money
+	Status        *entity.Status
+	// 指定した場合、現在の値が一致する行のみを更新する（一致しない場合はErrUpdateConditionFailed）
+	If *UpdateCondition
+}
+
+// 書き込むフィールドが無いかどうか
+func (c ItemChanges) IsEmpty() bool {
+	return c.Name == nil && c.Brand == nil && c.PurchasePrice == nil && c.Status == nil
+}
+
+// 更新前後のアイテムを比較し、値が変わったフィールドのみを返す
+func changedFields(before, after *entity.Item) ItemChanges {
+	var changes ItemChanges
+	if after.Name != before.Name {
+		changes.Name = &after.Name
+	}
+	if after.Brand != before.Brand {
+		changes.Brand = &after.Brand
+	}
+	if after.PurchasePrice != before.PurchasePrice {
+		changes.PurchasePrice = &after.PurchasePrice
+	}
+	if after.Status != before.Status {
+		changes.Status = &after.Status
+	}
+	return changes
+}
+
+// 削除時の事前条件（ゼロ値は無条件で削除）
+type DeleteItemInput struct {
+	// このETagのいずれかに一致する場合のみ削除する（"*" は任意に一致）
+	IfMatch []string
+	// この日時以降に更新されていない場合のみ削除する（IfMatch指定時は無視）
+	UnmodifiedSince *time.Time
+	// 削除の理由（売却・紛失など、空の場合は記録しない）
+	Reason string
+}
+
+// 削除の前提条件が指定されていないかどうか
+func (i DeleteItemInput) IsEmpty() bool {
+	return len(i.IfMatch) == 0 && i.UnmodifiedSince == nil
 }
 
 type CategorySummary struct {
-	Categories map[string]int `json:"categories"`
-	Total      int            `json:"total"`
+	Categories         map[string]int `json:"categories"`
+	Total              int            `json:"total"`
+	TotalPurchasePrice entity.Money   `json:"total_purchase_price"` // 全アイテムの購入価格の合計
+}
+
+// カテゴリーごとのアイテム数と購入価格の合計
+type CategoryTotal struct {
+	Count         int
+	PurchasePrice entity.Money
+}
+
+// 一覧の1ページ分と、条件に一致する全件数
+type ItemPage struct {
+	Items  []*entity.Item
+	Total  int
+	Limit  int
+	Offset int
+	// 検索語で絞り込んだ場合の、上限で切り詰める前の一致した件数（Totalは上限までの件数）
+	TotalMatches int
+	// 一致した件数が上限を超えたため、条件を絞り込むよう促す
+	RefineSuggested bool
+}
+
+// 差分同期のレスポンス
+type ItemChangeSet struct {
+	Items      []*entity.Item `json:"items"`       // 登録・更新されたアイテム
+	DeletedIDs []int64        `json:"deleted_ids"` // 削除されたアイテムのID
+	ServerTime time.Time      `json:"server_time"` // 次回のsinceに指定する時刻
+}
+
+// 使用中のカテゴリーとアイテム数
+type CategoryCount struct {
+	Category string `json:"category"`
+	Count    int    `json:"count"`
 }
 
 type itemUsecase struct {
-	itemRepo ItemRepository
+	itemRepo   ItemRepository
+	publisher  EventPublisher
+	transactor Transactor
+	// 一括登録でカテゴリーが空の行に使うカテゴリー（空の場合は必須のまま）
+	importDefaultCategory entity.Category
+	// 一括登録で仮の値と思われる購入価格の行の扱い（ゼロ値は確認しない）
+	importPriceCheck importPriceCheck
+	// カテゴリーが空の入力に、ブランドから補うカテゴリー（キーは小文字のブランド、空の場合は補わない）
+	brandCategories map[string]entity.Category
+	// 登録・更新時のブランドの表記の統一方法（空の場合は前後の空白を除くのみ）
+	brandCase BrandCase
+	// 登録・更新時に名前・ブランド・シリアル番号から制御文字などを除き、NFCに正規化する
+	textSanitization bool
+	// アイテム名からカテゴリーを推定する実装（nilの場合は既定のキーワードの対応表）
+	categorySuggester CategorySuggester
+	// カテゴリーごとに、常に必須のフィールドに加えて必須とするフィールド（JSONのキー）
+	categoryRequiredFields map[entity.Category][]string
+	// ?sort= を指定しない一覧の並び順（空の場合は登録の新しい順）
+	defaultSort SortOrder
+	// ページ単位の一覧で指定できるoffsetの上限（0の場合は無制限）
+	maxPageOffset int
+	// 検索語（q）で絞り込んだページ単位の一覧で返す最大件数（0の場合は無制限）
+	maxSearchResults int
+	// GetItemByIDの結果のキャッシュ（nilの場合は使わない）
+	cache *ItemCache
+	// 削除の理由として指定できる値（空の場合は自由記述）
+	deleteReasons []string
+	// 同じアイテムへの連続した更新をまとめる（nilの場合はまとめない）
+	coalescer *UpdateCoalescer
+	// 集計の平均購入価格の小数点以下の桁数
+	averageDecimals int
+	// 登録するアイテムにUUIDを振るか（ID_TYPE=uuid）
+	uuidIDs bool
+	// 見直しが必要なアイテムの判定で画像の有無を調べるリポジトリ（nilの場合は判定しない）
+	completenessImages ItemImageRepository
 }
 
-func NewItemUsecase(itemRepo ItemRepository) ItemUsecase {
-	return &itemUsecase{
-		itemRepo: itemRepo,
+func NewItemUsecase(itemRepo ItemRepository, opts ...Option) ItemUsecase {
+	u := &itemUsecase{
+		itemRepo:   itemRepo,
+		publisher:  noopEventPublisher{},
+		transactor: noopTransactor{},
+	}
+	for _, opt := range opts {
+		opt(u)
 	}
+	return u
 }
 
-func (u *itemUsecase) GetAllItems(ctx context.Context) ([]*entity.Item, error) {
-	items, err := u.itemRepo.FindAll(ctx)
+func (u *itemUsecase) GetAllItems(ctx context.Context, filter ItemFilter) ([]*entity.Item, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	filter = u.withDefaultSort(filter)
+	if filter.Fuzzy {
+		return u.searchItemsFuzzy(ctx, filter)
+	}
+
+	// 検索語で絞り込んだ場合は、ページ単位の一覧と同じく上限までを返す
+	var items []*entity.Item
+	var err error
+	if window := u.searchWindow(filter); window > 0 {
+		items, err = u.itemRepo.FindPage(ctx, filter, Page{Limit: window})
+	} else {
+		items, err = u.itemRepo.FindAll(ctx, filter)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve items: %w", err)
 	}
@@ -56,7 +270,78 @@ func (u *itemUsecase) GetAllItems(ctx context.Context) ([]*entity.Item, error) {
 	return items, nil
 }
 
+func (u *itemUsecase) GetItemPage(ctx context.Context, filter ItemFilter, page Page) (*ItemPage, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	if err := page.Validate(); err != nil {
+		return nil, err
+	}
+	if err := u.validatePageDepth(page); err != nil {
+		return nil, err
+	}
+	filter = u.withDefaultSort(filter)
+
+	window := u.searchWindow(filter)
+	items := []*entity.Item{}
+	if fetch, ok := page.within(window); ok {
+		var err error
+		items, err = u.itemRepo.FindPage(ctx, filter, fetch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve items: %w", err)
+		}
+	}
+
+	total, err := u.itemRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count items: %w", err)
+	}
+
+	result := &ItemPage{
+		Items:  items,
+		Total:  total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	}
+	if window > 0 {
+		result.TotalMatches = total
+		if total > window {
+			result.Total = window
+			result.RefineSuggested = true
+		}
+	}
+	return result, nil
+}
+
 func (u *itemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
+	item, err := u.cachedItem(ctx, id)
+	if domainErrors.IsNotFoundError(err) {
+		return nil, u.notFoundError(ctx, id)
+	}
+	return item, err
+}
+
+// 保持している値があれば使い、無ければDBから取得して保持する
+// レプリカの遅延した値を保持すると、更新で破棄した後に古い値が再び保持されるため、保持する値はプライマリーから読み込む
+func (u *itemUsecase) cachedItem(ctx context.Context, id int64) (*entity.Item, error) {
+	if u.cache == nil || id <= 0 {
+		return u.findItem(ctx, id)
+	}
+
+	if item, ok := u.cache.get(id); ok {
+		return item, nil
+	}
+	generation := u.cache.currentGeneration()
+	item, err := u.findItem(WithPrimaryReads(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+	u.cache.put(item, generation)
+	return item, nil
+}
+
+// キャッシュを使わずにDBから取得する（書き込みの前の確認など、最新の値が必要な場合に使う）
+func (u *itemUsecase) findItem(ctx context.Context, id int64) (*entity.Item, error) {
 	if id <= 0 {
 		return nil, domainErrors.ErrInvalidInput
 	}
@@ -72,8 +357,81 @@ func (u *itemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item,
 	return item, nil
 }
 
+// 存在したが削除されたアイテムは、一度も存在しないIDと区別できるようErrItemDeletedにもする
+// 差分同期のクライアントが手元のコピーを破棄してよいかを判断するため（書き込みの前の確認では判定しない）
+func (u *itemUsecase) notFoundError(ctx context.Context, id int64) error {
+	deleted, err := u.itemRepo.WasDeleted(ctx, id)
+	if err != nil {
+		// 判定できない場合も取得の結果としては存在しないことに変わりはない
+		slog.Warn("failed to check whether item was deleted", "item_id", id, "error", err)
+		return domainErrors.ErrItemNotFound
+	}
+	if deleted {
+		return fmt.Errorf("%w: %w", domainErrors.ErrItemNotFound, domainErrors.ErrItemDeleted)
+	}
+	return domainErrors.ErrItemNotFound
+}
+
 func (u *itemUsecase) CreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error) {
 	// バリデーションして、新しいエンティティを作成
+	item, err := u.newItemFromInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// 登録とイベントの記録を同一トランザクションで行う
+	var createdItem *entity.Item
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		createdItem, err = u.itemRepo.Create(ctx, item)
+		if err != nil {
+			return err
+		}
+		return u.publisher.Publish(ctx, newItemEvent(EventItemCreated, createdItem))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create item: %w", err)
+	}
+
+	return createdItem, nil
+}
+
+// シリアル番号が一致するアイテムがあれば更新し、無ければ登録する（boolは登録した場合にtrue）
+func (u *itemUsecase) UpsertItem(ctx context.Context, input CreateItemInput) (*entity.Item, bool, error) {
+	if strings.TrimSpace(input.SerialNumber) == "" {
+		return nil, false, fmt.Errorf("%w: serial_number is required", domainErrors.ErrInvalidInput)
+	}
+
+	item, err := u.newItemFromInput(input)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var upserted *entity.Item
+	var created bool
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		upserted, created, err = u.itemRepo.Upsert(ctx, item)
+		if err != nil {
+			return err
+		}
+		eventType := EventItemUpdated
+		if created {
+			eventType = EventItemCreated
+		}
+		return u.publisher.Publish(ctx, newItemEvent(eventType, upserted))
+	})
+	// 更新の場合に保持している値を破棄する（コミットの後に行い、更新前の値が再び保持されないようにする）
+	if upserted != nil {
+		u.invalidateItem(upserted.ID)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to upsert item: %w", err)
+	}
+
+	return upserted, created, nil
+}
+
+// 登録の入力を検証して新しいエンティティを作成する
+func newItemFromInput(input CreateItemInput) (*entity.Item, error) {
 	item, err := entity.NewItem(
 		input.Name,
 		input.Category,
@@ -84,19 +442,43 @@ func (u *itemUsecase) CreateItem(ctx context.Context, input CreateItemInput) (*e
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
 	}
-
-	createdItem, err := u.itemRepo.Create(ctx, item)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create item: %w", err)
+	if input.Status != "" {
+		item.Status = input.Status
 	}
+	if err := setSerialNumber(item, input.SerialNumber); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
 
-	return createdItem, nil
+// シリアル番号を設定してバリデーションする
+func setSerialNumber(item *entity.Item, serialNumber string) error {
+	item.SerialNumber = strings.TrimSpace(serialNumber)
+	if err := item.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+	return nil
 }
 
 func (u *itemUsecase) UpdateItem(ctx context.Context, id int64, input UpdateItemInput) (*entity.Item, error) {
 	if id <= 0 {
 		return nil, domainErrors.ErrInvalidInput
 	}
+	// 条件付きの更新は他の更新とまとめると条件の意味が変わるため、まとめずに書き込む
+	if u.coalescer != nil && input.If == nil {
+		// 不正な入力をまとめると他のリクエストの更新も失敗するため、まとめる前に検証する
+		if err := input.validate(); err != nil {
+			return nil, err
+		}
+		return u.coalescer.submit(ctx, id, input)
+	}
+	return u.updateItem(ctx, id, input)
+}
+
+func (u *itemUsecase) updateItem(ctx context.Context, id int64, input UpdateItemInput) (*entity.Item, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
 
 	// 既存のアイテムを取得
 	existingItem, err := u.itemRepo.FindByID(ctx, id)
@@ -107,24 +489,49 @@ func (u *itemUsecase) UpdateItem(ctx context.Context, id int64, input UpdateItem
 		return nil, fmt.Errorf("failed to retrieve item: %w", err)
 	}
 
+	if input.If != nil && !input.If.matches(existingItem) {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrUpdateConditionFailed, input.If.mismatches(existingItem))
+	}
+
 	// 更新対象フィールドのみを更新
+	original := *existingItem
+	input.applyTo(existingItem)
 	if input.Name != nil {
-		existingItem.Name = strings.TrimSpace(*input.Name)
+		existingItem.Name = u.sanitize(existingItem.Name)
 	}
 	if input.Brand != nil {
-		existingItem.Brand = strings.TrimSpace(*input.Brand)
-	}
-	if input.PurchasePrice != nil {
-		existingItem.PurchasePrice = *input.PurchasePrice
+		existingItem.Brand = u.brandCase.Apply(u.sanitize(existingItem.Brand))
 	}
 
 	// バリデーション
 	if err := existingItem.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
 	}
+	// 必須とする前に登録したアイテムも更新できるよう、変更するフィールドのみを確認する
+	if err := u.checkRequiredFields(existingItem, input.sets); err != nil {
+		return nil, err
+	}
 
-	// データベースを更新
-	updatedItem, err := u.itemRepo.Update(ctx, existingItem)
+	// 値が変わらない場合は書き込まない（updated_atも更新しない）
+	changes := changedFields(&original, existingItem)
+	if changes.IsEmpty() {
+		return existingItem, nil
+	}
+	// 取得してから書き込むまでに他の更新で値が変わった場合も更新しない
+	changes.If = input.If
+
+	// 変更されたカラムのみの更新とイベントの記録を同一トランザクションで行う
+	// コミットに失敗した場合も、保持している値が最新とは限らないため破棄する
+	defer u.invalidateItem(id)
+
+	var updatedItem *entity.Item
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		updatedItem, err = u.itemRepo.Update(ctx, id, changes)
+		if err != nil {
+			return err
+		}
+		return u.publisher.Publish(ctx, newItemEvent(EventItemUpdated, updatedItem))
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to update item: %w", err)
 	}
@@ -132,12 +539,15 @@ func (u *itemUsecase) UpdateItem(ctx context.Context, id int64, input UpdateItem
 	return updatedItem, nil
 }
 
-func (u *itemUsecase) DeleteItem(ctx context.Context, id int64) error {
+func (u *itemUsecase) DeleteItem(ctx context.Context, id int64, input DeleteItemInput) error {
 	if id <= 0 {
 		return domainErrors.ErrInvalidInput
 	}
+	if err := u.validateDeleteReason(input.Reason); err != nil {
+		return err
+	}
 
-	_, err := u.itemRepo.FindByID(ctx, id)
+	item, err := u.itemRepo.FindByID(ctx, id)
 	if err != nil {
 		if domainErrors.IsNotFoundError(err) {
 			return domainErrors.ErrItemNotFound
@@ -145,7 +555,28 @@ func (u *itemUsecase) DeleteItem(ctx context.Context, id int64) error {
 		return fmt.Errorf("failed to check item existence: %w", err)
 	}
 
-	err = u.itemRepo.Delete(ctx, id)
+	// 他のユーザーが更新したアイテムを誤って削除しないよう事前条件を確認
+	if !input.matches(item) {
+		return domainErrors.ErrPreconditionFailed
+	}
+
+	defer u.invalidateItem(id)
+
+	// 削除とイベントの記録を同一トランザクションで行う
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// 事前条件を確認した後に他の更新が入った場合も削除しないよう、読み込んだバージョンを削除の条件にする
+		var err error
+		if input.pinsVersion() {
+			err = u.itemRepo.DeleteVersion(ctx, id, item.Version, input.Reason)
+		} else {
+			err = u.itemRepo.Delete(ctx, id, input.Reason)
+		}
+		if err != nil {
+			return err
+		}
+		item.DeleteReason = input.Reason
+		return u.publisher.Publish(ctx, newItemEvent(EventItemDeleted, item))
+	})
 	if err != nil {
 		return fmt.Errorf("failed to delete item: %w", err)
 	}
@@ -153,29 +584,160 @@ func (u *itemUsecase) DeleteItem(ctx context.Context, id int64) error {
 	return nil
 }
 
+// 読み込んだ時点のバージョンでのみ削除するか（If-Match: * は存在のみを確認する）
+func (in DeleteItemInput) pinsVersion() bool {
+	if len(in.IfMatch) > 0 {
+		return !slices.Contains(in.IfMatch, "*")
+	}
+	return in.UnmodifiedSince != nil
+}
+
+// 事前条件を満たすかどうか
+func (in DeleteItemInput) matches(item *entity.Item) bool {
+	if len(in.IfMatch) > 0 {
+		etag := item.ETag()
+		for _, candidate := range in.IfMatch {
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if in.UnmodifiedSince != nil {
+		// HTTP日付は秒精度のため、更新日時も秒単位で比較する
+		return !item.UpdatedAt.Truncate(time.Second).After(*in.UnmodifiedSince)
+	}
+
+	return true
+}
+
 func (u *itemUsecase) GetCategorySummary(ctx context.Context) (*CategorySummary, error) {
-	categoryCounts, err := u.itemRepo.GetSummaryByCategory(ctx)
+	categoryTotals, err := u.itemRepo.GetSummaryByCategory(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category summary: %w", err)
+	}
+
+	summary, err := buildCategorySummary(categoryTotals)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get category summary: %w", err)
 	}
+	return summary, nil
+}
+
+// 指定したカテゴリーのみの集計（1件のカテゴリーカード分）
+type CategorySummaryEntry struct {
+	Category           string       `json:"category"`
+	Count              int          `json:"count"`
+	TotalPurchasePrice entity.Money `json:"total_purchase_price"`
+}
 
+// 指定したカテゴリーの件数と購入価格の合計を指定順に返す
+// 重複は除き、不明なカテゴリーやアイテムが無いカテゴリーは0件として返す
+func (u *itemUsecase) GetCategorySummaries(ctx context.Context, categories []string) ([]CategorySummaryEntry, error) {
+	requested := make([]string, 0, len(categories))
+	var known []string
+	seen := make(map[string]bool)
+	for _, category := range categories {
+		category = entity.Category(category).Normalize().String()
+		if seen[category] {
+			continue
+		}
+		seen[category] = true
+		requested = append(requested, category)
+		if entity.Category(category).IsValid() {
+			known = append(known, category)
+		}
+	}
+
+	totals, err := u.itemRepo.GetSummaryByCategories(ctx, known)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category summary: %w", err)
+	}
+
+	entries := make([]CategorySummaryEntry, 0, len(requested))
+	for _, category := range requested {
+		total, ok := totals[category]
+		if !ok {
+			total.PurchasePrice = entity.JPY(0)
+		}
+		entries = append(entries, CategorySummaryEntry{
+			Category:           category,
+			Count:              total.Count,
+			TotalPurchasePrice: total.PurchasePrice,
+		})
+	}
+	return entries, nil
+}
+
+// カテゴリー別の件数と購入価格から集計結果を組み立てる
+func buildCategorySummary(categoryTotals map[string]CategoryTotal) (*CategorySummary, error) {
 	// 合計計算
 	total := 0
-	for _, count := range categoryCounts {
-		total += count
+	totalPurchasePrice := entity.JPY(0)
+	var err error
+	for _, categoryTotal := range categoryTotals {
+		total += categoryTotal.Count
+		totalPurchasePrice, err = totalPurchasePrice.Add(categoryTotal.PurchasePrice)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	summary := make(map[string]int)
 	for _, category := range entity.GetValidCategories() {
-		if count, exists := categoryCounts[category]; exists {
-			summary[category] = count
-		} else {
-			summary[category] = 0
-		}
+		summary[category.String()] = categoryTotals[category.String()].Count
 	}
 
 	return &CategorySummary{
-		Categories: summary,
-		Total:      total,
+		Categories:         summary,
+		Total:              total,
+		TotalPurchasePrice: totalPurchasePrice,
 	}, nil
 }
+
+func (u *itemUsecase) GetUsedCategories(ctx context.Context) ([]CategoryCount, error) {
+	categories, err := u.itemRepo.GetUsedCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get used categories: %w", err)
+	}
+
+	// アイテムが0件でもJSONでは空配列を返す
+	if categories == nil {
+		categories = []CategoryCount{}
+	}
+
+	return categories, nil
+}
+
+func (u *itemUsecase) GetChangesSince(ctx context.Context, since time.Time) (*ItemChangeSet, error) {
+	// 取得前の時刻を次回のカーソルにする（秒精度のため同一秒の変更は次回も含まれる）
+	changes := &ItemChangeSet{ServerTime: entity.Now().UTC().Truncate(time.Second)}
+
+	// 登録・更新と削除を同一スナップショットから取得する
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		items, err := u.itemRepo.FindChangedSince(ctx, since)
+		if err != nil {
+			return err
+		}
+		deletedIDs, err := u.itemRepo.FindDeletedIDsSince(ctx, since)
+		if err != nil {
+			return err
+		}
+		changes.Items = items
+		changes.DeletedIDs = deletedIDs
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve changes: %w", err)
+	}
+
+	if changes.Items == nil {
+		changes.Items = []*entity.Item{}
+	}
+	if changes.DeletedIDs == nil {
+		changes.DeletedIDs = []int64{}
+	}
+
+	return changes, nil
+}
@@ -2,7 +2,9 @@ package usecase
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -17,11 +19,24 @@ type MockItemRepository struct {
 	mock.Mock
 }
 
-func (m *MockItemRepository) FindAll(ctx context.Context) ([]*entity.Item, error) {
-	args := m.Called(ctx)
+func (m *MockItemRepository) FindAll(ctx context.Context, filter ItemFilter) ([]*entity.Item, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) FindPage(ctx context.Context, filter ItemFilter, page Page) ([]*entity.Item, error) {
+	args := m.Called(ctx, filter, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	return args.Get(0).([]*entity.Item), args.Error(1)
 }
 
+func (m *MockItemRepository) Count(ctx context.Context, filter ItemFilter) (int, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -30,6 +45,87 @@ func (m *MockItemRepository) FindByID(ctx context.Context, id int64) (*entity.It
 	return args.Get(0).(*entity.Item), args.Error(1)
 }
 
+func (m *MockItemRepository) FindIDByUUID(ctx context.Context, uuid string) (int64, error) {
+	args := m.Called(ctx, uuid)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockItemRepository) AssignUUID(ctx context.Context, id int64, uuid string) (bool, error) {
+	args := m.Called(ctx, id, uuid)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockItemRepository) GetListVersion(ctx context.Context, filter ItemFilter) (*ListVersion, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ListVersion), args.Error(1)
+}
+
+func (m *MockItemRepository) FindChangedSince(ctx context.Context, since time.Time) ([]*entity.Item, error) {
+	args := m.Called(ctx, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) FindDeletedIDsSince(ctx context.Context, since time.Time) ([]int64, error) {
+	args := m.Called(ctx, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int64), args.Error(1)
+}
+
+func (m *MockItemRepository) GetChangeLogRange(ctx context.Context) (ChangeLogRange, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(ChangeLogRange), args.Error(1)
+}
+
+func (m *MockItemRepository) FindChangedAfter(ctx context.Context, seq int64) ([]*entity.Item, error) {
+	args := m.Called(ctx, seq)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) FindDeletedIDsAfter(ctx context.Context, seq int64) ([]int64, error) {
+	args := m.Called(ctx, seq)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int64), args.Error(1)
+}
+
+func (m *MockItemRepository) PruneChangeLog(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockItemRepository) FindChangeLog(ctx context.Context, seq int64, limit int) ([]ChangeLogEntry, error) {
+	args := m.Called(ctx, seq, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ChangeLogEntry), args.Error(1)
+}
+
+func (m *MockItemRepository) FindBatchAfter(ctx context.Context, afterID int64, limit int) ([]*entity.Item, error) {
+	args := m.Called(ctx, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) WasDeleted(ctx context.Context, id int64) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
 	args := m.Called(ctx, item)
 	if args.Get(0) == nil {
@@ -38,25 +134,119 @@ func (m *MockItemRepository) Create(ctx context.Context, item *entity.Item) (*en
 	return args.Get(0).(*entity.Item), args.Error(1)
 }
 
-func (m *MockItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+func (m *MockItemRepository) Upsert(ctx context.Context, item *entity.Item) (*entity.Item, bool, error) {
 	args := m.Called(ctx, item)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).(*entity.Item), args.Bool(1), args.Error(2)
+}
+
+func (m *MockItemRepository) Update(ctx context.Context, id int64, changes ItemChanges) (*entity.Item, error) {
+	args := m.Called(ctx, id, changes)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*entity.Item), args.Error(1)
 }
 
-func (m *MockItemRepository) Delete(ctx context.Context, id int64) error {
-	args := m.Called(ctx, id)
+func (m *MockItemRepository) Delete(ctx context.Context, id int64, reason string) error {
+	args := m.Called(ctx, id, reason)
 	return args.Error(0)
 }
 
-func (m *MockItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]int, error) {
+func (m *MockItemRepository) DeleteVersion(ctx context.Context, id, version int64, reason string) error {
+	args := m.Called(ctx, id, version, reason)
+	return args.Error(0)
+}
+
+func (m *MockItemRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockItemRepository) DeleteAll(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockItemRepository) Restore(ctx context.Context, item *entity.Item) (RestoreOutcome, error) {
+	args := m.Called(ctx, item)
+	return args.Get(0).(RestoreOutcome), args.Error(1)
+}
+
+func (m *MockItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]CategoryTotal, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]CategoryTotal), args.Error(1)
+}
+
+func (m *MockItemRepository) GetSummaryByCategories(ctx context.Context, categories []string) (map[string]CategoryTotal, error) {
+	args := m.Called(ctx, categories)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]CategoryTotal), args.Error(1)
+}
+
+func (m *MockItemRepository) GetSummaryByCategoryAsOf(ctx context.Context, asOf time.Time) (map[string]CategoryTotal, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]CategoryTotal), args.Error(1)
+}
+
+func (m *MockItemRepository) GetUsedCategories(ctx context.Context) ([]CategoryCount, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(map[string]int), args.Error(1)
+	return args.Get(0).([]CategoryCount), args.Error(1)
+}
+
+func (m *MockItemRepository) GetSummaryByBrand(ctx context.Context, filter ItemFilter) ([]BrandSummaryEntry, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]BrandSummaryEntry), args.Error(1)
+}
+
+func (m *MockItemRepository) GetSummaryByGroup(ctx context.Context, filter ItemFilter, by GroupBy) ([]GroupSummaryEntry, error) {
+	args := m.Called(ctx, filter, by)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]GroupSummaryEntry), args.Error(1)
+}
+
+func (m *MockItemRepository) GetSummaryByPurchaseYear(ctx context.Context, filter ItemFilter) ([]TimelineEntry, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]TimelineEntry), args.Error(1)
+}
+
+func (m *MockItemRepository) FindSiblings(ctx context.Context, id int64, filter ItemFilter) (*ItemSiblings, error) {
+	args := m.Called(ctx, id, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ItemSiblings), args.Error(1)
+}
+
+// MockEventPublisher はtestify/mockを使用したモックPublisher
+type MockEventPublisher struct {
+	mock.Mock
+}
+
+func (m *MockEventPublisher) Publish(ctx context.Context, event ItemEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
 }
 
 func TestNewItemUsecase(t *testing.T) {
@@ -69,6 +259,7 @@ func TestNewItemUsecase(t *testing.T) {
 func TestItemUsecase_GetAllItems(t *testing.T) {
 	tests := []struct {
 		name          string
+		filter        ItemFilter
 		setupMock     func(*MockItemRepository)
 		expectedCount int
 		expectedErr   error
@@ -79,16 +270,35 @@ func TestItemUsecase_GetAllItems(t *testing.T) {
 				item1, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
 				item2, _ := entity.NewItem("バッグ1", "バッグ", "HERMÈS", 500000, "2023-01-02")
 				items := []*entity.Item{item1, item2}
-				mockRepo.On("FindAll", mock.Anything).Return(items, nil)
+				mockRepo.On("FindAll", mock.Anything, ItemFilter{}).Return(items, nil)
 			},
 			expectedCount: 2,
 			expectedErr:   nil,
 		},
+		{
+			name:   "正常系: カテゴリーで絞り込み",
+			filter: ItemFilter{Categories: []entity.Category{entity.CategoryWatch}},
+			setupMock: func(mockRepo *MockItemRepository) {
+				item1, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+				mockRepo.On("FindAll", mock.Anything, ItemFilter{Categories: []entity.Category{entity.CategoryWatch}}).Return([]*entity.Item{item1}, nil)
+			},
+			expectedCount: 1,
+			expectedErr:   nil,
+		},
+		{
+			name:   "異常系: 無効なカテゴリーで絞り込み",
+			filter: ItemFilter{Categories: []entity.Category{entity.CategoryWatch, "無効なカテゴリー"}},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// FindAllは呼ばれない
+			},
+			expectedCount: 0,
+			expectedErr:   domainErrors.ErrInvalidInput,
+		},
 		{
 			name: "正常系: アイテムが0件",
 			setupMock: func(mockRepo *MockItemRepository) {
 				items := []*entity.Item{}
-				mockRepo.On("FindAll", mock.Anything).Return(items, nil)
+				mockRepo.On("FindAll", mock.Anything, ItemFilter{}).Return(items, nil)
 			},
 			expectedCount: 0,
 			expectedErr:   nil,
@@ -96,7 +306,7 @@ func TestItemUsecase_GetAllItems(t *testing.T) {
 		{
 			name: "異常系: データベースエラー",
 			setupMock: func(mockRepo *MockItemRepository) {
-				mockRepo.On("FindAll", mock.Anything).Return(([]*entity.Item)(nil), domainErrors.ErrDatabaseError)
+				mockRepo.On("FindAll", mock.Anything, ItemFilter{}).Return(([]*entity.Item)(nil), domainErrors.ErrDatabaseError)
 			},
 			expectedCount: 0,
 			expectedErr:   domainErrors.ErrDatabaseError,
@@ -110,7 +320,7 @@ func TestItemUsecase_GetAllItems(t *testing.T) {
 			usecase := NewItemUsecase(mockRepo)
 
 			ctx := context.Background()
-			items, err := usecase.GetAllItems(ctx)
+			items, err := usecase.GetAllItems(ctx, tt.filter)
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
@@ -149,6 +359,27 @@ func TestItemUsecase_GetItemByID(t *testing.T) {
 			id:   999,
 			setupMock: func(mockRepo *MockItemRepository) {
 				mockRepo.On("FindByID", mock.Anything, int64(999)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+				mockRepo.On("WasDeleted", mock.Anything, int64(999)).Return(false, nil)
+			},
+			expectError: true,
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+		{
+			name: "異常系: 削除されたアイテム",
+			id:   5,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(5)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+				mockRepo.On("WasDeleted", mock.Anything, int64(5)).Return(true, nil)
+			},
+			expectError: true,
+			expectedErr: domainErrors.ErrItemDeleted,
+		},
+		{
+			name: "異常系: 削除の判定に失敗した場合は存在しないアイテム",
+			id:   5,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(5)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+				mockRepo.On("WasDeleted", mock.Anything, int64(5)).Return(false, domainErrors.ErrDatabaseError)
 			},
 			expectError: true,
 			expectedErr: domainErrors.ErrItemNotFound,
@@ -289,7 +520,7 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 				assert.Equal(t, tt.input.Name, item.Name)
 				assert.Equal(t, tt.input.Category, item.Category)
 				assert.Equal(t, tt.input.Brand, item.Brand)
-				assert.Equal(t, tt.input.PurchasePrice, item.PurchasePrice)
+				assert.Equal(t, entity.JPY(tt.input.PurchasePrice), item.PurchasePrice)
 				assert.Equal(t, tt.input.PurchaseDate, item.PurchaseDate)
 			}
 
@@ -298,6 +529,58 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 	}
 }
 
+func TestItemUsecase_CreateItem_Status(t *testing.T) {
+	t.Run("正常系: 下書きとして登録", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.Status == entity.StatusDraft
+		})).Return(&entity.Item{ID: 1, Status: entity.StatusDraft}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		item, err := usecase.CreateItem(context.Background(), CreateItemInput{
+			Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15",
+			Status: entity.StatusDraft,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.StatusDraft, item.Status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 無効な状態", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.CreateItem(context.Background(), CreateItemInput{
+			Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15",
+			Status: "sold",
+		})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "Create")
+	})
+}
+
+func TestItemUsecase_CreateItem_ReturnsStoredItem(t *testing.T) {
+	// 入力をそのまま返さず、DBに保存した（IDや日時が決まった）アイテムを返す
+	createdAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	stored := &entity.Item{
+		ID: 42, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15",
+		Status: entity.StatusActive, CreatedAt: createdAt, UpdatedAt: createdAt,
+	}
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(stored, nil)
+	usecase := NewItemUsecase(mockRepo)
+
+	item, err := usecase.CreateItem(context.Background(), CreateItemInput{
+		Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15",
+	})
+
+	require.NoError(t, err)
+	assert.Same(t, stored, item)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestItemUsecase_UpdateItem(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -319,7 +602,7 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 				updatedItem, _ := entity.NewItem("更新されたアイテム名", "時計", "ROLEX", 1000000, "2023-01-01")
 				updatedItem.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
-				mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(updatedItem, nil)
+				mockRepo.On("Update", mock.Anything, int64(1), ItemChanges{Name: stringPtr("更新されたアイテム名")}).Return(updatedItem, nil)
 			},
 			expectError: false,
 		},
@@ -335,7 +618,7 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 				updatedItem, _ := entity.NewItem("アイテム", "時計", "更新されたブランド", 1000000, "2023-01-01")
 				updatedItem.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
-				mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(updatedItem, nil)
+				mockRepo.On("Update", mock.Anything, int64(1), ItemChanges{Brand: stringPtr("更新されたブランド")}).Return(updatedItem, nil)
 			},
 			expectError: false,
 		},
@@ -343,7 +626,7 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 			name: "正常系: purchase_priceのみ更新",
 			id:   1,
 			input: UpdateItemInput{
-				PurchasePrice: intPtr(2000000),
+				PurchasePrice: int64Ptr(2000000),
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
 				existingItem, _ := entity.NewItem("アイテム", "時計", "ROLEX", 1000000, "2023-01-01")
@@ -351,7 +634,7 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 				updatedItem, _ := entity.NewItem("アイテム", "時計", "ROLEX", 2000000, "2023-01-01")
 				updatedItem.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
-				mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(updatedItem, nil)
+				mockRepo.On("Update", mock.Anything, int64(1), ItemChanges{PurchasePrice: moneyPtr(entity.JPY(2000000))}).Return(updatedItem, nil)
 			},
 			expectError: false,
 		},
@@ -361,7 +644,7 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 			input: UpdateItemInput{
 				Name:          stringPtr("更新されたアイテム名"),
 				Brand:         stringPtr("更新されたブランド"),
-				PurchasePrice: intPtr(2000000),
+				PurchasePrice: int64Ptr(2000000),
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
 				existingItem, _ := entity.NewItem("元のアイテム", "時計", "元のブランド", 1000000, "2023-01-01")
@@ -369,7 +652,42 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 				updatedItem, _ := entity.NewItem("更新されたアイテム名", "時計", "更新されたブランド", 2000000, "2023-01-01")
 				updatedItem.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
-				mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(updatedItem, nil)
+				mockRepo.On("Update", mock.Anything, int64(1), ItemChanges{
+					Name:          stringPtr("更新されたアイテム名"),
+					Brand:         stringPtr("更新されたブランド"),
+					PurchasePrice: moneyPtr(entity.JPY(2000000)),
+				}).Return(updatedItem, nil)
+			},
+			expectError: false,
+		},
+		{
+			name: "正常系: 値が変わらないフィールドは書き込まない",
+			id:   1,
+			input: UpdateItemInput{
+				Name:          stringPtr("アイテム"),
+				PurchasePrice: int64Ptr(2000000),
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				existingItem, _ := entity.NewItem("アイテム", "時計", "ROLEX", 1000000, "2023-01-01")
+				existingItem.ID = 1
+				updatedItem, _ := entity.NewItem("アイテム", "時計", "ROLEX", 2000000, "2023-01-01")
+				updatedItem.ID = 1
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
+				mockRepo.On("Update", mock.Anything, int64(1), ItemChanges{PurchasePrice: moneyPtr(entity.JPY(2000000))}).Return(updatedItem, nil)
+			},
+			expectError: false,
+		},
+		{
+			name: "正常系: 変更が無い場合は更新しない",
+			id:   1,
+			input: UpdateItemInput{
+				Name: stringPtr("アイテム"),
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				existingItem, _ := entity.NewItem("アイテム", "時計", "ROLEX", 1000000, "2023-01-01")
+				existingItem.ID = 1
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
+				// Updateは呼ばれない
 			},
 			expectError: false,
 		},
@@ -404,9 +722,7 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 				Name: stringPtr(""),
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
-				existingItem, _ := entity.NewItem("元のアイテム", "時計", "ROLEX", 1000000, "2023-01-01")
-				existingItem.ID = 1
-				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
+				// 不正な入力ではFindByIDは呼ばれない
 			},
 			expectError: true,
 			expectedErr: domainErrors.ErrInvalidInput,
@@ -418,9 +734,7 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 				Brand: stringPtr(""),
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
-				existingItem, _ := entity.NewItem("アイテム", "時計", "元のブランド", 1000000, "2023-01-01")
-				existingItem.ID = 1
-				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
+				// 不正な入力ではFindByIDは呼ばれない
 			},
 			expectError: true,
 			expectedErr: domainErrors.ErrInvalidInput,
@@ -429,12 +743,10 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 			name: "異常系: 無効なpurchase_price（負の値）",
 			id:   1,
 			input: UpdateItemInput{
-				PurchasePrice: intPtr(-1),
+				PurchasePrice: int64Ptr(-1),
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
-				existingItem, _ := entity.NewItem("アイテム", "時計", "ROLEX", 1000000, "2023-01-01")
-				existingItem.ID = 1
-				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
+				// 不正な入力ではFindByIDは呼ばれない
 			},
 			expectError: true,
 			expectedErr: domainErrors.ErrInvalidInput,
@@ -460,7 +772,7 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 				existingItem, _ := entity.NewItem("元のアイテム", "時計", "ROLEX", 1000000, "2023-01-01")
 				existingItem.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
-				mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Item")).Return((*entity.Item)(nil), domainErrors.ErrDatabaseError)
+				mockRepo.On("Update", mock.Anything, int64(1), mock.AnythingOfType("usecase.ItemChanges")).Return((*entity.Item)(nil), domainErrors.ErrDatabaseError)
 			},
 			expectError: true,
 		},
@@ -492,19 +804,108 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 	}
 }
 
+func TestItemUsecase_UpdateItem_Condition(t *testing.T) {
+	active, archived := entity.StatusActive, entity.StatusArchived
+
+	t.Run("正常系: 条件に一致する場合は条件付きで書き込む", func(t *testing.T) {
+		existingItem, _ := entity.NewItem("デイトナ", "時計", "ROLEX", 1000000, "2023-01-01")
+		existingItem.ID = 1
+		updatedItem := *existingItem
+		updatedItem.Status = archived
+		condition := &UpdateCondition{Status: &active}
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
+		mockRepo.On("Update", mock.Anything, int64(1), ItemChanges{Status: &archived, If: condition}).Return(&updatedItem, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		item, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{Status: &archived, If: condition})
+
+		require.NoError(t, err)
+		assert.Equal(t, archived, item.Status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 現在の値が条件に一致しない", func(t *testing.T) {
+		existingItem, _ := entity.NewItem("デイトナ", "時計", "ROLEX", 1000000, "2023-01-01")
+		existingItem.ID = 1
+		existingItem.Status = archived
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{
+			Name: stringPtr("デイトナ 16520"),
+			If:   &UpdateCondition{Status: &active, PurchasePrice: int64Ptr(900000)},
+		})
+
+		assert.ErrorIs(t, err, domainErrors.ErrUpdateConditionFailed)
+		assert.Contains(t, err.Error(), `purchase_price is 1000000, status is "archived"`)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: フィールドを指定しない条件", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{Status: &archived, If: &UpdateCondition{}})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_UpdateItem_ValidatesBeforeQuery(t *testing.T) {
+	invalidStatus := entity.Status("sold")
+	tests := []struct {
+		name            string
+		input           UpdateItemInput
+		expectedMessage string
+	}{
+		{name: "異常系: nameが空白のみ", input: UpdateItemInput{Name: stringPtr("  ")}, expectedMessage: "name is required"},
+		{name: "異常系: nameが長すぎる", input: UpdateItemInput{Name: stringPtr(strings.Repeat("a", 101))}, expectedMessage: "name must be 100 characters or less"},
+		{name: "異常系: brandが長すぎる", input: UpdateItemInput{Brand: stringPtr(strings.Repeat("a", 101))}, expectedMessage: "brand must be 100 characters or less"},
+		{name: "異常系: purchase_priceが負の値", input: UpdateItemInput{PurchasePrice: int64Ptr(-1)}, expectedMessage: "purchase_price must be 0 or greater"},
+		{name: "異常系: 無効なstatus", input: UpdateItemInput{Status: &invalidStatus}, expectedMessage: entity.ErrInvalidStatus.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			usecase := NewItemUsecase(mockRepo)
+
+			_, err := usecase.UpdateItem(context.Background(), 1, tt.input)
+
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			assert.Contains(t, err.Error(), tt.expectedMessage)
+			// 不正な入力ではクエリを発行しない
+			mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
 // Helper functions for test
 func stringPtr(s string) *string {
 	return &s
 }
 
-func intPtr(i int) *int {
+func int64Ptr(i int64) *int64 {
 	return &i
 }
 
+func moneyPtr(m entity.Money) *entity.Money {
+	return &m
+}
+
 func TestItemUsecase_DeleteItem(t *testing.T) {
+	updatedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	before := updatedAt.Add(-time.Hour)
+	after := updatedAt.Add(time.Hour)
+
 	tests := []struct {
 		name        string
 		id          int64
+		input       DeleteItemInput
 		setupMock   func(*MockItemRepository)
 		expectError bool
 		expectedErr error
@@ -516,7 +917,7 @@ func TestItemUsecase_DeleteItem(t *testing.T) {
 				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
 				item.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
-				mockRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+				mockRepo.On("Delete", mock.Anything, int64(1), "").Return(nil)
 			},
 			expectError: false,
 		},
@@ -547,60 +948,198 @@ func TestItemUsecase_DeleteItem(t *testing.T) {
 			expectError: true,
 		},
 		{
-			name: "異常系: Deleteでデータベースエラー",
-			id:   1,
+			name:  "正常系: If-Matchが一致",
+			id:    1,
+			input: DeleteItemInput{IfMatch: []string{`"1-3"`}},
 			setupMock: func(mockRepo *MockItemRepository) {
 				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
 				item.ID = 1
+				item.Version = 3
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
-				mockRepo.On("Delete", mock.Anything, int64(1)).Return(domainErrors.ErrDatabaseError)
+				// 読み込んだバージョンのままの場合のみ削除する
+				mockRepo.On("DeleteVersion", mock.Anything, int64(1), int64(3), "").Return(nil)
 			},
-			expectError: true,
+			expectError: false,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			mockRepo := new(MockItemRepository)
-			tt.setupMock(mockRepo)
-			usecase := NewItemUsecase(mockRepo)
-
-			ctx := context.Background()
-			err := usecase.DeleteItem(ctx, tt.id)
-
-			if tt.expectError {
-				assert.Error(t, err)
-				if tt.expectedErr != nil {
-					assert.ErrorIs(t, err, tt.expectedErr)
-				}
-			} else {
-				assert.NoError(t, err)
-			}
-
-			mockRepo.AssertExpectations(t)
-		})
-	}
-}
-
-func TestItemUsecase_GetCategorySummary(t *testing.T) {
-	tests := []struct {
-		name               string
-		setupMock          func(*MockItemRepository)
-		expectedTotal      int
-		expectedWatchCount int
-		expectedBagCount   int
-		expectError        bool
-	}{
 		{
-			name: "正常系: 複数カテゴリーのアイテムがある場合",
+			name:  "異常系: If-Matchを確認した後に他のリクエストが更新",
+			id:    1,
+			input: DeleteItemInput{IfMatch: []string{`"1-3"`}},
 			setupMock: func(mockRepo *MockItemRepository) {
-				summary := map[string]int{
-					"時計":  2,
-					"バッグ": 1,
-				}
+				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+				item.ID = 1
+				item.Version = 3
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				mockRepo.On("DeleteVersion", mock.Anything, int64(1), int64(3), "").Return(domainErrors.ErrPreconditionFailed)
+			},
+			expectError: true,
+			expectedErr: domainErrors.ErrPreconditionFailed,
+		},
+		{
+			name:  "正常系: If-Match: * はバージョンによらず削除",
+			id:    1,
+			input: DeleteItemInput{IfMatch: []string{"*"}},
+			setupMock: func(mockRepo *MockItemRepository) {
+				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+				item.ID = 1
+				item.Version = 3
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				mockRepo.On("Delete", mock.Anything, int64(1), "").Return(nil)
+			},
+			expectError: false,
+		},
+		{
+			name:  "異常系: If-Matchが不一致",
+			id:    1,
+			input: DeleteItemInput{IfMatch: []string{`"1-2"`}},
+			setupMock: func(mockRepo *MockItemRepository) {
+				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+				item.ID = 1
+				item.Version = 3
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				// Deleteは呼ばれない
+			},
+			expectError: true,
+			expectedErr: domainErrors.ErrPreconditionFailed,
+		},
+		{
+			name:  "正常系: If-Unmodified-Since以降に更新されていない",
+			id:    1,
+			input: DeleteItemInput{UnmodifiedSince: &after},
+			setupMock: func(mockRepo *MockItemRepository) {
+				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+				item.ID = 1
+				item.UpdatedAt = updatedAt
+				item.Version = 2
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				mockRepo.On("DeleteVersion", mock.Anything, int64(1), int64(2), "").Return(nil)
+			},
+			expectError: false,
+		},
+		{
+			name:  "異常系: If-Unmodified-Since以降に更新されている",
+			id:    1,
+			input: DeleteItemInput{UnmodifiedSince: &before},
+			setupMock: func(mockRepo *MockItemRepository) {
+				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+				item.ID = 1
+				item.UpdatedAt = updatedAt
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				// Deleteは呼ばれない
+			},
+			expectError: true,
+			expectedErr: domainErrors.ErrPreconditionFailed,
+		},
+		{
+			name: "異常系: Deleteでデータベースエラー",
+			id:   1,
+			setupMock: func(mockRepo *MockItemRepository) {
+				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+				item.ID = 1
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				mockRepo.On("Delete", mock.Anything, int64(1), "").Return(domainErrors.ErrDatabaseError)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			ctx := context.Background()
+			err := usecase.DeleteItem(ctx, tt.id, tt.input)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				if tt.expectedErr != nil {
+					assert.ErrorIs(t, err, tt.expectedErr)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_DeleteItem_Reason(t *testing.T) {
+	newItem := func() *entity.Item {
+		item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		item.ID = 1
+		return item
+	}
+
+	t.Run("正常系: 理由を記録する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(), nil)
+		mockRepo.On("Delete", mock.Anything, int64(1), "売却").Return(nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		err := usecase.DeleteItem(context.Background(), 1, DeleteItemInput{Reason: "売却"})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 許可リストに含まれる理由", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(), nil)
+		mockRepo.On("Delete", mock.Anything, int64(1), "lost").Return(nil)
+		usecase := NewItemUsecase(mockRepo, WithDeleteReasons([]string{"sold", "lost", "returned"}))
+
+		err := usecase.DeleteItem(context.Background(), 1, DeleteItemInput{Reason: "lost"})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 許可リストに無い理由", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo, WithDeleteReasons([]string{"sold", "lost", "returned"}))
+
+		err := usecase.DeleteItem(context.Background(), 1, DeleteItemInput{Reason: "broken"})
+
+		assert.True(t, domainErrors.IsValidationError(err))
+		assert.Contains(t, err.Error(), "reason must be one of: sold, lost, returned")
+		mockRepo.AssertNotCalled(t, "FindByID")
+	})
+
+	t.Run("異常系: 自由記述の理由が長すぎる", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		err := usecase.DeleteItem(context.Background(), 1, DeleteItemInput{Reason: strings.Repeat("あ", entity.MaxDeleteReasonLength+1)})
+
+		assert.True(t, domainErrors.IsValidationError(err))
+		mockRepo.AssertNotCalled(t, "FindByID")
+	})
+}
+
+func TestItemUsecase_GetCategorySummary(t *testing.T) {
+	tests := []struct {
+		name               string
+		setupMock          func(*MockItemRepository)
+		expectedTotal      int
+		expectedTotalPrice entity.Money
+		expectedWatchCount int
+		expectedBagCount   int
+		expectError        bool
+	}{
+		{
+			name: "正常系: 複数カテゴリーのアイテムがある場合",
+			setupMock: func(mockRepo *MockItemRepository) {
+				summary := map[string]CategoryTotal{
+					"時計":  {Count: 2, PurchasePrice: entity.JPY(3000000)},
+					"バッグ": {Count: 1, PurchasePrice: entity.JPY(500000)},
+				}
 				mockRepo.On("GetSummaryByCategory", mock.Anything).Return(summary, nil)
 			},
 			expectedTotal:      3,
+			expectedTotalPrice: entity.JPY(3500000),
 			expectedWatchCount: 2,
 			expectedBagCount:   1,
 			expectError:        false,
@@ -608,10 +1147,11 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 		{
 			name: "正常系: アイテムが0件の場合",
 			setupMock: func(mockRepo *MockItemRepository) {
-				summary := map[string]int{}
+				summary := map[string]CategoryTotal{}
 				mockRepo.On("GetSummaryByCategory", mock.Anything).Return(summary, nil)
 			},
 			expectedTotal:      0,
+			expectedTotalPrice: entity.JPY(0),
 			expectedWatchCount: 0,
 			expectedBagCount:   0,
 			expectError:        false,
@@ -619,7 +1159,7 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 		{
 			name: "異常系: データベースエラー",
 			setupMock: func(mockRepo *MockItemRepository) {
-				mockRepo.On("GetSummaryByCategory", mock.Anything).Return((map[string]int)(nil), domainErrors.ErrDatabaseError)
+				mockRepo.On("GetSummaryByCategory", mock.Anything).Return((map[string]CategoryTotal)(nil), domainErrors.ErrDatabaseError)
 			},
 			expectError: true,
 		},
@@ -645,6 +1185,7 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 			require.NotNil(t, summary)
 
 			assert.Equal(t, tt.expectedTotal, summary.Total)
+			assert.Equal(t, tt.expectedTotalPrice, summary.TotalPurchasePrice)
 			assert.Equal(t, tt.expectedWatchCount, summary.Categories["時計"])
 			assert.Equal(t, tt.expectedBagCount, summary.Categories["バッグ"])
 
@@ -658,3 +1199,1352 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 		})
 	}
 }
+
+func TestItemUsecase_GetCategorySummaries(t *testing.T) {
+	t.Run("正常系: 指定順に返し、不明なカテゴリーは0件", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategories", mock.Anything, []string{"バッグ", "時計", "靴"}).Return(map[string]CategoryTotal{
+			"時計":  {Count: 2, PurchasePrice: entity.JPY(3000000)},
+			"バッグ": {Count: 1, PurchasePrice: entity.JPY(500000)},
+		}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		entries, err := usecase.GetCategorySummaries(context.Background(), []string{"バッグ", "家電", "時計", "バッグ", "靴"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []CategorySummaryEntry{
+			{Category: "バッグ", Count: 1, TotalPurchasePrice: entity.JPY(500000)},
+			{Category: "家電", Count: 0, TotalPurchasePrice: entity.JPY(0)},
+			{Category: "時計", Count: 2, TotalPurchasePrice: entity.JPY(3000000)},
+			{Category: "靴", Count: 0, TotalPurchasePrice: entity.JPY(0)},
+		}, entries)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: データベースエラー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategories", mock.Anything, []string{"時計"}).Return(nil, domainErrors.ErrDatabaseError)
+		usecase := NewItemUsecase(mockRepo)
+
+		entries, err := usecase.GetCategorySummaries(context.Background(), []string{"時計"})
+
+		assert.True(t, domainErrors.IsDatabaseError(err))
+		assert.Nil(t, entries)
+	})
+}
+
+func TestItemUsecase_CompareCategorySummary(t *testing.T) {
+	asOf := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常系: 現在との差分を返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategoryAsOf", mock.Anything, asOf).Return(map[string]CategoryTotal{
+			"時計":  {Count: 2, PurchasePrice: entity.JPY(3000000)},
+			"バッグ": {Count: 1, PurchasePrice: entity.JPY(500000)},
+		}, nil)
+		mockRepo.On("GetSummaryByCategory", mock.Anything).Return(map[string]CategoryTotal{
+			"時計": {Count: 3, PurchasePrice: entity.JPY(4500000)},
+			"靴":  {Count: 1, PurchasePrice: entity.JPY(80000)},
+		}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		comparison, err := usecase.CompareCategorySummary(context.Background(), asOf)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, comparison.Past.Total)
+		assert.Equal(t, 4, comparison.Current.Total)
+		assert.Equal(t, 1, comparison.Diff.Total)
+		assert.Equal(t, 1, comparison.Diff.Categories["時計"])
+		assert.Equal(t, -1, comparison.Diff.Categories["バッグ"])
+		assert.Equal(t, 1, comparison.Diff.Categories["靴"])
+		assert.Equal(t, 0, comparison.Diff.Categories["ジュエリー"])
+		assert.Equal(t, entity.JPY(1080000), comparison.Diff.TotalPurchasePrice)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 未来の日時", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		comparison, err := usecase.CompareCategorySummary(context.Background(), time.Now().Add(time.Hour))
+
+		assert.True(t, domainErrors.IsValidationError(err))
+		assert.Nil(t, comparison)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: データベースエラー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategoryAsOf", mock.Anything, asOf).Return(nil, domainErrors.ErrDatabaseError)
+		usecase := NewItemUsecase(mockRepo)
+
+		comparison, err := usecase.CompareCategorySummary(context.Background(), asOf)
+
+		assert.True(t, domainErrors.IsDatabaseError(err))
+		assert.Nil(t, comparison)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestItemUsecase_GetIncompleteItems(t *testing.T) {
+	t.Run("正常系: 欠けのあるアイテムのみ返す", func(t *testing.T) {
+		complete := &entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1000000), PurchaseDate: "2023-01-01"}
+		noBrand := &entity.Item{ID: 2, Name: "バッグ1", Category: "バッグ", Brand: "", PurchasePrice: entity.JPY(500000), PurchaseDate: "2023-01-02"}
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, ItemFilter{}).Return([]*entity.Item{complete, noBrand}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		items, err := usecase.GetIncompleteItems(context.Background())
+
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, int64(2), items[0].Item.ID)
+		assert.Equal(t, []entity.MissingField{{Field: "brand", Reason: "brand is empty"}}, items[0].MissingFields)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 該当なしの場合は空配列", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, ItemFilter{}).Return([]*entity.Item{}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		items, err := usecase.GetIncompleteItems(context.Background())
+
+		require.NoError(t, err)
+		assert.NotNil(t, items)
+		assert.Empty(t, items)
+	})
+
+	t.Run("正常系: 画像が無いアイテムも返す", func(t *testing.T) {
+		withImage := &entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1000000), PurchaseDate: "2023-01-01"}
+		noImage := &entity.Item{ID: 2, Name: "バッグ1", Category: "バッグ", Brand: "", PurchasePrice: entity.JPY(500000), PurchaseDate: "2023-01-02"}
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, ItemFilter{}).Return([]*entity.Item{withImage, noImage}, nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindCoversByItemIDs", mock.Anything, []int64{1, 2}).Return([]*entity.ItemImage{{ID: 10, ItemID: 1}}, nil)
+		usecase := NewItemUsecase(mockRepo, WithCompletenessImages(imageRepo))
+
+		items, err := usecase.GetIncompleteItems(context.Background())
+
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, int64(2), items[0].Item.ID)
+		assert.Equal(t, []entity.MissingField{
+			{Field: "brand", Reason: "brand is empty"},
+			{Field: "images", Reason: "item has no images"},
+		}, items[0].MissingFields)
+		imageRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 画像は1000件ずつまとめて取得する", func(t *testing.T) {
+		items := make([]*entity.Item, coverImageBatchSize+1)
+		ids := make([]int64, len(items))
+		for i := range items {
+			ids[i] = int64(i + 1)
+			items[i] = &entity.Item{ID: ids[i], Name: "時計", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1000), PurchaseDate: "2023-01-01"}
+		}
+		covers := make([]*entity.ItemImage, coverImageBatchSize)
+		for i := range covers {
+			covers[i] = &entity.ItemImage{ID: ids[i], ItemID: ids[i]}
+		}
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, ItemFilter{}).Return(items, nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindCoversByItemIDs", mock.Anything, ids[:coverImageBatchSize]).Return(covers, nil).Once()
+		imageRepo.On("FindCoversByItemIDs", mock.Anything, ids[coverImageBatchSize:]).Return([]*entity.ItemImage{}, nil).Once()
+		usecase := NewItemUsecase(mockRepo, WithCompletenessImages(imageRepo))
+
+		incomplete, err := usecase.GetIncompleteItems(context.Background())
+
+		require.NoError(t, err)
+		require.Len(t, incomplete, 1)
+		assert.Equal(t, ids[coverImageBatchSize], incomplete[0].Item.ID)
+		assert.Equal(t, []entity.MissingField{{Field: "images", Reason: "item has no images"}}, incomplete[0].MissingFields)
+		imageRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: データベースエラー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, ItemFilter{}).Return(([]*entity.Item)(nil), domainErrors.ErrDatabaseError)
+		usecase := NewItemUsecase(mockRepo)
+
+		items, err := usecase.GetIncompleteItems(context.Background())
+
+		assert.True(t, domainErrors.IsDatabaseError(err))
+		assert.Nil(t, items)
+	})
+
+	t.Run("異常系: 画像の取得でデータベースエラー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, ItemFilter{}).Return([]*entity.Item{{ID: 1, Name: "時計1"}}, nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindCoversByItemIDs", mock.Anything, []int64{1}).Return(([]*entity.ItemImage)(nil), domainErrors.ErrDatabaseError)
+		usecase := NewItemUsecase(mockRepo, WithCompletenessImages(imageRepo))
+
+		items, err := usecase.GetIncompleteItems(context.Background())
+
+		assert.True(t, domainErrors.IsDatabaseError(err))
+		assert.Nil(t, items)
+	})
+}
+
+func TestItemUsecase_ArchiveItem(t *testing.T) {
+	newItem := func(status entity.Status) *entity.Item {
+		return &entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1000000), PurchaseDate: "2023-01-01", Status: status}
+	}
+
+	t.Run("正常系: 所有中のアイテムをアーカイブ", func(t *testing.T) {
+		archived := entity.StatusArchived
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(entity.StatusActive), nil)
+		mockRepo.On("Update", mock.Anything, int64(1), ItemChanges{Status: &archived}).Return(newItem(entity.StatusArchived), nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		item, err := usecase.ArchiveItem(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.StatusArchived, item.Status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: アーカイブ済みの場合は更新しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(entity.StatusArchived), nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		item, err := usecase.ArchiveItem(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.StatusArchived, item.Status)
+		mockRepo.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("正常系: アーカイブを解除", func(t *testing.T) {
+		active := entity.StatusActive
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(entity.StatusArchived), nil)
+		mockRepo.On("Update", mock.Anything, int64(1), ItemChanges{Status: &active}).Return(newItem(entity.StatusActive), nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		item, err := usecase.UnarchiveItem(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.StatusActive, item.Status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 下書きはアーカイブできない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(entity.StatusDraft), nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.ArchiveItem(context.Background(), 1)
+
+		assert.True(t, domainErrors.IsInvalidStatusTransitionError(err))
+		mockRepo.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("異常系: アイテムが存在しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.UnarchiveItem(context.Background(), 1)
+
+		assert.True(t, domainErrors.IsNotFoundError(err))
+	})
+}
+
+func TestItemUsecase_GetBrandSummary(t *testing.T) {
+	t.Run("正常系: カテゴリーで絞り込んだブランド別集計", func(t *testing.T) {
+		filter := ItemFilter{Categories: []entity.Category{entity.CategoryWatch}}
+		expected := []BrandSummaryEntry{
+			{Brand: "ROLEX", Count: 2, TotalPurchasePrice: entity.JPY(3000000)},
+			{Brand: "OMEGA", Count: 1, TotalPurchasePrice: entity.JPY(800000)},
+		}
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByBrand", mock.Anything, filter).Return(expected, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		entries, err := usecase.GetBrandSummary(context.Background(), filter)
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, entries)
+		assert.Equal(t, float64(1500000), entries[0].AveragePurchasePrice)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 無効なカテゴリー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.GetBrandSummary(context.Background(), ItemFilter{Categories: []entity.Category{"家電"}})
+
+		assert.True(t, domainErrors.IsValidationError(err))
+		mockRepo.AssertNotCalled(t, "GetSummaryByBrand")
+	})
+
+	t.Run("異常系: データベースエラー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByBrand", mock.Anything, ItemFilter{}).Return(nil, domainErrors.ErrDatabaseError)
+		usecase := NewItemUsecase(mockRepo)
+
+		entries, err := usecase.GetBrandSummary(context.Background(), ItemFilter{})
+
+		assert.True(t, domainErrors.IsDatabaseError(err))
+		assert.Nil(t, entries)
+	})
+}
+
+func TestItemUsecase_GetGroupSummary(t *testing.T) {
+	t.Run("正常系: 状態ごとの集計はすべての状態を対象にする", func(t *testing.T) {
+		expected := []GroupSummaryEntry{
+			{Value: "active", Count: 3, TotalPurchasePrice: entity.JPY(3800000)},
+			{Value: "archived", Count: 1, TotalPurchasePrice: entity.JPY(500000)},
+		}
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByGroup", mock.Anything, ItemFilter{AnyStatus: true}, GroupByStatus).Return(expected, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		entries, err := usecase.GetGroupSummary(context.Background(), ItemFilter{}, GroupByStatus)
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, entries)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 状態を指定した場合はその状態のみ", func(t *testing.T) {
+		filter := ItemFilter{Status: entity.StatusArchived}
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByGroup", mock.Anything, filter, GroupByCategory).Return(nil, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		entries, err := usecase.GetGroupSummary(context.Background(), filter, GroupByCategory)
+
+		require.NoError(t, err)
+		assert.Equal(t, []GroupSummaryEntry{}, entries)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 許可リストに無いカラム", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.GetGroupSummary(context.Background(), ItemFilter{}, GroupBy("purchase_price"))
+
+		assert.True(t, domainErrors.IsValidationError(err))
+		assert.Contains(t, err.Error(), "must be one of: category, brand, status")
+		mockRepo.AssertNotCalled(t, "GetSummaryByGroup")
+	})
+}
+
+func TestItemUsecase_GetGroupSummary_Average(t *testing.T) {
+	tests := []struct {
+		name     string
+		decimals int
+		expected []float64
+	}{
+		{name: "正常系: 円単位に四捨五入", decimals: 0, expected: []float64{1266667, 0}},
+		{name: "正常系: 小数点以下2桁", decimals: 2, expected: []float64{1266666.67, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			mockRepo.On("GetSummaryByGroup", mock.Anything, ItemFilter{}, GroupByBrand).Return([]GroupSummaryEntry{
+				{Value: "ROLEX", Count: 3, TotalPurchasePrice: entity.JPY(3800000)},
+				{Value: "OMEGA", Count: 0, TotalPurchasePrice: entity.JPY(0)},
+			}, nil)
+			usecase := NewItemUsecase(mockRepo, WithAverageDecimals(tt.decimals))
+
+			entries, err := usecase.GetGroupSummary(context.Background(), ItemFilter{}, GroupByBrand)
+
+			require.NoError(t, err)
+			require.Len(t, entries, 2)
+			assert.Equal(t, tt.expected[0], entries[0].AveragePurchasePrice)
+			assert.Equal(t, tt.expected[1], entries[1].AveragePurchasePrice)
+			// 合計と件数はそのまま返す
+			assert.Equal(t, entity.JPY(3800000), entries[0].TotalPurchasePrice)
+			assert.Equal(t, 3, entries[0].Count)
+		})
+	}
+}
+
+func TestItemUsecase_GetAllItems_Fuzzy(t *testing.T) {
+	omega := &entity.Item{ID: 1, Name: "スピードマスター", Category: "時計", Brand: "OMEGA", PurchasePrice: entity.JPY(800000), PurchaseDate: "2023-01-01"}
+	rolex := &entity.Item{ID: 2, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-02"}
+	hermes := &entity.Item{ID: 3, Name: "バーキン", Category: "バッグ", Brand: "HERMES", PurchasePrice: entity.JPY(2000000), PurchaseDate: "2023-01-03"}
+
+	t.Run("正常系: 入力ミスを許容し、類似度の高い順に返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		// 類似度はアプリケーション側で計算するため、Queryなしで候補を取得する
+		mockRepo.On("FindAll", mock.Anything, ItemFilter{}).Return([]*entity.Item{rolex, hermes, omega}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		items, err := usecase.GetAllItems(context.Background(), ItemFilter{Query: "Omoga", Fuzzy: true})
+
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, int64(1), items[0].ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: qなしでfuzzyを指定", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.GetAllItems(context.Background(), ItemFilter{Fuzzy: true})
+
+		assert.True(t, domainErrors.IsValidationError(err))
+		mockRepo.AssertNotCalled(t, "FindAll")
+	})
+}
+
+func TestRankBySimilarity(t *testing.T) {
+	daytona := &entity.Item{ID: 1, Name: "ロレックス デイトナ", Brand: "ROLEX"}
+	datejust := &entity.Item{ID: 2, Name: "ロレックス デイトジャスト", Brand: "ROLEX"}
+	birkin := &entity.Item{ID: 3, Name: "バーキン", Brand: "HERMES"}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []int64
+	}{
+		{name: "部分一致は類似度1", query: "rolex", expected: []int64{1, 2}},
+		{name: "1文字の入力ミス", query: "ROLAX", expected: []int64{1, 2}},
+		{name: "単語ごとに判定し、近い順に並べる", query: "デイトナー", expected: []int64{1}},
+		{name: "類似度が低いものは返さない", query: "CHANEL", expected: []int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranked := rankBySimilarity([]*entity.Item{daytona, datejust, birkin}, tt.query)
+
+			ids := make([]int64, len(ranked))
+			for i, item := range ranked {
+				ids[i] = item.ID
+			}
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+}
+
+func TestRankBySimilarity_MaxResults(t *testing.T) {
+	items := make([]*entity.Item, FuzzyMaxResults+10)
+	for i := range items {
+		items[i] = &entity.Item{ID: int64(i + 1), Name: "デイトナ", Brand: "ROLEX"}
+	}
+
+	assert.Len(t, rankBySimilarity(items, "ROLEX"), FuzzyMaxResults)
+}
+
+func TestItemUsecase_GetUsedCategories(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMock   func(*MockItemRepository)
+		expected    []CategoryCount
+		expectError bool
+	}{
+		{
+			name: "正常系: 件数の多い順に取得",
+			setupMock: func(mockRepo *MockItemRepository) {
+				categories := []CategoryCount{
+					{Category: "時計", Count: 3},
+					{Category: "バッグ", Count: 1},
+				}
+				mockRepo.On("GetUsedCategories", mock.Anything).Return(categories, nil)
+			},
+			expected: []CategoryCount{
+				{Category: "時計", Count: 3},
+				{Category: "バッグ", Count: 1},
+			},
+		},
+		{
+			name: "正常系: アイテムが0件の場合は空配列",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetUsedCategories", mock.Anything).Return(([]CategoryCount)(nil), nil)
+			},
+			expected: []CategoryCount{},
+		},
+		{
+			name: "異常系: データベースエラー",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetUsedCategories", mock.Anything).Return(([]CategoryCount)(nil), domainErrors.ErrDatabaseError)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			categories, err := usecase.GetUsedCategories(context.Background())
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.True(t, domainErrors.IsDatabaseError(err))
+				assert.Nil(t, categories)
+				mockRepo.AssertExpectations(t)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, categories)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_GetDepreciationSchedule(t *testing.T) {
+	item := &entity.Item{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1000000), PurchaseDate: "2023-01-15"}
+
+	tests := []struct {
+		name         string
+		id           int64
+		years        int
+		salvage      int64
+		setupMock    func(*MockItemRepository)
+		expectedBook []int64
+		checkErr     func(error) bool
+	}{
+		{
+			name:    "正常系: 端数は最終年度で調整",
+			id:      1,
+			years:   3,
+			salvage: 100000,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+			},
+			expectedBook: []int64{700000, 400000, 100000},
+		},
+		{
+			name:    "異常系: 残存価額が購入価格を超える",
+			id:      1,
+			years:   5,
+			salvage: 2000000,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+			},
+			checkErr: domainErrors.IsValidationError,
+		},
+		{
+			name:  "異常系: 耐用年数が0",
+			id:    1,
+			years: 0,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+			},
+			checkErr: domainErrors.IsValidationError,
+		},
+		{
+			name:  "異常系: 存在しないアイテム",
+			id:    999,
+			years: 5,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(999)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+			},
+			checkErr: domainErrors.IsNotFoundError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			schedule, err := usecase.GetDepreciationSchedule(context.Background(), tt.id, tt.years, tt.salvage)
+
+			if tt.checkErr != nil {
+				assert.True(t, tt.checkErr(err), "unexpected error: %v", err)
+				assert.Nil(t, schedule)
+				mockRepo.AssertExpectations(t)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, entity.JPY(300000), schedule.AnnualDepreciation)
+			bookValues := make([]int64, 0, len(schedule.Schedule))
+			for _, entry := range schedule.Schedule {
+				bookValues = append(bookValues, entry.BookValue.Amount)
+			}
+			assert.Equal(t, tt.expectedBook, bookValues)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_PublishEvents(t *testing.T) {
+	item := &entity.Item{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15"}
+	newName := "更新後"
+
+	tests := []struct {
+		name          string
+		setupMock     func(*MockItemRepository)
+		run           func(ItemUsecase) error
+		expectedEvent EventType
+	}{
+		{
+			name: "正常系: 登録でitem.createdを通知",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(item, nil)
+			},
+			run: func(u ItemUsecase) error {
+				_, err := u.CreateItem(context.Background(), CreateItemInput{
+					Name: item.Name, Category: item.Category, Brand: item.Brand,
+					PurchasePrice: item.PurchasePrice.Amount, PurchaseDate: item.PurchaseDate,
+				})
+				return err
+			},
+			expectedEvent: EventItemCreated,
+		},
+		{
+			name: "正常系: 更新でitem.updatedを通知",
+			setupMock: func(mockRepo *MockItemRepository) {
+				existing := *item
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&existing, nil)
+				mockRepo.On("Update", mock.Anything, int64(1), ItemChanges{Name: &newName}).Return(item, nil)
+			},
+			run: func(u ItemUsecase) error {
+				_, err := u.UpdateItem(context.Background(), 1, UpdateItemInput{Name: &newName})
+				return err
+			},
+			expectedEvent: EventItemUpdated,
+		},
+		{
+			name: "正常系: 削除でitem.deletedを通知",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				mockRepo.On("Delete", mock.Anything, int64(1), "").Return(nil)
+			},
+			run: func(u ItemUsecase) error {
+				return u.DeleteItem(context.Background(), 1, DeleteItemInput{})
+			},
+			expectedEvent: EventItemDeleted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			mockPublisher := new(MockEventPublisher)
+			mockPublisher.On("Publish", mock.Anything, mock.MatchedBy(func(event ItemEvent) bool {
+				return event.Type == tt.expectedEvent && event.ItemID == item.ID
+			})).Return(nil)
+
+			err := tt.run(NewItemUsecase(mockRepo, WithEventPublisher(mockPublisher)))
+
+			require.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+			mockPublisher.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_PublishEvents_NotOnFailure(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+	mockPublisher := new(MockEventPublisher)
+
+	err := NewItemUsecase(mockRepo, WithEventPublisher(mockPublisher)).DeleteItem(context.Background(), 1, DeleteItemInput{})
+
+	assert.Error(t, err)
+	mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestItemUsecase_PublishEvents_Failure(t *testing.T) {
+	item := &entity.Item{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15"}
+
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(item, nil)
+	mockPublisher := new(MockEventPublisher)
+	mockPublisher.On("Publish", mock.Anything, mock.Anything).Return(domainErrors.ErrDatabaseError)
+
+	// イベントを記録できない場合は登録自体を失敗させ、トランザクションをロールバックさせる
+	created, err := NewItemUsecase(mockRepo, WithEventPublisher(mockPublisher)).CreateItem(context.Background(), CreateItemInput{
+		Name: item.Name, Category: item.Category, Brand: item.Brand,
+		PurchasePrice: item.PurchasePrice.Amount, PurchaseDate: item.PurchaseDate,
+	})
+
+	assert.Error(t, err)
+	assert.True(t, domainErrors.IsDatabaseError(err))
+	assert.Nil(t, created)
+}
+
+func TestItemUsecase_GetChangesSince(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	item := &entity.Item{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15"}
+
+	tests := []struct {
+		name            string
+		setupMock       func(*MockItemRepository)
+		expectedItems   int
+		expectedDeleted []int64
+		expectError     bool
+	}{
+		{
+			name: "正常系: 変更と削除を取得",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindChangedSince", mock.Anything, since).Return([]*entity.Item{item}, nil)
+				mockRepo.On("FindDeletedIDsSince", mock.Anything, since).Return([]int64{2, 3}, nil)
+			},
+			expectedItems:   1,
+			expectedDeleted: []int64{2, 3},
+		},
+		{
+			name: "正常系: 変更が無い場合は空配列",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindChangedSince", mock.Anything, since).Return(([]*entity.Item)(nil), nil)
+				mockRepo.On("FindDeletedIDsSince", mock.Anything, since).Return(([]int64)(nil), nil)
+			},
+			expectedItems:   0,
+			expectedDeleted: []int64{},
+		},
+		{
+			name: "異常系: データベースエラー",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindChangedSince", mock.Anything, since).Return(([]*entity.Item)(nil), domainErrors.ErrDatabaseError)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+			defer entity.SetClock(entity.FixedClock(time.Date(2024, 1, 2, 3, 4, 5, 600, time.UTC)))()
+
+			changes, err := usecase.GetChangesSince(context.Background(), since)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.True(t, domainErrors.IsDatabaseError(err))
+				assert.Nil(t, changes)
+				mockRepo.AssertExpectations(t)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, changes.Items, tt.expectedItems)
+			assert.NotNil(t, changes.Items)
+			assert.Equal(t, tt.expectedDeleted, changes.DeletedIDs)
+			assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), changes.ServerTime)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_UpsertItem(t *testing.T) {
+	input := CreateItemInput{
+		Name:          "ロレックス デイトナ",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: 1500000,
+		PurchaseDate:  "2023-01-15",
+		SerialNumber:  " SN-001 ",
+	}
+	upserted := &entity.Item{ID: 1, Name: input.Name, Category: input.Category, Brand: input.Brand, PurchasePrice: entity.JPY(input.PurchasePrice), PurchaseDate: input.PurchaseDate, SerialNumber: "SN-001"}
+	withSerial := mock.MatchedBy(func(item *entity.Item) bool { return item.SerialNumber == "SN-001" })
+
+	tests := []struct {
+		name            string
+		input           CreateItemInput
+		setupMock       func(*MockItemRepository)
+		expectedCreated bool
+		expectedErr     error
+	}{
+		{
+			name:  "正常系: 新規登録",
+			input: input,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Upsert", mock.Anything, withSerial).Return(upserted, true, nil)
+			},
+			expectedCreated: true,
+		},
+		{
+			name:  "正常系: 既存アイテムを更新",
+			input: input,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Upsert", mock.Anything, withSerial).Return(upserted, false, nil)
+			},
+			expectedCreated: false,
+		},
+		{
+			name: "異常系: serial_numberが空",
+			input: CreateItemInput{
+				Name: input.Name, Category: input.Category, Brand: input.Brand,
+				PurchasePrice: input.PurchasePrice, PurchaseDate: input.PurchaseDate,
+			},
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: データベースエラー",
+			input: input,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Upsert", mock.Anything, withSerial).Return(nil, false, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			item, created, err := usecase.UpsertItem(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, item)
+				mockRepo.AssertExpectations(t)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCreated, created)
+			assert.Equal(t, "SN-001", item.SerialNumber)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemFilter_Validate_Sort(t *testing.T) {
+	assert.NoError(t, ItemFilter{Deleted: true, Sort: SortDeletedAtAsc}.Validate())
+	assert.ErrorIs(t, ItemFilter{Sort: SortDeletedAtDesc}.Validate(), domainErrors.ErrInvalidInput)
+	assert.ErrorIs(t, ItemFilter{Deleted: true, Sort: "name"}.Validate(), domainErrors.ErrInvalidInput)
+	assert.NoError(t, ItemFilter{Sort: SortPurchaseDateDesc}.Validate())
+	assert.NoError(t, ItemFilter{Deleted: true, Sort: SortIDAsc}.Validate())
+	assert.NoError(t, ItemFilter{Sort: SortSerialNumberDesc}.Validate())
+}
+
+func TestItemFilter_Validate_SerialPrefix(t *testing.T) {
+	assert.NoError(t, ItemFilter{SerialPrefix: "ABC"}.Validate())
+	assert.NoError(t, ItemFilter{SerialPrefix: "シリアル"}.Validate())
+	assert.ErrorIs(t, ItemFilter{SerialPrefix: "AB"}.Validate(), domainErrors.ErrInvalidInput)
+	assert.ErrorIs(t, ItemFilter{SerialPrefix: strings.Repeat("A", MaxSerialPrefixLength+1)}.Validate(), domainErrors.ErrInvalidInput)
+}
+
+func TestItemFilter_Validate_IDRange(t *testing.T) {
+	assert.NoError(t, ItemFilter{IDFrom: 100, IDTo: 200}.Validate())
+	assert.NoError(t, ItemFilter{IDFrom: 5, IDTo: 5}.Validate())
+	assert.NoError(t, ItemFilter{IDFrom: 1, IDTo: MaxIDRangeSpan}.Validate())
+	assert.ErrorIs(t, ItemFilter{IDFrom: 1, IDTo: MaxIDRangeSpan + 1}.Validate(), domainErrors.ErrInvalidInput)
+	assert.ErrorIs(t, ItemFilter{IDFrom: 200, IDTo: 100}.Validate(), domainErrors.ErrInvalidInput)
+	assert.ErrorIs(t, ItemFilter{IDFrom: 100}.Validate(), domainErrors.ErrInvalidInput)
+	assert.ErrorIs(t, ItemFilter{IDTo: 100}.Validate(), domainErrors.ErrInvalidInput)
+}
+
+func TestItemFilter_Validate_PurchaseRanges(t *testing.T) {
+	zero, low, high, negative := int64(0), int64(500000), int64(2000000), int64(-1)
+	assert.NoError(t, ItemFilter{MinPurchasePrice: &low, MaxPurchasePrice: &high}.Validate())
+	assert.NoError(t, ItemFilter{MaxPurchasePrice: &zero}.Validate())
+	assert.NoError(t, ItemFilter{PurchasedFrom: "2020-01-01", PurchasedTo: "2020-01-01"}.Validate())
+	assert.ErrorIs(t, ItemFilter{MinPurchasePrice: &high, MaxPurchasePrice: &low}.Validate(), domainErrors.ErrInvalidInput)
+	assert.ErrorIs(t, ItemFilter{MinPurchasePrice: &negative}.Validate(), domainErrors.ErrInvalidInput)
+	assert.ErrorIs(t, ItemFilter{PurchasedFrom: "2020/01/01"}.Validate(), domainErrors.ErrInvalidInput)
+	assert.ErrorIs(t, ItemFilter{PurchasedFrom: "2023-01-01", PurchasedTo: "2020-01-01"}.Validate(), domainErrors.ErrInvalidInput)
+}
+
+func TestItemFilter_Validate_PurchaseMonthAndWeekdays(t *testing.T) {
+	assert.NoError(t, ItemFilter{PurchaseMonth: 12, PurchaseWeekdays: []time.Weekday{time.Saturday, time.Sunday}}.Validate())
+	assert.ErrorIs(t, ItemFilter{PurchaseMonth: 13}.Validate(), domainErrors.ErrInvalidInput)
+	assert.ErrorIs(t, ItemFilter{PurchaseMonth: -1}.Validate(), domainErrors.ErrInvalidInput)
+	assert.ErrorIs(t, ItemFilter{PurchaseWeekdays: []time.Weekday{7}}.Validate(), domainErrors.ErrInvalidInput)
+}
+
+func TestSortOrder_IsValidDefault(t *testing.T) {
+	assert.True(t, SortCreatedAtDesc.IsValidDefault())
+	assert.True(t, SortPurchaseDateAsc.IsValidDefault())
+	assert.False(t, SortDeletedAtDesc.IsValidDefault())
+	assert.False(t, SortOrder("").IsValidDefault())
+	assert.False(t, SortOrder("name").IsValidDefault())
+}
+
+func TestItemUsecase_DefaultSort(t *testing.T) {
+	items := []*entity.Item{{ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-01"}}
+
+	t.Run("正常系: ?sort= を指定しない場合は既定の並び順", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, ItemFilter{Sort: SortPurchaseDateDesc}).Return(items, nil)
+		usecase := NewItemUsecase(mockRepo, WithDefaultSort(SortPurchaseDateDesc))
+
+		result, err := usecase.GetAllItems(context.Background(), ItemFilter{})
+
+		require.NoError(t, err)
+		assert.Len(t, result, 1)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 指定した並び順を優先", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindPage", mock.Anything, ItemFilter{Sort: SortIDAsc}, Page{Limit: 20}).Return(items, nil)
+		mockRepo.On("Count", mock.Anything, ItemFilter{Sort: SortIDAsc}).Return(1, nil)
+		usecase := NewItemUsecase(mockRepo, WithDefaultSort(SortPurchaseDateDesc))
+
+		result, err := usecase.GetItemPage(context.Background(), ItemFilter{Sort: SortIDAsc}, Page{Limit: 20})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Total)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: シリアル番号で絞り込む場合はシリアル番号順", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, ItemFilter{SerialPrefix: "ABC", Sort: SortSerialNumberAsc}).Return(items, nil)
+		usecase := NewItemUsecase(mockRepo, WithDefaultSort(SortPurchaseDateDesc))
+
+		result, err := usecase.GetAllItems(context.Background(), ItemFilter{SerialPrefix: "ABC"})
+
+		require.NoError(t, err)
+		assert.Len(t, result, 1)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 一覧のバージョンに適用する並び順を含める", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetListVersion", mock.Anything, ItemFilter{Sort: SortPurchaseDateDesc}).Return(&ListVersion{Count: 1}, nil)
+		usecase := NewItemUsecase(mockRepo, WithDefaultSort(SortPurchaseDateDesc))
+
+		version, err := usecase.GetItemListVersion(context.Background(), ItemFilter{})
+
+		require.NoError(t, err)
+		assert.Equal(t, SortPurchaseDateDesc, version.Sort)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestItemUsecase_GetItemPage(t *testing.T) {
+	items := []*entity.Item{{ID: 1, Name: "ネヴァーフル", Category: "バッグ", Brand: "LOUIS VUITTON", PurchasePrice: entity.JPY(200000), PurchaseDate: "2023-03-01"}}
+	filter := ItemFilter{Brand: "louis vuitton"}
+
+	tests := []struct {
+		name        string
+		page        Page
+		setupMock   func(*MockItemRepository)
+		expected    *ItemPage
+		expectedErr error
+	}{
+		{
+			name: "正常系: 1ページ分と全件数を返す",
+			page: Page{Limit: 1, Offset: 0},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindPage", mock.Anything, filter, Page{Limit: 1, Offset: 0}).Return(items, nil)
+				mockRepo.On("Count", mock.Anything, filter).Return(3, nil)
+			},
+			expected: &ItemPage{Items: items, Total: 3, Limit: 1, Offset: 0},
+		},
+		{
+			name:        "異常系: limitが上限を超える",
+			page:        Page{Limit: MaxPageLimit + 1},
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: offsetが負",
+			page:        Page{Limit: 10, Offset: -1},
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name: "異常系: 件数の取得に失敗",
+			page: Page{Limit: 10},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindPage", mock.Anything, filter, Page{Limit: 10}).Return(items, nil)
+				mockRepo.On("Count", mock.Anything, filter).Return(0, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			result, err := usecase.GetItemPage(context.Background(), filter, tt.page)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_GetItemPage_MaxOffset(t *testing.T) {
+	items := []*entity.Item{{ID: 1, Name: "ネヴァーフル", Category: "バッグ", Brand: "LOUIS VUITTON", PurchasePrice: entity.JPY(200000), PurchaseDate: "2023-03-01"}}
+
+	t.Run("正常系: 上限ちょうどのoffset", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindPage", mock.Anything, mock.Anything, Page{Limit: 20, Offset: 100}).Return(items, nil)
+		mockRepo.On("Count", mock.Anything, mock.Anything).Return(101, nil)
+		usecase := NewItemUsecase(mockRepo, WithMaxPageOffset(100))
+
+		result, err := usecase.GetItemPage(context.Background(), ItemFilter{}, Page{Limit: 20, Offset: 100})
+
+		require.NoError(t, err)
+		assert.Equal(t, 100, result.Offset)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 上限を超えるoffsetはDBに問い合わせない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo, WithMaxPageOffset(100))
+
+		result, err := usecase.GetItemPage(context.Background(), ItemFilter{}, Page{Limit: 20, Offset: 101})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "offset must be 100 or less")
+		assert.Nil(t, result)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestItemUsecase_GetItemPage_MaxSearchResults(t *testing.T) {
+	items := []*entity.Item{{ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15"}}
+	filter := ItemFilter{Query: "rolex"}
+
+	t.Run("正常系: 上限を超えた場合は上限までを返し、絞り込みを促す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		// 上限をまたぐページは上限までに切り詰めて取得する
+		mockRepo.On("FindPage", mock.Anything, mock.Anything, Page{Limit: 10, Offset: 90}).Return(items, nil)
+		mockRepo.On("Count", mock.Anything, mock.Anything).Return(2500, nil)
+		usecase := NewItemUsecase(mockRepo, WithMaxSearchResults(100))
+
+		result, err := usecase.GetItemPage(context.Background(), filter, Page{Limit: 20, Offset: 90})
+
+		require.NoError(t, err)
+		assert.Equal(t, 100, result.Total)
+		assert.Equal(t, 2500, result.TotalMatches)
+		assert.True(t, result.RefineSuggested)
+		assert.Equal(t, 20, result.Limit)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 上限以降のページは取得せず件数のみ返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Count", mock.Anything, mock.Anything).Return(2500, nil)
+		usecase := NewItemUsecase(mockRepo, WithMaxSearchResults(100))
+
+		result, err := usecase.GetItemPage(context.Background(), filter, Page{Limit: 20, Offset: 100})
+
+		require.NoError(t, err)
+		assert.Empty(t, result.Items)
+		assert.Equal(t, 2500, result.TotalMatches)
+		assert.True(t, result.RefineSuggested)
+		mockRepo.AssertNotCalled(t, "FindPage", mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 上限以下の場合は絞り込みを促さない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindPage", mock.Anything, mock.Anything, Page{Limit: 20}).Return(items, nil)
+		mockRepo.On("Count", mock.Anything, mock.Anything).Return(1, nil)
+		usecase := NewItemUsecase(mockRepo, WithMaxSearchResults(100))
+
+		result, err := usecase.GetItemPage(context.Background(), filter, Page{Limit: 20})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Total)
+		assert.Equal(t, 1, result.TotalMatches)
+		assert.False(t, result.RefineSuggested)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 検索語が無い一覧には適用しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindPage", mock.Anything, mock.Anything, Page{Limit: 20, Offset: 100}).Return(items, nil)
+		mockRepo.On("Count", mock.Anything, mock.Anything).Return(2500, nil)
+		usecase := NewItemUsecase(mockRepo, WithMaxSearchResults(100))
+
+		result, err := usecase.GetItemPage(context.Background(), ItemFilter{Brand: "ROLEX"}, Page{Limit: 20, Offset: 100})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2500, result.Total)
+		assert.Zero(t, result.TotalMatches)
+		assert.False(t, result.RefineSuggested)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestItemUsecase_GetAllItems_MaxSearchResults(t *testing.T) {
+	items := []*entity.Item{{ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15"}}
+
+	t.Run("正常系: 検索語を指定した場合は上限までを取得する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindPage", mock.Anything, mock.Anything, Page{Limit: 100}).Return(items, nil)
+		usecase := NewItemUsecase(mockRepo, WithMaxSearchResults(100))
+
+		result, err := usecase.GetAllItems(context.Background(), ItemFilter{Query: "rolex"})
+
+		require.NoError(t, err)
+		assert.Equal(t, items, result)
+		mockRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 検索語が無い一覧は全件を取得する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, mock.Anything).Return(items, nil)
+		usecase := NewItemUsecase(mockRepo, WithMaxSearchResults(100))
+
+		result, err := usecase.GetAllItems(context.Background(), ItemFilter{Brand: "ROLEX"})
+
+		require.NoError(t, err)
+		assert.Equal(t, items, result)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 一覧の集計値に上限を含める", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetListVersion", mock.Anything, mock.Anything).Return(&ListVersion{Count: 2500}, nil)
+		usecase := NewItemUsecase(mockRepo, WithMaxSearchResults(100))
+
+		version, err := usecase.GetItemListVersion(context.Background(), ItemFilter{Query: "rolex"})
+
+		require.NoError(t, err)
+		assert.Equal(t, 100, version.SearchWindow)
+		assert.Equal(t, 100, version.ReturnedCount())
+		assert.True(t, version.RefineSuggested())
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestItemUsecase_GetDiversity(t *testing.T) {
+	t.Run("正常系: ブランド別・カテゴリー別の集計から集中度を計算する", func(t *testing.T) {
+		filter := ItemFilter{Categories: []entity.Category{entity.CategoryWatch}}
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByGroup", mock.Anything, filter, GroupByBrand).Return([]GroupSummaryEntry{
+			{Value: "ROLEX", Count: 2, TotalPurchasePrice: entity.JPY(3000000)},
+			{Value: "OMEGA", Count: 1, TotalPurchasePrice: entity.JPY(1000000)},
+		}, nil)
+		mockRepo.On("GetSummaryByGroup", mock.Anything, filter, GroupByCategory).Return([]GroupSummaryEntry{
+			{Value: "時計", Count: 3, TotalPurchasePrice: entity.JPY(4000000)},
+		}, nil)
+		top := []*entity.Item{{ID: 1, Name: "デイトナ", PurchasePrice: entity.JPY(2500000)}}
+		mockRepo.On("FindPage", mock.Anything, ItemFilter{Categories: filter.Categories, Sort: SortPurchasePriceDesc}, Page{Limit: 1}).Return(top, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		diversity, err := usecase.GetDiversity(context.Background(), filter)
+
+		require.NoError(t, err)
+		assert.Equal(t, &Diversity{
+			Count:              3,
+			TotalPurchasePrice: entity.JPY(4000000),
+			TopItem:            &DiversityTopItem{ID: 1, Name: "デイトナ", Share: 0.625},
+			Brands:             DiversityConcentration{Distinct: 2, Top: "ROLEX", TopShare: 0.75, HHI: 0.625, Gini: 0.25},
+			Categories:         DiversityConcentration{Distinct: 1, Top: "時計", TopShare: 1, HHI: 1, Gini: 0},
+		}, diversity)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: アイテムが無い場合はすべて0", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByGroup", mock.Anything, ItemFilter{}, GroupByBrand).Return(nil, nil)
+		mockRepo.On("GetSummaryByGroup", mock.Anything, ItemFilter{}, GroupByCategory).Return(nil, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		diversity, err := usecase.GetDiversity(context.Background(), ItemFilter{})
+
+		require.NoError(t, err)
+		assert.Equal(t, &Diversity{TotalPurchasePrice: entity.JPY(0)}, diversity)
+		mockRepo.AssertNotCalled(t, "FindPage")
+	})
+
+	t.Run("異常系: 無効なカテゴリー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.GetDiversity(context.Background(), ItemFilter{Categories: []entity.Category{"家電"}})
+
+		assert.True(t, domainErrors.IsValidationError(err))
+		mockRepo.AssertNotCalled(t, "GetSummaryByGroup")
+	})
+}
+
+func TestGiniCoefficient(t *testing.T) {
+	assert.Equal(t, 0.0, giniCoefficient(nil))
+	assert.Equal(t, 0.0, giniCoefficient([]int64{500, 500, 500}))
+	// 1つの値に集中する場合は (n-1)/n
+	assert.Equal(t, 0.75, giniCoefficient([]int64{0, 0, 1000, 0}))
+	assert.Equal(t, 0.2222, giniCoefficient([]int64{1, 2, 3}))
+}
+
+func TestItemUsecase_GetTimeline(t *testing.T) {
+	t.Run("正常系: 購入年ごとの集計", func(t *testing.T) {
+		year2020, year2023 := 2020, 2023
+		expected := []TimelineEntry{
+			{Year: &year2020, Count: 1, TotalPurchasePrice: entity.JPY(500000)},
+			{Year: &year2023, Count: 3, TotalPurchasePrice: entity.JPY(3800000)},
+			{Year: nil, Count: 1, TotalPurchasePrice: entity.JPY(10000)},
+		}
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByPurchaseYear", mock.Anything, ItemFilter{}).Return(expected, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		entries, err := usecase.GetTimeline(context.Background(), ItemFilter{})
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, entries)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: アイテムが無い場合は空の配列", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByPurchaseYear", mock.Anything, ItemFilter{}).Return(nil, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		entries, err := usecase.GetTimeline(context.Background(), ItemFilter{})
+
+		require.NoError(t, err)
+		assert.Equal(t, []TimelineEntry{}, entries)
+	})
+
+	t.Run("異常系: 無効なカテゴリー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.GetTimeline(context.Background(), ItemFilter{Categories: []entity.Category{"家電"}})
+
+		assert.True(t, domainErrors.IsValidationError(err))
+		mockRepo.AssertNotCalled(t, "GetSummaryByPurchaseYear")
+	})
+}
+
+func TestItemUsecase_GetTrends(t *testing.T) {
+	pct := func(value float64) *float64 { return &value }
+
+	t.Run("正常系: 前年比を計算し、購入の無い年は0件で含める", func(t *testing.T) {
+		year2020, year2021, year2023 := 2020, 2021, 2023
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByPurchaseYear", mock.Anything, ItemFilter{}).Return([]TimelineEntry{
+			{Year: &year2020, Count: 2, TotalPurchasePrice: entity.JPY(1000000)},
+			{Year: &year2021, Count: 3, TotalPurchasePrice: entity.JPY(750000)},
+			{Year: &year2023, Count: 1, TotalPurchasePrice: entity.JPY(300000)},
+			{Year: nil, Count: 4, TotalPurchasePrice: entity.JPY(10000)},
+		}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		trends, err := usecase.GetTrends(context.Background(), ItemFilter{})
+
+		require.NoError(t, err)
+		assert.Equal(t, []TrendEntry{
+			{Year: 2020, Count: 2, TotalSpend: entity.JPY(1000000)},
+			{Year: 2021, Count: 3, TotalSpend: entity.JPY(750000), CountChangePct: pct(50), SpendChangePct: pct(-25)},
+			{Year: 2022, Count: 0, TotalSpend: entity.JPY(0), CountChangePct: pct(-100), SpendChangePct: pct(-100)},
+			// 前年が0件の場合は増減率を計算できない
+			{Year: 2023, Count: 1, TotalSpend: entity.JPY(300000)},
+		}, trends)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 増減率は小数点以下1桁に丸める", func(t *testing.T) {
+		year2022, year2023 := 2022, 2023
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByPurchaseYear", mock.Anything, ItemFilter{}).Return([]TimelineEntry{
+			{Year: &year2022, Count: 3, TotalPurchasePrice: entity.JPY(300000)},
+			{Year: &year2023, Count: 4, TotalPurchasePrice: entity.JPY(100000)},
+		}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		trends, err := usecase.GetTrends(context.Background(), ItemFilter{})
+
+		require.NoError(t, err)
+		require.Len(t, trends, 2)
+		assert.Equal(t, pct(33.3), trends[1].CountChangePct)
+		assert.Equal(t, pct(-66.7), trends[1].SpendChangePct)
+	})
+
+	t.Run("正常系: 購入年が不明なアイテムのみの場合は空の配列", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByPurchaseYear", mock.Anything, ItemFilter{}).
+			Return([]TimelineEntry{{Year: nil, Count: 1, TotalPurchasePrice: entity.JPY(10000)}}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		trends, err := usecase.GetTrends(context.Background(), ItemFilter{})
+
+		require.NoError(t, err)
+		assert.Equal(t, []TrendEntry{}, trends)
+	})
+
+	t.Run("異常系: 無効なカテゴリー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.GetTrends(context.Background(), ItemFilter{Categories: []entity.Category{"家電"}})
+
+		assert.True(t, domainErrors.IsValidationError(err))
+		mockRepo.AssertNotCalled(t, "GetSummaryByPurchaseYear")
+	})
+}
+
+func TestListVersion_ETag(t *testing.T) {
+	updatedAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	later := updatedAt.Add(time.Second)
+	version := ListVersion{Count: 3, LastUpdatedAt: &updatedAt}
+	filter := ItemFilter{Categories: []entity.Category{entity.CategoryWatch}}
+
+	etag := version.ETag(filter)
+	assert.Regexp(t, `^W/"[0-9a-f]{16}"$`, etag)
+	assert.Equal(t, etag, ListVersion{Count: 3, LastUpdatedAt: &updatedAt}.ETag(ItemFilter{Categories: []entity.Category{entity.CategoryWatch}}))
+
+	// 件数・更新日時・絞り込み条件のいずれかが異なれば別の値
+	assert.NotEqual(t, etag, ListVersion{Count: 2, LastUpdatedAt: &updatedAt}.ETag(filter))
+	assert.NotEqual(t, etag, ListVersion{Count: 3, LastUpdatedAt: &later}.ETag(filter))
+	assert.NotEqual(t, etag, version.ETag(ItemFilter{Categories: []entity.Category{entity.CategoryBag}}))
+	assert.NotEqual(t, etag, version.ETag(ItemFilter{Categories: []entity.Category{entity.CategoryWatch}, Query: "ROLEX"}))
+	assert.NotEqual(t, ListVersion{}.ETag(ItemFilter{}), ListVersion{}.ETag(ItemFilter{Status: entity.StatusArchived}))
+	// 既定の並び順を変更した場合も別の値
+	assert.NotEqual(t, etag, ListVersion{Count: 3, LastUpdatedAt: &updatedAt, Sort: SortIDAsc}.ETag(filter))
+	// 件数と更新日時が同じでも、同じ秒の中で書き込まれた場合は別の値
+	assert.NotEqual(t, etag, ListVersion{Count: 3, LastUpdatedAt: &updatedAt, VersionSum: 4}.ETag(filter))
+	assert.NotEqual(t, etag, ListVersion{Count: 3, LastUpdatedAt: &updatedAt, Checksum: 12345}.ETag(filter))
+}
+
+func TestItemUsecase_GetItemSiblings(t *testing.T) {
+	t.Run("正常系: 並び順を指定しない場合は既定の並び順", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindSiblings", mock.Anything, int64(5), ItemFilter{Sort: SortIDAsc}).
+			Return(&ItemSiblings{Previous: &ItemSibling{ID: 4}}, nil)
+		usecase := NewItemUsecase(mockRepo, WithDefaultSort(SortIDAsc))
+
+		siblings, err := usecase.GetItemSiblings(context.Background(), 5, ItemFilter{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &ItemSiblings{ID: 5, Sort: SortIDAsc, Previous: &ItemSibling{ID: 4}}, siblings)
+	})
+
+	t.Run("異常系: 条件に一致しないアイテム", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindSiblings", mock.Anything, int64(5), ItemFilter{Sort: SortPurchasePriceAsc}).Return(nil, domainErrors.ErrItemNotFound)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.GetItemSiblings(context.Background(), 5, ItemFilter{Sort: SortPurchasePriceAsc})
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+
+	t.Run("異常系: 不明な並び順", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.GetItemSiblings(context.Background(), 5, ItemFilter{Sort: "price"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "FindSiblings", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
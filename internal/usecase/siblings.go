@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 前後のアイテムの一覧に表示する最小限の情報
+type ItemSibling struct {
+	ID       int64           `json:"id"`
+	UUID     string          `json:"-"` // ID_TYPE=uuid の場合にAPIで使うID
+	Name     string          `json:"name"`
+	Category entity.Category `json:"category"`
+	Brand    string          `json:"brand"`
+}
+
+// 並び順で指定したアイテムの直前・直後のアイテム（先頭・末尾の場合はnil）
+type ItemSiblings struct {
+	ID       int64        `json:"id"`
+	Sort     SortOrder    `json:"sort"`
+	Previous *ItemSibling `json:"previous"`
+	Next     *ItemSibling `json:"next"`
+}
+
+// 絞り込み条件に一致するアイテムを並び順に並べたときの、指定したアイテムの前後を返す
+// 指定したアイテムが条件に一致しない場合はErrItemNotFound
+func (u *itemUsecase) GetItemSiblings(ctx context.Context, id int64, filter ItemFilter) (*ItemSiblings, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	filter = u.withDefaultSort(filter)
+
+	siblings, err := u.itemRepo.FindSiblings(ctx, id, filter)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to get item siblings: %w", err)
+	}
+	siblings.ID = id
+	siblings.Sort = filter.Sort
+	return siblings, nil
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 指定時点と現在のカテゴリー別集計の比較
+type SummaryComparison struct {
+	AsOf    time.Time        `json:"as_of"`
+	Past    *CategorySummary `json:"past"`    // as_ofの時点で存在していたアイテムの集計
+	Current *CategorySummary `json:"current"` // 現在の集計
+	Diff    *CategorySummary `json:"diff"`    // 現在 - 過去（減少した場合は負の値）
+}
+
+// 過去の時点の集計を論理削除の履歴から再構成し、現在の集計との差分を返す
+// 購入価格は更新履歴を持たないため、過去の時点の集計にも現在の価格を使う
+func (u *itemUsecase) CompareCategorySummary(ctx context.Context, asOf time.Time) (*SummaryComparison, error) {
+	if asOf.After(entity.Now()) {
+		return nil, fmt.Errorf("%w: as_of must not be in the future", domainErrors.ErrInvalidInput)
+	}
+
+	// 過去と現在の集計を同一スナップショットから取得する
+	var pastTotals, currentTotals map[string]CategoryTotal
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if pastTotals, err = u.itemRepo.GetSummaryByCategoryAsOf(ctx, asOf); err != nil {
+			return err
+		}
+		currentTotals, err = u.itemRepo.GetSummaryByCategory(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare category summary: %w", err)
+	}
+
+	past, err := buildCategorySummary(pastTotals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare category summary: %w", err)
+	}
+	current, err := buildCategorySummary(currentTotals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare category summary: %w", err)
+	}
+
+	diff := &CategorySummary{
+		Categories:         make(map[string]int, len(current.Categories)),
+		Total:              current.Total - past.Total,
+		TotalPurchasePrice: entity.NewMoney(current.TotalPurchasePrice.Amount-past.TotalPurchasePrice.Amount, current.TotalPurchasePrice.Currency),
+	}
+	for category, count := range current.Categories {
+		diff.Categories[category] = count - past.Categories[category]
+	}
+
+	return &SummaryComparison{
+		AsOf:    asOf,
+		Past:    past,
+		Current: current,
+		Diff:    diff,
+	}, nil
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// 集計ジョブの状態
+type SummaryJobStatus string
+
+const (
+	SummaryJobPending SummaryJobStatus = "pending"
+	SummaryJobDone    SummaryJobStatus = "done"
+	SummaryJobFailed  SummaryJobStatus = "failed"
+)
+
+// バックグラウンドで計算するカテゴリー別の集計（doneの場合のみSummaryを含む）
+type SummaryJob struct {
+	ID      string           `json:"job_id"`
+	Status  SummaryJobStatus `json:"status"`
+	Summary *CategorySummary `json:"summary,omitempty"`
+
+	finishedAt time.Time
+}
+
+// アイテムが多く集計に時間がかかる場合に、集計をバックグラウンドで計算して結果を一定時間保持する
+// 計算中に開始した場合は同じジョブを返し、同時に複数の集計を実行しない
+type SummaryJobs struct {
+	compute func(ctx context.Context) (*CategorySummary, error)
+	ttl     time.Duration // 完了したジョブを保持する期間
+
+	mu      sync.Mutex
+	jobs    map[string]*SummaryJob
+	running string // 計算中のジョブのID（無い場合は空）
+}
+
+// 完了したジョブはttlの経過後に削除する
+func NewSummaryJobs(compute func(ctx context.Context) (*CategorySummary, error), ttl time.Duration) *SummaryJobs {
+	return &SummaryJobs{
+		compute: compute,
+		ttl:     ttl,
+		jobs:    make(map[string]*SummaryJob),
+	}
+}
+
+// 集計を開始して、ジョブのコピーを返す（計算中のジョブがある場合はそのジョブ）
+func (j *SummaryJobs) Start() SummaryJob {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.removeExpired(time.Now())
+	if job, exists := j.jobs[j.running]; exists {
+		return *job
+	}
+
+	job := &SummaryJob{ID: newSummaryJobID(), Status: SummaryJobPending}
+	j.jobs[job.ID] = job
+	j.running = job.ID
+	// リクエストの終了後も計算を続ける
+	go j.run(job.ID)
+	return *job
+}
+
+// ジョブのコピーを返す（存在しない、または保持期間が過ぎた場合はfalse）
+func (j *SummaryJobs) Get(id string) (SummaryJob, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.removeExpired(time.Now())
+	job, exists := j.jobs[id]
+	if !exists {
+		return SummaryJob{}, false
+	}
+	return *job, true
+}
+
+func (j *SummaryJobs) run(id string) {
+	summary, err := j.compute(context.Background())
+	if err != nil {
+		slog.Error("failed to compute summary job", "job_id", id, "error", err)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	job := j.jobs[id]
+	job.finishedAt = time.Now()
+	if err != nil {
+		job.Status = SummaryJobFailed
+	} else {
+		job.Status = SummaryJobDone
+		job.Summary = summary
+	}
+	j.running = ""
+}
+
+// 保持期間が過ぎた完了済みのジョブを削除する（muを取得して呼ぶ）
+func (j *SummaryJobs) removeExpired(now time.Time) {
+	for id, job := range j.jobs {
+		if job.Status != SummaryJobPending && now.Sub(job.finishedAt) > j.ttl {
+			delete(j.jobs, id)
+		}
+	}
+}
+
+func newSummaryJobID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
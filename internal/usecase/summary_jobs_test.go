@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummaryJobs(t *testing.T) {
+	release := make(chan struct{})
+	calls := 0
+	jobs := NewSummaryJobs(func(ctx context.Context) (*CategorySummary, error) {
+		calls++
+		<-release
+		return &CategorySummary{Total: 3}, nil
+	}, time.Minute)
+
+	first := jobs.Start()
+	assert.Equal(t, SummaryJobPending, first.Status)
+
+	// 計算中は同じジョブを返す
+	second := jobs.Start()
+	assert.Equal(t, first.ID, second.ID)
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		job, _ := jobs.Get(first.ID)
+		return job.Status == SummaryJobDone
+	}, time.Second, time.Millisecond)
+
+	job, exists := jobs.Get(first.ID)
+	assert.True(t, exists)
+	assert.Equal(t, 3, job.Summary.Total)
+	assert.Equal(t, 1, calls)
+
+	// 完了後は新しいジョブを開始する
+	assert.NotEqual(t, first.ID, jobs.Start().ID)
+}
+
+func TestSummaryJobs_Failed(t *testing.T) {
+	jobs := NewSummaryJobs(func(ctx context.Context) (*CategorySummary, error) {
+		return nil, errors.New("db error")
+	}, time.Minute)
+
+	started := jobs.Start()
+	assert.Eventually(t, func() bool {
+		job, _ := jobs.Get(started.ID)
+		return job.Status == SummaryJobFailed
+	}, time.Second, time.Millisecond)
+
+	job, _ := jobs.Get(started.ID)
+	assert.Nil(t, job.Summary)
+}
+
+func TestSummaryJobs_Expired(t *testing.T) {
+	jobs := NewSummaryJobs(func(ctx context.Context) (*CategorySummary, error) {
+		return &CategorySummary{}, nil
+	}, time.Minute)
+
+	started := jobs.Start()
+	assert.Eventually(t, func() bool {
+		job, _ := jobs.Get(started.ID)
+		return job.Status == SummaryJobDone
+	}, time.Second, time.Millisecond)
+
+	// 保持期間が過ぎたジョブは削除する
+	jobs.mu.Lock()
+	jobs.removeExpired(time.Now().Add(2 * time.Minute))
+	jobs.mu.Unlock()
+	_, exists := jobs.Get(started.ID)
+	assert.False(t, exists)
+	_, exists = jobs.Get("unknown")
+	assert.False(t, exists)
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 同期トークンによる差分同期のレスポンス
+type ItemSyncSet struct {
+	Items      []*entity.Item // 登録・更新されたアイテム（FullResyncの場合は全件）
+	DeletedIDs []int64        // 削除されたアイテムのID（FullResyncの場合は空）
+	SyncToken  string         // 次回に指定する同期トークン
+	FullResync bool           // トークンが無い・不正・期限切れのため全件を返した
+}
+
+// トークンの形式を変えた場合に古いトークンを全件の同期にするための接頭辞
+const syncTokenPrefix = "v1:"
+
+// 変更履歴のシーケンス番号を同期トークンにする（クライアントには中身を意識させない）
+func encodeSyncToken(seq int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(syncTokenPrefix + strconv.FormatInt(seq, 10)))
+}
+
+// 同期トークンから変更履歴のシーケンス番号を取り出す
+func decodeSyncToken(token string) (int64, bool) {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || !strings.HasPrefix(string(decoded), syncTokenPrefix) {
+		return 0, false
+	}
+	seq, err := strconv.ParseInt(strings.TrimPrefix(string(decoded), syncTokenPrefix), 10, 64)
+	if err != nil || seq < 0 {
+		return 0, false
+	}
+	return seq, true
+}
+
+// 同期トークン以降の変更を返す
+// トークンが空・不正の場合や、変更履歴が保持期間を過ぎて削除されている場合は、全件の同期（FullResync）にする
+// 新しいトークンはコミット済みとみなせる位置（Settled）のため、それより後の変更は次回の同期でも再度返す（手元のデータはIDで上書きする）
+func (u *itemUsecase) SyncItems(ctx context.Context, token string) (*ItemSyncSet, error) {
+	sync := &ItemSyncSet{}
+
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		changeLog, err := u.itemRepo.GetChangeLogRange(ctx)
+		if err != nil {
+			return err
+		}
+		sync.SyncToken = encodeSyncToken(changeLog.Settled)
+
+		// 最も古いエントリーの直前までのトークンなら、それ以降の変更がすべて残っている
+		seq, ok := decodeSyncToken(token)
+		if !ok || seq > changeLog.Latest || seq < changeLog.Oldest-1 {
+			sync.FullResync = true
+			sync.Items, err = u.itemRepo.FindAll(ctx, ItemFilter{AnyStatus: true})
+			return err
+		}
+
+		if sync.Items, err = u.itemRepo.FindChangedAfter(ctx, seq); err != nil {
+			return err
+		}
+		sync.DeletedIDs, err = u.itemRepo.FindDeletedIDsAfter(ctx, seq)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync items: %w", err)
+	}
+
+	if sync.Items == nil {
+		sync.Items = []*entity.Item{}
+	}
+	if sync.DeletedIDs == nil {
+		sync.DeletedIDs = []int64{}
+	}
+
+	return sync, nil
+}
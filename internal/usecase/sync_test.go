@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_SyncItems(t *testing.T) {
+	item := &entity.Item{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15"}
+	changeLog := ChangeLogRange{Oldest: 5, Latest: 12, Settled: 12}
+
+	tests := []struct {
+		name               string
+		token              string
+		setupMock          func(*MockItemRepository)
+		expectedItems      int
+		expectedDeleted    []int64
+		expectedFullResync bool
+		expectError        bool
+	}{
+		{
+			name:  "正常系: トークン以降の変更と削除",
+			token: encodeSyncToken(10),
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetChangeLogRange", mock.Anything).Return(changeLog, nil)
+				mockRepo.On("FindChangedAfter", mock.Anything, int64(10)).Return([]*entity.Item{item}, nil)
+				mockRepo.On("FindDeletedIDsAfter", mock.Anything, int64(10)).Return([]int64{3}, nil)
+			},
+			expectedItems:   1,
+			expectedDeleted: []int64{3},
+		},
+		{
+			name:  "正常系: 最も古い変更履歴の直前のトークン",
+			token: encodeSyncToken(4),
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetChangeLogRange", mock.Anything).Return(changeLog, nil)
+				mockRepo.On("FindChangedAfter", mock.Anything, int64(4)).Return(([]*entity.Item)(nil), nil)
+				mockRepo.On("FindDeletedIDsAfter", mock.Anything, int64(4)).Return(([]int64)(nil), nil)
+			},
+			expectedItems:   0,
+			expectedDeleted: []int64{},
+		},
+		{
+			name:  "正常系: 空のトークンは全件の同期",
+			token: "",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetChangeLogRange", mock.Anything).Return(changeLog, nil)
+				mockRepo.On("FindAll", mock.Anything, ItemFilter{AnyStatus: true}).Return([]*entity.Item{item}, nil)
+			},
+			expectedItems:      1,
+			expectedDeleted:    []int64{},
+			expectedFullResync: true,
+		},
+		{
+			name:  "正常系: 期限切れのトークンは全件の同期",
+			token: encodeSyncToken(3),
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetChangeLogRange", mock.Anything).Return(changeLog, nil)
+				mockRepo.On("FindAll", mock.Anything, ItemFilter{AnyStatus: true}).Return([]*entity.Item{item}, nil)
+			},
+			expectedItems:      1,
+			expectedDeleted:    []int64{},
+			expectedFullResync: true,
+		},
+		{
+			name:  "正常系: 最新の変更履歴より新しいトークンは全件の同期",
+			token: encodeSyncToken(13),
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetChangeLogRange", mock.Anything).Return(changeLog, nil)
+				mockRepo.On("FindAll", mock.Anything, ItemFilter{AnyStatus: true}).Return([]*entity.Item{}, nil)
+			},
+			expectedItems:      0,
+			expectedDeleted:    []int64{},
+			expectedFullResync: true,
+		},
+		{
+			name:  "正常系: 不正なトークンは全件の同期",
+			token: "not-a-token",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetChangeLogRange", mock.Anything).Return(changeLog, nil)
+				mockRepo.On("FindAll", mock.Anything, ItemFilter{AnyStatus: true}).Return([]*entity.Item{}, nil)
+			},
+			expectedItems:      0,
+			expectedDeleted:    []int64{},
+			expectedFullResync: true,
+		},
+		{
+			name:  "異常系: データベースエラー",
+			token: encodeSyncToken(10),
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetChangeLogRange", mock.Anything).Return(ChangeLogRange{}, domainErrors.ErrDatabaseError)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			sync, err := usecase.SyncItems(context.Background(), tt.token)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.True(t, domainErrors.IsDatabaseError(err))
+				assert.Nil(t, sync)
+				mockRepo.AssertExpectations(t)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, sync.Items, tt.expectedItems)
+			assert.NotNil(t, sync.Items)
+			assert.Equal(t, tt.expectedDeleted, sync.DeletedIDs)
+			assert.Equal(t, tt.expectedFullResync, sync.FullResync)
+			assert.Equal(t, encodeSyncToken(12), sync.SyncToken)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_SyncItems_UnsettledChanges(t *testing.T) {
+	// 12は記録されたばかりで、先に採番された11がまだコミットされていない可能性がある
+	changeLog := ChangeLogRange{Oldest: 5, Latest: 12, Settled: 10}
+	item := &entity.Item{ID: 2, Name: "エルメス バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: entity.JPY(2500000), PurchaseDate: "2023-02-20"}
+
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("GetChangeLogRange", mock.Anything).Return(changeLog, nil)
+	mockRepo.On("FindChangedAfter", mock.Anything, int64(10)).Return([]*entity.Item{item}, nil)
+	mockRepo.On("FindDeletedIDsAfter", mock.Anything, int64(10)).Return([]int64{}, nil)
+	usecase := NewItemUsecase(mockRepo)
+
+	sync, err := usecase.SyncItems(context.Background(), encodeSyncToken(10))
+
+	require.NoError(t, err)
+	assert.Len(t, sync.Items, 1)
+	// 次回もコミット済みとみなせる位置から取得し直し、後からコミットされた変更を取りこぼさない
+	assert.Equal(t, encodeSyncToken(10), sync.SyncToken)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDecodeSyncToken(t *testing.T) {
+	seq, ok := decodeSyncToken(encodeSyncToken(42))
+	assert.True(t, ok)
+	assert.Equal(t, int64(42), seq)
+
+	for _, token := range []string{"", "!!", "djI6NDI", "djE6LTE", "djE6YWJj"} {
+		_, ok := decodeSyncToken(token)
+		assert.False(t, ok, token)
+	}
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// 登録・更新時に名前・ブランド・シリアル番号を整える（貼り付けた文字列の制御文字などで表示や検索が崩れないようにする）
+// 無効にした場合は前後の空白を除くのみ
+func WithTextSanitization(enabled bool) Option {
+	return func(u *itemUsecase) {
+		u.textSanitization = enabled
+	}
+}
+
+// 表示されない文字のうち、除いても意味が変わらないもの
+// 絵文字の結合に使うゼロ幅接合子（U+200D）や、文字の表示を変える異体字セレクタは残す
+var invisibleRunes = map[rune]bool{
+	'\u200b': true, // ゼロ幅スペース
+	'\u2060': true, // ワードジョイナー
+	'\ufeff': true, // ゼロ幅ノーブレークスペース（BOM）
+}
+
+// 制御文字とゼロ幅スペースなどを除き、NFCに正規化する
+// タブ・改行は単語がつながらないよう空白にする（CRLFは1つの空白、前後の空白は除く）
+func sanitizeText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\t' || r == '\n' || r == '\r':
+			return ' '
+		case unicode.IsControl(r) || invisibleRunes[r]:
+			return -1
+		}
+		return r
+	}, text)
+	return strings.TrimSpace(norm.NFC.String(text))
+}
+
+// 設定が有効な場合のみ整える
+func (u *itemUsecase) sanitize(text string) string {
+	if !u.textSanitization {
+		return text
+	}
+	return sanitizeText(text)
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+func TestSanitizeText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "正常系: 制御文字を除く", text: "Rolex\x00 Day\x07tona\x7f", expected: "Rolex Daytona"},
+		{name: "正常系: C1制御文字を除く", text: "Daytona\u0085", expected: "Daytona"},
+		{name: "正常系: ゼロ幅スペース・ワードジョイナー・BOMを除く", text: "\ufeffRo\u200blex\u2060", expected: "Rolex"},
+		{name: "正常系: タブ・改行は空白にする", text: "Lady\tDior\r\nBag\n", expected: "Lady Dior Bag"},
+		{name: "正常系: NFCに正規化する", text: "Herme\u0300s", expected: "Herm\u00e8s"},
+		{name: "正常系: 濁点の結合文字をNFCにする", text: "ハ\u3099ック\u3099", expected: "バッグ"},
+		{name: "正常系: 絵文字のゼロ幅接合子は残す", text: "👨\u200d👩\u200d👧 Bag", expected: "👨\u200d👩\u200d👧 Bag"},
+		{name: "正常系: 異体字セレクタは残す", text: "葛\U000E0100飾", expected: "葛\U000E0100飾"},
+		{name: "正常系: 全角文字・記号はそのまま", text: "ＲＯＬＥＸ　デイトナ №1", expected: "ＲＯＬＥＸ　デイトナ №1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, sanitizeText(tt.text))
+		})
+	}
+}
+
+func TestItemUsecase_CreateItem_TextSanitization(t *testing.T) {
+	input := CreateItemInput{
+		Name:          "Day\u200btona\x00",
+		Category:      entity.CategoryWatch,
+		Brand:         "\ufeffROLEX",
+		PurchasePrice: 1000000,
+		PurchaseDate:  "2023-01-01",
+		SerialNumber:  "SN\t001",
+	}
+
+	t.Run("正常系: 有効な場合は整えて登録する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.Name == "Daytona" && item.Brand == "ROLEX" && item.SerialNumber == "SN 001"
+		})).Return(&entity.Item{ID: 1}, nil)
+		usecase := NewItemUsecase(mockRepo, WithTextSanitization(true))
+
+		_, err := usecase.CreateItem(context.Background(), input)
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 無効な場合はそのまま登録する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.Name == input.Name && item.Brand == input.Brand
+		})).Return(&entity.Item{ID: 1}, nil)
+		usecase := NewItemUsecase(mockRepo, WithTextSanitization(false))
+
+		_, err := usecase.CreateItem(context.Background(), input)
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestItemUsecase_UpdateItem_TextSanitization(t *testing.T) {
+	existing, _ := entity.NewItem("デイトナ", entity.CategoryWatch, "ROLEX", 1000000, "2023-01-01")
+	existing.ID = 1
+	updated := *existing
+	updated.Name = "サブマリーナ"
+
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing, nil)
+	mockRepo.On("Update", mock.Anything, int64(1), ItemChanges{Name: stringPtr("サブマリーナ")}).Return(&updated, nil)
+	usecase := NewItemUsecase(mockRepo, WithTextSanitization(true))
+
+	// ブランドは整えると現在の値と同じになるため書き込まない
+	name := "サブ\u200bマリーナ\n"
+	brand := "ROLEX\x00"
+	item, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{Name: &name, Brand: &brand})
+
+	require.NoError(t, err)
+	assert.Equal(t, "サブマリーナ", item.Name)
+	mockRepo.AssertExpectations(t)
+}
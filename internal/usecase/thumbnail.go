@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	_ "image/gif" // image.Decodeで使う形式を登録する
+	"image/jpeg"
+	_ "image/png"
+)
+
+// 読み込む画像の最大画素数（小さなファイルで巨大な画像を展開させないため）
+const maxImagePixels = 40_000_000
+
+// 縮小画像のJPEGの品質
+const thumbnailQuality = 80
+
+// 画像を最後まで読み込み、壊れていないことを確認する
+func decodeImage(data []byte) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("image is corrupt or in an unsupported format")
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, errors.New("image has no pixels")
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, errors.New("image dimensions are too large")
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("image is corrupt or in an unsupported format")
+	}
+	return img, nil
+}
+
+// 長辺がmaxDimension以下になるよう縮小したJPEGを返す（元の画像が十分小さい場合はnil）
+// 行ごとにctxを確認し、タイムアウトした場合はctxのエラーを返す
+func generateThumbnail(ctx context.Context, src image.Image, maxDimension int) ([]byte, error) {
+	bounds := src.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	if maxDimension <= 0 || (srcWidth <= maxDimension && srcHeight <= maxDimension) {
+		return nil, nil
+	}
+
+	width, height := maxDimension, maxDimension
+	if srcWidth > srcHeight {
+		height = max(srcHeight*maxDimension/srcWidth, 1)
+	} else {
+		width = max(srcWidth*maxDimension/srcHeight, 1)
+	}
+
+	thumbnail := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		y0 := bounds.Min.Y + y*srcHeight/height
+		y1 := max(bounds.Min.Y+(y+1)*srcHeight/height, y0+1)
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*srcWidth/width
+			x1 := max(bounds.Min.X+(x+1)*srcWidth/width, x0+1)
+			setAverage(thumbnail, x, y, src, x0, y0, x1, y1)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, thumbnail, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// srcの矩形 [x0, x1) × [y0, y1) の平均色をdstの(x, y)に書き込む
+// JPEGは透過を扱えないため、画素ごとに白の背景に重ねてから平均する（元の大きさの画像を確保しない）
+func setAverage(dst *image.RGBA, x, y int, src image.Image, x0, y0, x1, y1 int) {
+	var r, g, b, count uint64
+	for sy := y0; sy < y1; sy++ {
+		for sx := x0; sx < x1; sx++ {
+			// 乗算済みアルファの値のため、白の背景は透過した分 (0xffff - a) を足せばよい
+			sr, sg, sb, sa := src.At(sx, sy).RGBA()
+			r += uint64(sr + 0xffff - sa)
+			g += uint64(sg + 0xffff - sa)
+			b += uint64(sb + 0xffff - sa)
+			count++
+		}
+	}
+
+	offset := dst.PixOffset(x, y)
+	dst.Pix[offset] = uint8(r / count >> 8)
+	dst.Pix[offset+1] = uint8(g / count >> 8)
+	dst.Pix[offset+2] = uint8(b / count >> 8)
+	dst.Pix[offset+3] = 0xff
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 購入年ごとのアイテム数と購入価格の合計
+type TimelineEntry struct {
+	Year               *int         `json:"year"` // 購入日を解釈できないアイテムはnull
+	Count              int          `json:"count"`
+	TotalPurchasePrice entity.Money `json:"total_purchase_price"`
+}
+
+// 絞り込み条件に一致するアイテムを購入年ごとに集計し、古い年から順に返す（購入年が不明な分は最後）
+func (u *itemUsecase) GetTimeline(ctx context.Context, filter ItemFilter) ([]TimelineEntry, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	entries, err := u.itemRepo.GetSummaryByPurchaseYear(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get timeline: %w", err)
+	}
+	if entries == nil {
+		entries = []TimelineEntry{}
+	}
+	return entries, nil
+}
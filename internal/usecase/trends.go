@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 購入年ごとのアイテム数と購入価格の合計、および前年比
+type TrendEntry struct {
+	Year       int          `json:"year"`
+	Count      int          `json:"count"`
+	TotalSpend entity.Money `json:"total_spend"`
+	// 前年からの増減率（%、小数点以下1桁）。最初の年と、前年が0件・0円の場合はnull
+	CountChangePct *float64 `json:"count_change_pct"`
+	SpendChangePct *float64 `json:"spend_change_pct"`
+}
+
+// 購入年ごとの集計から前年比を計算し、古い年から順に返す
+// 購入の無い年も0件として含め、購入年が不明なアイテムは含めない
+func (u *itemUsecase) GetTrends(ctx context.Context, filter ItemFilter) ([]TrendEntry, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	entries, err := u.itemRepo.GetSummaryByPurchaseYear(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trends: %w", err)
+	}
+	return buildTrends(entries), nil
+}
+
+// 購入年ごとの集計（古い年から順）を、最初の年から最後の年まで1年ずつの推移にする
+func buildTrends(entries []TimelineEntry) []TrendEntry {
+	byYear := make(map[int]TimelineEntry, len(entries))
+	first, last := 0, 0
+	for _, entry := range entries {
+		if entry.Year == nil {
+			continue
+		}
+		year := *entry.Year
+		byYear[year] = entry
+		if first == 0 || year < first {
+			first = year
+		}
+		if year > last {
+			last = year
+		}
+	}
+
+	trends := []TrendEntry{}
+	if len(byYear) == 0 {
+		return trends
+	}
+	for year := first; year <= last; year++ {
+		trend := TrendEntry{Year: year, TotalSpend: entity.JPY(0)}
+		if entry, ok := byYear[year]; ok {
+			trend.Count = entry.Count
+			trend.TotalSpend = entry.TotalPurchasePrice
+		}
+		if len(trends) > 0 {
+			prev := trends[len(trends)-1]
+			trend.CountChangePct = changePct(int64(prev.Count), int64(trend.Count))
+			trend.SpendChangePct = changePct(prev.TotalSpend.Amount, trend.TotalSpend.Amount)
+		}
+		trends = append(trends, trend)
+	}
+	return trends
+}
+
+// 前年からの増減率（%）。前年が0の場合は計算できないためnil
+func changePct(prev, current int64) *float64 {
+	if prev == 0 {
+		return nil
+	}
+	pct := math.Round(float64(current-prev)/float64(prev)*1000) / 10
+	return &pct
+}
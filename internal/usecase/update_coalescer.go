@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 同じアイテムへの短時間の連続した更新（自動保存のPATCHなど）を、window の間まとめて1回の書き込みにする
+// まとめた入力は後に届いたフィールドの値を優先し、待っていたすべてのリクエストに書き込み後の同じアイテム（またはエラー）を返す
+// 書き込みはアイテムごとに1件ずつ行い、後にまとめた更新が先の更新より前に書き込まれることはない
+type UpdateCoalescer struct {
+	window time.Duration
+	write  func(ctx context.Context, id int64, input UpdateItemInput) (*entity.Item, error)
+
+	mu      sync.Mutex
+	pending map[int64]*pendingUpdate // 書き込みを待っている更新（アイテムごとに1件）
+	writing map[int64]bool           // 書き込み中のアイテム
+	closed  bool                     // Flushの後は、まとめずにそのまま書き込む
+	writers sync.WaitGroup
+}
+
+type pendingUpdate struct {
+	ctx     context.Context
+	input   UpdateItemInput
+	waiters []chan updateResult
+	timer   *time.Timer
+	ready   bool // windowが過ぎ、前の書き込みが終わり次第書き込む
+}
+
+type updateResult struct {
+	item *entity.Item
+	err  error
+}
+
+func NewUpdateCoalescer(window time.Duration) *UpdateCoalescer {
+	return &UpdateCoalescer{
+		window:  window,
+		pending: make(map[int64]*pendingUpdate),
+		writing: make(map[int64]bool),
+	}
+}
+
+// UpdateItemをcoalescerを経由して行う（nilの場合はまとめない）
+func WithUpdateCoalescer(coalescer *UpdateCoalescer) Option {
+	return func(u *itemUsecase) {
+		if coalescer != nil {
+			coalescer.write = u.updateItem
+		}
+		u.coalescer = coalescer
+	}
+}
+
+// 更新をまとめて書き込み、結果を返す
+// 呼び出し元のcontextがキャンセルされた場合はエラーを返すが、まとめた更新の書き込みは取り消さない
+func (c *UpdateCoalescer) submit(ctx context.Context, id int64, input UpdateItemInput) (*entity.Item, error) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return c.write(ctx, id, input)
+	}
+
+	result := make(chan updateResult, 1)
+	if batch, exists := c.pending[id]; exists {
+		batch.input = mergeUpdateInputs(batch.input, input)
+		batch.waiters = append(batch.waiters, result)
+	} else {
+		// 最初のリクエストのcontextの値（トレースなど）を引き継ぎ、キャンセルは引き継がない
+		batch := &pendingUpdate{
+			ctx:     context.WithoutCancel(ctx),
+			input:   input,
+			waiters: []chan updateResult{result},
+		}
+		batch.timer = time.AfterFunc(c.window, func() { c.flush(id, batch) })
+		c.pending[id] = batch
+	}
+	c.mu.Unlock()
+
+	select {
+	case r := <-result:
+		return r.item, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// 待っている更新を書き込む（他の更新を書き込み中の場合は、その書き込みの後に書き込む）
+func (c *UpdateCoalescer) flush(id int64, batch *pendingUpdate) {
+	c.mu.Lock()
+	if c.pending[id] != batch {
+		// Flushで既に書き込んだ
+		c.mu.Unlock()
+		return
+	}
+	if c.writing[id] {
+		batch.ready = true
+		c.mu.Unlock()
+		return
+	}
+	delete(c.pending, id)
+	c.writing[id] = true
+	c.writers.Add(1)
+	c.mu.Unlock()
+
+	defer c.writers.Done()
+	for batch != nil {
+		item, err := c.write(batch.ctx, id, batch.input)
+		for _, waiter := range batch.waiters {
+			waiter <- updateResult{item: item, err: err}
+		}
+
+		c.mu.Lock()
+		batch = nil
+		if next, exists := c.pending[id]; exists && next.ready {
+			delete(c.pending, id)
+			batch = next
+		} else {
+			delete(c.writing, id)
+		}
+		c.mu.Unlock()
+	}
+}
+
+// 待っているすべての更新をすぐに書き込み、書き込みが終わるまで待つ（サーバーの停止時に呼ぶ）
+// 以降の更新はまとめずにそのまま書き込む
+func (c *UpdateCoalescer) Flush() {
+	c.mu.Lock()
+	c.closed = true
+	batches := make(map[int64]*pendingUpdate, len(c.pending))
+	for id, batch := range c.pending {
+		batch.timer.Stop()
+		batches[id] = batch
+	}
+	c.mu.Unlock()
+
+	for id, batch := range batches {
+		c.flush(id, batch)
+	}
+	c.writers.Wait()
+}
+
+// 後の入力で指定したフィールドを優先して1つの入力にする
+func mergeUpdateInputs(earlier, later UpdateItemInput) UpdateItemInput {
+	merged := earlier
+	if later.Name != nil {
+		merged.Name = later.Name
+	}
+	if later.Brand != nil {
+		merged.Brand = later.Brand
+	}
+	if later.PurchasePrice != nil {
+		merged.PurchasePrice = later.PurchasePrice
+	}
+	if later.Status != nil {
+		merged.Status = later.Status
+	}
+	return merged
+}
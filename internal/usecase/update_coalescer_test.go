@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func (c *UpdateCoalescer) waiters(id int64) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if batch, exists := c.pending[id]; exists {
+		return len(batch.waiters)
+	}
+	return 0
+}
+
+func TestUpdateCoalescer(t *testing.T) {
+	existing := func() *entity.Item {
+		item, _ := entity.NewItem("デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+		item.ID = 1
+		return item
+	}
+	name := func(value string) *string { return &value }
+	price := func(value int64) *int64 { return &value }
+
+	t.Run("正常系: 連続した更新を後勝ちでまとめて1回で書き込む", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing(), nil).Once()
+		updated := &entity.Item{ID: 1, Name: "デイトナ 116500LN", PurchasePrice: entity.JPY(1800000)}
+		mockRepo.On("Update", mock.Anything, int64(1), mock.MatchedBy(func(changes ItemChanges) bool {
+			return changes.Name != nil && *changes.Name == "デイトナ 116500LN" &&
+				changes.PurchasePrice != nil && changes.PurchasePrice.Amount == 1800000
+		})).Return(updated, nil).Once()
+		coalescer := NewUpdateCoalescer(time.Hour)
+		usecase := NewItemUsecase(mockRepo, WithUpdateCoalescer(coalescer))
+
+		results := make(chan *entity.Item, 2)
+		update := func(input UpdateItemInput) {
+			item, err := usecase.UpdateItem(context.Background(), 1, input)
+			assert.NoError(t, err)
+			results <- item
+		}
+		go update(UpdateItemInput{Name: name("デイトナ 1165"), PurchasePrice: price(1800000)})
+		require.Eventually(t, func() bool { return coalescer.waiters(1) == 1 }, time.Second, time.Millisecond)
+		go update(UpdateItemInput{Name: name("デイトナ 116500LN")})
+		require.Eventually(t, func() bool { return coalescer.waiters(1) == 2 }, time.Second, time.Millisecond)
+
+		coalescer.Flush()
+
+		assert.Same(t, updated, <-results)
+		assert.Same(t, updated, <-results)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 不正な入力はまとめずに拒否し、他のリクエストの更新は書き込む", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing(), nil).Once()
+		updated := &entity.Item{ID: 1, Name: "デイトナ 116500LN"}
+		mockRepo.On("Update", mock.Anything, int64(1), mock.MatchedBy(func(changes ItemChanges) bool {
+			return changes.Name != nil && *changes.Name == "デイトナ 116500LN" && changes.PurchasePrice == nil
+		})).Return(updated, nil).Once()
+		coalescer := NewUpdateCoalescer(time.Hour)
+		usecase := NewItemUsecase(mockRepo, WithUpdateCoalescer(coalescer))
+
+		results := make(chan *entity.Item, 1)
+		go func() {
+			item, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{Name: name("デイトナ 116500LN")})
+			assert.NoError(t, err)
+			results <- item
+		}()
+		require.Eventually(t, func() bool { return coalescer.waiters(1) == 1 }, time.Second, time.Millisecond)
+
+		_, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{PurchasePrice: price(-1)})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.Equal(t, 1, coalescer.waiters(1))
+
+		coalescer.Flush()
+
+		assert.Same(t, updated, <-results)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: windowが過ぎると書き込む", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing(), nil).Once()
+		mockRepo.On("Update", mock.Anything, int64(1), mock.Anything).Return(&entity.Item{ID: 1}, nil).Once()
+		usecase := NewItemUsecase(mockRepo, WithUpdateCoalescer(NewUpdateCoalescer(10*time.Millisecond)))
+
+		item, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{Name: name("デイトナ 116500LN")})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), item.ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: Flushの後はまとめずに書き込む", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing(), nil).Once()
+		mockRepo.On("Update", mock.Anything, int64(1), mock.Anything).Return(&entity.Item{ID: 1}, nil).Once()
+		coalescer := NewUpdateCoalescer(time.Hour)
+		usecase := NewItemUsecase(mockRepo, WithUpdateCoalescer(coalescer))
+		coalescer.Flush()
+
+		_, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{Name: name("デイトナ 116500LN")})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 待っている間にキャンセルされても書き込む", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing(), nil).Once()
+		mockRepo.On("Update", mock.Anything, int64(1), mock.Anything).Return(&entity.Item{ID: 1}, nil).Once()
+		coalescer := NewUpdateCoalescer(time.Hour)
+		usecase := NewItemUsecase(mockRepo, WithUpdateCoalescer(coalescer))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errs := make(chan error, 1)
+		go func() {
+			_, err := usecase.UpdateItem(ctx, 1, UpdateItemInput{Name: name("デイトナ 116500LN")})
+			errs <- err
+		}()
+		require.Eventually(t, func() bool { return coalescer.waiters(1) == 1 }, time.Second, time.Millisecond)
+		cancel()
+
+		assert.ErrorIs(t, <-errs, context.Canceled)
+		coalescer.Flush()
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 条件付き更新の条件（指定したフィールドの現在の値がすべて一致する場合のみ更新する）
+// 自動化のスクリプトなどで「activeの場合のみarchivedにする」のような比較と交換を行う
+type UpdateCondition struct {
+	Name          *string        `json:"name,omitempty"`
+	Brand         *string        `json:"brand,omitempty"`
+	PurchasePrice *int64         `json:"purchase_price,omitempty"`
+	Status        *entity.Status `json:"status,omitempty"`
+}
+
+// 条件のバリデーション（フィールドを1つも指定しない条件は指定ミスとして扱う）
+func (c UpdateCondition) validate() error {
+	if c.Name == nil && c.Brand == nil && c.PurchasePrice == nil && c.Status == nil {
+		return fmt.Errorf("%w: if must specify at least one field (name, brand, purchase_price, status)", domainErrors.ErrInvalidInput)
+	}
+	if c.Status != nil && !c.Status.IsValid() {
+		return fmt.Errorf("%w: if.status: %s", domainErrors.ErrInvalidInput, entity.ErrInvalidStatus.Error())
+	}
+	return nil
+}
+
+// アイテムの現在の値が条件に一致するか
+func (c UpdateCondition) matches(item *entity.Item) bool {
+	return c.mismatches(item) == ""
+}
+
+// 条件に一致しないフィールドと現在の値（一致する場合は空）
+func (c UpdateCondition) mismatches(item *entity.Item) string {
+	var fields []string
+	if c.Name != nil && strings.TrimSpace(*c.Name) != item.Name {
+		fields = append(fields, fmt.Sprintf("name is %q", item.Name))
+	}
+	if c.Brand != nil && strings.TrimSpace(*c.Brand) != item.Brand {
+		fields = append(fields, fmt.Sprintf("brand is %q", item.Brand))
+	}
+	if c.PurchasePrice != nil && entity.JPY(*c.PurchasePrice) != item.PurchasePrice {
+		fields = append(fields, fmt.Sprintf("purchase_price is %d", item.PurchasePrice.Amount))
+	}
+	if c.Status != nil && *c.Status != item.Status {
+		fields = append(fields, fmt.Sprintf("status is %q", item.Status))
+	}
+	return strings.Join(fields, ", ")
+}
@@ -8,17 +8,94 @@ CREATE TABLE IF NOT EXISTS items (
     name VARCHAR(100) NOT NULL COMMENT 'Item name',
     category VARCHAR(50) NOT NULL COMMENT 'Item category: 時計, バッグ, ジュエリー, 靴, その他',
     brand VARCHAR(100) NOT NULL COMMENT 'Brand name',
-    purchase_price INT NOT NULL DEFAULT 0 COMMENT 'Purchase price in yen',
+    purchase_price BIGINT NOT NULL DEFAULT 0 COMMENT 'Purchase price in yen',
     purchase_date DATE NOT NULL COMMENT 'Purchase date in YYYY-MM-DD format',
+    serial_number VARCHAR(100) NULL DEFAULT NULL COMMENT 'Serial number (unique when set)',
+    status VARCHAR(20) NOT NULL DEFAULT 'active' COMMENT 'Item status: draft, active, archived',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
+    deleted_at TIMESTAMP NULL DEFAULT NULL COMMENT 'Soft delete timestamp (NULL while active)',
+    delete_reason VARCHAR(100) NULL DEFAULT NULL COMMENT 'Reason given when soft-deleted (sold, lost, returned, ...)',
+    version BIGINT NOT NULL DEFAULT 1 COMMENT 'Incremented on every write (used for the ETag)',
+    uuid CHAR(36) NULL DEFAULT NULL COMMENT 'Public ID used by the API when ID_TYPE=uuid (NULL otherwise)',
     
     INDEX idx_category (category),
     INDEX idx_brand (brand),
     INDEX idx_purchase_date (purchase_date),
-    INDEX idx_created_at (created_at)
+    INDEX idx_created_at (created_at),
+    INDEX idx_category_purchase_date (category, purchase_date),
+    INDEX idx_deleted_at (deleted_at),
+    INDEX idx_status (status),
+    UNIQUE INDEX uniq_serial_number (serial_number),
+    UNIQUE INDEX uniq_uuid (uuid)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for managing valuable items and collections';
 
+-- Create outbox table for reliable delivery of item lifecycle events
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    event_type VARCHAR(50) NOT NULL COMMENT 'Event type: item.created, item.updated, item.deleted',
+    payload JSON NOT NULL COMMENT 'Event payload sent to webhooks',
+    attempts INT NOT NULL DEFAULT 0 COMMENT 'Number of delivery attempts',
+    last_error TEXT NULL COMMENT 'Error of the last failed delivery',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    delivered_at TIMESTAMP NULL DEFAULT NULL COMMENT 'Delivery timestamp (NULL while pending)',
+    dead_lettered_at TIMESTAMP NULL DEFAULT NULL COMMENT 'Time delivery was given up after OUTBOX_MAX_ATTEMPTS failures',
+
+    INDEX idx_outbox_pending (delivered_at, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Transactional outbox for item events';
+
+-- Create item_changes table as the change log behind sync tokens and GET /items/changelog
+CREATE TABLE IF NOT EXISTS item_changes (
+    seq BIGINT AUTO_INCREMENT PRIMARY KEY COMMENT 'Monotonic change sequence',
+    item_id BIGINT NOT NULL COMMENT 'Changed item (no foreign key so purged items stay listed)',
+    operation VARCHAR(20) NOT NULL COMMENT 'Operation: created, updated, deleted, undeleted, purged',
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Change timestamp',
+
+    INDEX idx_item_changes_changed_at (changed_at),
+    INDEX idx_item_changes_item_id (item_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Change log of items written by triggers';
+
+-- Record every write to items in the change log within the same transaction
+CREATE TRIGGER IF NOT EXISTS trg_items_changes_insert AFTER INSERT ON items
+FOR EACH ROW INSERT INTO item_changes (item_id, operation) VALUES (NEW.id, 'created');
+
+-- Updates that change nothing (an upsert with the same values, a UUID backfill) keep the version and are not logged
+CREATE TRIGGER IF NOT EXISTS trg_items_changes_update AFTER UPDATE ON items
+FOR EACH ROW INSERT INTO item_changes (item_id, operation) SELECT NEW.id, CASE
+    WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN 'deleted'
+    WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN 'undeleted'
+    ELSE 'updated'
+END FROM DUAL WHERE NEW.version <> OLD.version OR NOT (OLD.deleted_at <=> NEW.deleted_at);
+
+CREATE TRIGGER IF NOT EXISTS trg_items_changes_delete AFTER DELETE ON items
+FOR EACH ROW INSERT INTO item_changes (item_id, operation) VALUES (OLD.id, 'purged');
+
+-- Create item_images table for storing multiple ordered images per item
+CREATE TABLE IF NOT EXISTS item_images (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Item the image belongs to',
+    position INT NOT NULL COMMENT 'Display order within the item (1-based)',
+    content_type VARCHAR(50) NOT NULL COMMENT 'Image MIME type detected from the data',
+    size INT NOT NULL COMMENT 'Image size in bytes',
+    data MEDIUMBLOB NOT NULL COMMENT 'Image data',
+    thumbnail MEDIUMBLOB NULL COMMENT 'Downscaled JPEG for list views (NULL when the original is small enough)',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    INDEX idx_item_images_item_position (item_id, position),
+    CONSTRAINT fk_item_images_item FOREIGN KEY (item_id) REFERENCES items (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Images attached to items';
+
+-- Create search_presets table for storing named search filters
+CREATE TABLE IF NOT EXISTS search_presets (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(50) NOT NULL COMMENT 'Preset name used in the URL',
+    filter JSON NOT NULL COMMENT 'Request body of POST /items/search',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
+
+    UNIQUE KEY uniq_search_presets_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Named filters for POST /items/search';
+
 -- Insert sample data for testing
 INSERT INTO items (name, category, brand, purchase_price, purchase_date) VALUES
 ('ロレックス デイトナ', '時計', 'ROLEX', 1500000, '2023-01-15'),